@@ -0,0 +1,164 @@
+package achievements
+
+import (
+	"CrispyBot/database/models"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Game events achievements listen to
+const (
+	EventCharacterRolled = "character_rolled"
+	EventBattleWon       = "battle_won"
+	EventItemBought      = "item_bought"
+	EventLevelReached    = "level_reached"
+	EventDailyClaimed    = "daily_claimed"
+	EventDungeonFloor    = "dungeon_floor"
+	EventRebirth         = "rebirth"
+)
+
+// Event attributes criteria can filter or collect on
+const (
+	AttrRace            = "race"
+	AttrLegendary       = "legendary"
+	AttrOpponent        = "opponent"
+	AttrOpponentElement = "opponentElement"
+	AttrNPC             = "npc"
+	AttrFinisher        = "finisher"
+	AttrBankrupt        = "bankrupt"
+	AttrRarity          = "rarity"
+	AttrSource          = "source"
+)
+
+// Roll sources
+const (
+	SourceRoll       = "roll"
+	SourceReroll     = "reroll"
+	SourceRerollStat = "rerollstat"
+)
+
+// Criteria kinds
+const (
+	KindCount    = "count"    // Counts matching events
+	KindDistinct = "distinct" // Counts distinct values of an attribute
+	KindReach    = "reach"    // Tracks the highest event value
+)
+
+// Event is something that happened in the game
+type Event struct {
+	Type       string
+	Attributes map[string]string
+	Value      int // Level, floor or streak for reach criteria
+}
+
+// Reward is paid once when an achievement unlocks
+type Reward struct {
+	Coins        int
+	RerollTokens int
+}
+
+// Definition describes an achievement and the criteria that unlock it
+type Definition struct {
+	ID          string
+	Name        string
+	Description string
+	Icon        string
+	Event       string            // Event type that advances the achievement
+	Kind        string            // How matching events are counted
+	Filter      map[string]string // Attributes an event must have to count
+	Key         string            // Attribute collected by distinct criteria
+	Target      int
+	Reward      Reward
+}
+
+// Find looks up an achievement by ID
+func Find(id string) (Definition, bool) {
+	for _, definition := range Definitions {
+		if definition.ID == id {
+			return definition, true
+		}
+	}
+	return Definition{}, false
+}
+
+// Satisfies checks if the event is of the given type and has every filtered attribute
+func (e Event) Satisfies(eventType string, filter map[string]string) bool {
+	if e.Type != eventType {
+		return false
+	}
+	for attribute, value := range filter {
+		if e.Attributes[attribute] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches checks if an event counts toward the achievement
+func (d Definition) Matches(event Event) bool {
+	if !event.Satisfies(d.Event, d.Filter) {
+		return false
+	}
+	if d.Kind == KindDistinct && event.Attributes[d.Key] == "" {
+		return false
+	}
+	return true
+}
+
+// Evaluate applies an event to a player's progress and returns the achievements it unlocked
+func Evaluate(progress *models.AchievementProgress, event Event, now time.Time) []Definition {
+	if progress.Counters == nil {
+		progress.Counters = make(map[string]int)
+	}
+	if progress.Collected == nil {
+		progress.Collected = make(map[string][]string)
+	}
+	if progress.Unlocked == nil {
+		progress.Unlocked = make(map[string]time.Time)
+	}
+
+	var unlocked []Definition
+	for _, definition := range Definitions {
+		if _, done := progress.Unlocked[definition.ID]; done || !definition.Matches(event) {
+			continue
+		}
+
+		switch definition.Kind {
+		case KindCount:
+			progress.Counters[definition.ID]++
+		case KindDistinct:
+			value := event.Attributes[definition.Key]
+			if !slices.Contains(progress.Collected[definition.ID], value) {
+				progress.Collected[definition.ID] = append(progress.Collected[definition.ID], value)
+			}
+			progress.Counters[definition.ID] = len(progress.Collected[definition.ID])
+		case KindReach:
+			progress.Counters[definition.ID] = max(progress.Counters[definition.ID], event.Value)
+		}
+
+		if progress.Counters[definition.ID] >= definition.Target {
+			progress.Unlocked[definition.ID] = now
+			unlocked = append(unlocked, definition)
+		}
+	}
+
+	return unlocked
+}
+
+// Progress returns how far a player is toward an achievement, capped at its target
+func Progress(progress models.AchievementProgress, definition Definition) int {
+	if _, done := progress.Unlocked[definition.ID]; done {
+		return definition.Target
+	}
+	return min(progress.Counters[definition.ID], definition.Target)
+}
+
+// ProgressBar draws a text progress bar of the given width
+func ProgressBar(current int, target int, width int) string {
+	filled := 0
+	if target > 0 {
+		filled = min(current*width/target, width)
+	}
+	return strings.Repeat("▰", filled) + strings.Repeat("▱", width-filled)
+}
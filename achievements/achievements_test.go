@@ -0,0 +1,96 @@
+package achievements
+
+import (
+	"CrispyBot/database/models"
+	"CrispyBot/roller"
+	"testing"
+	"time"
+)
+
+func TestEvaluate_CountUnlocksOnce(t *testing.T) {
+	progress := &models.AchievementProgress{}
+	now := time.Now()
+
+	unlocked := Evaluate(progress, BattleWonEvent("Fire", "Goblin", "attack"), now)
+	if !containsID(unlocked, "first_blood") {
+		t.Fatalf("Expected first win to unlock first_blood, got %v", unlocked)
+	}
+
+	unlocked = Evaluate(progress, BattleWonEvent("Fire", "Goblin", "attack"), now)
+	if containsID(unlocked, "first_blood") {
+		t.Errorf("first_blood should only unlock once")
+	}
+	if progress.Counters["centurion"] != 2 {
+		t.Errorf("Expected 2 wins toward centurion, got %d", progress.Counters["centurion"])
+	}
+}
+
+func TestEvaluate_DistinctElements(t *testing.T) {
+	progress := &models.AchievementProgress{}
+	now := time.Now()
+
+	Evaluate(progress, BattleWonEvent("Fire", "Goblin", "attack"), now)
+	Evaluate(progress, BattleWonEvent("Fire", "", "attack"), now)
+	Evaluate(progress, BattleWonEvent("None", "Slime", "magic"), now)
+	if progress.Counters["elementalist"] != 1 {
+		t.Fatalf("Expected 1 distinct element, got %d", progress.Counters["elementalist"])
+	}
+
+	var unlocked []Definition
+	for _, option := range roller.ElementOptions {
+		unlocked = append(unlocked, Evaluate(progress, BattleWonEvent(option.Value, "Goblin", "attack"), now)...)
+	}
+	if !containsID(unlocked, "elementalist") {
+		t.Errorf("Expected defeating every element to unlock elementalist")
+	}
+}
+
+func TestEvaluate_FiltersAndReach(t *testing.T) {
+	progress := &models.AchievementProgress{}
+	now := time.Now()
+
+	if unlocked := Evaluate(progress, PurchaseEvent("Common", 10), now); len(unlocked) != 0 {
+		t.Errorf("Purchase with coins left should not unlock anything, got %v", unlocked)
+	}
+	if unlocked := Evaluate(progress, PurchaseEvent("Rare", 0), now); !containsID(unlocked, "bankrupt") {
+		t.Errorf("Expected an empty wallet to unlock bankrupt")
+	}
+
+	Evaluate(progress, ValueEvent(EventLevelReached, 12), now)
+	Evaluate(progress, ValueEvent(EventLevelReached, 5), now)
+	if progress.Counters["veteran"] != 12 {
+		t.Errorf("Reach criteria should keep the highest value, got %d", progress.Counters["veteran"])
+	}
+	if _, ok := progress.Unlocked["seasoned"]; !ok {
+		t.Errorf("Expected level 12 to unlock seasoned")
+	}
+}
+
+func TestRollEvent_God(t *testing.T) {
+	character := models.Character{}
+	character.Characteristics.Race.Trait_Name = "God"
+	character.Stats.Speed.Rarity = "Legendary"
+
+	unlocked := Evaluate(&models.AchievementProgress{}, RollEvent(character, SourceRoll), time.Now())
+	if !containsID(unlocked, "divine") || !containsID(unlocked, "legendary_pull") {
+		t.Errorf("Expected divine and legendary_pull, got %v", unlocked)
+	}
+}
+
+func TestProgressBar(t *testing.T) {
+	if bar := ProgressBar(5, 10, 10); bar != "▰▰▰▰▰▱▱▱▱▱" {
+		t.Errorf("Unexpected half bar %q", bar)
+	}
+	if bar := ProgressBar(20, 10, 4); bar != "▰▰▰▰" {
+		t.Errorf("Bar should cap at full, got %q", bar)
+	}
+}
+
+func containsID(definitions []Definition, id string) bool {
+	for _, definition := range definitions {
+		if definition.ID == id {
+			return true
+		}
+	}
+	return false
+}
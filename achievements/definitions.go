@@ -0,0 +1,132 @@
+package achievements
+
+import "CrispyBot/roller"
+
+// Definitions lists every achievement in display order
+var Definitions = []Definition{
+	{
+		ID:          "first_blood",
+		Name:        "First Blood",
+		Description: "Win your first battle",
+		Icon:        "⚔️",
+		Event:       EventBattleWon,
+		Kind:        KindCount,
+		Target:      1,
+		Reward:      Reward{Coins: 50},
+	},
+	{
+		ID:          "centurion",
+		Name:        "Centurion",
+		Description: "Win 100 battles",
+		Icon:        "🛡️",
+		Event:       EventBattleWon,
+		Kind:        KindCount,
+		Target:      100,
+		Reward:      Reward{Coins: 1000, RerollTokens: 2},
+	},
+	{
+		ID:          "elementalist",
+		Name:        "Elementalist",
+		Description: "Defeat an opponent of every element",
+		Icon:        "🌈",
+		Event:       EventBattleWon,
+		Kind:        KindDistinct,
+		Key:         AttrOpponentElement,
+		Target:      elementCount(),
+		Reward:      Reward{Coins: 750, RerollTokens: 1},
+	},
+	{
+		ID:          "legendary_pull",
+		Name:        "Legendary Pull",
+		Description: "Roll a character with a Legendary stat, trait or characteristic",
+		Icon:        "🌟",
+		Event:       EventCharacterRolled,
+		Kind:        KindCount,
+		Filter:      map[string]string{AttrLegendary: "true"},
+		Target:      1,
+		Reward:      Reward{Coins: 300},
+	},
+	{
+		ID:          "divine",
+		Name:        "Divine",
+		Description: "Own a character of the God race",
+		Icon:        "👑",
+		Event:       EventCharacterRolled,
+		Kind:        KindCount,
+		Filter:      map[string]string{AttrRace: "God"},
+		Target:      1,
+		Reward:      Reward{Coins: 500, RerollTokens: 1},
+	},
+	{
+		ID:          "bankrupt",
+		Name:        "Shopaholic",
+		Description: "Spend your last coin in the shop",
+		Icon:        "💸",
+		Event:       EventItemBought,
+		Kind:        KindCount,
+		Filter:      map[string]string{AttrBankrupt: "true"},
+		Target:      1,
+		Reward:      Reward{Coins: 25},
+	},
+	{
+		ID:          "seasoned",
+		Name:        "Seasoned",
+		Description: "Reach level 10",
+		Icon:        "📈",
+		Event:       EventLevelReached,
+		Kind:        KindReach,
+		Target:      10,
+		Reward:      Reward{Coins: 200},
+	},
+	{
+		ID:          "veteran",
+		Name:        "Veteran",
+		Description: "Reach level 25",
+		Icon:        "🎖️",
+		Event:       EventLevelReached,
+		Kind:        KindReach,
+		Target:      25,
+		Reward:      Reward{Coins: 500, RerollTokens: 1},
+	},
+	{
+		ID:          "deep_diver",
+		Name:        "Deep Diver",
+		Description: "Clear dungeon floor 10",
+		Icon:        "🕳️",
+		Event:       EventDungeonFloor,
+		Kind:        KindReach,
+		Target:      10,
+		Reward:      Reward{Coins: 500},
+	},
+	{
+		ID:          "dedicated",
+		Name:        "Dedicated",
+		Description: "Reach a 30 day daily streak",
+		Icon:        "📅",
+		Event:       EventDailyClaimed,
+		Kind:        KindReach,
+		Target:      30,
+		Reward:      Reward{Coins: 1000, RerollTokens: 2},
+	},
+	{
+		ID:          "reborn",
+		Name:        "Reborn",
+		Description: "Rebirth a character for the first time",
+		Icon:        "🔥",
+		Event:       EventRebirth,
+		Kind:        KindCount,
+		Target:      1,
+		Reward:      Reward{RerollTokens: 1},
+	},
+}
+
+// elementCount returns how many elements can be collected, excluding None
+func elementCount() int {
+	count := 0
+	for _, option := range roller.ElementOptions {
+		if option.Value != "None" {
+			count++
+		}
+	}
+	return count
+}
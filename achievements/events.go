@@ -0,0 +1,85 @@
+package achievements
+
+import (
+	"CrispyBot/database/models"
+	"strconv"
+)
+
+// RollEvent describes a freshly rolled or rerolled character
+func RollEvent(character models.Character, source string) Event {
+	return Event{
+		Type: EventCharacterRolled,
+		Attributes: map[string]string{
+			AttrRace:      character.Characteristics.Race.Trait_Name,
+			AttrLegendary: strconv.FormatBool(hasLegendary(character)),
+			AttrSource:    source,
+		},
+	}
+}
+
+// BattleWonEvent describes a win against an NPC or another player
+// Note: npcName is empty for players, finisher is the action that landed the final blow.
+func BattleWonEvent(opponentElement string, npcName string, finisher string) Event {
+	opponent := "player"
+	if npcName != "" {
+		opponent = "npc"
+	}
+
+	// Elementless opponents don't count toward element collections
+	if opponentElement == "None" {
+		opponentElement = ""
+	}
+
+	return Event{
+		Type: EventBattleWon,
+		Attributes: map[string]string{
+			AttrOpponent:        opponent,
+			AttrOpponentElement: opponentElement,
+			AttrNPC:             npcName,
+			AttrFinisher:        finisher,
+		},
+	}
+}
+
+// PurchaseEvent describes a shop purchase and the wallet left afterwards
+func PurchaseEvent(rarity string, walletAfter int) Event {
+	return Event{
+		Type: EventItemBought,
+		Attributes: map[string]string{
+			AttrRarity:   rarity,
+			AttrBankrupt: strconv.FormatBool(walletAfter <= 0),
+		},
+	}
+}
+
+// ValueEvent describes reaching a level, dungeon floor or daily streak
+func ValueEvent(eventType string, value int) Event {
+	return Event{Type: eventType, Value: value}
+}
+
+// hasLegendary checks if any stat, trait or characteristic rolled Legendary
+func hasLegendary(character models.Character) bool {
+	rarities := []string{
+		character.Stats.Vitality.Rarity,
+		character.Stats.Durability.Rarity,
+		character.Stats.Speed.Rarity,
+		character.Stats.Strength.Rarity,
+		character.Stats.Intelligence.Rarity,
+		character.Stats.Mana.Rarity,
+		character.Stats.Mastery.Rarity,
+		character.Traits.Innate.Rarity,
+		character.Traits.Inadequacy.Rarity,
+		character.Traits.X_Factor.Rarity,
+		character.Characteristics.Race.Rarity,
+		character.Characteristics.Alignment.Rarity,
+		character.Characteristics.Element.Rarity,
+		character.Characteristics.Height.Rarity,
+	}
+
+	for _, rarity := range rarities {
+		if rarity == "Legendary" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,206 @@
+package banners
+
+import (
+	"CrispyBot/database/models"
+	"CrispyBot/roller"
+	"CrispyBot/variables"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StartLayout is the format admins use for a banner's start time
+const StartLayout = "2006-01-02 15:04"
+
+// Banner name limits
+const (
+	NameMinLength = 3
+	NameMaxLength = 40
+)
+
+// Usage explains the banner spec accepted by Parse
+const Usage = "`name=Dragon Week; hours=48; start=2026-10-20 18:00; race=Dragonborn:200, God:100; innate=Blessed:150; weapon=Excalibur:300; odds=40/25/20/10/5; description=...`"
+
+// Parse reads a `key=value; key=value` banner spec typed by an admin
+// Note: Start times are read in the reset timezone. Without one the banner starts now.
+func Parse(spec string, now time.Time, location *time.Location) (models.Banner, error) {
+	banner := models.Banner{StartsAt: now}
+	hours := 0
+
+	for _, part := range strings.Split(spec, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return models.Banner{}, fmt.Errorf("%q is missing a value, write it as key=value", part)
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		var err error
+		switch key {
+		case "name":
+			banner.Name = value
+		case "description":
+			banner.Description = value
+		case "hours":
+			hours, err = strconv.Atoi(value)
+			if err != nil || hours < 1 || hours > variables.BannerMaxHours {
+				err = fmt.Errorf("hours must be a number from 1 to %d", variables.BannerMaxHours)
+			}
+		case "start":
+			banner.StartsAt, err = time.ParseInLocation(StartLayout, value, location)
+			if err != nil {
+				err = fmt.Errorf("start must look like %s", StartLayout)
+			}
+		case "race":
+			banner.RaceBoosts, err = parseBoosts(value, namesOf(roller.RaceRarity))
+		case "innate":
+			banner.InnateBoosts, err = parseBoosts(value, namesOf(roller.InnateRarity))
+		case "weapon":
+			banner.WeaponBoosts, err = parseBoosts(value, weaponNames())
+		case "odds":
+			banner.Odds, err = parseOdds(value)
+		default:
+			err = fmt.Errorf("unknown banner setting %s", key)
+		}
+		if err != nil {
+			return models.Banner{}, err
+		}
+	}
+
+	if len(banner.Name) < NameMinLength || len(banner.Name) > NameMaxLength {
+		return models.Banner{}, fmt.Errorf("name must be %d to %d characters", NameMinLength, NameMaxLength)
+	}
+	if hours == 0 {
+		return models.Banner{}, fmt.Errorf("hours is required")
+	}
+	if banner.StartsAt.Before(now.Add(-time.Minute)) {
+		return models.Banner{}, fmt.Errorf("start can't be in the past")
+	}
+	if len(banner.RaceBoosts) == 0 && len(banner.InnateBoosts) == 0 && len(banner.WeaponBoosts) == 0 && banner.Odds == nil {
+		return models.Banner{}, fmt.Errorf("a banner needs at least one race, innate or weapon rate-up, or custom odds")
+	}
+
+	banner.EndsAt = banner.StartsAt.Add(time.Duration(hours) * time.Hour)
+	return banner, nil
+}
+
+// Featured lists a boost map as `Name (+N%)`, biggest rate-up first
+func Featured(boosts map[string]int) string {
+	names := make([]string, 0, len(boosts))
+	for name := range boosts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if boosts[names[i]] != boosts[names[j]] {
+			return boosts[names[i]] > boosts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s (+%d%%)", name, boosts[name])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// parseBoosts reads `Name:percent, Name:percent`, matching names case-insensitively
+func parseBoosts(value string, known []string) (map[string]int, error) {
+	boosts := map[string]int{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, percentText, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("%q needs a rate-up, write it as Name:percent", entry)
+		}
+
+		canonical, ok := matchName(strings.TrimSpace(name), known)
+		if !ok {
+			return nil, fmt.Errorf("%s is not something that can be rolled", strings.TrimSpace(name))
+		}
+
+		percent, err := strconv.Atoi(strings.TrimSpace(percentText))
+		if err != nil || percent < 1 || percent > variables.BannerMaxBoost {
+			return nil, fmt.Errorf("the rate-up for %s must be a percent from 1 to %d", canonical, variables.BannerMaxBoost)
+		}
+		boosts[canonical] = percent
+	}
+
+	if len(boosts) == 0 {
+		return nil, fmt.Errorf("list at least one Name:percent rate-up")
+	}
+	return boosts, nil
+}
+
+// parseOdds reads `common/uncommon/rare/epic/legendary` weights
+func parseOdds(value string) (*models.RarityOdds, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) != len(roller.TierNames()) {
+		return nil, fmt.Errorf("odds must be %d weights like 40/25/20/10/5", len(roller.TierNames()))
+	}
+
+	weights := make([]int, len(parts))
+	total := 0
+	for i, part := range parts {
+		weight, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("odds must be whole numbers of 0 or more")
+		}
+		weights[i] = weight
+		total += weight
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("odds can't all be 0")
+	}
+	// Pity guarantees Epic or better, so one of them needs a chance
+	if weights[3]+weights[4] == 0 {
+		return nil, fmt.Errorf("epic and legendary odds can't both be 0")
+	}
+
+	return &models.RarityOdds{
+		Common:    weights[0],
+		Uncommon:  weights[1],
+		Rare:      weights[2],
+		Epic:      weights[3],
+		Legendary: weights[4],
+	}, nil
+}
+
+// matchName finds the canonical spelling of a name
+func matchName(name string, known []string) (string, bool) {
+	for _, candidate := range known {
+		if strings.EqualFold(candidate, name) {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// namesOf lists every option of a rarity map
+func namesOf(rarityMap map[string][]string) []string {
+	names := []string{}
+	for _, tier := range roller.TierNames() {
+		names = append(names, rarityMap[tier]...)
+	}
+	return names
+}
+
+// weaponNames lists every starting weapon
+func weaponNames() []string {
+	names := make([]string, len(roller.WeaponOptions))
+	for i, option := range roller.WeaponOptions {
+		names[i] = option.Value
+	}
+	return names
+}
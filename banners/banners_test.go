@@ -0,0 +1,71 @@
+package banners
+
+import (
+	"CrispyBot/variables"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	banner, err := Parse("name=Dragon Week; hours=48; race=dragonborn:200, God:100; innate=Blessed:50; odds=40/25/20/10/5; description=Dragons!", now, time.UTC)
+	if err != nil {
+		t.Fatalf("Expected a valid banner, got %v", err)
+	}
+	if banner.Name != "Dragon Week" || banner.Description != "Dragons!" {
+		t.Errorf("Expected name and description to be read, got %+v", banner)
+	}
+	if banner.RaceBoosts["Dragonborn"] != 200 || banner.RaceBoosts["God"] != 100 || banner.InnateBoosts["Blessed"] != 50 {
+		t.Errorf("Expected canonical rate-ups, got %v and %v", banner.RaceBoosts, banner.InnateBoosts)
+	}
+	if banner.Odds == nil || banner.Odds.Legendary != 5 {
+		t.Errorf("Expected custom odds, got %+v", banner.Odds)
+	}
+	if !banner.StartsAt.Equal(now) || !banner.EndsAt.Equal(now.Add(48*time.Hour)) {
+		t.Errorf("Expected the banner to run for 48 hours from now, got %v to %v", banner.StartsAt, banner.EndsAt)
+	}
+}
+
+func TestParse_Start(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	banner, err := Parse("name=Later; hours=1; start=2026-10-20 18:00; weapon=Excalibur:300", now, time.UTC)
+	if err != nil {
+		t.Fatalf("Expected a scheduled banner, got %v", err)
+	}
+	if want := time.Date(2026, 10, 20, 18, 0, 0, 0, time.UTC); !banner.StartsAt.Equal(want) {
+		t.Errorf("Expected the banner to start at %v, got %v", want, banner.StartsAt)
+	}
+
+	if _, err := Parse("name=Past; hours=1; start=2026-10-18 18:00; weapon=Excalibur:300", now, time.UTC); err == nil {
+		t.Errorf("Expected a start in the past to be rejected")
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	now := time.Now()
+	specs := map[string]string{
+		"missing name":     "hours=4; race=God:100",
+		"missing hours":    "name=Gods; race=God:100",
+		"too long":         "name=Gods; hours=100000; race=God:100",
+		"unknown race":     "name=Gods; hours=4; race=Robot:100",
+		"boost too big":    fmt.Sprintf("name=Gods; hours=4; race=God:%d", variables.BannerMaxBoost+1),
+		"bad odds":         "name=Gods; hours=4; odds=1/2/3",
+		"no epic odds":     "name=Gods; hours=4; race=God:100; odds=50/50/0/0/0",
+		"nothing featured": "name=Gods; hours=4",
+		"unknown setting":  "name=Gods; hours=4; race=God:100; luck=7",
+	}
+	for reason, spec := range specs {
+		if _, err := Parse(spec, now, time.UTC); err == nil {
+			t.Errorf("Expected %s to be rejected: %s", reason, spec)
+		}
+	}
+}
+
+func TestFeatured(t *testing.T) {
+	if got := Featured(map[string]int{"God": 100, "Dragonborn": 200}); got != "Dragonborn (+200%), God (+100%)" {
+		t.Errorf("Expected biggest rate-up first, got %q", got)
+	}
+}
@@ -0,0 +1,145 @@
+package achievementhandlers
+
+import (
+	"CrispyBot/achievements"
+	"CrispyBot/database"
+	"CrispyBot/i18n"
+	"CrispyBot/quests"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// progressBarWidth is how many segments the progress bars have
+const progressBarWidth = 10
+
+// Track records a game event toward the user's achievements and quests and announces anything it completed in the channel
+func Track(session *discordgo.Session, channelID string, userID string, events ...achievements.Event) {
+	db := database.DBInit()
+
+	for _, event := range events {
+		unlocked, err := database.RecordAchievementEvent(db, userID, event)
+		if err != nil {
+			fmt.Printf("Error recording achievement event %s: %v\n", event.Type, err)
+		}
+		for _, definition := range unlocked {
+			announce(session, channelID, userID, definition)
+		}
+
+		completed, err := database.RecordQuestEvent(db, userID, event)
+		if err != nil {
+			fmt.Printf("Error recording quest event %s: %v\n", event.Type, err)
+		}
+		for _, template := range completed {
+			announceQuest(session, channelID, userID, template)
+		}
+	}
+}
+
+// channelLocalizer returns the translator for a user in the guild a channel belongs to
+func channelLocalizer(session *discordgo.Session, channelID string, userID string) i18n.Localizer {
+	guildID := ""
+	if channel, err := session.State.Channel(channelID); err == nil {
+		guildID = channel.GuildID
+	}
+	return database.GetLocalizer(database.DBInit(), userID, guildID)
+}
+
+// announce posts an achievement unlock in the channel
+func announce(session *discordgo.Session, channelID string, userID string, definition achievements.Definition) {
+	loc := channelLocalizer(session, channelID, userID)
+	embed := &discordgo.MessageEmbed{
+		Title:       loc.T("achievement.unlocked", i18n.Args{"icon": definition.Icon, "name": AchievementName(definition, loc)}),
+		Description: fmt.Sprintf("<@%s> — %s", userID, AchievementDescription(definition, loc)),
+		Color:       0xFFD700,
+	}
+
+	if reward := FormatReward(definition.Reward, loc); reward != "" {
+		embed.Fields = []*discordgo.MessageEmbedField{{Name: loc.T("achievement.reward"), Value: reward}}
+	}
+
+	session.ChannelMessageSendEmbed(channelID, embed)
+}
+
+// announceQuest posts a quest completion in the channel
+func announceQuest(session *discordgo.Session, channelID string, userID string, template quests.Template) {
+	loc := channelLocalizer(session, channelID, userID)
+	embed := &discordgo.MessageEmbed{
+		Title:       loc.T("quest.complete"),
+		Description: fmt.Sprintf("<@%s> — %s", userID, QuestDescription(template, loc)),
+		Color:       0x00AAFF,
+		Fields:      []*discordgo.MessageEmbedField{{Name: loc.T("achievement.reward"), Value: FormatReward(template.Reward, loc)}},
+	}
+
+	session.ChannelMessageSendEmbed(channelID, embed)
+}
+
+// HandleAchievementsCommand shows the user's unlocked achievements and progress toward the rest
+func HandleAchievementsCommand(session *discordgo.Session, message *discordgo.MessageCreate) {
+	loc := database.GetLocalizer(database.DBInit(), message.Author.ID, message.GuildID)
+	progress, err := database.GetAchievementProgress(database.DBInit(), message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	var fields []*discordgo.MessageEmbedField
+	for _, definition := range achievements.Definitions {
+		current := achievements.Progress(progress, definition)
+		description := AchievementDescription(definition, loc)
+		value := fmt.Sprintf("%s\n%s %d/%d",
+			description,
+			achievements.ProgressBar(current, definition.Target, progressBarWidth),
+			current, definition.Target)
+
+		name := fmt.Sprintf("%s %s", definition.Icon, AchievementName(definition, loc))
+		if unlockedAt, ok := progress.Unlocked[definition.ID]; ok {
+			name = "✅ " + name
+			value = loc.T("achievement.unlocked_on", i18n.Args{"description": description, "date": unlockedAt.Format("2006-01-02")})
+		} else if reward := FormatReward(definition.Reward, loc); reward != "" {
+			value += "\n" + loc.T("achievement.reward_line", i18n.Args{"reward": reward})
+		}
+
+		fields = append(fields, &discordgo.MessageEmbedField{Name: name, Value: value})
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title: loc.T("achievement.title", i18n.Args{
+			"user":     message.Author.Username,
+			"unlocked": len(progress.Unlocked),
+			"total":    len(achievements.Definitions),
+		}),
+		Color:  0xFFD700,
+		Fields: fields,
+	}
+
+	session.ChannelMessageSendEmbed(message.ChannelID, embed)
+}
+
+// FormatReward describes an achievement reward, empty if there is none
+func FormatReward(reward achievements.Reward, loc i18n.Localizer) string {
+	var parts []string
+	if reward.Coins > 0 {
+		parts = append(parts, loc.T("common.coins", i18n.Args{"count": reward.Coins}))
+	}
+	if reward.RerollTokens > 0 {
+		parts = append(parts, loc.T("common.reroll_tokens", i18n.Args{"count": reward.RerollTokens}))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// AchievementName translates an achievement's name, its definition's English is the fallback
+func AchievementName(definition achievements.Definition, loc i18n.Localizer) string {
+	return loc.Or("data.achievement."+definition.ID+".name", definition.Name)
+}
+
+// AchievementDescription translates an achievement's description, its definition's English is the fallback
+func AchievementDescription(definition achievements.Definition, loc i18n.Localizer) string {
+	return loc.Or("data.achievement."+definition.ID+".description", definition.Description)
+}
+
+// QuestDescription translates a quest's description, its template's English is the fallback
+func QuestDescription(template quests.Template, loc i18n.Localizer) string {
+	return loc.Or("data.quest."+template.ID, template.Description)
+}
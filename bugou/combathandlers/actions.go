@@ -1,41 +1,46 @@
 package combathandlers
 
 import (
+	"CrispyBot/i18n"
+	"CrispyBot/npc"
 	"CrispyBot/variables"
 	"fmt"
 	"math/rand"
+	"strings"
 	"time"
 )
 
-// executeAction performs the selected action from the attacker to the target
-func executeAction(attacker, target *CombatParticipant, actionName string) (string, error) {
+// executeAction performs the selected action from the attacker to the target, logging it in the battle's language
+func executeAction(attacker, target *CombatParticipant, actionName string, loc i18n.Localizer) (string, error) {
 	// Check if attacker is stunned
 	if _, isStunned := attacker.StatusEffects["Stun"]; isStunned {
-		return fmt.Sprintf("%s is stunned and cannot move!", attacker.UserName), nil
+		return loc.T("battle.log.stunned", i18n.Args{"name": attacker.UserName}), nil
 	}
 
 	// Execute the appropriate action
 	switch actionName {
 	case "attack":
-		return physicalAttack(attacker, target)
+		return physicalAttack(attacker, target, loc)
 	case "magic":
-		return magicalAttack(attacker, target)
+		return magicalAttack(attacker, target, loc)
 	case "defend":
-		return defend(attacker)
+		return defend(attacker, loc)
 	case "item":
-		return useItem(attacker)
+		return useItem(attacker, loc)
+	case "skill":
+		return useSkill(attacker, target, attacker.SkillThisTurn, loc)
 	default:
 		return "", fmt.Errorf("unknown action: %s", actionName)
 	}
 }
 
 // physicalAttack executes a physical attack
-func physicalAttack(attacker, target *CombatParticipant) (string, error) {
+func physicalAttack(attacker, target *CombatParticipant, loc i18n.Localizer) (string, error) {
 	// Initialize random number generator
 	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
 
-	// Check if attack hits
-	hitChance := attacker.Accuracy
+	// Check if attack hits, easier against lower-level targets
+	hitChance := attacker.Accuracy + levelAccuracyBonus(attacker, target)
 	hitRoll := rng.Intn(100)
 
 	// Check for dodge
@@ -44,16 +49,16 @@ func physicalAttack(attacker, target *CombatParticipant) (string, error) {
 
 	// If dodge successful
 	if dodgeRoll < dodgeChance {
-		return fmt.Sprintf("%s attempts a physical attack, but %s dodges!", attacker.UserName, target.UserName), nil
+		return loc.T("battle.log.attack_dodged", i18n.Args{"name": attacker.UserName, "target": target.UserName}), nil
 	}
 
 	// If attack misses
 	if hitRoll >= hitChance {
-		return fmt.Sprintf("%s's attack misses!", attacker.UserName), nil
+		return loc.T("battle.log.attack_missed", i18n.Args{"name": attacker.UserName}), nil
 	}
 
-	// Calculate base damage
-	damage := attacker.PhysicalDamage
+	// Calculate base damage, scaled by the level gap
+	damage := int(float64(attacker.PhysicalDamage) * levelDamageMultiplier(attacker, target))
 
 	// Check for critical hit (base 5% chance)
 	critChance := variables.BaseCritChance
@@ -85,20 +90,20 @@ func physicalAttack(attacker, target *CombatParticipant) (string, error) {
 	// Format result message
 	var result string
 	if isCrit {
-		result = fmt.Sprintf("%s lands a critical hit for %d damage!", attacker.UserName, finalDamage)
+		result = loc.T("battle.log.attack_crit", i18n.Args{"name": attacker.UserName, "damage": finalDamage})
 	} else {
-		result = fmt.Sprintf("%s attacks %s for %d damage!", attacker.UserName, target.UserName, finalDamage)
+		result = loc.T("battle.log.attack", i18n.Args{"name": attacker.UserName, "target": target.UserName, "damage": finalDamage})
 	}
 
 	return result, nil
 }
 
 // magicalAttack executes a magical attack
-func magicalAttack(attacker, target *CombatParticipant) (string, error) {
+func magicalAttack(attacker, target *CombatParticipant, loc i18n.Localizer) (string, error) {
 	// Check if attacker has enough mana
 	manaCost := variables.MagicAttackBaseManaCost
 	if attacker.CurrentMP < manaCost {
-		return fmt.Sprintf("%s doesn't have enough mana to cast a spell!", attacker.UserName), nil
+		return loc.T("battle.log.magic_no_mana", i18n.Args{"name": attacker.UserName}), nil
 	}
 
 	// Consume mana
@@ -108,7 +113,7 @@ func magicalAttack(attacker, target *CombatParticipant) (string, error) {
 	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
 
 	// Check if spell hits
-	hitChance := attacker.Accuracy - 5 // Magic is slightly harder to hit with
+	hitChance := attacker.Accuracy - 5 + levelAccuracyBonus(attacker, target) // Magic is slightly harder to hit with
 	hitRoll := rng.Intn(100)
 
 	// Magic attacks can't be dodged as easily
@@ -117,16 +122,16 @@ func magicalAttack(attacker, target *CombatParticipant) (string, error) {
 
 	// If dodge successful
 	if dodgeRoll < dodgeChance {
-		return fmt.Sprintf("%s casts a spell, but %s manages to avoid it!", attacker.UserName, target.UserName), nil
+		return loc.T("battle.log.magic_dodged", i18n.Args{"name": attacker.UserName, "target": target.UserName}), nil
 	}
 
 	// If spell misses
 	if hitRoll >= hitChance {
-		return fmt.Sprintf("%s's spell fizzles out!", attacker.UserName), nil
+		return loc.T("battle.log.magic_missed", i18n.Args{"name": attacker.UserName}), nil
 	}
 
-	// Calculate base damage
-	damage := attacker.MagicalDamage
+	// Calculate base damage, scaled by the level gap
+	damage := int(float64(attacker.MagicalDamage) * levelDamageMultiplier(attacker, target))
 
 	// Check for critical hit (base 5% chance)
 	critChance := variables.BaseCritChance
@@ -162,17 +167,13 @@ func magicalAttack(attacker, target *CombatParticipant) (string, error) {
 	// Format result message with elemental effectiveness
 	var result string
 	if isCrit {
-		result = fmt.Sprintf("%s casts a critical %s spell for %d damage!", attacker.UserName, attacker.Element, finalDamage)
+		result = loc.T("battle.log.magic_crit", i18n.Args{"name": attacker.UserName, "element": attacker.Element, "damage": finalDamage})
 	} else {
-		result = fmt.Sprintf("%s casts a %s spell on %s for %d damage!", attacker.UserName, attacker.Element, target.UserName, finalDamage)
+		result = loc.T("battle.log.magic", i18n.Args{"name": attacker.UserName, "element": attacker.Element, "target": target.UserName, "damage": finalDamage})
 	}
 
 	// Add effectiveness message
-	if effectiveness > 1.0 {
-		result += " It's super effective!"
-	} else if effectiveness < 1.0 {
-		result += " It's not very effective..."
-	}
+	result += effectivenessText(effectiveness, loc)
 
 	// Chance to apply status effect based on element
 	statusRoll := rng.Intn(100)
@@ -180,28 +181,156 @@ func magicalAttack(attacker, target *CombatParticipant) (string, error) {
 		statusEffect := getElementalStatusEffect(attacker.Element)
 		if statusEffect != "" {
 			// Apply status effect (lasts 3 turns)
-			target.StatusEffects[statusEffect] = 3
-			result += fmt.Sprintf(" %s is now %s!", target.UserName, statusEffect)
+			result += applyStatusEffect(target, statusEffect, 3, loc)
 		}
 	}
 
 	return result, nil
 }
 
+// useSkill executes one of an NPC's signature skills
+func useSkill(attacker, target *CombatParticipant, skillName string, loc i18n.Localizer) (string, error) {
+	if attacker.NPC == nil {
+		return "", fmt.Errorf("%s has no skills", attacker.UserName)
+	}
+
+	var skill *npc.Skill
+	for i := range attacker.NPC.Skills {
+		if attacker.NPC.Skills[i].Name == skillName {
+			skill = &attacker.NPC.Skills[i]
+			break
+		}
+	}
+	if skill == nil {
+		return "", fmt.Errorf("unknown skill: %s", skillName)
+	}
+
+	if attacker.CurrentMP < skill.ManaCost {
+		return loc.T("battle.log.skill_no_mana", i18n.Args{"name": attacker.UserName, "skill": skill.Name}), nil
+	}
+	attacker.CurrentMP -= skill.ManaCost
+
+	// Healing skills restore a fraction of the target ally's max HP
+	if skill.Kind == npc.SkillHeal {
+		healAmount := int(float64(target.MaxHP) * skill.Power)
+		target.CurrentHP += healAmount
+		if target.CurrentHP > target.MaxHP {
+			target.CurrentHP = target.MaxHP
+		}
+		if target == attacker {
+			return loc.T("battle.log.skill_heal_self", i18n.Args{"name": attacker.UserName, "skill": skill.Name, "hp": healAmount}), nil
+		}
+		return loc.T("battle.log.skill_heal", i18n.Args{"name": attacker.UserName, "skill": skill.Name, "target": target.UserName, "hp": healAmount}), nil
+	}
+
+	// Initialize random number generator
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	// Skills use the same hit and dodge rules as the basic attack they're built on
+	dodgeChance := target.DodgeChance
+	damage := attacker.PhysicalDamage
+	maxReduction := 0.75
+	defenseReduction := float64(target.Defense) / 100.0
+	effectiveness := 1.0
+	if skill.Kind == npc.SkillMagic {
+		dodgeChance = target.DodgeChance / 2
+		damage = attacker.MagicalDamage
+		maxReduction = 0.5
+		defenseReduction = float64(target.Defense) / 200.0
+		effectiveness = getElementalEffectiveness(attacker.Element, target.Element)
+	}
+
+	if rng.Intn(100) < dodgeChance {
+		return loc.T("battle.log.skill_dodged", i18n.Args{"name": attacker.UserName, "skill": skill.Name, "target": target.UserName}), nil
+	}
+
+	if rng.Intn(100) >= attacker.Accuracy+levelAccuracyBonus(attacker, target) {
+		return loc.T("battle.log.skill_missed", i18n.Args{"name": attacker.UserName, "skill": skill.Name}), nil
+	}
+
+	if defenseReduction > maxReduction {
+		defenseReduction = maxReduction
+	}
+
+	finalDamage := int(float64(damage) * skill.Power * effectiveness * levelDamageMultiplier(attacker, target) * (1.0 - defenseReduction))
+	if finalDamage < 1 {
+		finalDamage = 1 // Minimum damage is 1
+	}
+
+	// Apply damage
+	target.CurrentHP -= finalDamage
+	if target.CurrentHP < 0 {
+		target.CurrentHP = 0
+	}
+
+	result := loc.T("battle.log.skill", i18n.Args{"name": attacker.UserName, "skill": skill.Name, "target": target.UserName, "damage": finalDamage})
+	result += effectivenessText(effectiveness, loc)
+
+	if skill.Effect != "" && rng.Intn(100) < skill.EffectChance {
+		result += applyStatusEffect(target, skill.Effect, skill.EffectTurns, loc)
+	}
+
+	return result, nil
+}
+
+// effectivenessText is the log remark for an elemental damage multiplier, empty when neutral
+func effectivenessText(effectiveness float64, loc i18n.Localizer) string {
+	if effectiveness > 1.0 {
+		return " " + loc.T("battle.log.super_effective")
+	} else if effectiveness < 1.0 {
+		return " " + loc.T("battle.log.not_effective")
+	}
+	return ""
+}
+
+// applyStatusEffect inflicts a status effect unless the target is immune, returning the log text
+func applyStatusEffect(target *CombatParticipant, effect string, turns int, loc i18n.Localizer) string {
+	if target.NPC != nil && target.NPC.IsImmune(effect) {
+		return " " + loc.T("battle.log.immune", i18n.Args{"name": target.UserName, "effect": effectName(effect, loc)})
+	}
+
+	target.StatusEffects[effect] = turns
+	return " " + loc.T("battle.log.afflicted", i18n.Args{"name": target.UserName, "effect": effectName(effect, loc)})
+}
+
+// effectName translates a status effect such as "Burn", falling back to the name itself
+func effectName(effect string, loc i18n.Localizer) string {
+	return loc.Or("battle.effect."+strings.ToLower(effect), effect)
+}
+
 // defend increases defense for one turn
-func defend(participant *CombatParticipant) (string, error) {
-	// Increase defense by 50% for one turn
-	defenseBoost := participant.Defense / 2
-	participant.Defense += defenseBoost
+func defend(participant *CombatParticipant, loc i18n.Localizer) (string, error) {
+	// Increase defense by 50% until the participant's next turn
+	if participant.DefenseBoost == 0 {
+		participant.DefenseBoost = participant.Defense / 2
+		participant.Defense += participant.DefenseBoost
+	}
 
 	// Add status to remove boost next turn
 	participant.StatusEffects["Defending"] = 1
 
-	return fmt.Sprintf("%s takes a defensive stance, increasing defense!", participant.UserName), nil
+	return loc.T("battle.log.defend", i18n.Args{"name": participant.UserName}), nil
+}
+
+// endDefending removes the defense boost once the defender's next turn comes around
+func endDefending(participant *CombatParticipant) {
+	if _, defending := participant.StatusEffects["Defending"]; !defending {
+		return
+	}
+
+	participant.Defense -= participant.DefenseBoost
+	participant.DefenseBoost = 0
+	delete(participant.StatusEffects, "Defending")
 }
 
 // useItem uses an item from inventory (placeholder for now)
-func useItem(participant *CombatParticipant) (string, error) {
+// Note: Each fighter only carries BattleItemUses of them.
+func useItem(participant *CombatParticipant, loc i18n.Localizer) (string, error) {
+	if participant.ItemsUsed >= variables.BattleItemUses {
+		return loc.T("battle.log.item_none", i18n.Args{"name": participant.UserName}), nil
+	}
+	participant.ItemsUsed++
+
 	// Heal 20% of max HP
 	healAmount := participant.MaxHP / 5
 	participant.CurrentHP += healAmount
@@ -211,7 +340,7 @@ func useItem(participant *CombatParticipant) (string, error) {
 		participant.CurrentHP = participant.MaxHP
 	}
 
-	return fmt.Sprintf("%s uses a healing item, recovering %d HP!", participant.UserName, healAmount), nil
+	return loc.T("battle.log.item", i18n.Args{"name": participant.UserName, "hp": healAmount}), nil
 }
 
 // getElementalEffectiveness returns the damage multiplier based on attacker and defender elements
@@ -0,0 +1,328 @@
+package combathandlers
+
+import (
+	"CrispyBot/i18n"
+	"CrispyBot/npc"
+	"CrispyBot/variables"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// NPCDecision is the action an NPC strategy picks for its turn
+type NPCDecision struct {
+	Action   string // attack, magic, defend, item or skill
+	Skill    string // Skill name when Action is "skill"
+	TargetID string
+}
+
+// NPCStrategy picks an NPC's action each turn
+type NPCStrategy interface {
+	ChooseAction(battle *Battle, self *CombatParticipant, rng *rand.Rand) NPCDecision
+}
+
+// NPCStrategies maps AI profiles to the strategies that play them
+var NPCStrategies = map[string]NPCStrategy{
+	npc.AIBalanced:   balancedStrategy{},
+	npc.AIAggressive: aggressiveStrategy{},
+	npc.AIDefensive:  defensiveStrategy{},
+	npc.AICaster:     casterStrategy{},
+	npc.AISupport:    supportStrategy{},
+	npc.AIBoss:       bossStrategy{},
+	npc.AIDummy:      dummyStrategy{},
+}
+
+// RegisterNPCStrategy adds or replaces the strategy for an AI profile
+func RegisterNPCStrategy(profile string, strategy NPCStrategy) {
+	NPCStrategies[profile] = strategy
+}
+
+// selectNPCAction chooses an action for an NPC using its AI profile
+func selectNPCAction(battle *Battle, opponent *CombatParticipant) {
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	decision := strategyFor(opponent).ChooseAction(battle, opponent, rng)
+
+	// Set NPC's action and target
+	opponent.ActionThisTurn = decision.Action
+	opponent.SkillThisTurn = decision.Skill
+	opponent.TargetThisTurn = decision.TargetID
+}
+
+// strategyFor returns the strategy for a participant's AI profile, defaulting to balanced
+func strategyFor(participant *CombatParticipant) NPCStrategy {
+	profile := npc.AIBalanced
+	if participant.NPC != nil && participant.NPC.AIProfile != "" {
+		profile = participant.NPC.AIProfile
+	}
+
+	if strategy, exists := NPCStrategies[profile]; exists {
+		return strategy
+	}
+	return balancedStrategy{}
+}
+
+// balancedStrategy uses its stronger attack and occasionally a signature skill
+type balancedStrategy struct{}
+
+func (balancedStrategy) ChooseAction(battle *Battle, self *CombatParticipant, rng *rand.Rand) NPCDecision {
+	target := primaryTarget(battle, self)
+
+	if rng.Intn(3) == 0 {
+		skills := affordableSkills(self, npc.SkillPhysical, npc.SkillMagic)
+		if hpPercent(self) < 50 {
+			skills = append(skills, affordableSkills(self, npc.SkillHeal)...)
+		}
+		if len(skills) > 0 {
+			skill := skills[rng.Intn(len(skills))]
+			return skillDecision(skill, target)
+		}
+	}
+
+	return basicAttack(self, target)
+}
+
+// aggressiveStrategy always goes for the hardest-hitting option and never defends
+type aggressiveStrategy struct{}
+
+func (aggressiveStrategy) ChooseAction(battle *Battle, self *CombatParticipant, rng *rand.Rand) NPCDecision {
+	target := primaryTarget(battle, self)
+
+	if skill, ok := strongestSkill(self, target, affordableSkills(self, npc.SkillPhysical, npc.SkillMagic)); ok {
+		if skillDamage(self, target, skill) > basicDamage(self, target) {
+			return skillDecision(skill, target)
+		}
+	}
+
+	return basicAttack(self, target)
+}
+
+// defensiveStrategy heals or defends once its HP drops below 30%
+type defensiveStrategy struct{}
+
+func (defensiveStrategy) ChooseAction(battle *Battle, self *CombatParticipant, rng *rand.Rand) NPCDecision {
+	target := primaryTarget(battle, self)
+
+	if hpPercent(self) < 30 {
+		if heals := affordableSkills(self, npc.SkillHeal); len(heals) > 0 {
+			return skillDecision(heals[0], self)
+		}
+		if _, defending := self.StatusEffects["Defending"]; !defending {
+			return NPCDecision{Action: "defend", TargetID: target.DiscordID}
+		}
+	}
+
+	return balancedStrategy{}.ChooseAction(battle, self, rng)
+}
+
+// casterStrategy leans on magic when its element is effective and switches to physical when resisted
+type casterStrategy struct{}
+
+func (casterStrategy) ChooseAction(battle *Battle, self *CombatParticipant, rng *rand.Rand) NPCDecision {
+	target := primaryTarget(battle, self)
+	effectiveness := getElementalEffectiveness(self.Element, target.Element)
+
+	if effectiveness >= 1.0 {
+		if skill, ok := strongestSkill(self, target, affordableSkills(self, npc.SkillMagic)); ok {
+			return skillDecision(skill, target)
+		}
+		if self.CurrentMP >= variables.MagicAttackBaseManaCost {
+			return NPCDecision{Action: "magic", TargetID: target.DiscordID}
+		}
+	}
+
+	// Resisted or out of mana, so hit them physically instead
+	if skill, ok := strongestSkill(self, target, affordableSkills(self, npc.SkillPhysical)); ok {
+		return skillDecision(skill, target)
+	}
+	return NPCDecision{Action: "attack", TargetID: target.DiscordID}
+}
+
+// supportStrategy heals the most wounded ally, escorts and itself included, before fighting like a caster
+type supportStrategy struct{}
+
+func (supportStrategy) ChooseAction(battle *Battle, self *CombatParticipant, rng *rand.Rand) NPCDecision {
+	allies := alliesOf(battle, self)
+	sort.SliceStable(allies, func(i, j int) bool {
+		return hpPercent(allies[i]) < hpPercent(allies[j])
+	})
+
+	if len(allies) > 0 && hpPercent(allies[0]) < 50 {
+		if heals := affordableSkills(self, npc.SkillHeal); len(heals) > 0 {
+			return skillDecision(heals[0], allies[0])
+		}
+		// Items only heal the user, and run out so a support can't stall the fight
+		if allies[0] == self && self.ItemsUsed < variables.BattleItemUses {
+			return NPCDecision{Action: "item", TargetID: primaryTarget(battle, self).DiscordID}
+		}
+	}
+
+	return casterStrategy{}.ChooseAction(battle, self, rng)
+}
+
+// bossStrategy follows the NPC's phase script, switching behavior as its HP falls
+type bossStrategy struct{}
+
+func (bossStrategy) ChooseAction(battle *Battle, self *CombatParticipant, rng *rand.Rand) NPCDecision {
+	phases := npc.DefaultBossPhases
+	script := "default"
+	if self.NPC != nil && len(self.NPC.Phases) > 0 {
+		phases = self.NPC.Phases
+		script = self.NPC.Name
+	}
+
+	// Enter every phase whose threshold has been crossed since the last turn
+	for self.BossPhase+1 < len(phases) && hpPercent(self) <= phases[self.BossPhase+1].HPPercent {
+		self.BossPhase++
+		phase := phases[self.BossPhase]
+
+		if phase.DamageBoost > 0 {
+			self.PhysicalDamage = int(float64(self.PhysicalDamage) * (1 + phase.DamageBoost))
+			self.MagicalDamage = int(float64(self.MagicalDamage) * (1 + phase.DamageBoost))
+		}
+		key := "data.npc_phase." + script + "." + phase.Name
+		battle.Log = append(battle.Log, battle.Localizer.T("battle.log.phase", i18n.Args{
+			"name":    self.UserName,
+			"phase":   battle.Localizer.Or(key+".name", phase.Name),
+			"message": battle.Localizer.Or(key+".message", phase.Message),
+		}))
+	}
+
+	strategy, exists := NPCStrategies[phases[self.BossPhase].AIProfile]
+	if !exists || phases[self.BossPhase].AIProfile == npc.AIBoss {
+		strategy = balancedStrategy{}
+	}
+	return strategy.ChooseAction(battle, self, rng)
+}
+
+// dummyStrategy always makes a plain attack so fights against it are predictable
+type dummyStrategy struct{}
+
+func (dummyStrategy) ChooseAction(battle *Battle, self *CombatParticipant, rng *rand.Rand) NPCDecision {
+	return NPCDecision{Action: "attack", TargetID: primaryTarget(battle, self).DiscordID}
+}
+
+// enemiesOf returns the living participants on the other side, in a stable order
+func enemiesOf(battle *Battle, self *CombatParticipant) []*CombatParticipant {
+	enemies := []*CombatParticipant{}
+	for _, participant := range battle.Participants {
+		if participant.side() != self.side() && participant.CurrentHP > 0 {
+			enemies = append(enemies, participant)
+		}
+	}
+
+	sort.Slice(enemies, func(i, j int) bool {
+		return enemies[i].DiscordID < enemies[j].DiscordID
+	})
+	return enemies
+}
+
+// alliesOf returns the living participants on the same side, including self, in a stable order
+func alliesOf(battle *Battle, self *CombatParticipant) []*CombatParticipant {
+	allies := []*CombatParticipant{}
+	for _, participant := range battle.Participants {
+		if participant.side() == self.side() && participant.CurrentHP > 0 {
+			allies = append(allies, participant)
+		}
+	}
+
+	sort.Slice(allies, func(i, j int) bool {
+		return allies[i].DiscordID < allies[j].DiscordID
+	})
+	return allies
+}
+
+// primaryTarget picks the weakest enemy, falling back to self if none are left
+func primaryTarget(battle *Battle, self *CombatParticipant) *CombatParticipant {
+	enemies := enemiesOf(battle, self)
+	if len(enemies) == 0 {
+		return self
+	}
+
+	target := enemies[0]
+	for _, enemy := range enemies[1:] {
+		if enemy.CurrentHP < target.CurrentHP {
+			target = enemy
+		}
+	}
+	return target
+}
+
+// hpPercent returns a participant's remaining HP as a percentage
+func hpPercent(participant *CombatParticipant) int {
+	if participant.MaxHP == 0 {
+		return 0
+	}
+	return participant.CurrentHP * 100 / participant.MaxHP
+}
+
+// affordableSkills returns the NPC's skills of the given kinds that it has mana for
+func affordableSkills(self *CombatParticipant, kinds ...string) []npc.Skill {
+	if self.NPC == nil {
+		return nil
+	}
+
+	skills := []npc.Skill{}
+	for _, skill := range self.NPC.Skills {
+		if skill.ManaCost > self.CurrentMP {
+			continue
+		}
+		for _, kind := range kinds {
+			if skill.Kind == kind {
+				skills = append(skills, skill)
+				break
+			}
+		}
+	}
+	return skills
+}
+
+// strongestSkill returns the skill expected to deal the most damage to the target
+func strongestSkill(self *CombatParticipant, target *CombatParticipant, skills []npc.Skill) (npc.Skill, bool) {
+	if len(skills) == 0 {
+		return npc.Skill{}, false
+	}
+
+	best := skills[0]
+	for _, skill := range skills[1:] {
+		if skillDamage(self, target, skill) > skillDamage(self, target, best) {
+			best = skill
+		}
+	}
+	return best, true
+}
+
+// skillDamage estimates a damaging skill's output before defense
+func skillDamage(self *CombatParticipant, target *CombatParticipant, skill npc.Skill) float64 {
+	if skill.Kind == npc.SkillMagic {
+		return float64(self.MagicalDamage) * skill.Power * getElementalEffectiveness(self.Element, target.Element)
+	}
+	return float64(self.PhysicalDamage) * skill.Power
+}
+
+// basicDamage estimates the better of a plain attack or spell before defense
+func basicDamage(self *CombatParticipant, target *CombatParticipant) float64 {
+	physical := float64(self.PhysicalDamage)
+	if self.CurrentMP < variables.MagicAttackBaseManaCost {
+		return physical
+	}
+
+	magical := float64(self.MagicalDamage) * getElementalEffectiveness(self.Element, target.Element)
+	if magical > physical {
+		return magical
+	}
+	return physical
+}
+
+// basicAttack picks a plain attack or spell, whichever should hit harder
+func basicAttack(self *CombatParticipant, target *CombatParticipant) NPCDecision {
+	if self.CurrentMP >= variables.MagicAttackBaseManaCost &&
+		float64(self.MagicalDamage)*getElementalEffectiveness(self.Element, target.Element) > float64(self.PhysicalDamage) {
+		return NPCDecision{Action: "magic", TargetID: target.DiscordID}
+	}
+	return NPCDecision{Action: "attack", TargetID: target.DiscordID}
+}
+
+// skillDecision builds the decision to use a skill on a target, which is an ally for heals
+func skillDecision(skill npc.Skill, target *CombatParticipant) NPCDecision {
+	return NPCDecision{Action: "skill", Skill: skill.Name, TargetID: target.DiscordID}
+}
@@ -0,0 +1,185 @@
+package combathandlers
+
+import (
+	"CrispyBot/i18n"
+	"CrispyBot/npc"
+	"CrispyBot/variables"
+	"math/rand"
+	"testing"
+)
+
+// Helper to build a battle between a test player and one or more NPCs
+func newTestBattle(playerElement string, opponents ...*CombatParticipant) (*Battle, *CombatParticipant) {
+	player := &CombatParticipant{
+		DiscordID:     "player",
+		UserName:      "Player",
+		CurrentHP:     1000,
+		MaxHP:         1000,
+		Element:       playerElement,
+		StatusEffects: make(map[string]int),
+	}
+
+	battle := &Battle{Participants: map[string]*CombatParticipant{player.DiscordID: player}}
+	for i, opponent := range opponents {
+		opponent.DiscordID = opponent.DiscordID + string(rune('a'+i))
+		battle.Participants[opponent.DiscordID] = opponent
+	}
+	return battle, player
+}
+
+func TestDummyStrategy_Deterministic(t *testing.T) {
+	dummy := CreateNPCOpponent("Training Dummy", 1)
+	battle, player := newTestBattle("None", dummy)
+
+	for seed := int64(0); seed < 20; seed++ {
+		decision := strategyFor(dummy).ChooseAction(battle, dummy, rand.New(rand.NewSource(seed)))
+		if decision.Action != "attack" || decision.TargetID != player.DiscordID {
+			t.Fatalf("Dummy should always attack the player, got %+v", decision)
+		}
+	}
+}
+
+func TestDefensiveStrategy_DefendsWhenLow(t *testing.T) {
+	knight := CreateNPCOpponent("Dark Knight", 5)
+	battle, _ := newTestBattle("None", knight)
+	knight.CurrentHP = knight.MaxHP / 5
+
+	decision := defensiveStrategy{}.ChooseAction(battle, knight, rand.New(rand.NewSource(1)))
+	if decision.Action != "defend" {
+		t.Errorf("Expected defend below 30%% HP, got %s", decision.Action)
+	}
+
+	// Already defending, so it should fight back instead
+	knight.StatusEffects["Defending"] = 1
+	decision = defensiveStrategy{}.ChooseAction(battle, knight, rand.New(rand.NewSource(1)))
+	if decision.Action == "defend" {
+		t.Errorf("Expected no repeated defend while already defending")
+	}
+}
+
+func TestCasterStrategy_ExploitsElements(t *testing.T) {
+	whelp := CreateNPCOpponent("Dragon Whelp", 7)
+
+	// Fire is strong against Nature
+	battle, _ := newTestBattle("Nature", whelp)
+	decision := casterStrategy{}.ChooseAction(battle, whelp, rand.New(rand.NewSource(1)))
+	if decision.Action != "skill" && decision.Action != "magic" {
+		t.Errorf("Expected magic against a weak element, got %s", decision.Action)
+	}
+
+	// Fire is resisted by Water
+	battle, _ = newTestBattle("Water", whelp)
+	decision = casterStrategy{}.ChooseAction(battle, whelp, rand.New(rand.NewSource(1)))
+	if decision.Action != "attack" {
+		t.Errorf("Expected a physical attack against a resisting element, got %s", decision.Action)
+	}
+}
+
+func TestSupportStrategy_HealsItselfEarly(t *testing.T) {
+	healer := CreateNPCOpponent("Necromancer", 8)
+	battle, player := newTestBattle("None", healer)
+
+	healer.CurrentHP = healer.MaxHP * 2 / 5
+	decision := supportStrategy{}.ChooseAction(battle, healer, rand.New(rand.NewSource(1)))
+	if decision.Action != "skill" || decision.TargetID != healer.DiscordID {
+		t.Errorf("Expected a self heal below 50%% HP, got %+v", decision)
+	}
+
+	// Out of mana, so it falls back to a healing item
+	healer.CurrentMP = 0
+	decision = supportStrategy{}.ChooseAction(battle, healer, rand.New(rand.NewSource(1)))
+	if decision.Action != "item" {
+		t.Errorf("Expected an item without mana to heal, got %+v", decision)
+	}
+
+	healer.CurrentHP = healer.MaxHP
+	healer.CurrentMP = healer.MaxMP
+	decision = supportStrategy{}.ChooseAction(battle, healer, rand.New(rand.NewSource(1)))
+	if decision.Action == "item" || decision.TargetID != player.DiscordID {
+		t.Errorf("Expected an attack on the player at full HP, got %+v", decision)
+	}
+}
+
+// Helper to build a fight against the Lich and its Necromancer escort
+func newEscortBattle() (*Battle, *CombatParticipant, *CombatParticipant, *CombatParticipant) {
+	player := &CombatParticipant{
+		DiscordID:     "player",
+		UserName:      "Player",
+		CurrentHP:     1000,
+		MaxHP:         1000,
+		Element:       "None",
+		StatusEffects: make(map[string]int),
+	}
+	lich := CreateNPCOpponent("Lich", 9)
+	battle := NewBattle("channel", player, lich, i18n.New("en"))
+	addNPCEscorts(battle)
+
+	var escort *CombatParticipant
+	for _, participant := range battle.Participants {
+		if participant.AllyOf != "" {
+			escort = participant
+		}
+	}
+	return battle, player, lich, escort
+}
+
+func TestSupportStrategy_HealsWoundedAlly(t *testing.T) {
+	battle, player, lich, necromancer := newEscortBattle()
+	if necromancer == nil || necromancer.AllyOf != lich.DiscordID {
+		t.Fatalf("Expected the Lich to bring its Necromancer escort")
+	}
+
+	lich.CurrentHP = lich.MaxHP / 3
+	decision := supportStrategy{}.ChooseAction(battle, necromancer, rand.New(rand.NewSource(1)))
+	if decision.Action != "skill" || decision.TargetID != lich.DiscordID {
+		t.Errorf("Expected the escort to heal the wounded Lich, got %+v", decision)
+	}
+
+	lich.CurrentHP = lich.MaxHP
+	decision = supportStrategy{}.ChooseAction(battle, necromancer, rand.New(rand.NewSource(1)))
+	if decision.TargetID != player.DiscordID {
+		t.Errorf("Expected the escort to fight once its allies are healthy, got %+v", decision)
+	}
+}
+
+func TestSupportStrategy_RunsOutOfItems(t *testing.T) {
+	healer := CreateNPCOpponent("Necromancer", 8)
+	battle, _ := newTestBattle("None", healer)
+	healer.CurrentHP = healer.MaxHP * 2 / 5
+	healer.CurrentMP = 0
+	healer.ItemsUsed = variables.BattleItemUses
+
+	decision := supportStrategy{}.ChooseAction(battle, healer, rand.New(rand.NewSource(1)))
+	if decision.Action == "item" {
+		t.Errorf("Expected no item once they're used up, got %+v", decision)
+	}
+
+	if _, err := useItem(healer, i18n.New("en")); err != nil || healer.CurrentHP != healer.MaxHP*2/5 {
+		t.Errorf("Expected a used up item not to heal, HP is %d", healer.CurrentHP)
+	}
+}
+
+func TestBossStrategy_EntersPhasesOnce(t *testing.T) {
+	boss := CreateNPCOpponent("Goblin King", 10)
+	battle, _ := newTestBattle("None", boss)
+	baseDamage := boss.PhysicalDamage
+
+	boss.CurrentHP = boss.MaxHP / 2
+	bossStrategy{}.ChooseAction(battle, boss, rand.New(rand.NewSource(1)))
+	if boss.BossPhase != 1 {
+		t.Fatalf("Expected phase 1 at half HP, got %d", boss.BossPhase)
+	}
+	boosted := boss.PhysicalDamage
+	if boosted <= baseDamage {
+		t.Errorf("Expected phase damage boost, got %d from %d", boosted, baseDamage)
+	}
+
+	bossStrategy{}.ChooseAction(battle, boss, rand.New(rand.NewSource(1)))
+	if boss.PhysicalDamage != boosted {
+		t.Errorf("Phase boost should only apply once")
+	}
+
+	if npc.AIBoss != boss.NPC.AIProfile {
+		t.Errorf("Goblin King should use the boss profile")
+	}
+}
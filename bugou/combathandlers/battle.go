@@ -2,10 +2,13 @@ package combathandlers
 
 import (
 	"CrispyBot/database/models"
+	"CrispyBot/i18n"
+	"CrispyBot/npc"
 	"CrispyBot/variables"
 	"errors"
 	"fmt"
 	"math/rand"
+	"slices"
 	"time"
 )
 
@@ -18,12 +21,13 @@ const (
 
 // BattleResult represents the outcome of a battle
 type BattleResult struct {
-	Winner       string
-	Loser        string
-	Rounds       int
-	WinnerHP     int
-	Experience   int
-	CurrencyGain int
+	Winner        string
+	Loser         string
+	Rounds        int
+	WinnerHP      int
+	Experience    int
+	CurrencyGain  int
+	RewardPercent int // Percent of normal NPC rewards paid after level gap scaling
 }
 
 // CombatParticipant represents a character with combat-ready stats
@@ -45,7 +49,14 @@ type CombatParticipant struct {
 	StatusEffects  map[string]int // Effect name -> remaining turns
 	ActionThisTurn string
 	TargetThisTurn string
-	IsBot          bool // Flag for NPC opponents
+	SkillThisTurn  string          // Signature skill chosen with the "skill" action
+	DefenseBoost   int             // Defense added by defending, removed on the next turn
+	BossPhase      int             // Current phase index for boss scripts
+	IsBot          bool            // Flag for NPC opponents
+	NPC            *npc.Definition // Roster definition for NPC opponents
+	Level          int             // Character level, or the level the NPC was created at
+	AllyOf         string          // Main participant an escort fights for, empty for the two main fighters
+	ItemsUsed      int             // Healing items used this battle
 }
 
 // Battle represents a combat encounter between two participants
@@ -57,13 +68,20 @@ type Battle struct {
 	Round              int
 	State              string
 	LastUpdated        time.Time
-	TurnOrder          []string // IDs in initiative order
-	Log                []string // Combat log
-	InteractionMessage string   // Discord message ID for battle UI
+	TurnOrder          []string       // IDs in initiative order
+	Log                []string       // Combat log
+	InteractionMessage string         // Discord message ID for battle UI
+	Ranked             bool           // Ranked PvP battles update ratings
+	TournamentID       string         // Tournament the battle was scheduled by, if any
+	TournamentMatch    int            // Match number within the tournament
+	DungeonRunID       string         // Dungeon run the fight belongs to, if any
+	ClanWarID          string         // Clan war the fight scores for, if any
+	FinishingAction    string         // Action that landed the final blow
+	Localizer          i18n.Localizer // Language of the battle log and embeds
 }
 
-// NewBattle initializes a new battle between two participants
-func NewBattle(channelID string, participant1 *CombatParticipant, participant2 *CombatParticipant) *Battle {
+// NewBattle initializes a new battle between two participants, logged in the given language
+func NewBattle(channelID string, participant1 *CombatParticipant, participant2 *CombatParticipant, loc i18n.Localizer) *Battle {
 	battleID := fmt.Sprintf("battle_%s_%s_%d", participant1.DiscordID, participant2.DiscordID, time.Now().Unix())
 
 	// Initialize participants map
@@ -83,7 +101,8 @@ func NewBattle(channelID string, participant1 *CombatParticipant, participant2 *
 		State:        BattlePending,
 		LastUpdated:  time.Now(),
 		TurnOrder:    turnOrder,
-		Log:          []string{fmt.Sprintf("Battle between %s and %s begins!", participant1.UserName, participant2.UserName)},
+		Log:          []string{loc.T("battle.log.begins", i18n.Args{"first": participant1.UserName, "second": participant2.UserName})},
+		Localizer:    loc,
 	}
 }
 
@@ -129,40 +148,46 @@ func CharacterToCombatParticipant(character models.Character, discordID string,
 		Element:        element,
 		StatusEffects:  make(map[string]int),
 		IsBot:          false,
+		Level:          character.Level,
 	}
 }
 
-// CreateNPCOpponent creates a computer-controlled opponent with the given stats
+// CreateNPCOpponent creates a computer-controlled opponent from its roster definition
 func CreateNPCOpponent(name string, level int) *CombatParticipant {
-	// Scale stats based on level
-	baseValue := 50 + (level * 5)
-	if baseValue > variables.MaxStatValue {
-		baseValue = variables.MaxStatValue
+	// NPCs that aren't on the roster fall back to evenly spread stats
+	definition, exists := npc.Find(name)
+	if !exists {
+		definition = npc.Generic(name)
 	}
 
+	stats := definition.StatsAt(level)
+
 	// Create NPC stats
-	maxHP := baseValue * variables.VitalityToHPRatio
-	maxMP := baseValue * variables.ManaToPoolRatio
-	physDamage := baseValue * variables.StrengthToDamageRatio
-	magDamage := baseValue * variables.IntelligenceToDamageRatio
-	defense := baseValue * variables.DurabilityToDefenseRatio
-	initiative := baseValue * variables.SpeedToInitiativeRatio
+	maxHP := stats.Vitality * variables.VitalityToHPRatio
+	maxMP := stats.Mana * variables.ManaToPoolRatio
+	physDamage := stats.Strength * variables.StrengthToDamageRatio
+	magDamage := stats.Intelligence * variables.IntelligenceToDamageRatio
+	defense := stats.Durability * variables.DurabilityToDefenseRatio
+	initiative := stats.Speed * variables.SpeedToInitiativeRatio
 
 	// Calculate accuracy and dodge
-	accuracy := variables.BaseAccuracy + (baseValue / 3)
-	dodgeChance := variables.BaseDodgeChance + (baseValue / 10)
+	accuracy := variables.BaseAccuracy + (stats.Mastery / 3)
+	dodgeChance := variables.BaseDodgeChance + (stats.Speed / 10)
 	if dodgeChance > variables.MaxDodgeChance {
 		dodgeChance = variables.MaxDodgeChance
 	}
 
-	// Randomly select element
-	elements := []string{"Fire", "Water", "Earth", "Wind", "Nature", "Lightning", "Ice", "Dark", "Light"}
-	rand.Seed(time.Now().UnixNano())
-	element := elements[rand.Intn(len(elements))]
+	// Randomly select element if the definition doesn't fix one
+	element := definition.Element
+	if element == "" {
+		elements := []string{"Fire", "Water", "Earth", "Wind", "Nature", "Lightning", "Frost", "Dark", "Light"}
+		rand.Seed(time.Now().UnixNano())
+		element = elements[rand.Intn(len(elements))]
+	}
 
 	return &CombatParticipant{
 		DiscordID:      "npc_" + fmt.Sprintf("%d", time.Now().UnixNano()),
-		UserName:       name,
+		UserName:       definition.Name,
 		CurrentHP:      maxHP,
 		MaxHP:          maxHP,
 		CurrentMP:      maxMP,
@@ -176,6 +201,8 @@ func CreateNPCOpponent(name string, level int) *CombatParticipant {
 		Element:        element,
 		StatusEffects:  make(map[string]int),
 		IsBot:          true,
+		NPC:            &definition,
+		Level:          level,
 	}
 }
 
@@ -201,6 +228,57 @@ func capStats(stats models.StatsSheets) models.StatsSheets {
 	return stats
 }
 
+// AddEscort brings an NPC into the fight on the side of a main participant
+// Note: Call it before the battle starts. A side only loses once its escorts have fallen too, but the result and rewards still come from the main fighters.
+func (b *Battle) AddEscort(escort *CombatParticipant, allyOf string) {
+	if _, exists := b.Participants[escort.DiscordID]; exists {
+		escort.DiscordID = fmt.Sprintf("%s_%d", escort.DiscordID, len(b.Participants))
+	}
+	escort.AllyOf = allyOf
+	b.Participants[escort.DiscordID] = escort
+
+	// Escorts slot into the initiative order after everyone at least as fast
+	position := len(b.TurnOrder)
+	for i, id := range b.TurnOrder {
+		if b.Participants[id].Initiative < escort.Initiative {
+			position = i
+			break
+		}
+	}
+	b.TurnOrder = slices.Insert(b.TurnOrder, position, escort.DiscordID)
+	b.CurrentTurn = b.TurnOrder[0]
+
+	b.Log = append(b.Log, b.Localizer.T("battle.log.escort_joins", i18n.Args{"name": escort.UserName, "ally": b.Participants[allyOf].UserName}))
+}
+
+// opponentOf returns the main fighter on the other side
+func (b *Battle) opponentOf(id string) *CombatParticipant {
+	for _, participant := range b.Participants {
+		if participant.AllyOf == "" && participant.DiscordID != id {
+			return participant
+		}
+	}
+	return nil
+}
+
+// side names the main participant a participant fights for
+func (participant *CombatParticipant) side() string {
+	if participant.AllyOf != "" {
+		return participant.AllyOf
+	}
+	return participant.DiscordID
+}
+
+// sideDefeated reports whether everyone fighting for a main participant is down
+func (b *Battle) sideDefeated(side string) bool {
+	for _, participant := range b.Participants {
+		if participant.side() == side && participant.CurrentHP > 0 {
+			return false
+		}
+	}
+	return true
+}
+
 // determineTurnOrder sets the initiative order based on speed
 func determineTurnOrder(p1, p2 *CombatParticipant) []string {
 	if p1.Initiative > p2.Initiative {
@@ -229,7 +307,7 @@ func (b *Battle) ProcessTurn() (string, error) {
 
 	// Skip turn if no action set (shouldn't happen normally)
 	if currentParticipant.ActionThisTurn == "" {
-		return "No action selected", nil
+		return b.Localizer.T("battle.log.no_action"), nil
 	}
 
 	// Get target
@@ -240,10 +318,11 @@ func (b *Battle) ProcessTurn() (string, error) {
 	}
 
 	// Process status effects at start of turn
+	endDefending(currentParticipant)
 	processStatusEffects(currentParticipant)
 
 	// Execute the selected action
-	result, err := executeAction(currentParticipant, target, currentParticipant.ActionThisTurn)
+	result, err := executeAction(currentParticipant, target, currentParticipant.ActionThisTurn, b.Localizer)
 	if err != nil {
 		return "", err
 	}
@@ -251,11 +330,18 @@ func (b *Battle) ProcessTurn() (string, error) {
 	// Log the result
 	b.Log = append(b.Log, result)
 
-	// Check if battle is over
+	// Check if battle is over, someone falling while their side still stands only takes them out of the turn order
 	if target.CurrentHP <= 0 {
 		target.CurrentHP = 0
+		if !b.sideDefeated(target.side()) {
+			b.Log = append(b.Log, b.Localizer.T("battle.log.falls", i18n.Args{"name": target.UserName}))
+			b.advanceTurn()
+			return result, nil
+		}
+
 		b.State = BattleComplete
-		b.Log = append(b.Log, fmt.Sprintf("%s has been defeated! %s wins the battle!", target.UserName, currentParticipant.UserName))
+		b.FinishingAction = currentParticipant.ActionThisTurn
+		b.Log = append(b.Log, b.Localizer.T("battle.log.defeated", i18n.Args{"loser": target.UserName, "winner": currentParticipant.UserName}))
 		return result, nil
 	}
 
@@ -276,24 +362,18 @@ func (b *Battle) advanceTurn() {
 		}
 	}
 
-	// Move to next participant
-	nextPos := (currentPos + 1) % len(b.TurnOrder)
+	// Move to the next participant still standing, fallen escorts lose their turns
+	nextPos := currentPos
+	for range b.TurnOrder {
+		nextPos = (nextPos + 1) % len(b.TurnOrder)
 
-	// If we've gone through all participants, increment round counter
-	if nextPos == 0 {
-		b.Round++
+		// If we've gone through all participants, increment round counter
+		if nextPos == 0 {
+			b.endRound()
+		}
 
-		// Process end-of-round effects
-		for _, participant := range b.Participants {
-			// Reduce status effect durations
-			for effect, turns := range participant.StatusEffects {
-				if turns > 0 {
-					participant.StatusEffects[effect] = turns - 1
-				}
-				if participant.StatusEffects[effect] == 0 {
-					delete(participant.StatusEffects, effect)
-				}
-			}
+		if b.Participants[b.TurnOrder[nextPos]].CurrentHP > 0 {
+			break
 		}
 	}
 
@@ -310,32 +390,25 @@ func (b *Battle) advanceTurn() {
 	}
 }
 
-// selectNPCAction chooses an action for an NPC
-func selectNPCAction(battle *Battle, npc *CombatParticipant) {
-	// Simple AI: choose between physical and magical attack based on stats
-	var action string
+// endRound starts a new round and counts down status effects
+func (b *Battle) endRound() {
+	b.Round++
 
-	// Find target (the human player)
-	var targetID string
-	for id := range battle.Participants {
-		if id != npc.DiscordID {
-			targetID = id
-			break
+	for _, participant := range b.Participants {
+		// Reduce status effect durations
+		for effect, turns := range participant.StatusEffects {
+			// Defending lasts until the participant's next turn instead
+			if effect == "Defending" {
+				continue
+			}
+			if turns > 0 {
+				participant.StatusEffects[effect] = turns - 1
+			}
+			if participant.StatusEffects[effect] == 0 {
+				delete(participant.StatusEffects, effect)
+			}
 		}
 	}
-
-	// Choose action based on stronger stat
-	if npc.PhysicalDamage > npc.MagicalDamage {
-		action = "attack"
-	} else if npc.CurrentMP >= variables.MagicAttackBaseManaCost {
-		action = "magic"
-	} else {
-		action = "attack"
-	}
-
-	// Set NPC's action and target
-	npc.ActionThisTurn = action
-	npc.TargetThisTurn = targetID
 }
 
 // processStatusEffects applies effects of status conditions
@@ -365,44 +438,30 @@ func processStatusEffects(participant *CombatParticipant) {
 // GetBattleStatus returns a formatted status of the current battle
 func (b *Battle) GetBattleStatus() string {
 	var status string
-
-	// Get both participants
-	var p1, p2 *CombatParticipant
-	for _, p := range b.Participants {
-		if p1 == nil {
-			p1 = p
-		} else {
-			p2 = p
-		}
-	}
-
-	status += fmt.Sprintf("⚔️ Round %d ⚔️\n\n", b.Round)
-
-	// Show participant health and mana
-	status += fmt.Sprintf("%s: HP %d/%d | MP %d/%d", p1.UserName, p1.CurrentHP, p1.MaxHP, p1.CurrentMP, p1.MaxMP)
-	if len(p1.StatusEffects) > 0 {
-		status += " | Status: "
-		for effect, turns := range p1.StatusEffects {
-			status += fmt.Sprintf("%s (%d) ", effect, turns)
+	loc := b.Localizer
+
+	status += loc.T("battle.status.round", i18n.Args{"round": b.Round}) + "\n\n"
+
+	// Show participant health and mana, escorts included
+	for _, id := range b.TurnOrder {
+		p := b.Participants[id]
+		status += fmt.Sprintf("%s: HP %d/%d | MP %d/%d", p.UserName, p.CurrentHP, p.MaxHP, p.CurrentMP, p.MaxMP)
+		if len(p.StatusEffects) > 0 {
+			status += " | " + loc.T("battle.status.effects") + " "
+			for effect, turns := range p.StatusEffects {
+				status += fmt.Sprintf("%s (%d) ", effectName(effect, loc), turns)
+			}
 		}
+		status += "\n"
 	}
 	status += "\n"
 
-	status += fmt.Sprintf("%s: HP %d/%d | MP %d/%d", p2.UserName, p2.CurrentHP, p2.MaxHP, p2.CurrentMP, p2.MaxMP)
-	if len(p2.StatusEffects) > 0 {
-		status += " | Status: "
-		for effect, turns := range p2.StatusEffects {
-			status += fmt.Sprintf("%s (%d) ", effect, turns)
-		}
-	}
-	status += "\n\n"
-
 	// Show whose turn it is
 	currentParticipant := b.Participants[b.CurrentTurn]
-	status += fmt.Sprintf("Current turn: %s\n", currentParticipant.UserName)
+	status += loc.T("battle.status.turn", i18n.Args{"name": currentParticipant.UserName}) + "\n"
 
 	// Show recent battle log (last 3 entries)
-	status += "\nRecent actions:\n"
+	status += "\n" + loc.T("battle.status.recent") + "\n"
 	startIdx := len(b.Log) - 3
 	if startIdx < 0 {
 		startIdx = 0
@@ -418,7 +477,7 @@ func (b *Battle) GetBattleStatus() string {
 func (b *Battle) SetAction(userID string, action string, targetID string) error {
 	// Verify it's this user's turn
 	if b.CurrentTurn != userID {
-		return errors.New("it's not your turn")
+		return errors.New(b.Localizer.T("battle.error.not_your_turn"))
 	}
 
 	// Verify action is valid
@@ -432,12 +491,12 @@ func (b *Battle) SetAction(userID string, action string, targetID string) error
 	}
 
 	if !actionValid {
-		return errors.New("invalid action")
+		return errors.New(b.Localizer.T("battle.error.invalid_action"))
 	}
 
 	// Verify target is valid
 	if _, exists := b.Participants[targetID]; !exists {
-		return errors.New("invalid target")
+		return errors.New(b.Localizer.T("battle.error.invalid_target"))
 	}
 
 	// Set the action
@@ -464,13 +523,16 @@ func (b *Battle) GetResult() (*BattleResult, error) {
 		return nil, errors.New("battle is not complete")
 	}
 
-	// Determine winner and loser
+	// Determine winner and loser, the main fighter of the side left standing wins
 	var winnerID, loserID string
 	for id, participant := range b.Participants {
-		if participant.CurrentHP > 0 {
-			winnerID = id
-		} else {
+		if participant.AllyOf != "" {
+			continue
+		}
+		if b.sideDefeated(id) {
 			loserID = id
+		} else {
+			winnerID = id
 		}
 	}
 
@@ -484,12 +546,27 @@ func (b *Battle) GetResult() (*BattleResult, error) {
 	// Base currency reward
 	currencyGain := 100 + (b.Round * 5)
 
+	// Defeated roster NPCs pay out their own rewards, scaled by the level gap
+	percent := 100
+	if loser := b.Participants[loserID]; loser.NPC != nil {
+		percent = rewardPercent(winner.Level, loser.Level)
+		expGain = int(float64(loser.NPC.ExperienceReward(loser.Level)) * variables.ExperienceModifier)
+		expGain = expGain * percent / 100
+		currencyGain = loser.NPC.CoinReward(loser.Level)
+
+		// Stronger NPCs only pay bonus XP, but farming weaker ones cuts coins too
+		if percent < 100 {
+			currencyGain = currencyGain * percent / 100
+		}
+	}
+
 	return &BattleResult{
-		Winner:       winnerID,
-		Loser:        loserID,
-		Rounds:       b.Round,
-		WinnerHP:     winner.CurrentHP,
-		Experience:   expGain,
-		CurrencyGain: currencyGain,
+		Winner:        winnerID,
+		Loser:         loserID,
+		Rounds:        b.Round,
+		WinnerHP:      winner.CurrentHP,
+		Experience:    expGain,
+		CurrencyGain:  currencyGain,
+		RewardPercent: percent,
 	}, nil
 }
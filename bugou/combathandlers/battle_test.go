@@ -0,0 +1,55 @@
+package combathandlers
+
+import "testing"
+
+// Helper to have the player land a guaranteed killing blow
+func killWithPlayer(t *testing.T, battle *Battle, player *CombatParticipant, target *CombatParticipant) {
+	t.Helper()
+	player.PhysicalDamage, player.Accuracy = 1000000, 1000
+	target.DodgeChance = 0
+
+	battle.CurrentTurn = player.DiscordID
+	if err := battle.SetAction(player.DiscordID, "attack", target.DiscordID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := battle.ProcessTurn(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestAddEscort_JoinsTurnOrder(t *testing.T) {
+	battle, _, lich, necromancer := newEscortBattle()
+
+	if len(battle.TurnOrder) != 3 || battle.Participants[necromancer.DiscordID] != necromancer {
+		t.Fatalf("Expected the escort in the fight, got turn order %v", battle.TurnOrder)
+	}
+	if necromancer.side() != lich.side() {
+		t.Errorf("Expected the escort on the Lich's side")
+	}
+	if battle.opponentOf("player") != lich {
+		t.Errorf("Expected the Lich to stay the player's opponent")
+	}
+}
+
+func TestProcessTurn_SideLosesWithItsLastFighter(t *testing.T) {
+	battle, player, lich, necromancer := newEscortBattle()
+	battle.State = BattleOngoing
+
+	killWithPlayer(t, battle, player, lich)
+	if battle.State != BattleOngoing {
+		t.Fatalf("Expected the fight to go on while the escort stands")
+	}
+	if battle.CurrentTurn == lich.DiscordID {
+		t.Errorf("Expected the fallen Lich to lose its turns")
+	}
+
+	killWithPlayer(t, battle, player, necromancer)
+	if battle.State != BattleComplete {
+		t.Fatalf("Expected the fight to end once the escort falls too")
+	}
+
+	result, err := battle.GetResult()
+	if err != nil || result.Winner != player.DiscordID || result.Loser != lich.DiscordID {
+		t.Errorf("Expected the player to beat the Lich, got %+v (%v)", result, err)
+	}
+}
@@ -0,0 +1,229 @@
+package combathandlers
+
+import (
+	"CrispyBot/clans"
+	"CrispyBot/database"
+	"CrispyBot/database/models"
+	"CrispyBot/i18n"
+	"CrispyBot/variables"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	// ClanWarSchedulerStarter makes sure only one clan war scheduler runs
+	ClanWarSchedulerStarter sync.Once
+)
+
+// StartClanWarScheduler starts the background loop that scores wars once their time runs out
+func StartClanWarScheduler(session *discordgo.Session) {
+	ClanWarSchedulerStarter.Do(func() {
+		go clanWarSchedulerRoutine(session)
+		fmt.Println("Clan war scheduler started")
+	})
+}
+
+// clanWarSchedulerRoutine periodically checks for ended wars
+func clanWarSchedulerRoutine(session *discordgo.Session) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		runClanWarScheduler(session)
+	}
+}
+
+// runClanWarScheduler finishes every war whose time is up and announces the result
+func runClanWarScheduler(session *discordgo.Session) {
+	db := database.DBInit()
+
+	wars, err := database.GetEndedClanWars(db)
+	if err != nil {
+		fmt.Printf("Error reading clan wars: %v\n", err)
+		return
+	}
+
+	for _, war := range wars {
+		finished, ok, err := database.FinishClanWar(db, war)
+		if err != nil {
+			fmt.Printf("Error finishing clan war: %v\n", err)
+		}
+		if ok {
+			session.ChannelMessageSendEmbed(finished.ChannelID, createClanWarEmbed(finished, channelLocalizer(session, finished.ChannelID, "")))
+		}
+	}
+}
+
+// HandleClanWarCommand processes clan war commands
+func HandleClanWarCommand(session *discordgo.Session, message *discordgo.MessageCreate, args []string) {
+	loc := localizer(message)
+	subCommand := "status"
+	if len(args) >= 4 {
+		subCommand = strings.ToLower(args[3])
+	}
+
+	switch subCommand {
+	case "declare":
+		if len(args) < 5 {
+			session.ChannelMessageSend(message.ChannelID, loc.T("war.declare_usage"))
+			return
+		}
+		declareClanWar(session, message, strings.Join(args[4:], " "), loc)
+	case "accept":
+		acceptClanWar(session, message, loc)
+	case "fight":
+		if len(args) < 5 || !strings.HasPrefix(args[4], "<@") || !strings.HasSuffix(args[4], ">") {
+			session.ChannelMessageSend(message.ChannelID, loc.T("war.fight_usage"))
+			return
+		}
+		targetID := strings.TrimPrefix(strings.TrimPrefix(strings.TrimSuffix(args[4], ">"), "<@"), "!")
+		challengeClanWarBattle(session, message, targetID, loc)
+	case "status":
+		showClanWarStatus(session, message, loc)
+	default:
+		session.ChannelMessageSend(message.ChannelID, loc.T("war.usage"))
+	}
+}
+
+// declareClanWar challenges another clan
+func declareClanWar(session *discordgo.Session, message *discordgo.MessageCreate, clanName string, loc i18n.Localizer) {
+	war, err := database.DeclareClanWar(database.DBInit(), message.Author.ID, clanName, message.ChannelID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	session.ChannelMessageSend(message.ChannelID, loc.T("war.declared", i18n.Args{
+		"attacker": war.AttackerName, "defender": war.DefenderName, "hours": variables.ClanWarHours}))
+}
+
+// acceptClanWar starts the war declared on the user's clan
+func acceptClanWar(session *discordgo.Session, message *discordgo.MessageCreate, loc i18n.Localizer) {
+	war, err := database.AcceptClanWar(database.DBInit(), message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	warEmbed := createClanWarEmbed(war, loc)
+	warEmbed.Description = loc.T("war.started", i18n.Args{"hours": variables.ClanWarHours})
+	session.ChannelMessageSendEmbed(message.ChannelID, warEmbed)
+	if war.ChannelID != message.ChannelID {
+		session.ChannelMessageSendEmbed(war.ChannelID, warEmbed)
+	}
+}
+
+// challengeClanWarBattle sends a war battle challenge to a member of the opposing clan
+func challengeClanWarBattle(session *discordgo.Session, message *discordgo.MessageCreate, targetID string, loc i18n.Localizer) {
+	db := database.DBInit()
+
+	clan, err := database.GetClanByMember(db, message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	war, err := database.GetCurrentClanWar(db, clan.ID)
+	if err != nil || war.Status != clans.WarActive || time.Now().After(war.EndsAt) {
+		session.ChannelMessageSend(message.ChannelID, loc.T("war.no_war"))
+		return
+	}
+
+	opponentClanID := war.DefenderID
+	if clan.ID == war.DefenderID {
+		opponentClanID = war.AttackerID
+	}
+
+	targetClan, err := database.GetClanByMember(db, targetID)
+	if err != nil || targetClan.ID != opponentClanID {
+		session.ChannelMessageSend(message.ChannelID, loc.T("war.wrong_clan"))
+		return
+	}
+
+	handlePvPBattleRequest(session, message, targetID, false, war.ID.Hex())
+}
+
+// showClanWarStatus shows the scores of the user's current war
+func showClanWarStatus(session *discordgo.Session, message *discordgo.MessageCreate, loc i18n.Localizer) {
+	db := database.DBInit()
+
+	clan, err := database.GetClanByMember(db, message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	war, err := database.GetCurrentClanWar(db, clan.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("war.no_war_declare", i18n.Args{"error": err}))
+		return
+	}
+
+	session.ChannelMessageSendEmbed(message.ChannelID, createClanWarEmbed(war, loc))
+}
+
+// reportClanWarResult scores a finished war battle for the winner's clan
+func reportClanWarResult(session *discordgo.Session, battle *Battle, winnerID string) {
+	db := database.DBInit()
+	loc := battle.Localizer
+
+	warID, err := primitive.ObjectIDFromHex(battle.ClanWarID)
+	if err != nil {
+		fmt.Printf("Error reading clan war ID: %v\n", err)
+		return
+	}
+
+	clan, err := database.GetClanByMember(db, winnerID)
+	if err != nil {
+		fmt.Printf("Error loading winner's clan: %v\n", err)
+		return
+	}
+
+	war, err := database.RecordClanWarWin(db, warID, clan.ID)
+	if err != nil {
+		session.ChannelMessageSend(battle.ChannelID, loc.T("war.score_failed", i18n.Args{"error": err}))
+		return
+	}
+
+	session.ChannelMessageSend(battle.ChannelID, loc.T("war.scored", i18n.Args{"winner": winnerID, "clan": clan.Name,
+		"attacker": war.AttackerName, "attackerScore": war.AttackerScore, "defenderScore": war.DefenderScore, "defender": war.DefenderName}))
+}
+
+// createClanWarEmbed renders a war's scoreboard
+func createClanWarEmbed(war models.ClanWar, loc i18n.Localizer) *discordgo.MessageEmbed {
+	warEmbed := &discordgo.MessageEmbed{
+		Title: loc.T("war.embed.title", i18n.Args{"attacker": war.AttackerName, "defender": war.DefenderName}),
+		Color: 0xB22222,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:  loc.T("war.embed.score"),
+				Value: fmt.Sprintf("%s: **%d**\n%s: **%d**", war.AttackerName, war.AttackerScore, war.DefenderName, war.DefenderScore),
+			},
+		},
+	}
+
+	switch war.Status {
+	case clans.WarPending:
+		warEmbed.Description = loc.T("war.embed.pending", i18n.Args{"defender": war.DefenderName})
+	case clans.WarActive:
+		warEmbed.Description = loc.T("war.embed.ends_in", i18n.Args{"duration": time.Until(war.EndsAt).Round(time.Minute)})
+	case clans.WarFinished:
+		result := loc.T("war.embed.draw")
+		if war.WinnerID == war.AttackerID.Hex() {
+			result = loc.T("war.embed.winner", i18n.Args{"clan": war.AttackerName})
+		} else if war.WinnerID == war.DefenderID.Hex() {
+			result = loc.T("war.embed.winner", i18n.Args{"clan": war.DefenderName})
+		}
+		if war.WinnerID != "" {
+			result += " " + loc.T("war.embed.prize", i18n.Args{"coins": variables.ClanWarPrize, "xp": variables.ClanWarXP})
+		}
+		warEmbed.Description = result
+	}
+
+	return warEmbed
+}
@@ -1,8 +1,13 @@
 package combathandlers
 
 import (
+	"CrispyBot/achievements"
+	achievementhandlers "CrispyBot/bugou/achievementhandlers"
 	"CrispyBot/database"
+	"CrispyBot/i18n"
+	"CrispyBot/npc"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -16,10 +21,36 @@ var (
 	ActiveBattlesMutex sync.Mutex
 )
 
+// localizer returns the translator for the author of a message
+func localizer(message *discordgo.MessageCreate) i18n.Localizer {
+	return database.GetLocalizer(database.DBInit(), message.Author.ID, message.GuildID)
+}
+
+// channelLocalizer returns the translator for a user in the guild a channel belongs to
+// Note: Pass an empty user for messages everyone in the channel reads.
+func channelLocalizer(session *discordgo.Session, channelID string, userID string) i18n.Localizer {
+	guildID := ""
+	if channel, err := session.State.Channel(channelID); err == nil {
+		guildID = channel.GuildID
+	}
+	return database.GetLocalizer(database.DBInit(), userID, guildID)
+}
+
+// interactionUserID returns who pressed a button, in a server or a direct message
+func interactionUserID(interaction *discordgo.InteractionCreate) string {
+	if interaction.Member != nil && interaction.Member.User != nil {
+		return interaction.Member.User.ID
+	}
+	if interaction.User != nil {
+		return interaction.User.ID
+	}
+	return ""
+}
+
 // HandleBattleCommand processes battle-related commands
 func HandleBattleCommand(session *discordgo.Session, message *discordgo.MessageCreate, args []string) {
 	if len(args) < 3 {
-		session.ChannelMessageSend(message.ChannelID, "Invalid battle command. Usage: `!cb battle [start|attack|magic|defend|item]`")
+		session.ChannelMessageSend(message.ChannelID, localizer(message).T("battle.usage"))
 		return
 	}
 
@@ -32,25 +63,34 @@ func HandleBattleCommand(session *discordgo.Session, message *discordgo.MessageC
 			// Check if it's a PvP request
 			if strings.HasPrefix(args[3], "<@") && strings.HasSuffix(args[3], ">") {
 				// Extract target user ID
-				targetID := strings.TrimPrefix(strings.TrimSuffix(args[3], ">"), "<@")
-				handlePvPBattleRequest(session, message, targetID)
+				targetID := strings.TrimPrefix(strings.TrimPrefix(strings.TrimSuffix(args[3], ">"), "<@"), "!")
+				rankedMatch := len(args) >= 5 && strings.ToLower(args[4]) == "ranked"
+				handlePvPBattleRequest(session, message, targetID, rankedMatch, "")
 			} else {
-				// Start battle with NPC
-				difficulty := 1
-				if len(args) >= 5 {
-					// Try to parse difficulty level
-					_, err := fmt.Sscanf(args[4], "%d", &difficulty)
-					if err != nil || difficulty < 1 || difficulty > 10 {
-						difficulty = 1
+				// Start battle with NPC, optionally at a given level or "auto"
+				nameParts := args[3:]
+				level := 0
+				if len(nameParts) > 1 {
+					if strings.EqualFold(nameParts[len(nameParts)-1], "auto") {
+						level = autoDifficulty
+						nameParts = nameParts[:len(nameParts)-1]
+					} else if _, err := fmt.Sscanf(nameParts[len(nameParts)-1], "%d", &level); err == nil {
+						nameParts = nameParts[:len(nameParts)-1]
+					} else {
+						level = 0
 					}
 				}
-				handleNPCBattle(session, message, args[3], difficulty)
+				handleNPCBattle(session, message, strings.Join(nameParts, " "), level)
 			}
 		} else {
 			// Default: start a battle with an NPC
-			handleNPCBattle(session, message, "Training Dummy", 1)
+			handleNPCBattle(session, message, "Training Dummy", 0)
 		}
 
+	case "npcs":
+		// List the NPC roster
+		showNPCRoster(session, message)
+
 	case "attack", "magic", "defend", "item":
 		// Execute combat action
 		handleCombatAction(session, message, subCommand)
@@ -64,19 +104,40 @@ func HandleBattleCommand(session *discordgo.Session, message *discordgo.MessageC
 		forfeitBattle(session, message)
 
 	default:
-		session.ChannelMessageSend(message.ChannelID, "Unknown battle command. Available commands: start, attack, magic, defend, item, status, forfeit")
+		session.ChannelMessageSend(message.ChannelID, localizer(message).T("battle.unknown_command"))
 	}
 }
 
-// handleNPCBattle starts a battle with an NPC
+// handleNPCBattle starts a battle with an NPC, using its default level when difficulty is 0
+// Note: autoDifficulty matches the player's level within the NPC's range.
 func handleNPCBattle(session *discordgo.Session, message *discordgo.MessageCreate, npcName string, difficulty int) {
+	loc := localizer(message)
+
+	// Only roster NPCs can be challenged, at levels they support
+	definition, exists := npc.Find(npcName)
+	if !exists || definition.Boss {
+		session.ChannelMessageSend(message.ChannelID, loc.T("battle.unknown_npc", i18n.Args{"name": npcName}))
+		return
+	}
+
 	// Get the database singleton
 	db := database.DBInit()
 
 	// Get user's character
 	character, err := database.GetCharacterByOwner(db, message.Author.ID)
 	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("You need a character to battle! Use `!cb roll` to create one."))
+		session.ChannelMessageSend(message.ChannelID, loc.T("battle.no_character"))
+		return
+	}
+
+	switch difficulty {
+	case 0:
+		difficulty = definition.DefaultLevel
+	case autoDifficulty:
+		difficulty = max(definition.MinLevel, min(character.Level, definition.MaxLevel))
+	}
+	if err := definition.ValidateLevel(difficulty); err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
 		return
 	}
 
@@ -85,7 +146,7 @@ func handleNPCBattle(session *discordgo.Session, message *discordgo.MessageCreat
 	for _, battle := range ActiveBattles {
 		if _, exists := battle.Participants[message.Author.ID]; exists {
 			ActiveBattlesMutex.Unlock()
-			session.ChannelMessageSend(message.ChannelID, "You are already in a battle! Finish or forfeit it first.")
+			session.ChannelMessageSend(message.ChannelID, loc.T("battle.already_in_battle"))
 			return
 		}
 	}
@@ -95,10 +156,22 @@ func handleNPCBattle(session *discordgo.Session, message *discordgo.MessageCreat
 	player := CharacterToCombatParticipant(character, message.Author.ID, message.Author.Username)
 
 	// Create NPC opponent
-	npc := CreateNPCOpponent(npcName, difficulty)
+	opponent := CreateNPCOpponent(definition.Name, difficulty)
+
+	// Warn before fights the level gap makes unrewarding or dangerous
+	if warning := difficultyWarning(character.Level, difficulty, loc); warning != "" {
+		session.ChannelMessageSend(message.ChannelID, warning)
+	}
 
 	// Create the battle
-	battle := NewBattle(message.ChannelID, player, npc)
+	battle := NewBattle(message.ChannelID, player, opponent, loc)
+
+	startNPCBattle(session, battle)
+}
+
+// startNPCBattle registers and opens a battle against an NPC, letting the NPC act if it goes first
+func startNPCBattle(session *discordgo.Session, battle *Battle) {
+	addNPCEscorts(battle)
 
 	// Store battle in active battles map
 	ActiveBattlesMutex.Lock()
@@ -112,7 +185,7 @@ func handleNPCBattle(session *discordgo.Session, message *discordgo.MessageCreat
 	battleEmbed := createBattleEmbed(battle)
 
 	// Send battle start message
-	msg, err := session.ChannelMessageSendEmbed(message.ChannelID, battleEmbed)
+	msg, err := session.ChannelMessageSendEmbed(battle.ChannelID, battleEmbed)
 	if err != nil {
 		fmt.Printf("Error sending battle message: %v\n", err)
 		return
@@ -122,20 +195,91 @@ func handleNPCBattle(session *discordgo.Session, message *discordgo.MessageCreat
 	battle.InteractionMessage = msg.ID
 
 	// If NPC goes first, process their turn automatically
-	if battle.CurrentTurn == npc.DiscordID {
+	if battle.Participants[battle.CurrentTurn].IsBot {
 		processBotTurn(session, battle)
 	}
 }
 
+// addNPCEscorts brings in the roster escorts of every NPC in the battle, at the nearest level they support
+func addNPCEscorts(battle *Battle) {
+	for _, id := range slices.Clone(battle.TurnOrder) {
+		leader := battle.Participants[id]
+		if !leader.IsBot || leader.NPC == nil {
+			continue
+		}
+
+		for _, name := range leader.NPC.Escorts {
+			definition, exists := npc.Find(name)
+			if !exists {
+				continue
+			}
+			level := max(definition.MinLevel, min(leader.Level, definition.MaxLevel))
+			battle.AddEscort(CreateNPCOpponent(definition.Name, level), leader.DiscordID)
+		}
+	}
+}
+
+// showNPCRoster lists the NPCs players can challenge
+func showNPCRoster(session *discordgo.Session, message *discordgo.MessageCreate) {
+	loc := localizer(message)
+	rosterEmbed := &discordgo.MessageEmbed{
+		Title:       loc.T("battle.roster.title"),
+		Description: loc.T("battle.roster.description"),
+		Color:       0xFF5500,
+		Fields:      []*discordgo.MessageEmbedField{},
+	}
+
+	for _, definition := range npc.Roster() {
+		details := loc.T("battle.roster.details", i18n.Args{
+			"description": loc.Or("data.npc."+definition.Name, definition.Description),
+			"min":         definition.MinLevel,
+			"max":         definition.MaxLevel,
+			"default":     definition.DefaultLevel,
+			"element":     definition.Element,
+			"race":        definition.Race,
+			"ai":          definition.AIProfile,
+		})
+
+		if len(definition.Skills) > 0 {
+			skills := []string{}
+			for _, skill := range definition.Skills {
+				skills = append(skills, skill.Name)
+			}
+			details += "\n" + loc.T("battle.roster.skills", i18n.Args{"skills": strings.Join(skills, ", ")})
+		}
+
+		if len(definition.Immunities) > 0 {
+			immunities := []string{}
+			for _, effect := range definition.Immunities {
+				immunities = append(immunities, effectName(effect, loc))
+			}
+			details += "\n" + loc.T("battle.roster.immune", i18n.Args{"effects": strings.Join(immunities, ", ")})
+		}
+
+		if len(definition.Escorts) > 0 {
+			details += "\n" + loc.T("battle.roster.escorts", i18n.Args{"names": strings.Join(definition.Escorts, ", ")})
+		}
+
+		rosterEmbed.Fields = append(rosterEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:  definition.Name,
+			Value: details,
+		})
+	}
+
+	session.ChannelMessageSendEmbed(message.ChannelID, rosterEmbed)
+}
+
 // handlePvPBattleRequest sends a battle challenge to another player
-func handlePvPBattleRequest(session *discordgo.Session, message *discordgo.MessageCreate, targetID string) {
+// Note: A clanWarID tags the battle so its result scores for the winner's clan.
+func handlePvPBattleRequest(session *discordgo.Session, message *discordgo.MessageCreate, targetID string, rankedMatch bool, clanWarID string) {
 	// Get the database singleton
 	db := database.DBInit()
+	loc := localizer(message)
 
 	// Check if target is valid
 	_, err := database.GetCharacterByOwner(db, targetID)
 	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, "That user doesn't have a character!")
+		session.ChannelMessageSend(message.ChannelID, loc.T("battle.target_no_character"))
 		return
 	}
 
@@ -145,40 +289,51 @@ func handlePvPBattleRequest(session *discordgo.Session, message *discordgo.Messa
 
 	for _, battle := range ActiveBattles {
 		if _, exists := battle.Participants[message.Author.ID]; exists {
-			session.ChannelMessageSend(message.ChannelID, "You are already in a battle! Finish or forfeit it first.")
+			session.ChannelMessageSend(message.ChannelID, loc.T("battle.already_in_battle"))
 			return
 		}
 		if _, exists := battle.Participants[targetID]; exists {
-			session.ChannelMessageSend(message.ChannelID, "That player is already in a battle!")
+			session.ChannelMessageSend(message.ChannelID, loc.T("battle.target_in_battle"))
 			return
 		}
 	}
 
+	// The challenge is written for the challenged player
+	targetLoc := database.GetLocalizer(db, targetID, message.GuildID)
+
+	// Ranked challenges are labelled so the opponent knows their rating is at stake
+	challengeTitle := targetLoc.T("battle.challenge.title")
+	if rankedMatch {
+		challengeTitle = targetLoc.T("battle.challenge.title_ranked")
+	} else if clanWarID != "" {
+		challengeTitle = targetLoc.T("battle.challenge.title_war")
+	}
+
 	// Create PvP battle request embed
 	challengeEmbed := &discordgo.MessageEmbed{
-		Title:       "⚔️ Battle Challenge!",
-		Description: fmt.Sprintf("<@%s> has challenged <@%s> to a battle!", message.Author.ID, targetID),
+		Title:       challengeTitle,
+		Description: targetLoc.T("battle.challenge.description", i18n.Args{"challenger": message.Author.ID, "target": targetID}),
 		Color:       0xFF0000,
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:  "How to Accept",
-				Value: "Click the Accept button below to begin the battle.",
+				Name:  targetLoc.T("battle.challenge.how"),
+				Value: targetLoc.T("battle.challenge.how_value"),
 			},
 		},
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: "Challenge expires in 60 seconds",
+			Text: targetLoc.T("battle.challenge.expires"),
 		},
 	}
 
 	// Add buttons for accepting/declining
 	acceptButton := discordgo.Button{
-		Label:    "Accept Challenge",
+		Label:    targetLoc.T("battle.challenge.accept"),
 		Style:    discordgo.SuccessButton,
 		CustomID: fmt.Sprintf("battle_accept_%s_%s", message.Author.ID, targetID),
 	}
 
 	declineButton := discordgo.Button{
-		Label:    "Decline",
+		Label:    targetLoc.T("battle.challenge.decline"),
 		Style:    discordgo.DangerButton,
 		CustomID: fmt.Sprintf("battle_decline_%s_%s", message.Author.ID, targetID),
 	}
@@ -207,18 +362,26 @@ func handlePvPBattleRequest(session *discordgo.Session, message *discordgo.Messa
 		if i.Type != discordgo.InteractionMessageComponent {
 			return
 		}
+		clickerLoc := database.GetLocalizer(database.DBInit(), interactionUserID(i), i.GuildID)
 
 		// Handle battle acceptance
 		if i.MessageComponentData().CustomID == fmt.Sprintf("battle_accept_%s_%s", message.Author.ID, targetID) {
 			if i.Member.User.ID == targetID {
 				// Start the PvP battle
-				startPvPBattle(s, message.Author.ID, targetID, message.ChannelID, i.Message.ID)
+				battle := startPvPBattle(s, message.Author.ID, targetID, message.ChannelID, i.Message.ID, rankedMatch)
+
+				// Tag war battles so the result is scored for the winner's clan
+				if battle != nil && clanWarID != "" {
+					ActiveBattlesMutex.Lock()
+					battle.ClanWarID = clanWarID
+					ActiveBattlesMutex.Unlock()
+				}
 
 				// Respond to the interaction
 				s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 					Type: discordgo.InteractionResponseUpdateMessage,
 					Data: &discordgo.InteractionResponseData{
-						Content:    "Battle challenge accepted! Starting battle...",
+						Content:    clickerLoc.T("battle.challenge.accepted"),
 						Components: []discordgo.MessageComponent{},
 					},
 				})
@@ -227,7 +390,7 @@ func handlePvPBattleRequest(session *discordgo.Session, message *discordgo.Messa
 				s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 					Type: discordgo.InteractionResponseChannelMessageWithSource,
 					Data: &discordgo.InteractionResponseData{
-						Content: "This challenge isn't for you!",
+						Content: clickerLoc.T("battle.challenge.not_for_you"),
 						Flags:   discordgo.MessageFlagsEphemeral,
 					},
 				})
@@ -238,7 +401,7 @@ func handlePvPBattleRequest(session *discordgo.Session, message *discordgo.Messa
 				s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 					Type: discordgo.InteractionResponseUpdateMessage,
 					Data: &discordgo.InteractionResponseData{
-						Content:    fmt.Sprintf("<@%s> declined the battle challenge.", targetID),
+						Content:    clickerLoc.T("battle.challenge.declined", i18n.Args{"user": targetID}),
 						Components: []discordgo.MessageComponent{},
 					},
 				})
@@ -247,7 +410,7 @@ func handlePvPBattleRequest(session *discordgo.Session, message *discordgo.Messa
 				s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 					Type: discordgo.InteractionResponseChannelMessageWithSource,
 					Data: &discordgo.InteractionResponseData{
-						Content: "This challenge isn't for you!",
+						Content: clickerLoc.T("battle.challenge.not_for_you"),
 						Flags:   discordgo.MessageFlagsEphemeral,
 					},
 				})
@@ -257,7 +420,7 @@ func handlePvPBattleRequest(session *discordgo.Session, message *discordgo.Messa
 
 	// Add timeout to remove buttons after 60 seconds
 	time.AfterFunc(60*time.Second, func() {
-		content := "Battle challenge expired."
+		content := targetLoc.T("battle.challenge.expired")
 		session.ChannelMessageEditComplex(&discordgo.MessageEdit{
 			Channel:    message.ChannelID,
 			ID:         message.ID,
@@ -267,8 +430,8 @@ func handlePvPBattleRequest(session *discordgo.Session, message *discordgo.Messa
 	})
 }
 
-// startPvPBattle creates a battle between two players
-func startPvPBattle(session *discordgo.Session, player1ID, player2ID, channelID, messageID string) {
+// startPvPBattle creates a battle between two players, returning nil if it could not start
+func startPvPBattle(session *discordgo.Session, player1ID, player2ID, channelID, messageID string, rankedMatch bool) *Battle {
 	// Get the database singleton
 	db := database.DBInit()
 
@@ -276,9 +439,12 @@ func startPvPBattle(session *discordgo.Session, player1ID, player2ID, channelID,
 	char1, err1 := database.GetCharacterByOwner(db, player1ID)
 	char2, err2 := database.GetCharacterByOwner(db, player2ID)
 
+	// Both players read the battle, so it's in the server's language
+	loc := channelLocalizer(session, channelID, "")
+
 	if err1 != nil || err2 != nil {
-		session.ChannelMessageSend(channelID, "Error starting battle: One or both players don't have characters.")
-		return
+		session.ChannelMessageSend(channelID, loc.T("battle.pvp_no_characters"))
+		return nil
 	}
 
 	// Get usernames
@@ -309,7 +475,8 @@ func startPvPBattle(session *discordgo.Session, player1ID, player2ID, channelID,
 	p2 := CharacterToCombatParticipant(char2, player2ID, username2)
 
 	// Create the battle
-	battle := NewBattle(channelID, p1, p2)
+	battle := NewBattle(channelID, p1, p2, loc)
+	battle.Ranked = rankedMatch
 
 	// Store battle in active battles map
 	ActiveBattlesMutex.Lock()
@@ -326,11 +493,13 @@ func startPvPBattle(session *discordgo.Session, player1ID, player2ID, channelID,
 	msg, err := session.ChannelMessageSendEmbed(channelID, battleEmbed)
 	if err != nil {
 		fmt.Printf("Error sending battle message: %v\n", err)
-		return
+		return battle
 	}
 
 	// Store message ID for updates
 	battle.InteractionMessage = msg.ID
+
+	return battle
 }
 
 // handleCombatAction processes a player's combat action
@@ -349,37 +518,32 @@ func handleCombatAction(session *discordgo.Session, message *discordgo.MessageCr
 	}
 	ActiveBattlesMutex.Unlock()
 
+	loc := localizer(message)
 	if playerBattle == nil {
-		session.ChannelMessageSend(message.ChannelID, "You're not in a battle! Use `!cb battle start` to begin one.")
+		session.ChannelMessageSend(message.ChannelID, loc.T("battle.not_in_battle"))
 		return
 	}
 
 	// Check if it's the player's turn
 	if playerBattle.CurrentTurn != message.Author.ID {
-		session.ChannelMessageSend(message.ChannelID, "It's not your turn!")
+		session.ChannelMessageSend(message.ChannelID, loc.T("battle.not_your_turn"))
 		return
 	}
 
-	// Find the target (the opponent)
-	var targetID string
-	for id := range playerBattle.Participants {
-		if id != message.Author.ID {
-			targetID = id
-			break
-		}
-	}
+	// Aim at the weakest enemy still standing, escorts included
+	targetID := primaryTarget(playerBattle, playerBattle.Participants[message.Author.ID]).DiscordID
 
 	// Set the action
 	err := playerBattle.SetAction(message.Author.ID, actionName, targetID)
 	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("Error: %v", err))
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
 		return
 	}
 
 	// Process the turn
 	result, err := playerBattle.ProcessTurn()
 	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("Error processing turn: %v", err))
+		session.ChannelMessageSend(message.ChannelID, loc.T("battle.turn_failed", i18n.Args{"error": err}))
 		return
 	}
 
@@ -401,34 +565,43 @@ func handleCombatAction(session *discordgo.Session, message *discordgo.MessageCr
 	}
 }
 
-// processBotTurn automatically processes a turn for an NPC
+// processBotTurn automatically processes NPC turns until it's a player's turn
+// Note: An NPC and its escorts can act back to back.
 func processBotTurn(session *discordgo.Session, battle *Battle) {
-	// Small delay to make it feel more natural
-	time.Sleep(1 * time.Second)
+	for battle.State == BattleOngoing && battle.Participants[battle.CurrentTurn].IsBot {
+		// Small delay to make it feel more natural
+		time.Sleep(1 * time.Second)
 
-	// Process the turn (NPC action should have been set automatically)
-	result, err := battle.ProcessTurn()
-	if err != nil {
-		fmt.Printf("Error processing NPC turn: %v\n", err)
-		return
-	}
+		// Process the turn (NPC action should have been set automatically)
+		result, err := battle.ProcessTurn()
+		if err != nil {
+			fmt.Printf("Error processing NPC turn: %v\n", err)
+			return
+		}
 
-	// Send the NPC action result
-	session.ChannelMessageSend(battle.ChannelID, result)
+		// Send the NPC action result
+		session.ChannelMessageSend(battle.ChannelID, result)
 
-	// Update the battle embed
-	updateBattleEmbed(session, battle)
+		// Update the battle embed
+		updateBattleEmbed(session, battle)
+	}
 
 	// Check if battle is complete
 	if battle.State == BattleComplete {
+		var battleID string
 		ActiveBattlesMutex.Lock()
 		for id, b := range ActiveBattles {
 			if b == battle {
-				handleBattleCompletion(session, battle, id)
+				battleID = id
 				break
 			}
 		}
 		ActiveBattlesMutex.Unlock()
+
+		// Completion removes the battle itself, so the lock must be released first
+		if battleID != "" {
+			handleBattleCompletion(session, battle, battleID)
+		}
 	}
 }
 
@@ -446,12 +619,17 @@ func updateBattleEmbed(session *discordgo.Session, battle *Battle) {
 
 // createBattleEmbed creates a rich embed for battle display
 func createBattleEmbed(battle *Battle) *discordgo.MessageEmbed {
-	// Get both participants for display
+	// Get both main fighters for display, escorts get their own fields
 	var p1, p2 *CombatParticipant
-	for _, p := range battle.Participants {
-		if p1 == nil {
+	escorts := []*CombatParticipant{}
+	for _, id := range battle.TurnOrder {
+		p := battle.Participants[id]
+		switch {
+		case p.AllyOf != "":
+			escorts = append(escorts, p)
+		case p1 == nil:
 			p1 = p
-		} else {
+		default:
 			p2 = p
 		}
 	}
@@ -471,41 +649,59 @@ func createBattleEmbed(battle *Battle) *discordgo.MessageEmbed {
 	p2ManaBar := createProgressBar(p2ManaPercent, 10)
 
 	// Create embed
+	loc := battle.Localizer
 	battleEmbed := &discordgo.MessageEmbed{
-		Title:       "⚔️ Battle ⚔️",
-		Description: fmt.Sprintf("Round %d\n\nCurrent turn: **%s**", battle.Round, battle.Participants[battle.CurrentTurn].UserName),
+		Title:       loc.T("battle.embed.title"),
+		Description: loc.T("battle.embed.description", i18n.Args{"round": battle.Round, "name": battle.Participants[battle.CurrentTurn].UserName}),
 		Color:       0xFF0000,
 		Fields: []*discordgo.MessageEmbedField{
 			{
 				Name: fmt.Sprintf("%s (%s)", p1.UserName, p1.Element),
-				Value: fmt.Sprintf("HP: %d/%d %s\nMP: %d/%d %s\nStatus: %s",
-					p1.CurrentHP, p1.MaxHP, p1HealthBar,
-					p1.CurrentMP, p1.MaxMP, p1ManaBar,
-					formatStatusEffects(p1)),
+				Value: loc.T("battle.embed.participant", i18n.Args{
+					"hp": p1.CurrentHP, "maxHP": p1.MaxHP, "hpBar": p1HealthBar,
+					"mp": p1.CurrentMP, "maxMP": p1.MaxMP, "mpBar": p1ManaBar,
+					"status": formatStatusEffects(p1, loc),
+				}),
 				Inline: true,
 			},
 			{
 				Name: fmt.Sprintf("%s (%s)", p2.UserName, p2.Element),
-				Value: fmt.Sprintf("HP: %d/%d %s\nMP: %d/%d %s\nStatus: %s",
-					p2.CurrentHP, p2.MaxHP, p2HealthBar,
-					p2.CurrentMP, p2.MaxMP, p2ManaBar,
-					formatStatusEffects(p2)),
+				Value: loc.T("battle.embed.participant", i18n.Args{
+					"hp": p2.CurrentHP, "maxHP": p2.MaxHP, "hpBar": p2HealthBar,
+					"mp": p2.CurrentMP, "maxMP": p2.MaxMP, "mpBar": p2ManaBar,
+					"status": formatStatusEffects(p2, loc),
+				}),
 				Inline: true,
 			},
 			{
-				Name:  "Battle Log",
+				Name:  loc.T("battle.embed.log"),
 				Value: formatBattleLog(battle),
 			},
 			{
-				Name:  "Commands",
-				Value: "• `!cb battle attack` - Physical attack\n• `!cb battle magic` - Magical attack\n• `!cb battle defend` - Increase defense\n• `!cb battle item` - Use healing item\n• `!cb battle forfeit` - Give up",
+				Name:  loc.T("battle.embed.commands"),
+				Value: loc.T("battle.embed.commands_value"),
 			},
 		},
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: fmt.Sprintf("Battle ID: %s", battle.ID),
+			Text: loc.T("battle.embed.footer", i18n.Args{"id": battle.ID}),
 		},
 	}
 
+	// Escorts are listed after the main fighters
+	escortFields := []*discordgo.MessageEmbedField{}
+	for _, escort := range escorts {
+		escortFields = append(escortFields, &discordgo.MessageEmbedField{
+			Name: fmt.Sprintf("%s (%s)", escort.UserName, escort.Element),
+			Value: loc.T("battle.embed.participant", i18n.Args{
+				"hp": escort.CurrentHP, "maxHP": escort.MaxHP, "hpBar": createProgressBar(float64(escort.CurrentHP)/float64(escort.MaxHP), 20),
+				"mp": escort.CurrentMP, "maxMP": escort.MaxMP, "mpBar": createProgressBar(float64(escort.CurrentMP)/float64(escort.MaxMP), 10),
+				"status": formatStatusEffects(escort, loc),
+			}),
+			Inline: true,
+		})
+	}
+	battleEmbed.Fields = slices.Insert(battleEmbed.Fields, 2, escortFields...)
+
 	return battleEmbed
 }
 
@@ -534,14 +730,14 @@ func createProgressBar(percent float64, width int) string {
 }
 
 // formatStatusEffects returns a string representation of status effects
-func formatStatusEffects(participant *CombatParticipant) string {
+func formatStatusEffects(participant *CombatParticipant, loc i18n.Localizer) string {
 	if len(participant.StatusEffects) == 0 {
-		return "None"
+		return loc.T("battle.effect.none")
 	}
 
 	statuses := []string{}
 	for effect, turns := range participant.StatusEffects {
-		statuses = append(statuses, fmt.Sprintf("%s (%d)", effect, turns))
+		statuses = append(statuses, fmt.Sprintf("%s (%d)", effectName(effect, loc), turns))
 	}
 
 	return strings.Join(statuses, ", ")
@@ -561,7 +757,7 @@ func formatBattleLog(battle *Battle) string {
 	}
 
 	if log == "" {
-		return "The battle has just begun!"
+		return battle.Localizer.T("battle.embed.log_empty")
 	}
 
 	return log
@@ -578,15 +774,53 @@ func handleBattleCompletion(session *discordgo.Session, battle *Battle, battleID
 
 	// Award experience and currency to winner
 	db := database.DBInit()
+	loc := battle.Localizer
+
+	// Record win/loss statistics for the human players
+	winnerStatsID, loserStatsID := result.Winner, result.Loser
+	if strings.HasPrefix(winnerStatsID, "npc_") {
+		winnerStatsID = ""
+	}
+	if strings.HasPrefix(loserStatsID, "npc_") {
+		loserStatsID = ""
+	}
+	isPvP := winnerStatsID != "" && loserStatsID != ""
+	err = database.RecordBattleResult(db, winnerStatsID, loserStatsID, isPvP)
+	if err != nil {
+		fmt.Printf("Error recording battle result: %v\n", err)
+	}
+
+	// Report tournament results without holding the battle lock while the bracket updates
+	if battle.TournamentID != "" {
+		go reportTournamentResult(session, battle, result.Winner)
+	}
+
+	// Score clan war battles for the winner's clan
+	if battle.ClanWarID != "" && isPvP {
+		go reportClanWarResult(session, battle, result.Winner)
+	}
+
+	// Dungeon fights pay into the run's loot instead of direct rewards
+	if battle.DungeonRunID != "" {
+		completeDungeonBattle(session, battle, result)
+	} else if !strings.HasPrefix(result.Winner, "npc_") {
+		// Only process rewards for human players (not NPCs)
+		// Ranked coin rewards are paid at season end instead
+		if battle.Ranked && isPvP {
+			result.CurrencyGain = 0
+		}
 
-	// Only process rewards for human players (not NPCs)
-	if !strings.HasPrefix(result.Winner, "npc_") {
 		// Add currency to winner
-		_, err = database.AddCurrency(db, result.Winner, result.CurrencyGain)
-		if err != nil {
-			fmt.Printf("Error adding currency: %v\n", err)
+		if result.CurrencyGain > 0 {
+			_, err = database.AddCurrency(db, result.Winner, result.CurrencyGain)
+			if err != nil {
+				fmt.Printf("Error adding currency: %v\n", err)
+			}
 		}
 
+		// Clan perks boost the winner's experience
+		result.Experience = database.ApplyClanXPBoost(db, result.Winner, result.Experience)
+
 		// Add experience and check for level up
 		newExp, newLevel, leveledUp, err := database.AddExperience(db, result.Winner, result.Experience)
 		if err != nil {
@@ -595,52 +829,93 @@ func handleBattleCompletion(session *discordgo.Session, battle *Battle, battleID
 
 		// Create result embed
 		resultEmbed := &discordgo.MessageEmbed{
-			Title:       "🏆 Battle Complete!",
-			Description: fmt.Sprintf("**%s** has won the battle!", battle.Participants[result.Winner].UserName),
+			Title:       loc.T("battle.result.title"),
+			Description: loc.T("battle.result.winner", i18n.Args{"name": battle.Participants[result.Winner].UserName}),
 			Color:       0x00FF00,
 			Fields: []*discordgo.MessageEmbedField{
 				{
-					Name:  "Battle Statistics",
-					Value: fmt.Sprintf("Rounds: %d\nRemaining HP: %d\n", result.Rounds, result.WinnerHP),
+					Name:  loc.T("battle.result.stats"),
+					Value: loc.T("battle.result.stats_value", i18n.Args{"rounds": result.Rounds, "hp": result.WinnerHP}),
 				},
 				{
-					Name: "Rewards",
-					Value: fmt.Sprintf("Experience: **+%d** XP (Total: %d)\nCurrency: **+%d** coins",
-						result.Experience, newExp, result.CurrencyGain),
+					Name:  loc.T("battle.result.rewards"),
+					Value: loc.T("battle.result.rewards_value", i18n.Args{"xp": result.Experience, "total": newExp, "coins": result.CurrencyGain}),
 				},
 			},
 		}
 
+		// Explain rewards changed by the level gap
+		if note := rewardScalingNote(result.RewardPercent, loc); note != "" {
+			resultEmbed.Fields = append(resultEmbed.Fields, &discordgo.MessageEmbedField{
+				Name:  loc.T("battle.result.level_gap"),
+				Value: note,
+			})
+		}
+
+		// Roll the defeated NPC's loot table
+		if !isPvP {
+			if drop := grantLootDrop(db, battle.Participants[result.Winner], battle.Participants[result.Loser], loc); drop != "" {
+				resultEmbed.Fields = append(resultEmbed.Fields, &discordgo.MessageEmbedField{
+					Name:  loc.T("battle.result.loot"),
+					Value: drop,
+				})
+			}
+		}
+
+		// Update ratings for ranked matches
+		if battle.Ranked && isPvP {
+			winnerCharacter := battle.Participants[result.Winner].Character
+			loserCharacter := battle.Participants[result.Loser].Character
+
+			rankedResult, err := database.RecordRankedMatch(db, result.Winner, winnerCharacter.ID, result.Loser, loserCharacter.ID)
+			if err != nil {
+				fmt.Printf("Error recording ranked match: %v\n", err)
+			} else {
+				resultEmbed.Fields = append(resultEmbed.Fields, &discordgo.MessageEmbedField{
+					Name: loc.T("battle.result.ranked"),
+					Value: fmt.Sprintf("%s: %d (%+d)\n%s: %d (%+d)",
+						battle.Participants[result.Winner].UserName, rankedResult.Winner.Rating, rankedResult.WinnerDelta,
+						battle.Participants[result.Loser].UserName, rankedResult.Loser.Rating, rankedResult.LoserDelta),
+				})
+			}
+		}
+
 		// Send the result message
 		session.ChannelMessageSendEmbed(battle.ChannelID, resultEmbed)
 
 		// If leveled up, send a separate level up message
 		if leveledUp {
 			levelUpEmbed := &discordgo.MessageEmbed{
-				Title: "🎉 Level Up!",
-				Description: fmt.Sprintf("**%s** has reached level **%d**!",
-					battle.Participants[result.Winner].UserName, newLevel),
-				Color: 0xFFD700,
+				Title:       loc.T("battle.level_up.title"),
+				Description: loc.T("battle.level_up.description", i18n.Args{"name": battle.Participants[result.Winner].UserName, "level": newLevel}),
+				Color:       0xFFD700,
 				Fields: []*discordgo.MessageEmbedField{
 					{
-						Name:  "Character Growth",
-						Value: "Your character grows stronger with each level!",
+						Name:  loc.T("battle.level_up.growth"),
+						Value: loc.T("battle.level_up.growth_value"),
 					},
 				},
 			}
 
 			session.ChannelMessageSendEmbed(battle.ChannelID, levelUpEmbed)
 		}
+
+		// Progress achievements for the winner
+		events := []achievements.Event{battleWonEvent(battle, battle.Participants[result.Loser])}
+		if leveledUp {
+			events = append(events, achievements.ValueEvent(achievements.EventLevelReached, newLevel))
+		}
+		achievementhandlers.Track(session, battle.ChannelID, result.Winner, events...)
 	} else {
 		// If winner is NPC, just show battle result without rewards
 		resultEmbed := &discordgo.MessageEmbed{
-			Title:       "Battle Complete",
-			Description: fmt.Sprintf("**%s** has won the battle!", battle.Participants[result.Winner].UserName),
+			Title:       loc.T("battle.result.title_lost"),
+			Description: loc.T("battle.result.winner", i18n.Args{"name": battle.Participants[result.Winner].UserName}),
 			Color:       0xFF0000,
 			Fields: []*discordgo.MessageEmbedField{
 				{
-					Name:  "Battle Statistics",
-					Value: fmt.Sprintf("Rounds: %d\nRemaining HP: %d\n", result.Rounds, result.WinnerHP),
+					Name:  loc.T("battle.result.stats"),
+					Value: loc.T("battle.result.stats_value", i18n.Args{"rounds": result.Rounds, "hp": result.WinnerHP}),
 				},
 			},
 		}
@@ -654,6 +929,15 @@ func handleBattleCompletion(session *discordgo.Session, battle *Battle, battleID
 	ActiveBattlesMutex.Unlock()
 }
 
+// battleWonEvent describes a victory over the loser for achievements and quests
+func battleWonEvent(battle *Battle, loser *CombatParticipant) achievements.Event {
+	npcName := ""
+	if loser.NPC != nil {
+		npcName = loser.NPC.Name
+	}
+	return achievements.BattleWonEvent(loser.Element, npcName, battle.FinishingAction)
+}
+
 // showBattleStatus shows the current battle status
 func showBattleStatus(session *discordgo.Session, message *discordgo.MessageCreate) {
 	// Find the battle this player is in
@@ -669,7 +953,7 @@ func showBattleStatus(session *discordgo.Session, message *discordgo.MessageCrea
 	ActiveBattlesMutex.Unlock()
 
 	if playerBattle == nil {
-		session.ChannelMessageSend(message.ChannelID, "You're not in a battle! Use `!cb battle start` to begin one.")
+		session.ChannelMessageSend(message.ChannelID, localizer(message).T("battle.not_in_battle"))
 		return
 	}
 
@@ -694,41 +978,33 @@ func forfeitBattle(session *discordgo.Session, message *discordgo.MessageCreate)
 
 	if playerBattle == nil {
 		ActiveBattlesMutex.Unlock()
-		session.ChannelMessageSend(message.ChannelID, "You're not in a battle!")
+		session.ChannelMessageSend(message.ChannelID, localizer(message).T("battle.not_in_battle"))
 		return
 	}
 
 	// Get opponent and set them as the winner
-	var opponentID string
-	for id := range playerBattle.Participants {
-		if id != message.Author.ID {
-			opponentID = id
-			break
-		}
-	}
+	opponentID := playerBattle.opponentOf(message.Author.ID).DiscordID
 
 	// Set player as defeated
 	playerBattle.Participants[message.Author.ID].CurrentHP = 0
 	playerBattle.State = BattleComplete
+	ActiveBattlesMutex.Unlock()
 
 	// If needed, we can explicitly set the opponent as the winner
 	// playerBattle.WinnerID = opponentID // Would need to add this field to Battle struct
 
 	// Update the battle log
-	playerBattle.Log = append(playerBattle.Log, fmt.Sprintf("%s has forfeited the battle! %s wins!",
-		playerBattle.Participants[message.Author.ID].UserName,
-		playerBattle.Participants[opponentID].UserName))
+	playerBattle.Log = append(playerBattle.Log, playerBattle.Localizer.T("battle.log.forfeited", i18n.Args{
+		"loser":  playerBattle.Participants[message.Author.ID].UserName,
+		"winner": playerBattle.Participants[opponentID].UserName,
+	}))
 
 	// Update the battle embed
 	updateBattleEmbed(session, playerBattle)
 
-	// Process battle completion
+	// Process battle completion, which also removes it from active battles
 	handleBattleCompletion(session, playerBattle, battleID)
 
-	// Remove from active battles
-	delete(ActiveBattles, battleID)
-	ActiveBattlesMutex.Unlock()
-
 	// Send forfeit message
-	session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("**%s** has forfeited the battle!", message.Author.Username))
+	session.ChannelMessageSend(message.ChannelID, playerBattle.Localizer.T("battle.forfeited", i18n.Args{"name": message.Author.Username}))
 }
@@ -0,0 +1,446 @@
+package combathandlers
+
+import (
+	"CrispyBot/achievements"
+	achievementhandlers "CrispyBot/bugou/achievementhandlers"
+	"CrispyBot/database"
+	"CrispyBot/database/models"
+	"CrispyBot/dungeon"
+	"CrispyBot/i18n"
+	"CrispyBot/variables"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// HandleDungeonCommand processes dungeon commands
+func HandleDungeonCommand(session *discordgo.Session, message *discordgo.MessageCreate, args []string) {
+	loc := localizer(message)
+	subCommand := "status"
+	if len(args) >= 3 {
+		subCommand = strings.ToLower(args[2])
+	}
+
+	switch subCommand {
+	case "enter":
+		enterDungeon(session, message, loc)
+	case "descend":
+		descendDungeon(session, message, loc)
+	case "retreat":
+		retreatDungeon(session, message, loc)
+	case "status":
+		showDungeonStatus(session, message, loc)
+	default:
+		session.ChannelMessageSend(message.ChannelID, loc.T("dungeon.usage"))
+	}
+}
+
+// enterDungeon starts a new run at the dungeon entrance
+func enterDungeon(session *discordgo.Session, message *discordgo.MessageCreate, loc i18n.Localizer) {
+	// Get the database singleton
+	db := database.DBInit()
+
+	character, err := database.GetCharacterByOwner(db, message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("dungeon.no_character"))
+		return
+	}
+
+	if IsPlayerInBattle(message.Author.ID) {
+		session.ChannelMessageSend(message.ChannelID, loc.T("battle.already_in_battle"))
+		return
+	}
+
+	// Runs start at full strength
+	player := CharacterToCombatParticipant(character, message.Author.ID, message.Author.Username)
+
+	now := time.Now()
+	run, err := database.CreateDungeonRun(db, models.DungeonRun{
+		DiscordID:     message.Author.ID,
+		CharacterID:   character.ID,
+		State:         database.DungeonExploring,
+		CurrentHP:     player.MaxHP,
+		CurrentMP:     player.MaxMP,
+		StatusEffects: make(map[string]int),
+		StartedAt:     now,
+		UpdatedAt:     now,
+	})
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	enterEmbed := createDungeonEmbed(run, player, character.DeepestFloor, loc)
+	enterEmbed.Title = loc.T("dungeon.enter.title")
+	enterEmbed.Description = loc.T("dungeon.enter.description", i18n.Args{"name": message.Author.Username})
+
+	session.ChannelMessageSendEmbed(message.ChannelID, enterEmbed)
+}
+
+// descendDungeon moves to the next floor and resolves its room
+func descendDungeon(session *discordgo.Session, message *discordgo.MessageCreate, loc i18n.Localizer) {
+	// Get the database singleton
+	db := database.DBInit()
+
+	run, err := database.GetActiveDungeonRun(db, message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("dungeon.no_run", i18n.Args{"error": err}))
+		return
+	}
+
+	if IsPlayerInBattle(message.Author.ID) {
+		session.ChannelMessageSend(message.ChannelID, loc.T("dungeon.finish_battle"))
+		return
+	}
+
+	character, err := database.GetCharacterByOwner(db, message.Author.ID)
+	if err != nil || character.ID != run.CharacterID {
+		session.ChannelMessageSend(message.ChannelID, loc.T("dungeon.character_gone"))
+		return
+	}
+
+	// The fight was lost to a restart or cleanup, so the enemy is still waiting
+	if run.State == database.DungeonFighting {
+		session.ChannelMessageSend(message.ChannelID, loc.T("dungeon.still_guarding", i18n.Args{"enemy": run.EnemyName, "floor": run.Floor}))
+		startDungeonFight(session, message.ChannelID, message.Author.Username, run, character, loc)
+		return
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	run.Floor++
+	room := dungeon.GenerateRoom(run.Floor, rng)
+	run.Room = room.Type
+
+	// Track the deepest floor reached per character
+	newRecord, err := database.UpdateDeepestFloor(db, character.ID, run.Floor)
+	if err != nil {
+		fmt.Printf("Error updating deepest floor: %v\n", err)
+	}
+	if newRecord {
+		character.DeepestFloor = run.Floor
+	}
+
+	player := dungeonParticipant(run, character, message.Author.Username)
+	floorEmbed := createDungeonEmbed(run, player, character.DeepestFloor, loc)
+
+	switch room.Type {
+	case dungeon.RoomRest:
+		run.CurrentHP = dungeon.RestHeal(player.CurrentHP, player.MaxHP)
+		run.CurrentMP = dungeon.RestHeal(player.CurrentMP, player.MaxMP)
+		run.StatusEffects = make(map[string]int)
+
+		floorEmbed = createDungeonEmbed(run, dungeonParticipant(run, character, message.Author.Username), character.DeepestFloor, loc)
+		floorEmbed.Title = loc.T("dungeon.rest.title", i18n.Args{"floor": run.Floor})
+		floorEmbed.Description = loc.T("dungeon.rest.description", i18n.Args{"percent": variables.DungeonRestHealPercent})
+	case dungeon.RoomTreasure:
+		run.LootCoins += room.Coins
+
+		floorEmbed = createDungeonEmbed(run, player, character.DeepestFloor, loc)
+		floorEmbed.Title = loc.T("dungeon.treasure.title", i18n.Args{"floor": run.Floor})
+		floorEmbed.Description = loc.T("dungeon.treasure.description", i18n.Args{"count": room.Coins})
+	default:
+		run.State = database.DungeonFighting
+		run.EnemyName = room.EnemyName
+		run.EnemyLevel = room.EnemyLevel
+
+		roomName := loc.Or("dungeon.room."+room.Type, strings.ToUpper(room.Type[:1])+room.Type[1:])
+		floorEmbed.Title = loc.T("dungeon.fight.title", i18n.Args{"floor": run.Floor, "room": roomName})
+		floorEmbed.Description = loc.T("dungeon.fight.description", i18n.Args{"level": room.EnemyLevel, "enemy": room.EnemyName})
+		if room.Type == dungeon.RoomBoss {
+			floorEmbed.Color = 0x8B0000
+			floorEmbed.Description += "\n" + loc.T("dungeon.fight.boss")
+		}
+	}
+
+	if newRecord {
+		floorEmbed.Description += "\n" + loc.T("dungeon.new_record", i18n.Args{"floor": run.Floor})
+	}
+
+	if err := database.SaveDungeonRun(db, run); err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	session.ChannelMessageSendEmbed(message.ChannelID, floorEmbed)
+
+	if run.State == database.DungeonFighting {
+		startDungeonFight(session, message.ChannelID, message.Author.Username, run, character, loc)
+	}
+}
+
+// retreatDungeon leaves the dungeon with the secured loot and part of the rest
+func retreatDungeon(session *discordgo.Session, message *discordgo.MessageCreate, loc i18n.Localizer) {
+	// Get the database singleton
+	db := database.DBInit()
+
+	run, err := database.GetActiveDungeonRun(db, message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	if run.State == database.DungeonFighting && IsPlayerInBattle(message.Author.ID) {
+		session.ChannelMessageSend(message.ChannelID, loc.T("dungeon.retreat_in_fight"))
+		return
+	}
+
+	coins := run.SecuredCoins + dungeon.RetreatLoot(run.LootCoins)
+	experience := run.SecuredExperience + dungeon.RetreatLoot(run.LootExperience)
+
+	run.State = database.DungeonRetreated
+	if err := database.SaveDungeonRun(db, run); err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	newLevel, leveledUp := payDungeonLoot(db, message.Author.ID, coins, experience)
+
+	retreatEmbed := &discordgo.MessageEmbed{
+		Title:       loc.T("dungeon.retreat.title"),
+		Description: loc.T("dungeon.retreat.description", i18n.Args{"name": message.Author.Username, "floor": run.Floor}),
+		Color:       0x00AAFF,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name: loc.T("dungeon.loot_kept"),
+				Value: loc.T("dungeon.retreat.loot", i18n.Args{"coins": coins, "xp": experience,
+					"percent": variables.DungeonRetreatLootPercent}),
+			},
+		},
+	}
+
+	if leveledUp {
+		retreatEmbed.Fields = append(retreatEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:  loc.T("battle.level_up.title"),
+			Value: loc.T("dungeon.level_up", i18n.Args{"level": newLevel}),
+		})
+	}
+
+	session.ChannelMessageSendEmbed(message.ChannelID, retreatEmbed)
+
+	if leveledUp {
+		achievementhandlers.Track(session, message.ChannelID, message.Author.ID, achievements.ValueEvent(achievements.EventLevelReached, newLevel))
+	}
+}
+
+// showDungeonStatus shows the player's current run
+func showDungeonStatus(session *discordgo.Session, message *discordgo.MessageCreate, loc i18n.Localizer) {
+	db := database.DBInit()
+
+	run, err := database.GetActiveDungeonRun(db, message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("dungeon.no_run", i18n.Args{"error": err}))
+		return
+	}
+
+	character, err := database.GetCharacterByOwner(db, message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	statusEmbed := createDungeonEmbed(run, dungeonParticipant(run, character, message.Author.Username), character.DeepestFloor, loc)
+	statusEmbed.Title = loc.T("dungeon.status.title", i18n.Args{"floor": run.Floor})
+	if run.State == database.DungeonFighting {
+		statusEmbed.Description = loc.T("dungeon.status.fighting", i18n.Args{"level": run.EnemyLevel, "enemy": run.EnemyName})
+	}
+
+	session.ChannelMessageSendEmbed(message.ChannelID, statusEmbed)
+}
+
+// startDungeonFight opens a battle against the floor's enemy using the run's carried-over state
+func startDungeonFight(session *discordgo.Session, channelID string, userName string, run models.DungeonRun, character models.Character, loc i18n.Localizer) {
+	player := dungeonParticipant(run, character, userName)
+	opponent := CreateNPCOpponent(run.EnemyName, run.EnemyLevel)
+
+	battle := NewBattle(channelID, player, opponent, loc)
+	battle.DungeonRunID = run.ID.Hex()
+
+	startNPCBattle(session, battle)
+}
+
+// completeDungeonBattle carries the fight's outcome back into the run
+func completeDungeonBattle(session *discordgo.Session, battle *Battle, result *BattleResult) {
+	db := database.DBInit()
+	loc := battle.Localizer
+
+	// Find the human side of the fight
+	var player *CombatParticipant
+	for _, participant := range battle.Participants {
+		if !participant.IsBot {
+			player = participant
+		}
+	}
+	if player == nil {
+		return
+	}
+
+	run, err := database.GetActiveDungeonRun(db, player.DiscordID)
+	if err != nil || run.ID.Hex() != battle.DungeonRunID {
+		fmt.Printf("Error loading dungeon run for battle %s: %v\n", battle.ID, err)
+		return
+	}
+
+	// Defeat ends the run with only the secured loot
+	if result.Winner != player.DiscordID {
+		run.State = database.DungeonDefeated
+		if err := database.SaveDungeonRun(db, run); err != nil {
+			fmt.Printf("Error saving dungeon run: %v\n", err)
+		}
+
+		newLevel, leveledUp := payDungeonLoot(db, player.DiscordID, run.SecuredCoins, run.SecuredExperience)
+
+		defeatEmbed := &discordgo.MessageEmbed{
+			Title:       loc.T("dungeon.defeat.title"),
+			Description: loc.T("dungeon.defeat.description", i18n.Args{"name": player.UserName, "enemy": run.EnemyName, "floor": run.Floor}),
+			Color:       0xFF0000,
+			Fields: []*discordgo.MessageEmbedField{
+				{
+					Name:  loc.T("dungeon.loot_kept"),
+					Value: loc.T("dungeon.defeat.loot", i18n.Args{"coins": run.SecuredCoins, "xp": run.SecuredExperience}),
+				},
+			},
+		}
+		if leveledUp {
+			defeatEmbed.Fields = append(defeatEmbed.Fields, &discordgo.MessageEmbedField{
+				Name:  loc.T("battle.level_up.title"),
+				Value: loc.T("dungeon.level_up", i18n.Args{"level": newLevel}),
+			})
+		}
+
+		session.ChannelMessageSendEmbed(battle.ChannelID, defeatEmbed)
+
+		if leveledUp {
+			achievementhandlers.Track(session, battle.ChannelID, player.DiscordID, achievements.ValueEvent(achievements.EventLevelReached, newLevel))
+		}
+		return
+	}
+
+	// Carry HP, MP and status effects to the next floor
+	run.CurrentHP = player.CurrentHP
+	run.CurrentMP = player.CurrentMP
+	run.StatusEffects = make(map[string]int)
+	for effect, turns := range player.StatusEffects {
+		run.StatusEffects[effect] = turns
+	}
+
+	coins, experience := dungeon.FightRewards(run.Floor)
+	experience = database.ApplyClanXPBoost(db, player.DiscordID, experience)
+	run.LootCoins += coins
+	run.LootExperience += experience
+
+	description := loc.T("dungeon.cleared.description", i18n.Args{"name": player.UserName, "enemy": run.EnemyName, "coins": coins, "xp": experience})
+
+	// Bosses secure everything carried so far
+	if run.Room == dungeon.RoomBoss {
+		run.SecuredCoins += run.LootCoins
+		run.SecuredExperience += run.LootExperience
+		run.LootCoins = 0
+		run.LootExperience = 0
+		description += "\n" + loc.T("dungeon.cleared.secured")
+	}
+
+	run.State = database.DungeonExploring
+	run.EnemyName = ""
+	run.EnemyLevel = 0
+
+	if err := database.SaveDungeonRun(db, run); err != nil {
+		fmt.Printf("Error saving dungeon run: %v\n", err)
+		return
+	}
+
+	clearEmbed := createDungeonEmbed(run, player, 0, loc)
+	clearEmbed.Title = loc.T("dungeon.cleared.title", i18n.Args{"floor": run.Floor})
+	clearEmbed.Description = description
+
+	// Item drops are granted right away rather than risked with the run's loot
+	if drop := grantLootDrop(db, player, battle.Participants[result.Loser], loc); drop != "" {
+		clearEmbed.Fields = append(clearEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:  loc.T("battle.result.loot"),
+			Value: drop,
+		})
+	}
+
+	session.ChannelMessageSendEmbed(battle.ChannelID, clearEmbed)
+
+	achievementhandlers.Track(session, battle.ChannelID, player.DiscordID,
+		battleWonEvent(battle, battle.Participants[result.Loser]),
+		achievements.ValueEvent(achievements.EventDungeonFloor, run.Floor))
+}
+
+// dungeonParticipant builds the player's combatant with the run's carried-over HP, MP and status
+func dungeonParticipant(run models.DungeonRun, character models.Character, userName string) *CombatParticipant {
+	player := CharacterToCombatParticipant(character, run.DiscordID, userName)
+
+	if run.CurrentHP < player.MaxHP {
+		player.CurrentHP = run.CurrentHP
+	}
+	if run.CurrentMP < player.MaxMP {
+		player.CurrentMP = run.CurrentMP
+	}
+	for effect, turns := range run.StatusEffects {
+		player.StatusEffects[effect] = turns
+	}
+
+	return player
+}
+
+// payDungeonLoot pays out coins and experience when a run ends
+func payDungeonLoot(db *database.DB, userID string, coins int, experience int) (int, bool) {
+	if coins > 0 {
+		if _, err := database.AddCurrency(db, userID, coins); err != nil {
+			fmt.Printf("Error adding currency: %v\n", err)
+		}
+	}
+
+	if experience <= 0 {
+		return 0, false
+	}
+
+	_, newLevel, leveledUp, err := database.AddExperience(db, userID, experience)
+	if err != nil {
+		fmt.Printf("Error adding experience: %v\n", err)
+	}
+
+	return newLevel, leveledUp
+}
+
+// createDungeonEmbed renders the run's vitals and loot
+func createDungeonEmbed(run models.DungeonRun, player *CombatParticipant, deepestFloor int, loc i18n.Localizer) *discordgo.MessageEmbed {
+	dungeonEmbed := &discordgo.MessageEmbed{
+		Color: 0x4B0082,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   loc.T("dungeon.embed.floor"),
+				Value:  fmt.Sprintf("%d", run.Floor),
+				Inline: true,
+			},
+			{
+				Name: loc.T("dungeon.embed.vitals"),
+				Value: loc.T("dungeon.embed.vitals_value", i18n.Args{"hp": player.CurrentHP, "maxHP": player.MaxHP,
+					"mp": player.CurrentMP, "maxMP": player.MaxMP, "status": formatStatusEffects(player, loc)}),
+				Inline: true,
+			},
+			{
+				Name: loc.T("dungeon.embed.loot"),
+				Value: loc.T("dungeon.embed.loot_value", i18n.Args{"coins": run.LootCoins, "xp": run.LootExperience,
+					"securedCoins": run.SecuredCoins, "securedXP": run.SecuredExperience}),
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: loc.T("dungeon.embed.footer", i18n.Args{"every": variables.DungeonBossEvery}),
+		},
+	}
+
+	if deepestFloor > 0 {
+		dungeonEmbed.Fields = append(dungeonEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:   loc.T("dungeon.embed.deepest"),
+			Value:  fmt.Sprintf("%d", deepestFloor),
+			Inline: true,
+		})
+	}
+
+	return dungeonEmbed
+}
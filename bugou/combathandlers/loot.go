@@ -0,0 +1,62 @@
+package combathandlers
+
+import (
+	"CrispyBot/database"
+	"CrispyBot/i18n"
+	"CrispyBot/shop"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+// grantLootDrop rolls the defeated NPC's loot table and gives any drop to the winner
+// Note: Returns the embed text for the drop, or "" if nothing dropped. Farmed NPCs drop nothing.
+func grantLootDrop(db *database.DB, winner *CombatParticipant, loser *CombatParticipant, loc i18n.Localizer) string {
+	if winner == nil || loser == nil || loser.NPC == nil || isFarming(winner.Level, loser.Level) {
+		return ""
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	rarity := loser.NPC.RollLoot(loser.Level, rng)
+	if rarity == "" {
+		return ""
+	}
+
+	item := shop.GenerateItem(rarity, rng)
+	inventoryKey, mailed, err := database.GrantItem(db, winner.DiscordID, item, fmt.Sprintf("%s loot", loser.NPC.Name))
+	if err != nil {
+		fmt.Printf("Error granting loot drop: %v\n", err)
+		return ""
+	}
+
+	text := fmt.Sprintf("**%s** (%s)\n%s\n", item.Name, item.Rarity, formatLootStats(item.Stats, loc))
+	if mailed {
+		text += loc.T("common.item_mailed")
+	} else {
+		text += loc.T("battle.loot.added", i18n.Args{"key": inventoryKey})
+	}
+
+	return text
+}
+
+// formatLootStats lists an item's stat bonuses on one line
+func formatLootStats(stats map[string]int, loc i18n.Localizer) string {
+	if len(stats) == 0 {
+		return loc.T("item.no_stats")
+	}
+
+	names := make([]string, 0, len(stats))
+	for name := range stats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s %+d", loc.Or("stat."+strings.ToLower(name), name), stats[name]))
+	}
+
+	return strings.Join(parts, ", ")
+}
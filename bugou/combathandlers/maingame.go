@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"sync"
 	"time"
+
+	"github.com/bwmarrin/discordgo"
 )
 
 var (
@@ -56,34 +58,6 @@ func cleanupStaleBattles() {
 		len(staleBattleIDs), len(ActiveBattles))
 }
 
-// Available NPC types for battles
-var NPCTemplates = map[string]int{
-	"Training Dummy":   1,
-	"Goblin":           2,
-	"Bandit":           3,
-	"Wolf Pack":        4,
-	"Dark Knight":      5,
-	"Troll":            6,
-	"Dragon Whelp":     7,
-	"Necromancer":      8,
-	"Ancient Guardian": 9,
-	"Dragon Lord":      10,
-}
-
-// GetNPCLevel returns the appropriate level for a named NPC type
-func GetNPCLevel(npcType string, customDifficulty int) int {
-	if level, exists := NPCTemplates[npcType]; exists {
-		// If a custom difficulty was provided, use it
-		if customDifficulty > 0 {
-			return customDifficulty
-		}
-		return level
-	}
-
-	// Default to level 1 if NPC type not found
-	return 1
-}
-
 // UpdateBattleTimestamp updates the last activity time for a battle
 func UpdateBattleTimestamp(battleID string) {
 	ActiveBattlesMutex.Lock()
@@ -93,3 +67,28 @@ func UpdateBattleTimestamp(battleID string) {
 		battle.LastUpdated = time.Now()
 	}
 }
+
+// ForceEndBattle stops the battle a player is in without a winner or rewards
+func ForceEndBattle(session *discordgo.Session, userID string, reason string) error {
+	ActiveBattlesMutex.Lock()
+	var playerBattle *Battle
+	for id, battle := range ActiveBattles {
+		if _, exists := battle.Participants[userID]; exists {
+			playerBattle = battle
+			delete(ActiveBattles, id)
+			break
+		}
+	}
+
+	if playerBattle == nil {
+		ActiveBattlesMutex.Unlock()
+		return fmt.Errorf("<@%s> isn't in a battle", userID)
+	}
+
+	playerBattle.State = BattleComplete
+	playerBattle.Log = append(playerBattle.Log, reason)
+	ActiveBattlesMutex.Unlock()
+
+	updateBattleEmbed(session, playerBattle)
+	return nil
+}
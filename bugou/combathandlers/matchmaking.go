@@ -0,0 +1,333 @@
+package combathandlers
+
+import (
+	"CrispyBot/database"
+	"CrispyBot/database/models"
+	"CrispyBot/i18n"
+	"CrispyBot/variables"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Queue modes
+const (
+	QueueRanked = "ranked"
+	QueueCasual = "casual"
+)
+
+var (
+	// MatchmakerStarter makes sure only one matchmaking loop runs
+	MatchmakerStarter sync.Once
+)
+
+// StartMatchmaker starts the background loop that pairs queued players
+func StartMatchmaker(session *discordgo.Session) {
+	MatchmakerStarter.Do(func() {
+		go matchmakingRoutine(session)
+		fmt.Println("Matchmaker started")
+	})
+}
+
+// matchmakingRoutine periodically pairs queued players and expires stale entries
+func matchmakingRoutine(session *discordgo.Session) {
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		runMatchmaking(session)
+	}
+}
+
+// runMatchmaking performs one matchmaking pass over every queue
+func runMatchmaking(session *discordgo.Session) {
+	db := database.DBInit()
+
+	// Let players know when they've been waiting too long
+	expired, err := database.PopExpiredQueueEntries(db)
+	if err != nil {
+		fmt.Printf("Error expiring queue entries: %v\n", err)
+	}
+	for _, entry := range expired {
+		loc := channelLocalizer(session, entry.ChannelID, entry.DiscordID)
+		session.ChannelMessageSend(entry.ChannelID, loc.T("queue.expired", i18n.Args{"user": entry.DiscordID, "mode": queueModeName(entry.Mode, loc)}))
+	}
+
+	for _, mode := range []string{QueueRanked, QueueCasual} {
+		entries, err := database.GetQueueEntries(db, mode)
+		if err != nil {
+			fmt.Printf("Error reading %s queue: %v\n", mode, err)
+			continue
+		}
+
+		for _, pair := range findQueueMatches(entries, time.Now()) {
+			// Skip anyone who started another battle while queued
+			if IsPlayerInBattle(pair[0].DiscordID) || IsPlayerInBattle(pair[1].DiscordID) {
+				continue
+			}
+
+			claimed, err := database.ClaimQueuePair(db, pair[0], pair[1])
+			if err != nil {
+				fmt.Printf("Error claiming queue pair: %v\n", err)
+				continue
+			}
+			if !claimed {
+				continue
+			}
+
+			startQueuedMatch(session, pair[0], pair[1])
+		}
+	}
+}
+
+// findQueueMatches greedily pairs the longest-waiting players with their closest acceptable opponent
+func findQueueMatches(entries []models.QueueEntry, now time.Time) [][2]models.QueueEntry {
+	matched := make(map[string]bool)
+	pairs := [][2]models.QueueEntry{}
+
+	for i, entry := range entries {
+		if matched[entry.DiscordID] {
+			continue
+		}
+
+		bestIdx := -1
+		bestGap := 0
+		for j := i + 1; j < len(entries); j++ {
+			candidate := entries[j]
+			if matched[candidate.DiscordID] || !canMatch(entry, candidate, now) {
+				continue
+			}
+
+			gap := abs(entry.Rating-candidate.Rating) + abs(entry.Level-candidate.Level)*variables.QueueRatingWindowGrowth
+			if bestIdx == -1 || gap < bestGap {
+				bestIdx = j
+				bestGap = gap
+			}
+		}
+
+		if bestIdx != -1 {
+			matched[entry.DiscordID] = true
+			matched[entries[bestIdx].DiscordID] = true
+			pairs = append(pairs, [2]models.QueueEntry{entry, entries[bestIdx]})
+		}
+	}
+
+	return pairs
+}
+
+// canMatch checks if two queued players of the same server are close enough given how long they've waited
+func canMatch(a, b models.QueueEntry, now time.Time) bool {
+	if a.DiscordID == b.DiscordID || a.Mode != b.Mode || a.GuildID != b.GuildID {
+		return false
+	}
+
+	// The search window widens with the longer wait of the two
+	waited := now.Sub(a.JoinedAt)
+	if other := now.Sub(b.JoinedAt); other > waited {
+		waited = other
+	}
+	steps := int(waited.Seconds()) / variables.QueueWidenSeconds
+
+	levelWindow := variables.QueueLevelWindowBase + steps*variables.QueueLevelWindowGrowth
+	if abs(a.Level-b.Level) > levelWindow {
+		return false
+	}
+
+	if a.Mode == QueueRanked {
+		ratingWindow := variables.QueueRatingWindowBase + steps*variables.QueueRatingWindowGrowth
+		if abs(a.Rating-b.Rating) > ratingWindow {
+			return false
+		}
+	}
+
+	return true
+}
+
+// startQueuedMatch opens a battle between two matched players
+func startQueuedMatch(session *discordgo.Session, first, second models.QueueEntry) {
+	channelID := matchChannel(session, first, second)
+
+	// Tell both players where to go
+	announce := func(entry models.QueueEntry) {
+		loc := channelLocalizer(session, entry.ChannelID, "")
+		session.ChannelMessageSend(entry.ChannelID, loc.T("queue.match_found", i18n.Args{
+			"first": first.DiscordID, "second": second.DiscordID, "mode": queueModeName(first.Mode, loc), "channel": channelID}))
+	}
+	announce(first)
+	if second.ChannelID != first.ChannelID {
+		announce(second)
+	}
+
+	startPvPBattle(session, first.DiscordID, second.DiscordID, channelID, "", first.Mode == QueueRanked)
+}
+
+// matchChannel opens a thread for the match in the server's first battle channel, or where the first player queued
+func matchChannel(session *discordgo.Session, first, second models.QueueEntry) string {
+	parentID := first.ChannelID
+	if battleChannels := database.GetGuildSettings(database.DBInit(), first.GuildID).BattleChannels; len(battleChannels) > 0 {
+		parentID = battleChannels[0]
+	}
+
+	threadName := fmt.Sprintf("%s vs %s", first.UserName, second.UserName)
+	thread, err := session.ThreadStart(parentID, threadName, discordgo.ChannelTypeGuildPublicThread, 60)
+	if err != nil {
+		fmt.Printf("Error creating match thread: %v\n", err)
+		return parentID
+	}
+
+	return thread.ID
+}
+
+// HandleQueueCommand processes matchmaking queue commands
+func HandleQueueCommand(session *discordgo.Session, message *discordgo.MessageCreate, args []string) {
+	loc := localizer(message)
+	subCommand := "status"
+	if len(args) >= 3 {
+		subCommand = strings.ToLower(args[2])
+	}
+
+	switch subCommand {
+	case QueueRanked, QueueCasual:
+		joinQueue(session, message, subCommand, loc)
+	case "leave":
+		leaveQueue(session, message, loc)
+	case "status":
+		showQueueStatus(session, message, loc)
+	default:
+		session.ChannelMessageSend(message.ChannelID, loc.T("queue.usage"))
+	}
+}
+
+// joinQueue places the player in a matchmaking pool
+func joinQueue(session *discordgo.Session, message *discordgo.MessageCreate, mode string, loc i18n.Localizer) {
+	// Get the database singleton
+	db := database.DBInit()
+
+	character, err := database.GetCharacterByOwner(db, message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("battle.no_character"))
+		return
+	}
+
+	if IsPlayerInBattle(message.Author.ID) {
+		session.ChannelMessageSend(message.ChannelID, loc.T("battle.already_in_battle"))
+		return
+	}
+
+	// Ranked matches are paired by rating as well as level
+	rating := 0
+	if mode == QueueRanked {
+		profile, err := database.GetRankedProfile(db, message.Author.ID, character.ID)
+		if err != nil {
+			session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+			return
+		}
+		rating = profile.Rating
+	}
+
+	now := time.Now()
+	entry, err := database.JoinQueue(db, models.QueueEntry{
+		DiscordID:   message.Author.ID,
+		UserName:    message.Author.Username,
+		CharacterID: character.ID,
+		Mode:        mode,
+		Rating:      rating,
+		Level:       character.Level,
+		GuildID:     message.GuildID,
+		ChannelID:   message.ChannelID,
+		JoinedAt:    now,
+		ExpiresAt:   now.Add(variables.QueueTimeoutMinutes * time.Minute),
+	})
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	queueEmbed := &discordgo.MessageEmbed{
+		Title:       loc.T("queue.title"),
+		Description: loc.T("queue.joined", i18n.Args{"name": message.Author.Username, "mode": queueModeName(entry.Mode, loc)}),
+		Color:       0x00AAFF,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   loc.T("queue.level"),
+				Value:  fmt.Sprintf("%d", entry.Level),
+				Inline: true,
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: loc.T("queue.footer", i18n.Args{"minutes": variables.QueueTimeoutMinutes}),
+		},
+	}
+
+	if mode == QueueRanked {
+		queueEmbed.Fields = append(queueEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:   loc.T("queue.rating"),
+			Value:  fmt.Sprintf("%d", entry.Rating),
+			Inline: true,
+		})
+	}
+
+	session.ChannelMessageSendEmbed(message.ChannelID, queueEmbed)
+}
+
+// leaveQueue removes the player from the matchmaking pool
+func leaveQueue(session *discordgo.Session, message *discordgo.MessageCreate, loc i18n.Localizer) {
+	db := database.DBInit()
+
+	removed, err := database.LeaveQueue(db, message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	if !removed {
+		session.ChannelMessageSend(message.ChannelID, loc.T("queue.not_queued"))
+		return
+	}
+
+	session.ChannelMessageSend(message.ChannelID, loc.T("queue.left"))
+}
+
+// showQueueStatus shows how long the player has been waiting
+func showQueueStatus(session *discordgo.Session, message *discordgo.MessageCreate, loc i18n.Localizer) {
+	db := database.DBInit()
+
+	entry, err := database.GetQueueEntry(db, message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("queue.no_entry", i18n.Args{"error": err}))
+		return
+	}
+
+	waited := time.Since(entry.JoinedAt).Round(time.Second)
+	remaining := time.Until(entry.ExpiresAt).Round(time.Second)
+	session.ChannelMessageSend(message.ChannelID, loc.T("queue.status", i18n.Args{"mode": queueModeName(entry.Mode, loc), "waited": waited, "remaining": remaining}))
+}
+
+// queueModeName translates a queue mode for display
+func queueModeName(mode string, loc i18n.Localizer) string {
+	return loc.Or("queue.mode."+mode, mode)
+}
+
+// IsPlayerInBattle checks if a player is in any active battle
+func IsPlayerInBattle(userID string) bool {
+	ActiveBattlesMutex.Lock()
+	defer ActiveBattlesMutex.Unlock()
+
+	for _, battle := range ActiveBattles {
+		if _, exists := battle.Participants[userID]; exists {
+			return true
+		}
+	}
+	return false
+}
+
+// abs returns the absolute value of an int
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,105 @@
+package combathandlers
+
+import (
+	"CrispyBot/database/models"
+	"CrispyBot/variables"
+	"testing"
+	"time"
+)
+
+func queueEntry(id string, guildID string, mode string, level int, rating int, joinedAt time.Time) models.QueueEntry {
+	return models.QueueEntry{DiscordID: id, GuildID: guildID, Mode: mode, Level: level, Rating: rating, JoinedAt: joinedAt}
+}
+
+func TestCanMatch_RequiresSameServerAndMode(t *testing.T) {
+	now := time.Now()
+	a := queueEntry("a", "guild1", QueueCasual, 10, 0, now)
+
+	if !canMatch(a, queueEntry("b", "guild1", QueueCasual, 10, 0, now), now) {
+		t.Errorf("Expected equal players in the same server to match")
+	}
+	if canMatch(a, queueEntry("b", "guild2", QueueCasual, 10, 0, now), now) {
+		t.Errorf("Expected players from different servers not to match")
+	}
+	if canMatch(a, queueEntry("b", "guild1", QueueRanked, 10, 0, now), now) {
+		t.Errorf("Expected players in different queues not to match")
+	}
+	if canMatch(a, a, now) {
+		t.Errorf("Expected a player not to match themselves")
+	}
+}
+
+func TestCanMatch_WindowWidensWithWait(t *testing.T) {
+	now := time.Now()
+	gap := variables.QueueLevelWindowBase + variables.QueueLevelWindowGrowth
+
+	a := queueEntry("a", "guild1", QueueCasual, 10, 0, now)
+	b := queueEntry("b", "guild1", QueueCasual, 10+gap, 0, now)
+	if canMatch(a, b, now) {
+		t.Errorf("Expected a %d level gap to be too wide right away", gap)
+	}
+
+	// Only one of the two needs to have waited
+	a.JoinedAt = now.Add(-time.Duration(variables.QueueWidenSeconds) * time.Second)
+	if !canMatch(a, b, now) {
+		t.Errorf("Expected a %d level gap to be allowed after one widen interval", gap)
+	}
+}
+
+func TestCanMatch_RankedChecksRating(t *testing.T) {
+	now := time.Now()
+	a := queueEntry("a", "guild1", QueueRanked, 10, 1000, now)
+	b := queueEntry("b", "guild1", QueueRanked, 10, 1000+variables.QueueRatingWindowBase+1, now)
+
+	if canMatch(a, b, now) {
+		t.Errorf("Expected a rating gap past the window to block a ranked match")
+	}
+
+	a.Mode, b.Mode = QueueCasual, QueueCasual
+	if !canMatch(a, b, now) {
+		t.Errorf("Expected casual matches to ignore rating")
+	}
+}
+
+func TestFindQueueMatches_PairsClosestWithinServer(t *testing.T) {
+	now := time.Now()
+	entries := []models.QueueEntry{
+		queueEntry("a", "guild1", QueueCasual, 10, 0, now.Add(-3*time.Second)),
+		queueEntry("far", "guild1", QueueCasual, 12, 0, now.Add(-2*time.Second)),
+		queueEntry("other", "guild2", QueueCasual, 10, 0, now.Add(-2*time.Second)),
+		queueEntry("close", "guild1", QueueCasual, 10, 0, now.Add(-time.Second)),
+	}
+
+	pairs := findQueueMatches(entries, now)
+	if len(pairs) != 1 {
+		t.Fatalf("Expected 1 pair, got %d", len(pairs))
+	}
+	if pairs[0][0].DiscordID != "a" || pairs[0][1].DiscordID != "close" {
+		t.Errorf("Expected a to be paired with the closest player, got %s vs %s", pairs[0][0].DiscordID, pairs[0][1].DiscordID)
+	}
+}
+
+func TestFindQueueMatches_NeverReusesPlayers(t *testing.T) {
+	now := time.Now()
+	entries := []models.QueueEntry{
+		queueEntry("a", "guild1", QueueCasual, 10, 0, now),
+		queueEntry("b", "guild1", QueueCasual, 10, 0, now),
+		queueEntry("c", "guild1", QueueCasual, 10, 0, now),
+		queueEntry("d", "guild1", QueueCasual, 10, 0, now),
+		queueEntry("e", "guild1", QueueCasual, 10, 0, now),
+	}
+
+	seen := map[string]bool{}
+	pairs := findQueueMatches(entries, now)
+	if len(pairs) != 2 {
+		t.Fatalf("Expected 2 pairs from 5 players, got %d", len(pairs))
+	}
+	for _, pair := range pairs {
+		for _, entry := range pair {
+			if seen[entry.DiscordID] {
+				t.Errorf("Expected %s to be matched only once", entry.DiscordID)
+			}
+			seen[entry.DiscordID] = true
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package combathandlers
+
+import (
+	"CrispyBot/i18n"
+	"CrispyBot/variables"
+)
+
+// autoDifficulty asks for an NPC at the player's level
+const autoDifficulty = -1
+
+// levelGap returns how many levels the attacker is above the target, clamped to the scaling range
+func levelGap(attacker, target *CombatParticipant) int {
+	gap := max(1, attacker.Level) - max(1, target.Level)
+	return max(-variables.LevelGapMaxLevels, min(gap, variables.LevelGapMaxLevels))
+}
+
+// levelDamageMultiplier scales damage up against lower-level targets and down against higher ones
+func levelDamageMultiplier(attacker, target *CombatParticipant) float64 {
+	return 1.0 + float64(levelGap(attacker, target)*variables.LevelGapDamagePercent)/100.0
+}
+
+// levelAccuracyBonus returns the accuracy points gained or lost from the level gap
+func levelAccuracyBonus(attacker, target *CombatParticipant) int {
+	return levelGap(attacker, target) * variables.LevelGapAccuracyPerLevel
+}
+
+// rewardPercent returns the percent of normal rewards paid for beating an NPC of a level
+// Note: Stronger NPCs pay bonus XP, far weaker ones pay less down to a floor.
+func rewardPercent(playerLevel int, npcLevel int) int {
+	gap := max(1, npcLevel) - max(1, playerLevel)
+	if gap > 0 {
+		return 100 + min(gap*variables.LevelGapXPBonusPercent, variables.LevelGapMaxXPBonusPercent)
+	}
+
+	below := -gap - variables.FarmingGraceLevels
+	if below <= 0 {
+		return 100
+	}
+	return max(100-below*variables.FarmingPenaltyPercent, variables.FarmingMinRewardPercent)
+}
+
+// isFarming reports whether an NPC is weak enough that rewards hit the floor
+func isFarming(playerLevel int, npcLevel int) bool {
+	return rewardPercent(playerLevel, npcLevel) <= variables.FarmingMinRewardPercent
+}
+
+// rewardScalingNote explains a reward percent other than 100 for the result embed
+func rewardScalingNote(percent int, loc i18n.Localizer) string {
+	switch {
+	case percent > 100:
+		return loc.T("battle.scaling.stronger", i18n.Args{"percent": percent - 100})
+	case percent <= variables.FarmingMinRewardPercent:
+		return loc.T("battle.scaling.farming", i18n.Args{"percent": percent})
+	case percent < 100:
+		return loc.T("battle.scaling.weaker", i18n.Args{"percent": percent})
+	}
+	return ""
+}
+
+// difficultyWarning warns about fighting an NPC far below or above the player's level
+func difficultyWarning(playerLevel int, npcLevel int, loc i18n.Localizer) string {
+	percent := rewardPercent(playerLevel, npcLevel)
+	args := i18n.Args{"npcLevel": npcLevel, "level": playerLevel, "percent": percent, "gap": npcLevel - playerLevel}
+	switch {
+	case percent <= variables.FarmingMinRewardPercent:
+		return loc.T("battle.warning.farming", args)
+	case percent < 100:
+		return loc.T("battle.warning.weaker", args)
+	case npcLevel-playerLevel >= variables.LevelGapMaxLevels/2:
+		return loc.T("battle.warning.stronger", args)
+	}
+	return ""
+}
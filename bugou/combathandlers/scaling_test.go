@@ -0,0 +1,68 @@
+package combathandlers
+
+import (
+	"CrispyBot/variables"
+	"testing"
+)
+
+func TestLevelDamageMultiplier_FavorsHigherLevel(t *testing.T) {
+	strong := &CombatParticipant{Level: 10}
+	weak := &CombatParticipant{Level: 5}
+
+	if multiplier := levelDamageMultiplier(strong, weak); multiplier <= 1.0 {
+		t.Errorf("Expected higher level to deal more damage, got %.2f", multiplier)
+	}
+	if multiplier := levelDamageMultiplier(weak, strong); multiplier >= 1.0 {
+		t.Errorf("Expected lower level to deal less damage, got %.2f", multiplier)
+	}
+	if multiplier := levelDamageMultiplier(strong, strong); multiplier != 1.0 {
+		t.Errorf("Expected no scaling between equal levels, got %.2f", multiplier)
+	}
+}
+
+func TestLevelGap_Clamped(t *testing.T) {
+	gap := levelGap(&CombatParticipant{Level: 100}, &CombatParticipant{Level: 1})
+	if gap != variables.LevelGapMaxLevels {
+		t.Errorf("Expected gap clamped to %d, got %d", variables.LevelGapMaxLevels, gap)
+	}
+}
+
+func TestRewardPercent(t *testing.T) {
+	if percent := rewardPercent(10, 10); percent != 100 {
+		t.Errorf("Expected full rewards at equal level, got %d", percent)
+	}
+	if percent := rewardPercent(10, 10-variables.FarmingGraceLevels); percent != 100 {
+		t.Errorf("Expected full rewards within the grace, got %d", percent)
+	}
+	if percent := rewardPercent(5, 8); percent <= 100 {
+		t.Errorf("Expected bonus rewards against a stronger NPC, got %d", percent)
+	}
+	if percent := rewardPercent(1, 50); percent != 100+variables.LevelGapMaxXPBonusPercent {
+		t.Errorf("Expected bonus capped at %d, got %d", variables.LevelGapMaxXPBonusPercent, percent)
+	}
+	if percent := rewardPercent(30, 1); percent != variables.FarmingMinRewardPercent || !isFarming(30, 1) {
+		t.Errorf("Expected farming rewards floored at %d, got %d", variables.FarmingMinRewardPercent, percent)
+	}
+}
+
+func TestGetResult_ScalesNPCRewards(t *testing.T) {
+	player := &CombatParticipant{DiscordID: "player", CurrentHP: 10, Level: 20}
+	goblin := CreateNPCOpponent("Goblin", 1)
+	goblin.CurrentHP = 0
+
+	battle := &Battle{
+		State:        BattleComplete,
+		Participants: map[string]*CombatParticipant{player.DiscordID: player, goblin.DiscordID: goblin},
+	}
+
+	result, err := battle.GetResult()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.RewardPercent != variables.FarmingMinRewardPercent {
+		t.Errorf("Expected farming reward percent, got %d", result.RewardPercent)
+	}
+	if result.CurrencyGain >= goblin.NPC.CoinReward(1) {
+		t.Errorf("Expected coins reduced when farming, got %d", result.CurrencyGain)
+	}
+}
@@ -0,0 +1,625 @@
+package combathandlers
+
+import (
+	"CrispyBot/database"
+	"CrispyBot/database/models"
+	"CrispyBot/i18n"
+	"CrispyBot/tournament"
+	"CrispyBot/variables"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	// TournamentSchedulerStarter makes sure only one tournament scheduler runs
+	TournamentSchedulerStarter sync.Once
+
+	// tournamentMutex serializes read-modify-write updates to tournaments
+	tournamentMutex sync.Mutex
+)
+
+// StartTournamentScheduler starts the background loop that resolves no-shows and advances rounds
+func StartTournamentScheduler(session *discordgo.Session) {
+	TournamentSchedulerStarter.Do(func() {
+		go tournamentSchedulerRoutine(session)
+		fmt.Println("Tournament scheduler started")
+	})
+}
+
+// tournamentSchedulerRoutine periodically checks running tournaments
+func tournamentSchedulerRoutine(session *discordgo.Session) {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		runTournamentScheduler(session)
+	}
+}
+
+// runTournamentScheduler forfeits players who missed their check-in and restarts lost battles
+func runTournamentScheduler(session *discordgo.Session) {
+	db := database.DBInit()
+
+	// Read under the lock so a result reported meanwhile isn't overwritten by a stale copy
+	tournamentMutex.Lock()
+	defer tournamentMutex.Unlock()
+
+	tournaments, err := database.GetRunningTournaments(db)
+	if err != nil {
+		fmt.Printf("Error reading tournaments: %v\n", err)
+		return
+	}
+
+	now := time.Now()
+	for i := range tournaments {
+		t := &tournaments[i]
+		loc := channelLocalizer(session, t.ChannelID, "")
+		changed := false
+
+		for _, match := range tournament.OpenMatches(t) {
+			switch match.Status {
+			case tournament.MatchWaiting:
+				if now.Before(match.Deadline) {
+					continue
+				}
+
+				// Whoever checked in wins, otherwise the better seed advances
+				winnerID := match.PlayerA
+				if match.ReadyB && !match.ReadyA {
+					winnerID = match.PlayerB
+				}
+				if err := tournament.RecordResult(t, match.ID, winnerID, true); err != nil {
+					fmt.Printf("Error recording forfeit: %v\n", err)
+					continue
+				}
+
+				session.ChannelMessageSend(t.ChannelID, loc.T("tournament.checkin_closed", i18n.Args{"match": match.ID, "winner": winnerID}))
+				changed = true
+			case tournament.MatchPlaying:
+				// The battle was lost (e.g. a restart), so the players need to ready up again
+				if IsPlayerInBattle(match.PlayerA) || IsPlayerInBattle(match.PlayerB) {
+					continue
+				}
+
+				match.Status = tournament.MatchWaiting
+				match.ReadyA = false
+				match.ReadyB = false
+				match.Deadline = now.Add(variables.TournamentCheckInMinutes * time.Minute)
+
+				session.ChannelMessageSend(t.ChannelID, loc.T("tournament.interrupted", i18n.Args{"match": match.ID, "playerA": match.PlayerA, "playerB": match.PlayerB}))
+				changed = true
+			}
+		}
+
+		if !changed {
+			continue
+		}
+
+		advanceTournament(session, t)
+		if err := database.SaveTournament(db, *t); err != nil {
+			fmt.Printf("Error saving tournament: %v\n", err)
+		}
+	}
+}
+
+// HandleTournamentCommand processes tournament commands
+func HandleTournamentCommand(session *discordgo.Session, message *discordgo.MessageCreate, args []string) {
+	loc := localizer(message)
+	subCommand := "status"
+	if len(args) >= 3 {
+		subCommand = strings.ToLower(args[2])
+	}
+
+	switch subCommand {
+	case "create":
+		createTournament(session, message, args, loc)
+	case "join":
+		joinTournament(session, message, loc)
+	case "start":
+		startTournament(session, message, loc)
+	case "ready":
+		readyTournamentMatch(session, message, loc)
+	case "cancel":
+		cancelTournament(session, message, loc)
+	case "status":
+		showTournamentStatus(session, message, loc)
+	default:
+		session.ChannelMessageSend(message.ChannelID, loc.T("tournament.usage"))
+	}
+}
+
+// createTournament opens signups for a new tournament
+func createTournament(session *discordgo.Session, message *discordgo.MessageCreate, args []string, loc i18n.Localizer) {
+	format := tournament.FormatSingle
+	if len(args) >= 4 {
+		format = strings.ToLower(args[3])
+	}
+
+	if !tournament.IsValidFormat(format) {
+		session.ChannelMessageSend(message.ChannelID, loc.T("tournament.invalid_format"))
+		return
+	}
+
+	name := loc.T("tournament.default_name", i18n.Args{"name": message.Author.Username})
+	if len(args) >= 5 {
+		name = strings.Join(args[4:], " ")
+	}
+
+	// Get the database singleton
+	db := database.DBInit()
+
+	t, err := database.CreateTournament(db, models.Tournament{
+		Name:        name,
+		GuildID:     message.GuildID,
+		ChannelID:   message.ChannelID,
+		OrganizerID: message.Author.ID,
+		Format:      format,
+		Status:      tournament.StatusSignup,
+		Players:     []models.TournamentPlayer{},
+		Matches:     []models.TournamentMatch{},
+		CreatedAt:   time.Now(),
+	})
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	createEmbed := &discordgo.MessageEmbed{
+		Title:       loc.T("tournament.created.title"),
+		Description: loc.T("tournament.created.description", i18n.Args{"name": t.Name}),
+		Color:       0xFFD700,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   loc.T("tournament.format"),
+				Value:  formatName(t.Format, loc),
+				Inline: true,
+			},
+			{
+				Name:   loc.T("tournament.entry_fee"),
+				Value:  loc.T("tournament.entry_fee_value", i18n.Args{"count": variables.TournamentEntryFee}),
+				Inline: true,
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: loc.T("tournament.created.footer"),
+		},
+	}
+
+	session.ChannelMessageSendEmbed(message.ChannelID, createEmbed)
+}
+
+// joinTournament signs the player's character up for the guild's tournament
+func joinTournament(session *discordgo.Session, message *discordgo.MessageCreate, loc i18n.Localizer) {
+	// Get the database singleton
+	db := database.DBInit()
+
+	character, err := database.GetCharacterByOwner(db, message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("tournament.no_character"))
+		return
+	}
+
+	tournamentMutex.Lock()
+	defer tournamentMutex.Unlock()
+
+	t, err := database.GetActiveTournament(db, message.GuildID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	if t.Status != tournament.StatusSignup {
+		session.ChannelMessageSend(message.ChannelID, loc.T("tournament.signups_closed"))
+		return
+	}
+
+	if tournament.FindPlayer(&t, message.Author.ID) != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("tournament.already_joined"))
+		return
+	}
+
+	if len(t.Players) >= variables.TournamentMaxPlayers {
+		session.ChannelMessageSend(message.ChannelID, loc.T("tournament.full", i18n.Args{"count": variables.TournamentMaxPlayers}))
+		return
+	}
+
+	// The entry fee funds the prize pool, so prizes never create coins
+	if err := database.PayTournamentEntryFee(db, message.Author.ID, variables.TournamentEntryFee); err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("tournament.fee_unaffordable", i18n.Args{"count": variables.TournamentEntryFee}))
+		return
+	}
+
+	t.Players = append(t.Players, models.TournamentPlayer{
+		DiscordID:   message.Author.ID,
+		UserName:    message.Author.Username,
+		CharacterID: character.ID,
+		Opponents:   []string{},
+		EntryFee:    variables.TournamentEntryFee,
+	})
+	t.PrizePool += variables.TournamentEntryFee
+
+	if err := database.SaveTournament(db, t); err != nil {
+		if _, refundErr := database.AddCurrency(db, message.Author.ID, variables.TournamentEntryFee); refundErr != nil {
+			fmt.Printf("Error refunding tournament entry fee: %v\n", refundErr)
+		}
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	session.ChannelMessageSend(message.ChannelID, loc.T("tournament.joined", i18n.Args{"name": message.Author.Username, "tournament": t.Name, "count": len(t.Players)}))
+}
+
+// startTournament seeds the bracket and announces the first round
+func startTournament(session *discordgo.Session, message *discordgo.MessageCreate, loc i18n.Localizer) {
+	// Get the database singleton
+	db := database.DBInit()
+
+	tournamentMutex.Lock()
+	defer tournamentMutex.Unlock()
+
+	t, err := database.GetActiveTournament(db, message.GuildID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	if t.OrganizerID != message.Author.ID {
+		session.ChannelMessageSend(message.ChannelID, loc.T("tournament.not_organizer"))
+		return
+	}
+
+	matches, err := tournament.Start(&t)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	t.ChannelID = message.ChannelID
+	setCheckInDeadlines(matches)
+
+	if err := database.SaveTournament(db, t); err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	session.ChannelMessageSendEmbed(message.ChannelID, createBracketEmbed(&t, loc))
+	announceRound(session, &t, matches, loc)
+}
+
+// cancelTournament lets the organizer call off a tournament before it starts, refunding every entry fee
+func cancelTournament(session *discordgo.Session, message *discordgo.MessageCreate, loc i18n.Localizer) {
+	// Get the database singleton
+	db := database.DBInit()
+
+	tournamentMutex.Lock()
+	defer tournamentMutex.Unlock()
+
+	t, err := database.GetActiveTournament(db, message.GuildID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	if t.OrganizerID != message.Author.ID {
+		session.ChannelMessageSend(message.ChannelID, loc.T("tournament.cancel_not_organizer"))
+		return
+	}
+
+	if t.Status != tournament.StatusSignup {
+		session.ChannelMessageSend(message.ChannelID, loc.T("tournament.cancel_started"))
+		return
+	}
+
+	if err := database.CancelTournament(db, t); err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	session.ChannelMessageSend(message.ChannelID, loc.T("tournament.cancelled", i18n.Args{"name": t.Name, "count": len(t.Players)}))
+}
+
+// readyTournamentMatch checks the player in and starts the battle once both sides are ready
+func readyTournamentMatch(session *discordgo.Session, message *discordgo.MessageCreate, loc i18n.Localizer) {
+	// Get the database singleton
+	db := database.DBInit()
+
+	tournamentMutex.Lock()
+	defer tournamentMutex.Unlock()
+
+	t, err := database.GetActiveTournament(db, message.GuildID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	match := tournament.PlayerMatch(&t, message.Author.ID)
+	if match == nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("tournament.no_match"))
+		return
+	}
+
+	if match.Status == tournament.MatchPlaying {
+		session.ChannelMessageSend(message.ChannelID, loc.T("tournament.match_underway"))
+		return
+	}
+
+	if match.PlayerA == message.Author.ID {
+		match.ReadyA = true
+	} else {
+		match.ReadyB = true
+	}
+
+	opponentID := match.PlayerA
+	if opponentID == message.Author.ID {
+		opponentID = match.PlayerB
+	}
+
+	if !match.ReadyA || !match.ReadyB {
+		if err := database.SaveTournament(db, t); err != nil {
+			session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+			return
+		}
+
+		remaining := time.Until(match.Deadline).Round(time.Second)
+		session.ChannelMessageSend(message.ChannelID, loc.T("tournament.ready", i18n.Args{"user": message.Author.ID, "match": match.ID, "opponent": opponentID, "remaining": remaining}))
+		return
+	}
+
+	if IsPlayerInBattle(match.PlayerA) || IsPlayerInBattle(match.PlayerB) {
+		session.ChannelMessageSend(message.ChannelID, loc.T("tournament.player_busy"))
+		return
+	}
+
+	battle := startPvPBattle(session, match.PlayerA, match.PlayerB, t.ChannelID, "", false)
+	if battle == nil {
+		return
+	}
+
+	// Tag the battle so its result is reported back to the bracket
+	ActiveBattlesMutex.Lock()
+	battle.TournamentID = t.ID.Hex()
+	battle.TournamentMatch = match.ID
+	ActiveBattlesMutex.Unlock()
+
+	match.Status = tournament.MatchPlaying
+	if err := database.SaveTournament(db, t); err != nil {
+		fmt.Printf("Error saving tournament: %v\n", err)
+	}
+}
+
+// showTournamentStatus renders the guild's bracket
+func showTournamentStatus(session *discordgo.Session, message *discordgo.MessageCreate, loc i18n.Localizer) {
+	db := database.DBInit()
+
+	t, err := database.GetActiveTournament(db, message.GuildID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("tournament.no_tournament", i18n.Args{"error": err}))
+		return
+	}
+
+	session.ChannelMessageSendEmbed(message.ChannelID, createBracketEmbed(&t, loc))
+}
+
+// reportTournamentResult records a finished tournament battle and advances the bracket
+func reportTournamentResult(session *discordgo.Session, battle *Battle, winnerID string) {
+	db := database.DBInit()
+
+	tournamentMutex.Lock()
+	defer tournamentMutex.Unlock()
+
+	tournamentID, err := primitive.ObjectIDFromHex(battle.TournamentID)
+	if err != nil {
+		fmt.Printf("Error reading tournament ID: %v\n", err)
+		return
+	}
+
+	t, err := database.GetTournament(db, tournamentID)
+	if err != nil {
+		fmt.Printf("Error loading tournament: %v\n", err)
+		return
+	}
+
+	if err := tournament.RecordResult(&t, battle.TournamentMatch, winnerID, false); err != nil {
+		fmt.Printf("Error recording tournament result: %v\n", err)
+		return
+	}
+
+	session.ChannelMessageSend(t.ChannelID, channelLocalizer(session, t.ChannelID, "").T("tournament.advances", i18n.Args{"match": battle.TournamentMatch, "winner": winnerID}))
+
+	advanceTournament(session, &t)
+	if err := database.SaveTournament(db, t); err != nil {
+		fmt.Printf("Error saving tournament: %v\n", err)
+	}
+}
+
+// advanceTournament moves to the next round or pays out prizes once the bracket is decided
+func advanceTournament(session *discordgo.Session, t *models.Tournament) {
+	loc := channelLocalizer(session, t.ChannelID, "")
+	for !tournament.IsFinished(t) {
+		if !tournament.RoundComplete(t) {
+			return
+		}
+
+		// A round can consist only of byes, so keep pairing until someone has to play
+		matches := tournament.NextRound(t)
+		if len(matches) > 0 {
+			setCheckInDeadlines(matches)
+			session.ChannelMessageSendEmbed(t.ChannelID, createBracketEmbed(t, loc))
+			announceRound(session, t, matches, loc)
+			return
+		}
+	}
+
+	finishTournament(session, t, loc)
+}
+
+// finishTournament pays the top three and announces the results
+func finishTournament(session *discordgo.Session, t *models.Tournament, loc i18n.Localizer) {
+	db := database.DBInit()
+
+	t.Status = tournament.StatusFinished
+	t.FinishedAt = time.Now()
+
+	standings := tournament.Standings(t)
+	shares := []int{variables.TournamentFirstPrizePct, variables.TournamentSecondPrizePct, variables.TournamentThirdPrizePct}
+	medals := []string{"🥇", "🥈", "🥉"}
+
+	var results strings.Builder
+	for i, share := range shares {
+		if i >= len(standings) {
+			break
+		}
+
+		prize := t.PrizePool * share / 100
+		if prize > 0 {
+			if _, err := database.AddCurrency(db, standings[i].DiscordID, prize); err != nil {
+				fmt.Printf("Error paying tournament prize: %v\n", err)
+			}
+		}
+
+		results.WriteString(loc.T("tournament.finish.standing", i18n.Args{"medal": medals[i], "name": standings[i].UserName,
+			"wins": standings[i].Wins, "losses": standings[i].Losses, "prize": prize}) + "\n")
+	}
+
+	finishEmbed := &discordgo.MessageEmbed{
+		Title:       loc.T("tournament.finish.title", i18n.Args{"name": t.Name}),
+		Description: loc.T("tournament.finish.champion", i18n.Args{"user": standings[0].DiscordID}),
+		Color:       0xFFD700,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:  loc.T("tournament.finish.standings"),
+				Value: results.String(),
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: loc.T("tournament.finish.footer", i18n.Args{"format": formatName(t.Format, loc), "players": len(t.Players), "pool": t.PrizePool}),
+		},
+	}
+
+	session.ChannelMessageSendEmbed(t.ChannelID, finishEmbed)
+}
+
+// announceRound pings the players of each new match
+func announceRound(session *discordgo.Session, t *models.Tournament, matches []*models.TournamentMatch, loc i18n.Localizer) {
+	var pings strings.Builder
+	for _, match := range matches {
+		pings.WriteString(loc.T("tournament.round.match", i18n.Args{"match": match.ID, "playerA": match.PlayerA, "playerB": match.PlayerB}) + "\n")
+	}
+
+	session.ChannelMessageSend(t.ChannelID, loc.T("tournament.round.ready", i18n.Args{"round": t.Round, "minutes": variables.TournamentCheckInMinutes})+"\n"+pings.String())
+}
+
+// setCheckInDeadlines gives every new match a check-in window
+func setCheckInDeadlines(matches []*models.TournamentMatch) {
+	deadline := time.Now().Add(variables.TournamentCheckInMinutes * time.Minute)
+	for _, match := range matches {
+		match.Deadline = deadline
+	}
+}
+
+// createBracketEmbed renders the rounds and matches of a tournament
+func createBracketEmbed(t *models.Tournament, loc i18n.Localizer) *discordgo.MessageEmbed {
+	names := make(map[string]string)
+	for _, player := range t.Players {
+		names[player.DiscordID] = player.UserName
+	}
+
+	bracketEmbed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("🏟️ %s", t.Name),
+		Description: loc.T("tournament.bracket.description", i18n.Args{"format": formatName(t.Format, loc), "status": formatName(t.Status, loc), "players": len(t.Players)}),
+		Color:       0xFFD700,
+		Fields:      []*discordgo.MessageEmbedField{},
+	}
+
+	if t.Status == tournament.StatusSignup {
+		var signups strings.Builder
+		for i, player := range t.Players {
+			signups.WriteString(fmt.Sprintf("%d. %s\n", i+1, player.UserName))
+		}
+		if signups.Len() == 0 {
+			signups.WriteString(loc.T("tournament.bracket.no_players"))
+		}
+
+		bracketEmbed.Fields = append(bracketEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:  loc.T("tournament.bracket.signups"),
+			Value: signups.String(),
+		})
+		return bracketEmbed
+	}
+
+	// One field per round, most recent rounds last
+	for round := 1; round <= t.Round; round++ {
+		var lines strings.Builder
+		for _, match := range t.Matches {
+			if match.Round != round {
+				continue
+			}
+			lines.WriteString(formatBracketMatch(match, names, loc) + "\n")
+		}
+
+		bracketEmbed.Fields = append(bracketEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:  loc.T("tournament.bracket.round", i18n.Args{"round": round}),
+			Value: lines.String(),
+		})
+	}
+
+	// Discord caps embeds at 25 fields, so keep the latest rounds
+	if len(bracketEmbed.Fields) > 24 {
+		bracketEmbed.Fields = bracketEmbed.Fields[len(bracketEmbed.Fields)-24:]
+	}
+
+	var standings strings.Builder
+	for i, player := range tournament.Standings(t) {
+		standings.WriteString(fmt.Sprintf("%d. %s (%d-%d)\n", i+1, player.UserName, player.Wins, player.Losses))
+	}
+
+	bracketEmbed.Fields = append(bracketEmbed.Fields, &discordgo.MessageEmbedField{
+		Name:  loc.T("tournament.bracket.standings"),
+		Value: standings.String(),
+	})
+
+	return bracketEmbed
+}
+
+// formatBracketMatch renders a single match line
+func formatBracketMatch(match models.TournamentMatch, names map[string]string, loc i18n.Localizer) string {
+	label := ""
+	switch match.Bracket {
+	case tournament.BracketLosers:
+		label = loc.T("tournament.match.losers") + " "
+	case tournament.BracketGrandFinal:
+		label = loc.T("tournament.match.final") + " "
+	}
+
+	if match.PlayerB == "" {
+		return fmt.Sprintf("`#%d` %s**%s** — %s", match.ID, label, names[match.PlayerA], loc.T("tournament.match.bye"))
+	}
+
+	line := fmt.Sprintf("`#%d` %s%s", match.ID, label, loc.T("tournament.match.versus", i18n.Args{"playerA": names[match.PlayerA], "playerB": names[match.PlayerB]}))
+	switch match.Status {
+	case tournament.MatchDone:
+		line += fmt.Sprintf(" — 🏆 **%s**", names[match.Winner])
+		if match.Forfeit {
+			line += " " + loc.T("tournament.match.forfeit")
+		}
+	case tournament.MatchPlaying:
+		line += " — " + loc.T("tournament.match.playing")
+	default:
+		line += " — " + loc.T("tournament.match.waiting")
+	}
+
+	return line
+}
+
+// formatName translates a format or status for display
+func formatName(value string, loc i18n.Localizer) string {
+	if value == "" {
+		return value
+	}
+	return loc.Or("tournament.name."+value, strings.ToUpper(value[:1])+value[1:])
+}
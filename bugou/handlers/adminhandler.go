@@ -0,0 +1,347 @@
+package bugouhandlers
+
+import (
+	combathandlers "CrispyBot/bugou/combathandlers"
+	"CrispyBot/database"
+	"CrispyBot/database/models"
+	"CrispyBot/i18n"
+	"CrispyBot/roller"
+	"CrispyBot/settings"
+	"CrispyBot/shop"
+	"CrispyBot/variables"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// adminTargetAction fixes up one user's state and describes what it did
+type adminTargetAction func(session *discordgo.Session, message *discordgo.MessageCreate, targetID string, args []string) (models.AdminAction, string, error)
+
+// adminTargetActions are the admin commands that take a @user, by name
+var adminTargetActions = map[string]adminTargetAction{
+	database.AdminCoins:      adminAdjustCoins,
+	database.AdminRerolls:    adminAdjustRerolls,
+	database.AdminItem:       adminGiveItem,
+	database.AdminResetChar:  adminResetCharacter,
+	database.AdminDeleteChar: adminDeleteCharacter,
+	database.AdminEndBattle:  adminEndBattle,
+	database.AdminBan:        adminBan,
+	database.AdminUnban:      adminUnban,
+}
+
+// Actions staff can't take on themselves, so nobody can fund their own account
+var adminSelfBlocked = []string{database.AdminCoins, database.AdminRerolls, database.AdminItem, database.AdminBan}
+
+// Actions on wallets, characters and the shop, which every server shares, so they're kept to the bot owners
+// Note: Otherwise the staff of any server, even one made for the purpose, could fund alts or wipe other servers' players.
+var adminOwnerOnly = []string{
+	database.AdminCoins,
+	database.AdminRerolls,
+	database.AdminItem,
+	database.AdminResetChar,
+	database.AdminDeleteChar,
+	database.AdminRefreshShop,
+}
+
+// HandleAdminCommand lets server staff fix up a user's state, with every action written to the audit log
+// Note: The command registry keeps it to staff in server channels.
+func HandleAdminCommand(session *discordgo.Session, message *discordgo.MessageCreate, args []string) {
+	loc := localizer(message)
+	if len(args) < 3 {
+		session.ChannelMessageSend(message.ChannelID, loc.T("admin.usage"))
+		return
+	}
+
+	db := database.DBInit()
+	subCommand := strings.ToLower(args[2])
+	if slices.Contains(adminOwnerOnly, subCommand) && !isBotOwner(message.Author.ID) {
+		session.ChannelMessageSend(message.ChannelID, loc.T("admin.owner_only", i18n.Args{"action": subCommand}))
+		return
+	}
+
+	switch subCommand {
+	case "log":
+		page := 1
+		if len(args) >= 4 {
+			page, _ = strconv.Atoi(args[3])
+		}
+		showAdminLog(session, message, max(page, 1)-1)
+		return
+
+	case database.AdminRefreshShop:
+		current, err := database.GetShop(db)
+		if err != nil {
+			session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+			return
+		}
+		database.RefreshShop(db, current)
+		recordAdminAction(session, message, models.AdminAction{Action: subCommand, Reason: strings.Join(args[3:], " ")},
+			loc.T("admin.shop_refreshed"))
+		return
+	}
+
+	action, ok := adminTargetActions[subCommand]
+	if !ok {
+		session.ChannelMessageSend(message.ChannelID, loc.T("admin.usage"))
+		return
+	}
+
+	targetID, ok := mentionArg(args, 3)
+	if !ok {
+		session.ChannelMessageSend(message.ChannelID, loc.T("admin.mention", i18n.Args{"action": subCommand, "usage": loc.T("admin.usage")}))
+		return
+	}
+	if targetID == message.Author.ID && slices.Contains(adminSelfBlocked, subCommand) {
+		session.ChannelMessageSend(message.ChannelID, loc.T("admin.self_blocked"))
+		return
+	}
+
+	// Staff only manage players of their own server
+	if _, err := session.GuildMember(message.GuildID, targetID); err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("admin.not_member", i18n.Args{"user": targetID}))
+		return
+	}
+
+	entry, summary, err := action(session, message, targetID, args[4:])
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("admin.failed", i18n.Args{"error": err}))
+		return
+	}
+
+	entry.Action = subCommand
+	entry.TargetID = targetID
+	recordAdminAction(session, message, entry, summary)
+}
+
+// isStaff reports whether the author can use admin commands
+func isStaff(session *discordgo.Session, message *discordgo.MessageCreate, guildSettings settings.Settings) bool {
+	if message.Member != nil && guildSettings.IsAdminRole(message.Member.Roles) {
+		return true
+	}
+	return canManageServer(session, message)
+}
+
+// isBotOwner reports whether a user is listed in BOT_OWNERS
+func isBotOwner(userID string) bool {
+	for _, ownerID := range strings.Split(variables.Bot_owners, ",") {
+		if strings.TrimSpace(ownerID) == userID && userID != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// recordAdminAction writes an action to the audit log and confirms it
+func recordAdminAction(session *discordgo.Session, message *discordgo.MessageCreate, entry models.AdminAction, summary string) {
+	entry.GuildID = message.GuildID
+	entry.AdminID = message.Author.ID
+
+	err := database.RecordAdminAction(database.DBInit(), entry)
+	if err != nil {
+		fmt.Printf("Error recording admin action: %v\n", err)
+	}
+
+	session.ChannelMessageSend(message.ChannelID, "🛡️ "+summary)
+}
+
+// adminAmount reads the signed amount an action grants or deducts, and the reason after it
+func adminAmount(args []string, limit int, loc i18n.Localizer) (int, string, error) {
+	if len(args) == 0 {
+		return 0, "", errors.New(loc.T("admin.amount_missing"))
+	}
+
+	amount, err := strconv.Atoi(args[0])
+	if err != nil || amount == 0 || amount > limit || amount < -limit {
+		return 0, "", errors.New(loc.T("admin.amount_invalid", i18n.Args{"limit": limit}))
+	}
+
+	return amount, strings.Join(args[1:], " "), nil
+}
+
+// adminAdjustCoins grants or deducts coins
+func adminAdjustCoins(session *discordgo.Session, message *discordgo.MessageCreate, targetID string, args []string) (models.AdminAction, string, error) {
+	loc := localizer(message)
+	amount, reason, err := adminAmount(args, variables.AdminMaxCoinAdjust, loc)
+	if err != nil {
+		return models.AdminAction{}, "", err
+	}
+
+	balance, err := database.AdjustWallet(database.DBInit(), targetID, amount)
+	if err != nil {
+		return models.AdminAction{}, "", err
+	}
+
+	return models.AdminAction{Amount: amount, Reason: reason},
+		loc.T("admin.coins", i18n.Args{"amount": fmt.Sprintf("%+d", amount), "user": targetID, "balance": balance}), nil
+}
+
+// adminAdjustRerolls grants or deducts reroll tokens
+func adminAdjustRerolls(session *discordgo.Session, message *discordgo.MessageCreate, targetID string, args []string) (models.AdminAction, string, error) {
+	loc := localizer(message)
+	amount, reason, err := adminAmount(args, variables.AdminMaxRerollAdjust, loc)
+	if err != nil {
+		return models.AdminAction{}, "", err
+	}
+
+	tokens, err := database.AdjustRerollTokens(database.DBInit(), targetID, amount)
+	if err != nil {
+		return models.AdminAction{}, "", err
+	}
+
+	return models.AdminAction{Amount: amount, Reason: reason},
+		loc.T("admin.rerolls", i18n.Args{"amount": fmt.Sprintf("%+d", amount), "user": targetID, "tokens": tokens}), nil
+}
+
+// adminGiveItem gives a freshly generated item of a rarity
+func adminGiveItem(session *discordgo.Session, message *discordgo.MessageCreate, targetID string, args []string) (models.AdminAction, string, error) {
+	loc := localizer(message)
+	if len(args) == 0 {
+		return models.AdminAction{}, "", errors.New(loc.T("admin.rarity_missing", i18n.Args{"rarities": strings.Join(roller.TierNames(), ", ")}))
+	}
+
+	rarity := ""
+	for _, tier := range roller.TierNames() {
+		if strings.EqualFold(tier, args[0]) {
+			rarity = tier
+		}
+	}
+	if rarity == "" {
+		return models.AdminAction{}, "", errors.New(loc.T("admin.rarity_invalid", i18n.Args{"rarity": args[0], "rarities": strings.Join(roller.TierNames(), ", ")}))
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	item := shop.GenerateItem(rarity, rng)
+	_, mailed, err := database.GrantItem(database.DBInit(), targetID, item, "Staff gift")
+	if err != nil {
+		return models.AdminAction{}, "", err
+	}
+
+	summary := loc.T("admin.item", i18n.Args{"user": targetID, "item": item.Name, "rarity": item.Rarity})
+	if mailed {
+		summary += " " + loc.T("admin.item_mailed")
+	}
+	return models.AdminAction{Detail: fmt.Sprintf("%s (%s)", item.Name, item.Rarity), Reason: strings.Join(args[1:], " ")}, summary, nil
+}
+
+// adminResetCharacter puts the user's active character back to level 1
+func adminResetCharacter(session *discordgo.Session, message *discordgo.MessageCreate, targetID string, args []string) (models.AdminAction, string, error) {
+	loc := localizer(message)
+	if combathandlers.IsPlayerInBattle(targetID) {
+		return models.AdminAction{}, "", errors.New(loc.T("admin.in_battle", i18n.Args{"user": targetID}))
+	}
+
+	character, err := database.ResetCharacterProgress(database.DBInit(), targetID)
+	if err != nil {
+		return models.AdminAction{}, "", err
+	}
+
+	return models.AdminAction{Detail: formatSnapshotCharacter(character, loc), Reason: strings.Join(args, " ")},
+		loc.T("admin.reset_character", i18n.Args{"user": targetID}), nil
+}
+
+// adminDeleteCharacter deletes the user's active character
+func adminDeleteCharacter(session *discordgo.Session, message *discordgo.MessageCreate, targetID string, args []string) (models.AdminAction, string, error) {
+	loc := localizer(message)
+	if combathandlers.IsPlayerInBattle(targetID) {
+		return models.AdminAction{}, "", errors.New(loc.T("admin.in_battle", i18n.Args{"user": targetID}))
+	}
+
+	db := database.DBInit()
+	character, err := database.GetCharacterByOwner(db, targetID)
+	if err != nil {
+		return models.AdminAction{}, "", err
+	}
+
+	if err := database.DeleteCharacter(db, targetID); err != nil {
+		return models.AdminAction{}, "", err
+	}
+
+	return models.AdminAction{Detail: formatSnapshotCharacter(character, loc), Reason: strings.Join(args, " ")},
+		loc.T("admin.delete_character", i18n.Args{"user": targetID}), nil
+}
+
+// adminEndBattle stops the user's battle without a winner
+func adminEndBattle(session *discordgo.Session, message *discordgo.MessageCreate, targetID string, args []string) (models.AdminAction, string, error) {
+	loc := localizer(message)
+	err := combathandlers.ForceEndBattle(session, targetID, loc.T("admin.battle_ended_by", i18n.Args{"admin": message.Author.Username}))
+	if err != nil {
+		return models.AdminAction{}, "", err
+	}
+
+	return models.AdminAction{Reason: strings.Join(args, " ")},
+		loc.T("admin.end_battle", i18n.Args{"user": targetID}), nil
+}
+
+// adminBan stops the user from playing in this server
+func adminBan(session *discordgo.Session, message *discordgo.MessageCreate, targetID string, args []string) (models.AdminAction, string, error) {
+	err := database.SetUserBanned(database.DBInit(), message.GuildID, message.Author.ID, targetID, true)
+	if err != nil {
+		return models.AdminAction{}, "", err
+	}
+
+	return models.AdminAction{Reason: strings.Join(args, " ")},
+		localizer(message).T("admin.ban", i18n.Args{"user": targetID}), nil
+}
+
+// adminUnban lets the user play in this server again
+func adminUnban(session *discordgo.Session, message *discordgo.MessageCreate, targetID string, args []string) (models.AdminAction, string, error) {
+	err := database.SetUserBanned(database.DBInit(), message.GuildID, message.Author.ID, targetID, false)
+	if err != nil {
+		return models.AdminAction{}, "", err
+	}
+
+	return models.AdminAction{Reason: strings.Join(args, " ")},
+		localizer(message).T("admin.unban", i18n.Args{"user": targetID}), nil
+}
+
+// showAdminLog shows one page of the server's audit log
+func showAdminLog(session *discordgo.Session, message *discordgo.MessageCreate, page int) {
+	loc := localizer(message)
+	actions, total, err := database.GetAdminActions(database.DBInit(), message.GuildID, page, variables.AdminLogPageSize)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	totalPages := max((total+variables.AdminLogPageSize-1)/variables.AdminLogPageSize, 1)
+	logEmbed := &discordgo.MessageEmbed{
+		Title: loc.T("admin.log.title"),
+		Color: 0x607D8B,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: loc.T("admin.log.footer", i18n.Args{"page": page + 1, "pages": totalPages, "count": total}),
+		},
+	}
+
+	if len(actions) == 0 {
+		logEmbed.Description = loc.T("admin.log.none")
+	}
+
+	for _, action := range actions {
+		line := loc.T("admin.log.by", i18n.Args{"admin": action.AdminID})
+		if action.TargetID != "" {
+			line = fmt.Sprintf("<@%s> %s", action.TargetID, line)
+		}
+		if action.Amount != 0 {
+			line = fmt.Sprintf("%+d · %s", action.Amount, line)
+		}
+		if action.Detail != "" {
+			line = fmt.Sprintf("%s\n%s", line, action.Detail)
+		}
+		if action.Reason != "" {
+			line = fmt.Sprintf("%s\n%s", line, loc.T("admin.log.reason", i18n.Args{"reason": action.Reason}))
+		}
+
+		logEmbed.Fields = append(logEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("%s · <t:%d:R>", action.Action, action.CreatedAt.Unix()),
+			Value: line,
+		})
+	}
+
+	session.ChannelMessageSendEmbed(message.ChannelID, logEmbed)
+}
@@ -0,0 +1,167 @@
+package bugouhandlers
+
+import (
+	"CrispyBot/database"
+	"CrispyBot/i18n"
+	"CrispyBot/progression"
+	"CrispyBot/variables"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// HandleAllocateCommand spends level-up stat points, toggles auto growth or respecs a character
+func HandleAllocateCommand(session *discordgo.Session, message *discordgo.MessageCreate, args []string) {
+	db := database.DBInit()
+	loc := localizer(message)
+
+	if len(args) < 3 {
+		sendAllocationEmbed(session, message)
+		return
+	}
+
+	switch strings.ToLower(args[2]) {
+	case "auto":
+		handleAutoGrowth(session, message, args)
+		return
+	case "reset":
+		refunded, err := database.RespecCharacter(db, message.Author.ID)
+		if err != nil {
+			session.ChannelMessageSend(message.ChannelID, loc.T("allocate.respec_failed", i18n.Args{"error": err}))
+			return
+		}
+		session.ChannelMessageSend(message.ChannelID, loc.T("allocate.respec_done", i18n.Args{"count": refunded}))
+		return
+	}
+
+	statName, ok := progression.ParseStatName(args[2])
+	if !ok {
+		session.ChannelMessageSend(message.ChannelID, loc.T("allocate.invalid_stat"))
+		return
+	}
+
+	points := 1
+	if len(args) >= 4 {
+		parsed, err := strconv.Atoi(args[3])
+		if err != nil || parsed < 1 {
+			session.ChannelMessageSend(message.ChannelID, loc.T("allocate.invalid_points"))
+			return
+		}
+		points = parsed
+	}
+
+	character, err := database.AllocateStatPoints(db, message.Author.ID, statName, points)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("allocate.failed", i18n.Args{"error": err}))
+		return
+	}
+
+	stat := progression.StatByName(&character.Stats, statName)
+	session.ChannelMessageSend(message.ChannelID, loc.T("allocate.added", i18n.Args{
+		"points": points,
+		"stat":   statLabel(statName, loc),
+		"total":  stat.TotalValue,
+		"count":  progression.UnspentPoints(character),
+	}))
+}
+
+// handleAutoGrowth turns automatic stat growth on or off
+func handleAutoGrowth(session *discordgo.Session, message *discordgo.MessageCreate, args []string) {
+	loc := localizer(message)
+	if len(args) < 4 || (strings.ToLower(args[3]) != "on" && strings.ToLower(args[3]) != "off") {
+		session.ChannelMessageSend(message.ChannelID, loc.T("allocate.auto_usage"))
+		return
+	}
+
+	enabled := strings.ToLower(args[3]) == "on"
+	allocation, err := database.SetAutoGrowth(database.DBInit(), message.Author.ID, enabled)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	if !enabled {
+		session.ChannelMessageSend(message.ChannelID, loc.T("allocate.auto_off"))
+		return
+	}
+
+	response := loc.T("allocate.auto_on")
+	if len(allocation) > 0 {
+		response += "\n" + loc.T("allocate.auto_spent", i18n.Args{"allocation": formatAllocation(allocation, loc)})
+	}
+	session.ChannelMessageSend(message.ChannelID, response)
+}
+
+// sendAllocationEmbed shows the player's stat points, growth curve and respec tokens
+func sendAllocationEmbed(session *discordgo.Session, message *discordgo.MessageCreate) {
+	db := database.DBInit()
+	loc := localizer(message)
+
+	character, err := database.GetCharacterByOwner(db, message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.no_character"))
+		return
+	}
+
+	user, err := database.GetUserByID(db, message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	autoGrowth := loc.T("common.off")
+	if character.AutoGrowth {
+		autoGrowth = loc.T("common.on")
+	}
+
+	pointsText := ""
+	curveText := ""
+	curve := progression.GrowthCurve(character.Characteristics.Race)
+	for _, statName := range progression.StatNames {
+		pointsText += fmt.Sprintf("**%s:** +%d\n", statLabel(statName, loc), progression.StatByName(&character.Stats, statName).LevelBonus)
+		curveText += fmt.Sprintf("**%s:** %s\n", statLabel(statName, loc), strings.Repeat("▰", curve[statName]))
+	}
+
+	allocationEmbed := &discordgo.MessageEmbed{
+		Title: loc.T("allocate.title"),
+		Description: loc.T("allocate.description", i18n.Args{
+			"level":    character.Level,
+			"unspent":  progression.UnspentPoints(character),
+			"earned":   progression.PointsEarned(character.Level),
+			"perLevel": variables.StatPointsPerLevel,
+			"auto":     autoGrowth,
+			"tokens":   user.RespecTokens,
+		}),
+		Color: 0x00AAFF,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   loc.T("allocate.allocated"),
+				Value:  pointsText,
+				Inline: true,
+			},
+			{
+				Name:   loc.T("allocate.curve", i18n.Args{"race": character.Characteristics.Race.Trait_Name}),
+				Value:  curveText,
+				Inline: true,
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: loc.T("allocate.footer", i18n.Args{"price": variables.RespecTokenPrice}),
+		},
+	}
+
+	session.ChannelMessageSendEmbed(message.ChannelID, allocationEmbed)
+}
+
+// formatAllocation lists the points added to each stat in display order
+func formatAllocation(allocation map[string]int, loc i18n.Localizer) string {
+	parts := []string{}
+	for _, statName := range progression.StatNames {
+		if points := allocation[statName]; points > 0 {
+			parts = append(parts, fmt.Sprintf("%s +%d", statLabel(statName, loc), points))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,215 @@
+package bugouhandlers
+
+import (
+	"CrispyBot/banners"
+	"CrispyBot/database"
+	"CrispyBot/database/models"
+	"CrispyBot/i18n"
+	"CrispyBot/shop"
+	"CrispyBot/variables"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// HandleBannerCommand shows the guild's banners and lets staff schedule them
+// Note: The command registry keeps it to server channels and its changes to staff.
+func HandleBannerCommand(session *discordgo.Session, message *discordgo.MessageCreate, args []string) {
+	subCommand := "list"
+	if len(args) >= 3 {
+		subCommand = strings.ToLower(args[2])
+	}
+
+	switch subCommand {
+	case "list":
+		showBanners(session, message)
+	case "create":
+		createBanner(session, message, args)
+	case "end":
+		endBanner(session, message, args)
+	case "audit":
+		auditBanner(session, message, args)
+	default:
+		session.ChannelMessageSend(message.ChannelID, localizer(message).T("banner.unknown_command"))
+	}
+}
+
+// showBanners lists the running and scheduled banners
+func showBanners(session *discordgo.Session, message *discordgo.MessageCreate) {
+	loc := localizer(message)
+	upcoming, err := database.GetUpcomingBanners(database.DBInit(), message.GuildID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+	if len(upcoming) == 0 {
+		session.ChannelMessageSend(message.ChannelID, loc.T("banner.none"))
+		return
+	}
+
+	embeds := []*discordgo.MessageEmbed{}
+	for _, banner := range upcoming {
+		embeds = append(embeds, createBannerEmbed(banner, loc))
+	}
+
+	// Discord allows 10 embeds per message
+	if len(embeds) > 10 {
+		embeds = embeds[:10]
+	}
+	session.ChannelMessageSendEmbeds(message.ChannelID, embeds)
+}
+
+// createBannerEmbed describes a banner's schedule, rate-ups and odds
+func createBannerEmbed(banner models.Banner, loc i18n.Localizer) *discordgo.MessageEmbed {
+	status := loc.T("banner.scheduled", i18n.Args{"start": banner.StartsAt.Unix(), "end": banner.EndsAt.Unix()})
+	color := 0x95A5A6
+	if !banner.StartsAt.After(time.Now()) {
+		status = loc.T("banner.live", i18n.Args{"end": banner.EndsAt.Unix()})
+		color = 0xF1C40F
+	}
+
+	description := status
+	if banner.Description != "" {
+		description = fmt.Sprintf("%s\n\n%s", banner.Description, status)
+	}
+
+	bannerEmbed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("🎏 %s", banner.Name),
+		Description: description,
+		Color:       color,
+	}
+
+	featured := []struct {
+		name   string
+		boosts map[string]int
+	}{
+		{loc.T("banner.featured_races"), banner.RaceBoosts},
+		{loc.T("banner.featured_innates"), banner.InnateBoosts},
+		{loc.T("banner.featured_weapons"), banner.WeaponBoosts},
+	}
+	for _, group := range featured {
+		if len(group.boosts) == 0 {
+			continue
+		}
+		bannerEmbed.Fields = append(bannerEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:  group.name,
+			Value: banners.Featured(group.boosts),
+		})
+	}
+
+	if banner.Odds != nil {
+		bannerEmbed.Fields = append(bannerEmbed.Fields, &discordgo.MessageEmbedField{
+			Name: loc.T("banner.odds"),
+			Value: loc.T("banner.odds_value", i18n.Args{
+				"common":    banner.Odds.Common,
+				"uncommon":  banner.Odds.Uncommon,
+				"rare":      banner.Odds.Rare,
+				"epic":      banner.Odds.Epic,
+				"legendary": banner.Odds.Legendary,
+			}),
+		})
+	}
+
+	return bannerEmbed
+}
+
+// createBanner handles `!cb banner create <spec>`
+func createBanner(session *discordgo.Session, message *discordgo.MessageCreate, args []string) {
+	loc := localizer(message)
+	if len(args) < 4 {
+		session.ChannelMessageSend(message.ChannelID, loc.T("banner.create_usage", i18n.Args{"example": banners.Usage}))
+		return
+	}
+
+	banner, err := banners.Parse(strings.Join(args[3:], " "), time.Now(), shop.ResetLocation())
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("banner.invalid", i18n.Args{"error": err}))
+		return
+	}
+	banner.GuildID = message.GuildID
+	banner.CreatedBy = message.Author.ID
+
+	banner, err = database.CreateBanner(database.DBInit(), banner)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("banner.create_failed", i18n.Args{"error": err}))
+		return
+	}
+
+	session.ChannelMessageSendComplex(message.ChannelID, &discordgo.MessageSend{
+		Content: loc.T("banner.created", i18n.Args{"banner": banner.Name, "user": message.Author.ID}),
+		Embed:   createBannerEmbed(banner, loc),
+	})
+}
+
+// endBanner handles `!cb banner end <name>`
+func endBanner(session *discordgo.Session, message *discordgo.MessageCreate, args []string) {
+	loc := localizer(message)
+	if len(args) < 4 {
+		session.ChannelMessageSend(message.ChannelID, loc.T("banner.end_usage"))
+		return
+	}
+
+	db := database.DBInit()
+	banner, err := database.GetBannerByName(db, message.GuildID, strings.Join(args[3:], " "))
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	if err := database.EndBanner(db, banner.ID); err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	session.ChannelMessageSend(message.ChannelID, loc.T("banner.ended", i18n.Args{"banner": banner.Name}))
+}
+
+// auditBanner handles `!cb banner audit <name>`
+func auditBanner(session *discordgo.Session, message *discordgo.MessageCreate, args []string) {
+	loc := localizer(message)
+	if len(args) < 4 {
+		session.ChannelMessageSend(message.ChannelID, loc.T("banner.audit_usage"))
+		return
+	}
+
+	db := database.DBInit()
+	banner, err := database.GetBannerByName(db, message.GuildID, strings.Join(args[3:], " "))
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	rolls, players, recent, err := database.GetBannerAudit(db, banner.ID, variables.BannerAuditSize)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	auditEmbed := &discordgo.MessageEmbed{
+		Title: loc.T("banner.audit_title", i18n.Args{"banner": banner.Name}),
+		Description: loc.T("banner.audit_description", i18n.Args{
+			"rolls":   rolls,
+			"players": players,
+			"user":    banner.CreatedBy,
+			"start":   banner.StartsAt.Unix(),
+			"end":     banner.EndsAt.Unix(),
+		}),
+		Color: 0x9B59B6,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: loc.T("banner.audit_footer"),
+		},
+	}
+
+	for _, snapshot := range recent {
+		label := loc.Or(snapshotSourceLabels[snapshot.Source], snapshot.Source)
+
+		auditEmbed.Fields = append(auditEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("%s · <t:%d:R> · %s", label, snapshot.CreatedAt.Unix(), loc.T("history.seed", i18n.Args{"seed": snapshot.Seed})),
+			Value: fmt.Sprintf("<@%s>: %s", snapshot.DiscordID, formatSnapshotCharacter(snapshot.After, loc)),
+		})
+	}
+
+	session.ChannelMessageSendEmbed(message.ChannelID, auditEmbed)
+}
@@ -1,10 +1,16 @@
 package bugouhandlers
 
 import (
+	"CrispyBot/achievements"
+	achievementhandlers "CrispyBot/bugou/achievementhandlers"
+	combathandlers "CrispyBot/bugou/combathandlers"
 	"CrispyBot/database"
-	"CrispyBot/roller"
+	"CrispyBot/i18n"
+	"CrispyBot/progression"
+	"CrispyBot/variables"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -14,66 +20,68 @@ import (
 func HandleRollCommand(session *discordgo.Session, message *discordgo.MessageCreate) {
 	// Get the database singleton
 	db := database.DBInit()
+	loc := localizer(message)
 
-	// Check if user already has a character
-	existingChar, err := database.GetCharacterByOwner(db, message.Author.ID)
-	if err == nil {
-		// User already has a character
-		characterID := existingChar.ID.Hex()
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("You already have a character with ID **%s**! Use `!cb stats` to see your character.", characterID))
+	// Check if user has a free character slot
+	used, slots, err := database.GetCharacterSlots(db, message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+	if used >= slots {
+		session.ChannelMessageSend(message.ChannelID, loc.T("roll.slots_full", i18n.Args{"count": slots, "price": variables.CharacterSlotPrice}))
 		return
 	}
 
-	// Generate a new character
-	newCharacter := roller.GenerateCharacter(message.Author.ID)
-
-	// Save to the database
-	_, err = database.SaveCharacter(db, newCharacter, message.Author.ID)
-	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("Error generating character: %v", err))
+	// Switching mid-fight would swap the fighter out from under the battle
+	if used > 0 && combathandlers.IsPlayerInBattle(message.Author.ID) {
+		session.ChannelMessageSend(message.ChannelID, loc.T("roll.in_battle"))
 		return
 	}
 
-	// Get the user's character
-	character, err := database.GetCharacterByOwner(db, message.Author.ID)
+	// Roll and save a new character, which also makes it the active character
+	character, err := database.RollCharacter(db, message.Author.ID, message.GuildID)
 	if err != nil {
-		text := fmt.Errorf("error: %w", err)
-		session.ChannelMessageSend(message.ChannelID, text.Error())
+		session.ChannelMessageSend(message.ChannelID, loc.T("roll.failed", i18n.Args{"error": err}))
 		return
 	}
 
 	// Create an embed message with the character details
-	charEmbed := CreateCharacterEmbed(character, message.Author)
+	charEmbed := CreateCharacterEmbed(character, message.Author, loc)
 	session.ChannelMessageSendEmbed(message.ChannelID, charEmbed)
+
+	achievementhandlers.Track(session, message.ChannelID, message.Author.ID, achievements.RollEvent(character, achievements.SourceRoll))
 }
 
 // handleStatsCommand shows the user's character stats
 func HandleStatsCommand(session *discordgo.Session, message *discordgo.MessageCreate) {
 	// Get the database singleton
 	db := database.DBInit()
+	loc := localizer(message)
 
 	// Get the user's character
 	character, err := database.GetCharacterByOwner(db, message.Author.ID)
 	if err != nil {
-		text := fmt.Errorf("error: %w", err)
-		session.ChannelMessageSend(message.ChannelID, text.Error())
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
 		return
 	}
 
 	// Create an embed message with the character details
-	charEmbed := CreateCharacterEmbed(character, message.Author)
+	charEmbed := CreateCharacterEmbed(character, message.Author, loc)
 	session.ChannelMessageSendEmbed(message.ChannelID, charEmbed)
 }
 
 // HandleDeleteCharacterRequest processes a character deletion request
 func HandleDeleteCharacterRequest(session *discordgo.Session, message *discordgo.MessageCreate) {
+	loc := localizer(message)
+
 	// Create a confirmation message with buttons
 	confirmEmbed := &discordgo.MessageEmbed{
-		Title:       "⚠️ Delete Character",
-		Description: "Are you sure you want to delete your character? This action cannot be undone!",
+		Title:       loc.T("delete.title"),
+		Description: loc.T("delete.confirm"),
 		Color:       0xFF0000,
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: "This confirmation will expire in 60 seconds",
+			Text: loc.T("common.confirm_expires"),
 		},
 	}
 
@@ -82,13 +90,13 @@ func HandleDeleteCharacterRequest(session *discordgo.Session, message *discordgo
 
 	// Create confirm and cancel buttons
 	confirmButton := discordgo.Button{
-		Label:    "Yes, Delete Character",
+		Label:    loc.T("delete.button"),
 		Style:    discordgo.DangerButton,
 		CustomID: fmt.Sprintf("%s_confirm", deleteRequestID),
 	}
 
 	cancelButton := discordgo.Button{
-		Label:    "Cancel",
+		Label:    loc.T("common.cancel"),
 		Style:    discordgo.SecondaryButton,
 		CustomID: fmt.Sprintf("%s_cancel", deleteRequestID),
 	}
@@ -105,7 +113,7 @@ func HandleDeleteCharacterRequest(session *discordgo.Session, message *discordgo
 	})
 
 	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("Error: %v", err))
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
 		return
 	}
 
@@ -129,9 +137,9 @@ func HandleDeleteCharacterRequest(session *discordgo.Session, message *discordgo
 
 			var responseContent string
 			if err != nil {
-				responseContent = fmt.Sprintf("Failed to delete character: %v", err)
+				responseContent = loc.T("delete.failed", i18n.Args{"error": err})
 			} else {
-				responseContent = "Your character has been deleted. You can create a new one with `!cb roll`."
+				responseContent = loc.T("delete.deleted")
 			}
 
 			// Respond to the interaction
@@ -147,7 +155,7 @@ func HandleDeleteCharacterRequest(session *discordgo.Session, message *discordgo
 			s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 				Type: discordgo.InteractionResponseUpdateMessage,
 				Data: &discordgo.InteractionResponseData{
-					Content:    "Character deletion canceled.",
+					Content:    loc.T("delete.canceled"),
 					Components: []discordgo.MessageComponent{}, // Remove the buttons
 				},
 			})
@@ -164,3 +172,122 @@ func HandleDeleteCharacterRequest(session *discordgo.Session, message *discordgo
 		})
 	})
 }
+
+// HandleRebirthCommand asks for confirmation, then trades a high-level character for prestige perks
+func HandleRebirthCommand(session *discordgo.Session, message *discordgo.MessageCreate) {
+	db := database.DBInit()
+	loc := localizer(message)
+
+	character, err := database.GetCharacterByOwner(db, message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.no_character"))
+		return
+	}
+	if err := progression.CanRebirth(character.Level); err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("rebirth.failed", i18n.Args{"error": err}))
+		return
+	}
+
+	next := progression.PerksFor(character.Prestige + 1)
+	confirmEmbed := &discordgo.MessageEmbed{
+		Title:       loc.T("rebirth.title"),
+		Description: loc.T("rebirth.description"),
+		Color:       0xFFD700,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name: loc.T("rebirth.perks", i18n.Args{"level": next.Level}),
+				Value: loc.T("rebirth.perks_value", i18n.Args{
+					"stats":   next.StatBonusPercent,
+					"rerolls": next.BonusRerolls,
+					"luck":    next.WeaponLuck,
+					"badge":   progression.PrestigeBadge(next.Level),
+				}),
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: loc.T("common.confirm_expires"),
+		},
+	}
+
+	rebirthRequestID := fmt.Sprintf("rebirth_%s_%d", message.Author.ID, time.Now().Unix())
+	actionRow := discordgo.ActionsRow{
+		Components: []discordgo.MessageComponent{
+			discordgo.Button{
+				Label:    loc.T("rebirth.button"),
+				Style:    discordgo.SuccessButton,
+				CustomID: fmt.Sprintf("%s_confirm", rebirthRequestID),
+			},
+			discordgo.Button{
+				Label:    loc.T("common.cancel"),
+				Style:    discordgo.SecondaryButton,
+				CustomID: fmt.Sprintf("%s_cancel", rebirthRequestID),
+			},
+		},
+	}
+
+	confirmMessage, err := session.ChannelMessageSendComplex(message.ChannelID, &discordgo.MessageSend{
+		Embed:      confirmEmbed,
+		Components: []discordgo.MessageComponent{actionRow},
+	})
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	// Only the requester can answer, and the handler goes away once they do
+	var removeHandler func()
+	var once sync.Once
+	removeHandler = session.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		if i.Type != discordgo.InteractionMessageComponent {
+			return
+		}
+
+		customID := i.MessageComponentData().CustomID
+		if !strings.HasPrefix(customID, rebirthRequestID) {
+			return
+		}
+		clicker := i.User
+		if i.Member != nil {
+			clicker = i.Member.User
+		}
+		if clicker == nil || clicker.ID != message.Author.ID {
+			return
+		}
+		once.Do(removeHandler)
+
+		responseContent := loc.T("rebirth.canceled")
+		var responseEmbeds []*discordgo.MessageEmbed
+		if strings.HasSuffix(customID, "_confirm") {
+			reborn, perks, err := database.RebirthCharacter(database.DBInit(), message.Author.ID, message.GuildID)
+			if err != nil {
+				responseContent = loc.T("rebirth.failed", i18n.Args{"error": err})
+			} else {
+				responseContent = loc.T("rebirth.reborn", i18n.Args{"badge": progression.PrestigeBadge(perks.Level)})
+				responseEmbeds = []*discordgo.MessageEmbed{CreateCharacterEmbed(reborn, message.Author, loc)}
+				defer achievementhandlers.Track(s, message.ChannelID, message.Author.ID, achievements.ValueEvent(achievements.EventRebirth, perks.Level))
+			}
+		}
+
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Content:    responseContent,
+				Embeds:     responseEmbeds,
+				Components: []discordgo.MessageComponent{}, // Remove the buttons
+			},
+		})
+	})
+
+	// Remove the buttons if nobody answers in time
+	time.AfterFunc(60*time.Second, func() {
+		once.Do(func() {
+			removeHandler()
+			session.ChannelMessageEditComplex(&discordgo.MessageEdit{
+				Channel:    message.ChannelID,
+				ID:         confirmMessage.ID,
+				Embed:      confirmEmbed,
+				Components: &[]discordgo.MessageComponent{},
+			})
+		})
+	})
+}
@@ -0,0 +1,319 @@
+package bugouhandlers
+
+import (
+	combathandlers "CrispyBot/bugou/combathandlers"
+	"CrispyBot/clans"
+	"CrispyBot/database"
+	"CrispyBot/database/models"
+	"CrispyBot/i18n"
+	"CrispyBot/variables"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// clanLedgerShown is how many bank entries the ledger lists
+const clanLedgerShown = 10
+
+// HandleClanCommand processes clan commands
+func HandleClanCommand(session *discordgo.Session, message *discordgo.MessageCreate, args []string) {
+	subCommand := "info"
+	if len(args) >= 3 {
+		subCommand = strings.ToLower(args[2])
+	}
+
+	db := database.DBInit()
+	loc := localizer(message)
+
+	switch subCommand {
+	case "info":
+		showClanInfo(session, message, strings.Join(args[min(3, len(args)):], " "))
+
+	case "create":
+		if len(args) < 4 {
+			session.ChannelMessageSend(message.ChannelID, loc.T("clan.create_usage", i18n.Args{"price": variables.ClanCreationCost}))
+			return
+		}
+		clan, err := database.CreateClan(db, message.Author.ID, strings.Join(args[3:], " "))
+		if err != nil {
+			session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+			return
+		}
+		session.ChannelMessageSend(message.ChannelID, loc.T("clan.created", i18n.Args{"clan": clan.Name}))
+
+	case "invite":
+		targetID, ok := mentionArg(args, 3)
+		if !ok {
+			session.ChannelMessageSend(message.ChannelID, loc.T("clan.invite_usage"))
+			return
+		}
+		clan, err := database.InviteToClan(db, message.Author.ID, targetID)
+		if err != nil {
+			session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+			return
+		}
+		session.ChannelMessageSend(message.ChannelID, loc.T("clan.invited", i18n.Args{"user": targetID, "clan": clan.Name}))
+
+	case "join":
+		if len(args) < 4 {
+			session.ChannelMessageSend(message.ChannelID, loc.T("clan.join_usage"))
+			return
+		}
+		clan, err := database.JoinClan(db, message.Author.ID, strings.Join(args[3:], " "))
+		if err != nil {
+			session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+			return
+		}
+		session.ChannelMessageSend(message.ChannelID, loc.T("clan.joined", i18n.Args{"user": message.Author.ID, "clan": clan.Name}))
+
+	case "leave":
+		clan, disbanded, err := database.LeaveClan(db, message.Author.ID)
+		if err != nil {
+			session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+			return
+		}
+		if disbanded {
+			session.ChannelMessageSend(message.ChannelID, loc.T("clan.disbanded", i18n.Args{"clan": clan.Name}))
+			return
+		}
+		session.ChannelMessageSend(message.ChannelID, loc.T("clan.left", i18n.Args{"clan": clan.Name}))
+
+	case "kick":
+		targetID, ok := mentionArg(args, 3)
+		if !ok {
+			session.ChannelMessageSend(message.ChannelID, loc.T("clan.kick_usage"))
+			return
+		}
+		clan, err := database.KickFromClan(db, message.Author.ID, targetID)
+		if err != nil {
+			session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+			return
+		}
+		session.ChannelMessageSend(message.ChannelID, loc.T("clan.kicked", i18n.Args{"user": targetID, "clan": clan.Name}))
+
+	case "promote":
+		targetID, ok := mentionArg(args, 3)
+		if !ok || len(args) < 5 {
+			session.ChannelMessageSend(message.ChannelID, loc.T("clan.promote_usage"))
+			return
+		}
+		role, err := clans.ParseRole(args[4])
+		if err == nil {
+			err = database.SetClanRole(db, message.Author.ID, targetID, role)
+		}
+		if err != nil {
+			session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+			return
+		}
+		session.ChannelMessageSend(message.ChannelID, loc.T("clan.promoted", i18n.Args{"user": targetID, "role": loc.Or("clan.role."+role, role)}))
+
+	case "deposit", "withdraw":
+		amount := 0
+		if len(args) >= 4 {
+			amount, _ = strconv.Atoi(args[3])
+		}
+		if amount <= 0 {
+			session.ChannelMessageSend(message.ChannelID, loc.T("clan.bank_usage", i18n.Args{"action": subCommand}))
+			return
+		}
+
+		var balance int
+		var err error
+		if subCommand == "deposit" {
+			balance, err = database.DepositToClanBank(db, message.Author.ID, amount)
+		} else {
+			balance, err = database.WithdrawFromClanBank(db, message.Author.ID, amount)
+		}
+		if err != nil {
+			session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+			return
+		}
+		key := "clan.deposited"
+		if subCommand == "withdraw" {
+			key = "clan.withdrew"
+		}
+		session.ChannelMessageSend(message.ChannelID, loc.T(key, i18n.Args{"amount": amount, "balance": balance}))
+
+	case "ledger":
+		showClanLedger(session, message)
+
+	case "perks":
+		showClanPerks(session, message)
+
+	case "war":
+		combathandlers.HandleClanWarCommand(session, message, args)
+
+	default:
+		session.ChannelMessageSend(message.ChannelID, loc.T("clan.unknown_command"))
+	}
+}
+
+// showClanInfo shows a clan's level, bank and roster, defaulting to the user's own clan
+func showClanInfo(session *discordgo.Session, message *discordgo.MessageCreate, name string) {
+	db := database.DBInit()
+	loc := localizer(message)
+
+	var clan models.Clan
+	var err error
+	if name != "" {
+		clan, err = database.GetClanByName(db, name)
+	} else {
+		clan, err = database.GetClanByMember(db, message.Author.ID)
+	}
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("clan.not_found", i18n.Args{"error": err}))
+		return
+	}
+
+	level := clans.Level(clan)
+	progress := loc.T("clan.max_level")
+	if level < variables.ClanMaxLevel {
+		progress = loc.T("clan.progress", i18n.Args{"xp": clan.Experience, "needed": clans.ExperienceForLevel(level + 1), "level": level + 1})
+	}
+
+	// Leader first, then officers, then members by contribution
+	members := append([]models.ClanMember(nil), clan.Members...)
+	sort.SliceStable(members, func(i, j int) bool {
+		if clans.Rank(members[i].Role) != clans.Rank(members[j].Role) {
+			return clans.Rank(members[i].Role) > clans.Rank(members[j].Role)
+		}
+		return members[i].Contributed > members[j].Contributed
+	})
+
+	var roster strings.Builder
+	for _, member := range members {
+		roster.WriteString(fmt.Sprintf("%s <@%s> — %d XP\n", roleIcon(member.Role), member.DiscordID, member.Contributed))
+	}
+
+	clanEmbed := &discordgo.MessageEmbed{
+		Title: fmt.Sprintf("🏰 %s", clan.Name),
+		Color: 0x8B4513,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   loc.T("clan.level"),
+				Value:  fmt.Sprintf("**%d**\n%s", level, progress),
+				Inline: true,
+			},
+			{
+				Name:   loc.T("clan.bank"),
+				Value:  loc.T("common.coins", i18n.Args{"count": clan.Bank}),
+				Inline: true,
+			},
+			{
+				Name:  loc.T("clan.members", i18n.Args{"count": len(clan.Members), "max": variables.ClanMaxMembers}),
+				Value: roster.String(),
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: loc.T("clan.info_footer", i18n.Args{"date": clan.CreatedAt.Format("2006-01-02")}),
+		},
+	}
+
+	session.ChannelMessageSendEmbed(message.ChannelID, clanEmbed)
+}
+
+// showClanLedger lists the most recent clan bank movements
+func showClanLedger(session *discordgo.Session, message *discordgo.MessageCreate) {
+	db := database.DBInit()
+	loc := localizer(message)
+
+	clan, err := database.GetClanByMember(db, message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	entries, err := database.GetClanLedger(db, clan.ID, clanLedgerShown)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	if len(entries) == 0 {
+		session.ChannelMessageSend(message.ChannelID, loc.T("clan.ledger_empty"))
+		return
+	}
+
+	var lines []string
+	for _, entry := range entries {
+		who := loc.T("clan.war_spoils")
+		if entry.DiscordID != "" {
+			who = fmt.Sprintf("<@%s>", entry.DiscordID)
+		}
+		lines = append(lines, loc.T("clan.ledger_line", i18n.Args{
+			"date":    entry.CreatedAt.Format("01-02 15:04"),
+			"who":     who,
+			"kind":    loc.Or("clan.ledger_kind."+strings.ReplaceAll(entry.Kind, " ", "_"), entry.Kind),
+			"amount":  fmt.Sprintf("%+d", entry.Amount),
+			"balance": entry.Balance,
+		}))
+	}
+
+	ledgerEmbed := &discordgo.MessageEmbed{
+		Title:       loc.T("clan.ledger_title", i18n.Args{"clan": clan.Name}),
+		Description: strings.Join(lines, "\n"),
+		Color:       0x8B4513,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: loc.T("clan.ledger_footer", i18n.Args{"count": clan.Bank}),
+		},
+	}
+
+	session.ChannelMessageSendEmbed(message.ChannelID, ledgerEmbed)
+}
+
+// showClanPerks lists every perk and whether the user's clan has unlocked it
+func showClanPerks(session *discordgo.Session, message *discordgo.MessageCreate) {
+	loc := localizer(message)
+	level := 0
+	clan, err := database.GetClanByMember(database.DBInit(), message.Author.ID)
+	if err == nil {
+		level = clans.Level(clan)
+	}
+
+	var lines []string
+	for _, perk := range clans.Perks {
+		status := "🔒"
+		if perk.Level <= level {
+			status = "✅"
+		}
+		lines = append(lines, loc.T("clan.perk_line", i18n.Args{
+			"status":      status,
+			"level":       perk.Level,
+			"name":        loc.Or(fmt.Sprintf("data.clan_perk.%d.name", perk.Level), perk.Name),
+			"description": loc.Or(fmt.Sprintf("data.clan_perk.%d.description", perk.Level), perk.Description),
+		}))
+	}
+
+	perkEmbed := &discordgo.MessageEmbed{
+		Title:       loc.T("clan.perks_title"),
+		Description: strings.Join(lines, "\n"),
+		Color:       0x8B4513,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: loc.T("clan.perks_footer"),
+		},
+	}
+
+	session.ChannelMessageSendEmbed(message.ChannelID, perkEmbed)
+}
+
+// mentionArg reads a user mention at the given argument position
+func mentionArg(args []string, index int) (string, bool) {
+	if len(args) <= index || !strings.HasPrefix(args[index], "<@") || !strings.HasSuffix(args[index], ">") {
+		return "", false
+	}
+	return strings.TrimPrefix(strings.TrimPrefix(strings.TrimSuffix(args[index], ">"), "<@"), "!"), true
+}
+
+// roleIcon marks a member's clan role
+func roleIcon(role string) string {
+	switch role {
+	case clans.RoleLeader:
+		return "👑"
+	case clans.RoleOfficer:
+		return "⭐"
+	}
+	return "•"
+}
@@ -0,0 +1,452 @@
+package bugouhandlers
+
+import (
+	achievementhandlers "CrispyBot/bugou/achievementhandlers"
+	combathandlers "CrispyBot/bugou/combathandlers"
+	"CrispyBot/clans"
+	"CrispyBot/commands"
+	"CrispyBot/database"
+	"CrispyBot/progression"
+	"CrispyBot/roller"
+	"CrispyBot/settings"
+	"CrispyBot/tournament"
+	"CrispyBot/variables"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Help categories in the order help lists them
+const (
+	categoryGeneral    = "General"
+	categoryCharacters = "Characters"
+	categoryRolls      = "Rolls"
+	categoryEconomy    = "Economy"
+	categoryBattles    = "Battles"
+	categorySocial     = "Social"
+	categoryServer     = "Server"
+)
+
+// commandRegistry defines every command, the parser, help and slash commands are all built from it
+var commandRegistry *commands.Registry
+
+// Built in init since help reads the registry it's part of
+func init() {
+	commandRegistry = commands.MustNew(prefixCommand, []*commands.Command{
+		{
+			Name: helpCommand, Category: categoryGeneral, Run: HandleHelpCommand,
+			Description: "Lists the commands, or explains one of them",
+			Args: []commands.Arg{
+				{Name: "command", Type: commands.ArgText, Description: "Command to explain, like `clan war`"},
+			},
+		},
+		{
+			Name: languageCommand, Aliases: []string{"idioma"}, Category: categoryGeneral, Run: HandleLanguageCommand,
+			Description: "Shows or picks the language the bot answers you in",
+			Args: []commands.Arg{
+				{Name: "language", Type: commands.ArgString, Description: "Language name or code, or reset to follow the server"},
+			},
+		},
+		{
+			Name: rollCommand, Category: categoryRolls, Run: withoutArgs(HandleRollCommand),
+			Description: "Rolls a new character into a free slot and makes it active",
+			Channel:     commands.ChannelRoll, Cooldown: variables.RateLimitRollSeconds * time.Second,
+		},
+		{
+			Name: statCommand, Category: categoryCharacters, Run: withoutArgs(HandleStatsCommand),
+			Description: "Shows your active character's stats",
+		},
+		{
+			Name: charactersCommand, Aliases: []string{"roster"}, Category: categoryCharacters, Run: withoutArgs(HandleCharactersCommand),
+			Description: "Lists your character roster (prestige and `buy slot` unlock more slots)",
+		},
+		{
+			Name: switchCommand, Category: categoryCharacters, Run: HandleSwitchCommand,
+			Description: "Changes your active character, used for battles, equipment and XP",
+			Args: []commands.Arg{
+				{Name: "number", Type: commands.ArgInteger, Description: "Roster number from the characters command", Required: true},
+			},
+		},
+		{
+			Name: allocateCommand, Category: categoryCharacters, Run: HandleAllocateCommand,
+			Description: "Spends stat points earned from leveling up",
+			Subcommands: []*commands.Command{
+				{
+					Name: "spend", Implicit: true,
+					Description: "Spends points on a stat, or shows your points when no stat is given",
+					Args: []commands.Arg{
+						{Name: "stat", Type: commands.ArgString, Description: "Stat to raise"},
+						{Name: "points", Type: commands.ArgInteger, Description: "Points to spend, 1 if left out"},
+					},
+				},
+				{
+					Name: "auto", Description: "Spends new points along your race's growth curve",
+					Args: []commands.Arg{
+						{Name: "state", Type: commands.ArgChoice, Description: "Turn auto growth on or off", Required: true, Choices: []string{"on", "off"}},
+					},
+				},
+				{Name: "reset", Description: "Uses a respec token to refund every allocated point"},
+			},
+		},
+		{
+			Name: rebirthCommand, Category: categoryCharacters, Run: withoutArgs(HandleRebirthCommand),
+			Description: "Resets a high-level character for permanent prestige perks",
+		},
+		{
+			Name: deleteCommand, Category: categoryCharacters, Run: withoutArgs(HandleDeleteCharacterRequest),
+			Description: "Deletes your active character (asks for confirmation)",
+		},
+		{
+			Name: rerollCommand, Category: categoryRolls, Run: HandleFullRerollCommand,
+			Description: "Rerolls your entire character, using one of your daily full rerolls",
+			Feature:     settings.FeatureRerolls, Channel: commands.ChannelRoll, Cooldown: variables.RateLimitRerollSeconds * time.Second,
+			Args: []commands.Arg{
+				{Name: "lock", Type: commands.ArgText, Description: "`--lock` then stats or characteristics to keep, each lock costs more coins"},
+			},
+		},
+		{
+			Name: rerollStatCommand, Category: categoryRolls, Run: HandleStatRerollCommand,
+			Description: "Rerolls one stat, using one of your daily stat rerolls",
+			Feature:     settings.FeatureRerolls, Channel: commands.ChannelRoll, Cooldown: variables.RateLimitRerollSeconds * time.Second,
+			Args: []commands.Arg{
+				{Name: "stat", Type: commands.ArgChoice, Description: "Stat to reroll", Required: true, Choices: progression.StatNames},
+			},
+		},
+		{
+			Name: rerollStatusCommand, Category: categoryRolls, Run: withoutArgs(HandleRerollStatusCommand),
+			Description: "Shows your rerolls left today and your pity toward a guaranteed Epic",
+			Feature:     settings.FeatureRerolls,
+		},
+		{
+			Name: historyCommand, Category: categoryRolls, Run: withoutArgs(HandleHistoryCommand),
+			Description: "Pages through your rolls and rerolls with the seed each came from",
+		},
+		{
+			Name: undoCommand, Category: categoryRolls, Run: withoutArgs(HandleUndoCommand),
+			Description: "Spends a reroll token to restore your character from before its last reroll",
+			Feature:     settings.FeatureRerolls, Channel: commands.ChannelRoll, Cooldown: variables.RateLimitRerollSeconds * time.Second,
+		},
+		{
+			Name: bannerCommand, Category: categoryRolls, Run: HandleBannerCommand,
+			Description: "Shows this server's limited-time banners and their rate-ups",
+			Feature:     settings.FeatureBanners, GuildOnly: true,
+			Subcommands: []*commands.Command{
+				{Name: "list", Default: true, Description: "Lists the running and scheduled banners"},
+				{
+					Name: "create", Description: "Schedules a banner", Permission: commands.PermissionStaff,
+					Args: []commands.Arg{
+						{Name: "spec", Type: commands.ArgText, Description: "Banner settings like name=...; hours=...; race=...", Required: true},
+					},
+				},
+				{
+					Name: "end", Description: "Ends a banner early", Permission: commands.PermissionStaff,
+					Args: []commands.Arg{
+						{Name: "name", Type: commands.ArgText, Description: "Banner name", Required: true},
+					},
+				},
+				{
+					Name: "audit", Description: "Shows who rolled on a banner, with seeds", Permission: commands.PermissionStaff,
+					Args: []commands.Arg{
+						{Name: "name", Type: commands.ArgText, Description: "Banner name", Required: true},
+					},
+				},
+			},
+		},
+		{
+			Name: shopCommand, Category: categoryEconomy, Run: withoutArgs(HandleShopCommand),
+			Description: "Browses today's item shop",
+			Feature:     settings.FeatureShop,
+		},
+		{
+			Name: buyCommand, Category: categoryEconomy, Run: HandleBuyCommand,
+			Description: "Buys a shop item, a respec token or an extra character slot",
+			Feature:     settings.FeatureShop,
+			Args: []commands.Arg{
+				{Name: "item", Type: commands.ArgString, Description: "Shop item number, `respec` or `slot`", Required: true},
+			},
+		},
+		{
+			Name: walletCommand, Aliases: []string{"coins"}, Category: categoryEconomy, Run: withoutArgs(HandleWalletCommand),
+			Description: "Checks your coin balance",
+		},
+		{
+			Name: dailyCommand, Category: categoryEconomy, Run: withoutArgs(HandleDailyCommand),
+			Description: "Collects your daily reward, consecutive days build a streak",
+			Feature:     settings.FeatureDaily,
+		},
+		{
+			Name: inventoryCommand, Aliases: []string{"inv"}, Category: categoryEconomy, Run: withoutArgs(HandleInventoryCommand),
+			Description: "Shows the items you own",
+		},
+		{
+			Name: mailboxCommand, Category: categoryEconomy, Run: HandleMailboxCommand,
+			Description: "Holds items that arrived while your inventory was full",
+			Subcommands: []*commands.Command{
+				{Name: "list", Default: true, Description: "Lists the items waiting for you"},
+				{
+					Name: "claim", Description: "Moves items into your inventory",
+					Args: []commands.Arg{
+						{Name: "item", Type: commands.ArgString, Description: "Mailbox item number, or `all`", Required: true},
+					},
+				},
+			},
+		},
+		{
+			Name: equipCommand, Category: categoryEconomy, Run: HandleEquipCommand,
+			Description: "Equips an item from your inventory",
+			Args: []commands.Arg{
+				{Name: "item", Type: commands.ArgInteger, Description: "Inventory item number", Required: true},
+			},
+		},
+		{
+			Name: unequipCommand, Category: categoryEconomy, Run: withoutArgs(HandleUnequipCommand),
+			Description: "Unequips your equipped item",
+		},
+		{
+			Name: battleCommand, Category: categoryBattles, Run: combathandlers.HandleBattleCommand,
+			Description: "Fights NPCs or other players",
+			Feature:     settings.FeatureBattles, Channel: commands.ChannelBattle,
+			Subcommands: []*commands.Command{
+				{
+					Name: "start", Description: "Starts a battle", Cooldown: variables.RateLimitBattleSeconds * time.Second,
+					Args: []commands.Arg{
+						{Name: "opponent", Type: commands.ArgText, Description: "NPC name with an optional level or `auto`, or a player mention and `ranked`"},
+					},
+				},
+				{Name: "npcs", Description: "Lists the NPCs you can fight with their levels, skills and immunities"},
+				{Name: "attack", Description: "Attacks with your weapon"},
+				{Name: "magic", Description: "Casts a spell"},
+				{Name: "defend", Description: "Braces for the next hit"},
+				{Name: "item", Description: "Uses your equipped item"},
+				{Name: "status", Description: "Shows the battle so far"},
+				{Name: "forfeit", Description: "Gives up the battle"},
+			},
+		},
+		{
+			Name: queueCommand, Category: categoryBattles, Run: combathandlers.HandleQueueCommand,
+			Description: "Matchmaking with players near your level and rating",
+			Feature:     settings.FeatureBattles, Channel: commands.ChannelBattle,
+			Subcommands: []*commands.Command{
+				{Name: "status", Default: true, Description: "Shows whether you're queued"},
+				{Name: combathandlers.QueueRanked, Description: "Queues for a rated match", Feature: settings.FeatureRanked, Cooldown: variables.RateLimitBattleSeconds * time.Second},
+				{Name: combathandlers.QueueCasual, Description: "Queues for a casual match", Cooldown: variables.RateLimitBattleSeconds * time.Second},
+				{Name: "leave", Description: "Leaves the queue"},
+			},
+		},
+		{
+			Name: rankCommand, Category: categoryBattles, Run: withoutArgs(HandleRankCommand),
+			Description: "Shows your ranked rating, tier and season standing",
+			Feature:     settings.FeatureRanked,
+		},
+		{
+			Name: tournamentCommand, Category: categoryBattles, Run: combathandlers.HandleTournamentCommand,
+			Description: "Runs single elimination, double elimination or Swiss tournaments",
+			Feature:     settings.FeatureTournaments, Channel: commands.ChannelBattle, GuildOnly: true,
+			Subcommands: []*commands.Command{
+				{Name: "status", Default: true, Description: "Shows the bracket and your next match"},
+				{
+					Name: "create", Description: "Opens signups for a tournament",
+					Args: []commands.Arg{
+						{Name: "format", Type: commands.ArgChoice, Description: "Bracket format", Choices: []string{tournament.FormatSingle, tournament.FormatDouble, tournament.FormatSwiss}},
+						{Name: "name", Type: commands.ArgText, Description: "Tournament name"},
+					},
+				},
+				{Name: "join", Description: "Pays the entry fee and signs up for the open tournament"},
+				{Name: "start", Description: "Closes signups and seeds the bracket"},
+				{Name: "ready", Description: "Plays your next match"},
+				{Name: "cancel", Description: "Calls off a tournament still taking signups and refunds the entry fees"},
+			},
+		},
+		{
+			Name: dungeonCommand, Category: categoryBattles, Run: combathandlers.HandleDungeonCommand,
+			Description: "Descends through dungeon floors with rest rooms, treasure and bosses",
+			Feature:     settings.FeatureDungeons, Channel: commands.ChannelBattle,
+			Subcommands: []*commands.Command{
+				{Name: "status", Default: true, Description: "Shows your current run"},
+				{Name: "enter", Description: "Starts a new run"},
+				{Name: "descend", Description: "Goes down to the next floor"},
+				{Name: "retreat", Description: "Leaves with the loot found so far"},
+			},
+		},
+		{
+			Name: leaderboardCommand, Aliases: []string{"lb"}, Category: categorySocial, Run: HandleLeaderboardCommand,
+			Description: "Shows the top players in this server or globally",
+			Args: []commands.Arg{
+				{Name: "category", Type: commands.ArgChoice, Description: "What to rank by", Choices: database.LeaderboardCategories},
+				{Name: "scope", Type: commands.ArgChoice, Description: "Rank every server instead of this one", Choices: []string{"global"}},
+			},
+		},
+		{
+			Name: achievementsCommand, Category: categorySocial, Run: withoutArgs(achievementhandlers.HandleAchievementsCommand),
+			Description: "Shows your achievements and progress toward the locked ones",
+			Feature:     settings.FeatureAchievements,
+		},
+		{
+			Name: questsCommand, Category: categorySocial, Run: withoutArgs(HandleQuestsCommand),
+			Description: "Shows your daily and weekly quests (they refresh with the shop)",
+			Feature:     settings.FeatureQuests,
+		},
+		{
+			Name: clanCommand, Category: categorySocial, Run: HandleClanCommand,
+			Description: "Clans level up together for shop and XP perks",
+			Feature:     settings.FeatureClans,
+			Subcommands: []*commands.Command{
+				{
+					Name: "info", Default: true, Description: "Shows your clan, or another by name",
+					Args: []commands.Arg{
+						{Name: "name", Type: commands.ArgText, Description: "Clan name"},
+					},
+				},
+				{
+					Name: "create", Description: "Founds a clan",
+					Args: []commands.Arg{
+						{Name: "name", Type: commands.ArgText, Description: "Clan name", Required: true},
+					},
+				},
+				{
+					Name: "invite", Description: "Invites a player (officers)",
+					Args: []commands.Arg{
+						{Name: "player", Type: commands.ArgUser, Description: "Player to invite", Required: true},
+					},
+				},
+				{
+					Name: "join", Description: "Accepts a clan's invite",
+					Args: []commands.Arg{
+						{Name: "name", Type: commands.ArgText, Description: "Clan name", Required: true},
+					},
+				},
+				{Name: "leave", Description: "Leaves your clan"},
+				{
+					Name: "kick", Description: "Removes a member (officers)",
+					Args: []commands.Arg{
+						{Name: "member", Type: commands.ArgUser, Description: "Member to remove", Required: true},
+					},
+				},
+				{
+					Name: "promote", Description: "Changes a member's role (leader)",
+					Args: []commands.Arg{
+						{Name: "member", Type: commands.ArgUser, Description: "Member to promote", Required: true},
+						{Name: "role", Type: commands.ArgChoice, Description: "New role", Required: true, Choices: []string{clans.RoleLeader, clans.RoleOfficer, clans.RoleMember}},
+					},
+				},
+				{
+					Name: "deposit", Description: "Puts coins in the clan bank",
+					Args: []commands.Arg{
+						{Name: "amount", Type: commands.ArgInteger, Description: "Coins to deposit", Required: true},
+					},
+				},
+				{
+					Name: "withdraw", Description: "Takes coins from the clan bank (officers)",
+					Args: []commands.Arg{
+						{Name: "amount", Type: commands.ArgInteger, Description: "Coins to withdraw", Required: true},
+					},
+				},
+				{Name: "ledger", Description: "Shows the clan bank's recent deposits and withdrawals"},
+				{Name: "perks", Description: "Shows the perks of each clan level"},
+				{
+					Name: "war", Description: "Every battle won against the other clan's members scores a point",
+					Subcommands: []*commands.Command{
+						{Name: "status", Default: true, Description: "Shows the score of your clan's war"},
+						{
+							Name: "declare", Description: "Declares war on another clan (officers)",
+							Args: []commands.Arg{
+								{Name: "clan", Type: commands.ArgText, Description: "Clan name", Required: true},
+							},
+						},
+						{Name: "accept", Description: "Accepts a declared war (officers)"},
+						{
+							Name: "fight", Description: "Challenges a member of the enemy clan",
+							Args: []commands.Arg{
+								{Name: "opponent", Type: commands.ArgUser, Description: "Enemy clan member", Required: true},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: configCommand, Category: categoryServer, Run: HandleConfigCommand,
+			Description: "Shows this server's settings, server managers can change them",
+			GuildOnly:   true,
+			Subcommands: []*commands.Command{
+				{Name: "show", Default: true, Description: "Shows every setting"},
+				{
+					Name: "set", Description: "Changes a setting", Permission: commands.PermissionManageServer,
+					Args: []commands.Arg{
+						{Name: "setting", Type: commands.ArgChoice, Description: "Setting to change", Required: true, Choices: settings.Keys},
+						{Name: "value", Type: commands.ArgText, Description: "New value, `any` clears a channel or role list", Required: true},
+					},
+				},
+				{
+					Name: "reset", Description: "Puts a setting back to its default", Permission: commands.PermissionManageServer,
+					Args: []commands.Arg{
+						{Name: "setting", Type: commands.ArgChoice, Description: "Setting to reset", Required: true, Choices: settings.Keys},
+					},
+				},
+				{
+					Name: "enable", Description: "Switches a feature on", Permission: commands.PermissionManageServer,
+					Args: []commands.Arg{
+						{Name: "feature", Type: commands.ArgChoice, Description: "Feature to switch on", Required: true, Choices: settings.Features},
+					},
+				},
+				{
+					Name: "disable", Description: "Switches a feature off", Permission: commands.PermissionManageServer,
+					Args: []commands.Arg{
+						{Name: "feature", Type: commands.ArgChoice, Description: "Feature to switch off", Required: true, Choices: settings.Features},
+					},
+				},
+			},
+		},
+		{
+			Name: adminCommand, Category: categoryServer, Run: HandleAdminCommand,
+			Description: "Staff tools to fix up players, every action is kept in the audit log",
+			Permission:  commands.PermissionStaff, GuildOnly: true,
+			Subcommands: []*commands.Command{
+				{Name: database.AdminCoins, Description: "Grants or deducts coins, bot owners only", Args: adminAmountArgs(variables.AdminMaxCoinAdjust)},
+				{Name: database.AdminRerolls, Description: "Grants or deducts reroll tokens, bot owners only", Args: adminAmountArgs(variables.AdminMaxRerollAdjust)},
+				{
+					Name: database.AdminItem, Description: "Grants an item of a rarity, bot owners only",
+					Args: []commands.Arg{
+						adminTargetArg,
+						{Name: "rarity", Type: commands.ArgChoice, Description: "Item rarity", Required: true, Choices: roller.TierNames()},
+						adminReasonArg,
+					},
+				},
+				{Name: database.AdminResetChar, Description: "Puts a player's character back to level 1, bot owners only", Args: []commands.Arg{adminTargetArg, adminReasonArg}},
+				{Name: database.AdminDeleteChar, Description: "Deletes a player's active character, bot owners only", Args: []commands.Arg{adminTargetArg, adminReasonArg}},
+				{Name: database.AdminEndBattle, Description: "Ends a player's stuck battle", Args: []commands.Arg{adminTargetArg, adminReasonArg}},
+				{Name: database.AdminBan, Description: "Bans a player from the game in this server", Args: []commands.Arg{adminTargetArg, adminReasonArg}},
+				{Name: database.AdminUnban, Description: "Lifts a player's ban", Args: []commands.Arg{adminTargetArg, adminReasonArg}},
+				{Name: database.AdminRefreshShop, Description: "Restocks the shop, bot owners only", Args: []commands.Arg{adminReasonArg}},
+				{
+					Name: "log", Description: "Pages through the audit log",
+					Args: []commands.Arg{
+						{Name: "page", Type: commands.ArgInteger, Description: "Page number"},
+					},
+				},
+			},
+		},
+	})
+}
+
+// Arguments shared by admin actions
+var (
+	adminTargetArg = commands.Arg{Name: "player", Type: commands.ArgUser, Description: "Player to act on", Required: true}
+	adminReasonArg = commands.Arg{Name: "reason", Type: commands.ArgText, Description: "Why, kept in the audit log"}
+)
+
+// adminAmountArgs are the arguments of admin actions that grant or deduct an amount
+func adminAmountArgs(maxAdjust int) []commands.Arg {
+	return []commands.Arg{
+		adminTargetArg,
+		{Name: "amount", Type: commands.ArgInteger, Description: "Amount to grant, negative to deduct", Required: true, Min: -maxAdjust, Max: maxAdjust},
+		adminReasonArg,
+	}
+}
+
+// withoutArgs adapts a handler that doesn't read arguments
+func withoutArgs(handler func(session *discordgo.Session, message *discordgo.MessageCreate)) commands.Handler {
+	return func(session *discordgo.Session, message *discordgo.MessageCreate, args []string) {
+		handler(session, message)
+	}
+}
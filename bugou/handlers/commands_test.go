@@ -0,0 +1,109 @@
+package bugouhandlers
+
+import (
+	"CrispyBot/commands"
+	"CrispyBot/i18n"
+	"strings"
+	"testing"
+)
+
+// Discord's embed limits
+const (
+	embedFieldNameMax  = 256
+	embedFieldValueMax = 1024
+	embedTotalMax      = 6000
+	slashCommandsMax   = 100
+)
+
+func TestCommandRegistry_HelpFitsDiscord(t *testing.T) {
+	if len(commandRegistry.SlashCommands()) > slashCommandsMax {
+		t.Errorf("Expected at most %d slash commands, got %d", slashCommandsMax, len(commandRegistry.SlashCommands()))
+	}
+
+	for _, locale := range i18n.Locales {
+		loc := i18n.New(locale.Code)
+
+		total := 0
+		for _, category := range commandRegistry.Categories() {
+			value := 0
+			for _, command := range commandRegistry.Commands() {
+				if command.Category == category {
+					value += len(prefixCommand) + len(command.Name) + len(commandDescription([]*commands.Command{command}, loc)) + 4
+				}
+			}
+			if value > embedFieldValueMax {
+				t.Errorf("Expected the %s %s help field to fit in %d characters, got %d", locale.Code, category, embedFieldValueMax, value)
+			}
+			total += len(category) + value
+		}
+		if total > embedTotalMax {
+			t.Errorf("Expected the %s help embed to fit in %d characters, got %d", locale.Code, embedTotalMax, total)
+		}
+
+		for _, command := range commandRegistry.Commands() {
+			path := []*commands.Command{command}
+			if leaves := commands.Leaves(path); len(leaves) > helpMaxFields {
+				t.Errorf("Expected %s help to fit in %d fields, got %d", command.Name, helpMaxFields, len(leaves))
+			}
+
+			helpEmbed := createCommandHelpEmbed(path, loc)
+			for _, field := range helpEmbed.Fields {
+				if field.Name == "" || field.Value == "" || len(field.Name) > embedFieldNameMax || len(field.Value) > embedFieldValueMax {
+					t.Errorf("Expected %s %s help field %q to fit Discord's limits", locale.Code, command.Name, field.Name)
+				}
+			}
+		}
+	}
+}
+
+func TestCommandRegistry_TranslationsMatchCommands(t *testing.T) {
+	known := map[string]bool{}
+	var walk func(path []*commands.Command)
+	walk = func(path []*commands.Command) {
+		known[commandKey(path)] = true
+		for _, arg := range path[len(path)-1].Args {
+			known[commandKey(path)+":"+arg.Name] = true
+		}
+		for _, subcommand := range path[len(path)-1].Subcommands {
+			walk(append(append([]*commands.Command{}, path...), subcommand))
+		}
+	}
+	for _, command := range commandRegistry.Commands() {
+		walk([]*commands.Command{command})
+	}
+
+	for _, locale := range i18n.Locales {
+		for key := range locale.Messages {
+			if strings.HasPrefix(key, "command.") && !known[key] {
+				t.Errorf("%s translates %s, which isn't a command or argument", locale.Code, key)
+			}
+		}
+	}
+
+	for _, locale := range i18n.Locales[1:] {
+		for key := range known {
+			if _, ok := locale.Messages[key]; !ok {
+				t.Errorf("%s is missing the description %s", locale.Code, key)
+			}
+		}
+	}
+}
+
+func TestCommandRegistry_ParsesCommonCommands(t *testing.T) {
+	valid := [][]string{
+		{prefixCommand, "roll"},
+		{prefixCommand, "allocate", "strength", "3"},
+		{prefixCommand, "reroll", "--lock", "race,mana"},
+		{prefixCommand, "battle", "start", "Goblin", "auto"},
+		{prefixCommand, "clan", "war", "fight", "<@123>"},
+		{prefixCommand, "admin", "coins", "<@123>", "-50", "refund"},
+		{prefixCommand, "config", "set", "rollchannels", "<#1>", "<#2>"},
+		{prefixCommand, "leaderboard", "wins", "global"},
+		{prefixCommand, "help", "clan", "war"},
+	}
+	for _, parts := range valid {
+		if _, err := commandRegistry.Parse(parts); err != nil {
+			t.Errorf("Expected %v to parse, got %v", parts, err)
+		}
+	}
+}
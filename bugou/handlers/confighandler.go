@@ -0,0 +1,147 @@
+package bugouhandlers
+
+import (
+	"CrispyBot/database"
+	"CrispyBot/database/models"
+	"CrispyBot/i18n"
+	"CrispyBot/settings"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// HandleConfigCommand shows the guild's settings and lets server managers change them
+// Note: The command registry keeps it to server channels and its changes to server managers.
+func HandleConfigCommand(session *discordgo.Session, message *discordgo.MessageCreate, args []string) {
+	loc := localizer(message)
+	subCommand := "show"
+	if len(args) >= 3 {
+		subCommand = strings.ToLower(args[2])
+	}
+
+	var change func(*models.GuildConfig) error
+	switch subCommand {
+	case "show":
+		session.ChannelMessageSendEmbed(message.ChannelID, createConfigEmbed(database.GetGuildSettings(database.DBInit(), message.GuildID), loc))
+		return
+
+	case "set":
+		if len(args) < 5 {
+			session.ChannelMessageSend(message.ChannelID, loc.T("config.usage_set", i18n.Args{"settings": strings.Join(settings.Keys, ", ")}))
+			return
+		}
+		change = func(config *models.GuildConfig) error {
+			return settings.Set(config, args[3], strings.Join(args[4:], " "))
+		}
+
+	case "reset":
+		if len(args) < 4 {
+			session.ChannelMessageSend(message.ChannelID, loc.T("config.usage_reset", i18n.Args{"settings": strings.Join(settings.Keys, ", ")}))
+			return
+		}
+		change = func(config *models.GuildConfig) error {
+			return settings.Reset(config, args[3])
+		}
+
+	case "enable", "disable":
+		if len(args) < 4 {
+			session.ChannelMessageSend(message.ChannelID, loc.T("config.usage_feature", i18n.Args{"action": subCommand, "features": strings.Join(settings.Features, ", ")}))
+			return
+		}
+		change = func(config *models.GuildConfig) error {
+			return settings.SetFeature(config, args[3], subCommand == "enable")
+		}
+
+	default:
+		session.ChannelMessageSend(message.ChannelID, loc.T("config.unknown"))
+		return
+	}
+
+	updated, err := database.UpdateGuildConfig(database.DBInit(), message.GuildID, message.Author.ID, change)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("config.failed", i18n.Args{"error": err}))
+		return
+	}
+
+	// The embed follows the new language right away
+	loc = localizer(message)
+	session.ChannelMessageSendComplex(message.ChannelID, &discordgo.MessageSend{
+		Content: loc.T("config.updated"),
+		Embed:   createConfigEmbed(updated, loc),
+	})
+}
+
+// canManageServer reports whether the author has the Manage Server permission
+func canManageServer(session *discordgo.Session, message *discordgo.MessageCreate) bool {
+	permissions, err := session.UserChannelPermissions(message.Author.ID, message.ChannelID)
+	if err != nil {
+		fmt.Printf("Error checking permissions: %v\n", err)
+		return false
+	}
+	return permissions&discordgo.PermissionManageServer != 0
+}
+
+// createConfigEmbed lists a guild's settings
+func createConfigEmbed(guildSettings settings.Settings, loc i18n.Localizer) *discordgo.MessageEmbed {
+	prefix := prefixCommand
+	if guildSettings.Prefix != "" {
+		prefix = loc.T("config.prefix_value", i18n.Args{"prefix": guildSettings.Prefix})
+	}
+	language, _ := i18n.Find(i18n.New(guildSettings.Locale).Locale())
+
+	features := []string{}
+	for _, feature := range settings.Features {
+		marker := "✅"
+		if !guildSettings.Enabled(feature) {
+			marker = "❌"
+		}
+		features = append(features, fmt.Sprintf("%s %s", marker, feature))
+	}
+
+	return &discordgo.MessageEmbed{
+		Title: loc.T("config.title"),
+		Color: 0x607D8B,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: loc.T("config.field.prefix"), Value: prefix, Inline: true},
+			{Name: loc.T("config.field.daily"), Value: loc.T("common.coins", i18n.Args{"count": guildSettings.DailyReward}), Inline: true},
+			{Name: loc.T("config.field.shopsize"), Value: loc.T("config.items", i18n.Args{"count": guildSettings.ShopSize}), Inline: true},
+			{Name: loc.T("config.field.rerolls"), Value: loc.T("config.per_day", i18n.Args{"count": guildSettings.FullRerolls}), Inline: true},
+			{Name: loc.T("config.field.statrerolls"), Value: loc.T("config.per_day", i18n.Args{"count": guildSettings.StatRerolls}), Inline: true},
+			{Name: loc.T("config.field.language"), Value: language.Name, Inline: true},
+			{Name: loc.T("config.field.rollchannels"), Value: formatChannels(guildSettings.RollChannels, loc)},
+			{Name: loc.T("config.field.battlechannels"), Value: formatChannels(guildSettings.BattleChannels, loc)},
+			{Name: loc.T("config.field.adminroles"), Value: formatRoles(guildSettings.AdminRoles, loc)},
+			{Name: loc.T("config.field.features"), Value: strings.Join(features, " · ")},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: loc.T("config.footer"),
+		},
+	}
+}
+
+// formatChannels mentions every channel in an allow list
+func formatChannels(channels []string, loc i18n.Localizer) string {
+	if len(channels) == 0 {
+		return loc.T("config.any_channel")
+	}
+
+	mentions := make([]string, len(channels))
+	for i, channelID := range channels {
+		mentions[i] = fmt.Sprintf("<#%s>", channelID)
+	}
+	return strings.Join(mentions, ", ")
+}
+
+// formatRoles mentions every admin role
+func formatRoles(roles []string, loc i18n.Localizer) string {
+	if len(roles) == 0 {
+		return loc.T("config.manage_server_only")
+	}
+
+	mentions := make([]string, len(roles))
+	for i, roleID := range roles {
+		mentions[i] = fmt.Sprintf("<@&%s>", roleID)
+	}
+	return strings.Join(mentions, ", ")
+}
@@ -2,16 +2,18 @@ package bugouhandlers
 
 import (
 	"CrispyBot/database"
-	"fmt"
+	"CrispyBot/i18n"
 
 	"github.com/bwmarrin/discordgo"
 )
 
 // HandleEquipCommand equips an item from the user's inventory
 func HandleEquipCommand(session *discordgo.Session, message *discordgo.MessageCreate, args []string) {
+	loc := localizer(message)
+
 	// Check if the user provided an item key
 	if len(args) < 3 {
-		session.ChannelMessageSend(message.ChannelID, "Please specify which item to equip. Usage: `!cb equip [item number]`")
+		session.ChannelMessageSend(message.ChannelID, loc.T("equip.usage"))
 		return
 	}
 
@@ -24,21 +26,21 @@ func HandleEquipCommand(session *discordgo.Session, message *discordgo.MessageCr
 	// Get user info
 	user, err := database.GetUserByID(db, message.Author.ID)
 	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("Error: %v", err))
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
 		return
 	}
 
 	// Check if item exists in inventory
 	itemName, ok := user.Inventory[itemKey]
 	if !ok {
-		session.ChannelMessageSend(message.ChannelID, "Item not found in your inventory.")
+		session.ChannelMessageSend(message.ChannelID, loc.T("equip.not_found"))
 		return
 	}
 
 	// Equip the item
 	err = database.EquipItem(db, message.Author.ID, itemKey)
 	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("Failed to equip item: %v", err))
+		session.ChannelMessageSend(message.ChannelID, loc.T("equip.failed", i18n.Args{"error": err}))
 		return
 	}
 
@@ -46,26 +48,26 @@ func HandleEquipCommand(session *discordgo.Session, message *discordgo.MessageCr
 	item, err := database.GetItem(db, message.Author.ID, itemKey)
 	if err != nil {
 		// If we can't find detailed stats, just show success message
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("Successfully equipped **%s**!", itemName))
+		session.ChannelMessageSend(message.ChannelID, loc.T("equip.equipped_short", i18n.Args{"name": itemName}))
 		return
 	}
 
 	// Format item stats
-	statsText := formatItemStats(item.Stats)
+	statsText := formatItemStats(item.Stats, loc)
 
 	// Create an equip confirmation embed
 	equipEmbed := &discordgo.MessageEmbed{
-		Title:       "Item Equipped",
-		Description: fmt.Sprintf("You equipped **%s**!", itemName),
+		Title:       loc.T("equip.title"),
+		Description: loc.T("equip.equipped", i18n.Args{"name": itemName}),
 		Color:       0x00FF00,
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:  "Item Details",
-				Value: fmt.Sprintf("**Rarity:** %s\n%s", item.Rarity, statsText),
+				Name:  loc.T("buy.details"),
+				Value: loc.T("buy.details_value", i18n.Args{"rarity": item.Rarity, "stats": statsText}),
 			},
 		},
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: "Type !cb stats to see your updated character stats",
+			Text: loc.T("equip.footer"),
 		},
 	}
 
@@ -76,17 +78,18 @@ func HandleEquipCommand(session *discordgo.Session, message *discordgo.MessageCr
 func HandleUnequipCommand(session *discordgo.Session, message *discordgo.MessageCreate) {
 	// Get the database singleton
 	db := database.DBInit()
+	loc := localizer(message)
 
 	// Get character info to check if there's an equipped weapon
 	character, err := database.GetCharacterByOwner(db, message.Author.ID)
 	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("Error: %v", err))
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
 		return
 	}
 
 	// Check if there's anything equipped
 	if character.EquippedWeapon.ItemKey == "" {
-		session.ChannelMessageSend(message.ChannelID, "You don't have any item equipped.")
+		session.ChannelMessageSend(message.ChannelID, loc.T("unequip.nothing"))
 		return
 	}
 
@@ -96,17 +99,17 @@ func HandleUnequipCommand(session *discordgo.Session, message *discordgo.Message
 	// Unequip the item
 	err = database.UnequipItem(db, message.Author.ID)
 	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("Failed to unequip item: %v", err))
+		session.ChannelMessageSend(message.ChannelID, loc.T("unequip.failed", i18n.Args{"error": err}))
 		return
 	}
 
 	// Create an unequip confirmation embed
 	unequipEmbed := &discordgo.MessageEmbed{
-		Title:       "Item Unequipped",
-		Description: fmt.Sprintf("You unequipped **%s**.", equippedItemName),
+		Title:       loc.T("unequip.title"),
+		Description: loc.T("unequip.unequipped", i18n.Args{"name": equippedItemName}),
 		Color:       0x00AAFF,
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: "Type !cb stats to see your updated character stats",
+			Text: loc.T("equip.footer"),
 		},
 	}
 
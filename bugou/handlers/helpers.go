@@ -2,12 +2,15 @@ package bugouhandlers
 
 import (
 	"CrispyBot/database/models"
+	"CrispyBot/i18n"
+	"CrispyBot/progression"
 	"fmt"
+	"strings"
 
 	"github.com/bwmarrin/discordgo"
 )
 
-func CreateCharacterEmbed(character models.Character, author *discordgo.User) *discordgo.MessageEmbed {
+func CreateCharacterEmbed(character models.Character, author *discordgo.User, loc i18n.Localizer) *discordgo.MessageEmbed {
 	// Get the character characteristics, traits, and stats
 	chars := character.Characteristics
 	stats := character.Stats
@@ -16,49 +19,60 @@ func CreateCharacterEmbed(character models.Character, author *discordgo.User) *d
 	// Create equipment info section
 	var equipmentInfo string
 	if character.EquippedWeapon.ItemName != "" {
-		equipmentInfo = fmt.Sprintf("**Equipped Weapon:** %s", character.EquippedWeapon.ItemName)
+		equipmentInfo = loc.T("character.equipped_weapon", i18n.Args{"name": character.EquippedWeapon.ItemName})
 	} else {
-		equipmentInfo = "No weapon equipped"
+		equipmentInfo = loc.T("character.no_weapon")
+	}
+
+	// Remind the player about points waiting to be spent
+	levelInfo := loc.T("character.level", i18n.Args{"level": character.Level, "xp": character.Experience})
+	if unspent := progression.UnspentPoints(character); unspent > 0 {
+		levelInfo += loc.T("character.unspent", i18n.Args{"count": unspent})
+	}
+
+	// Show the owner's prestige badge next to their name
+	title := loc.T("character.title", i18n.Args{"user": author.Username})
+	if badge := progression.PrestigeBadge(character.Prestige); badge != "" {
+		title += " · " + badge
 	}
 
 	// Create the embed
 	embed := &discordgo.MessageEmbed{
-		Title: fmt.Sprintf("%s's Character", author.Username),
-		Description: fmt.Sprintf("**Level %d** (%d XP)\nRace: **%s** | Element: **%s** | Alignment: **%s** | Height: **%s**",
-			character.Level,
-			character.Experience,
-			chars.Race.Trait_Name,
-			chars.Element.Trait_Name,
-			chars.Alignment.Trait_Name,
-			chars.Height.Trait_Name),
+		Title: title,
+		Description: levelInfo + "\n" + loc.T("character.summary", i18n.Args{
+			"race":      chars.Race.Trait_Name,
+			"element":   chars.Element.Trait_Name,
+			"alignment": chars.Alignment.Trait_Name,
+			"height":    chars.Height.Trait_Name,
+		}),
 		Color: 0xFF5500,
 		Thumbnail: &discordgo.MessageEmbedThumbnail{
 			URL: author.AvatarURL(""),
 		},
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "Characteristics",
-				Value:  formatCharacteristics(chars),
+				Name:   loc.T("character.characteristics"),
+				Value:  formatCharacteristics(chars, loc),
 				Inline: false,
 			},
 			{
-				Name:   "Stats",
-				Value:  formatStats(stats),
+				Name:   loc.T("character.stats"),
+				Value:  formatStats(stats, loc),
 				Inline: true,
 			},
 			{
-				Name:   "Traits",
-				Value:  formatTraits(traits),
+				Name:   loc.T("character.traits"),
+				Value:  formatTraits(traits, loc),
 				Inline: true,
 			},
 			{
-				Name:   "Equipment",
+				Name:   loc.T("character.equipment"),
 				Value:  equipmentInfo,
 				Inline: false,
 			},
 		},
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: fmt.Sprintf("Character ID: %s", character.ID.Hex()),
+			Text: loc.T("character.id", i18n.Args{"id": character.ID.Hex()}),
 		},
 	}
 
@@ -66,29 +80,27 @@ func CreateCharacterEmbed(character models.Character, author *discordgo.User) *d
 }
 
 // Updated formatCharacteristics to include height
-func formatCharacteristics(chars models.Characteristics) string {
+func formatCharacteristics(chars models.Characteristics, loc i18n.Localizer) string {
 	var charDetails string
 
 	// Format Race with its rarity
-	charDetails += fmt.Sprintf("**Race:** %s (%s)\n", chars.Race.Trait_Name, chars.Race.Rarity)
+	charDetails += loc.T("character.race", i18n.Args{"name": chars.Race.Trait_Name, "rarity": chars.Race.Rarity}) + "\n"
 
 	// Format Element with its rarity
-	charDetails += fmt.Sprintf("**Element:** %s (%s)\n", chars.Element.Trait_Name, chars.Element.Rarity)
+	charDetails += loc.T("character.element", i18n.Args{"name": chars.Element.Trait_Name, "rarity": chars.Element.Rarity}) + "\n"
 
 	// Format Alignment with its rarity
-	charDetails += fmt.Sprintf("**Alignment:** %s (%s)\n", chars.Alignment.Trait_Name, chars.Alignment.Rarity)
+	charDetails += loc.T("character.alignment", i18n.Args{"name": chars.Alignment.Trait_Name, "rarity": chars.Alignment.Rarity}) + "\n"
 
 	// Format Height
-	charDetails += fmt.Sprintf("**Height:** %s\n", chars.Height.Trait_Name)
+	charDetails += loc.T("character.height", i18n.Args{"name": chars.Height.Trait_Name}) + "\n"
 
 	// Add any race-specific stat bonuses or penalties
 	if len(chars.Race.Stats_Value) > 0 {
-		charDetails += "\n**Race Bonuses/Penalties:**\n"
+		charDetails += "\n" + loc.T("character.race_bonuses") + "\n"
 		for stat, value := range chars.Race.Stats_Value {
-			if value > 0 {
-				charDetails += fmt.Sprintf("• +%d to %s\n", value, stat)
-			} else if value < 0 {
-				charDetails += fmt.Sprintf("• %d to %s\n", value, stat)
+			if value != 0 {
+				charDetails += formatStatModifier(value, stat, loc) + "\n"
 			}
 		}
 	}
@@ -96,35 +108,49 @@ func formatCharacteristics(chars models.Characteristics) string {
 	return charDetails
 }
 
-// Updated formatStats to show both equipped item and trait bonuses
-func formatStats(stats models.StatsSheets) string {
-	// Create a uniform format for all stats with name, value, equipment and trait bonuses, and rarity
-	return fmt.Sprintf(
-		"**Vitality:** %d%s%s = %d (%s) [%s]\n**Strength:** %d%s%s = %d (%s) [%s]\n**Speed:** %d%s%s = %d (%s) [%s]\n**Durability:** %d%s%s = %d (%s) [%s]\n**Intelligence:** %d%s%s = %d (%s) [%s]\n**Mana:** %d%s%s = %d (%s) [%s]\n**Mastery:** %d%s%s = %d (%s) [%s]",
-		stats.Vitality.Value, formatEquipBonus(stats.Vitality.EquipBonus), formatTraitBonus(stats.Vitality.TraitBonus), stats.Vitality.TotalValue, stats.Vitality.Stat_Name, stats.Vitality.Rarity,
-		stats.Strength.Value, formatEquipBonus(stats.Strength.EquipBonus), formatTraitBonus(stats.Strength.TraitBonus), stats.Strength.TotalValue, stats.Strength.Stat_Name, stats.Strength.Rarity,
-		stats.Speed.Value, formatEquipBonus(stats.Speed.EquipBonus), formatTraitBonus(stats.Speed.TraitBonus), stats.Speed.TotalValue, stats.Speed.Stat_Name, stats.Speed.Rarity,
-		stats.Durability.Value, formatEquipBonus(stats.Durability.EquipBonus), formatTraitBonus(stats.Durability.TraitBonus), stats.Durability.TotalValue, stats.Durability.Stat_Name, stats.Durability.Rarity,
-		stats.Intelligence.Value, formatEquipBonus(stats.Intelligence.EquipBonus), formatTraitBonus(stats.Intelligence.TraitBonus), stats.Intelligence.TotalValue, stats.Intelligence.Stat_Name, stats.Intelligence.Rarity,
-		stats.Mana.Value, formatEquipBonus(stats.Mana.EquipBonus), formatTraitBonus(stats.Mana.TraitBonus), stats.Mana.TotalValue, stats.Mana.Stat_Name, stats.Mana.Rarity,
-		stats.Mastery.Value, formatEquipBonus(stats.Mastery.EquipBonus), formatTraitBonus(stats.Mastery.TraitBonus), stats.Mastery.TotalValue, stats.Mastery.Stat_Name, stats.Mastery.Rarity,
-	)
+// Updated formatStats to show equipped item, trait, level and prestige bonuses
+func formatStats(stats models.StatsSheets, loc i18n.Localizer) string {
+	// Create a uniform format for all stats with name, value, equipment, trait, level and prestige bonuses, and rarity
+	lines := []string{}
+	for _, stat := range []struct {
+		key   string
+		value models.Stat
+	}{
+		{"stat.vitality", stats.Vitality},
+		{"stat.strength", stats.Strength},
+		{"stat.speed", stats.Speed},
+		{"stat.durability", stats.Durability},
+		{"stat.intelligence", stats.Intelligence},
+		{"stat.mana", stats.Mana},
+		{"stat.mastery", stats.Mastery},
+	} {
+		lines = append(lines, fmt.Sprintf("**%s:** %d%s%s%s%s = %d (%s) [%s]",
+			loc.T(stat.key),
+			stat.value.Value, formatEquipBonus(stat.value.EquipBonus), formatTraitBonus(stat.value.TraitBonus), formatLevelBonus(stat.value.LevelBonus), formatPrestigeBonus(stat.value.PrestigeBonus),
+			stat.value.TotalValue, stat.value.Stat_Name, stat.value.Rarity))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// statLabel translates a stat name such as "Strength", falling back to the name itself
+func statLabel(statName string, loc i18n.Localizer) string {
+	return loc.Or("stat."+strings.ToLower(statName), statName)
 }
 
 // formatTraits formats the character traits for display
-func formatTraits(traits models.TraitsSheets) string {
+func formatTraits(traits models.TraitsSheets, loc i18n.Localizer) string {
 	var traitDetails string
 
 	// Format Innate trait (if not "None")
 	if traits.Innate.Trait_Name != "None" {
-		traitDetails += fmt.Sprintf("**Innate Trait:** %s (%s)\n", traits.Innate.Trait_Name, traits.Innate.Rarity)
+		traitDetails += loc.T("character.innate", i18n.Args{"name": traits.Innate.Trait_Name, "rarity": traits.Innate.Rarity}) + "\n"
 
 		// Show stat bonuses from innate trait if any
 		if len(traits.Innate.Stats_Value) > 0 {
-			traitDetails += "**Bonuses:**\n"
+			traitDetails += loc.T("character.bonuses") + "\n"
 			for stat, value := range traits.Innate.Stats_Value {
 				if value != 0 {
-					traitDetails += fmt.Sprintf("• %+d to %s\n", value, stat)
+					traitDetails += formatStatModifier(value, stat, loc) + "\n"
 				}
 			}
 		}
@@ -132,14 +158,14 @@ func formatTraits(traits models.TraitsSheets) string {
 
 	// Format Inadequacy trait (if not "None")
 	if traits.Inadequacy.Trait_Name != "None" {
-		traitDetails += fmt.Sprintf("\n**Weakness:** %s\n", traits.Inadequacy.Trait_Name)
+		traitDetails += "\n" + loc.T("character.weakness", i18n.Args{"name": traits.Inadequacy.Trait_Name}) + "\n"
 
 		// Show stat penalties from inadequacy trait if any
 		if len(traits.Inadequacy.Stats_Value) > 0 {
-			traitDetails += "**Penalties:**\n"
+			traitDetails += loc.T("character.penalties") + "\n"
 			for stat, value := range traits.Inadequacy.Stats_Value {
 				if value != 0 {
-					traitDetails += fmt.Sprintf("• %+d to %s\n", value, stat)
+					traitDetails += formatStatModifier(value, stat, loc) + "\n"
 				}
 			}
 		}
@@ -147,14 +173,14 @@ func formatTraits(traits models.TraitsSheets) string {
 
 	// Format X-Factor trait (if not "None")
 	if traits.X_Factor.Trait_Name != "None" {
-		traitDetails += fmt.Sprintf("\n**X-Factor:** %s\n", traits.X_Factor.Trait_Name)
+		traitDetails += "\n" + loc.T("character.x_factor", i18n.Args{"name": traits.X_Factor.Trait_Name}) + "\n"
 
 		// Show stat modifiers from X-Factor trait if any
 		if len(traits.X_Factor.Stats_Value) > 0 {
-			traitDetails += "**Effects:**\n"
+			traitDetails += loc.T("character.effects") + "\n"
 			for stat, value := range traits.X_Factor.Stats_Value {
 				if value != 0 {
-					traitDetails += fmt.Sprintf("• %+d to %s\n", value, stat)
+					traitDetails += formatStatModifier(value, stat, loc) + "\n"
 				}
 			}
 		}
@@ -162,7 +188,7 @@ func formatTraits(traits models.TraitsSheets) string {
 
 	// If there are no traits, provide a message
 	if traitDetails == "" {
-		traitDetails = "No special traits"
+		traitDetails = loc.T("character.no_traits")
 	}
 
 	return traitDetails
@@ -178,6 +204,22 @@ func formatEquipBonus(bonus int) string {
 	return ""
 }
 
+// Helper function to format allocated level points
+func formatLevelBonus(bonus int) string {
+	if bonus > 0 {
+		return fmt.Sprintf(" +%d⬆", bonus)
+	}
+	return ""
+}
+
+// Helper function to format prestige bonus
+func formatPrestigeBonus(bonus int) string {
+	if bonus > 0 {
+		return fmt.Sprintf(" +%d✨", bonus)
+	}
+	return ""
+}
+
 // Helper function to format trait bonus
 func formatTraitBonus(bonus int) string {
 	if bonus > 0 {
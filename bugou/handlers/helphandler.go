@@ -0,0 +1,155 @@
+package bugouhandlers
+
+import (
+	"CrispyBot/commands"
+	"CrispyBot/database"
+	"CrispyBot/i18n"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Discord caps embeds at 25 fields
+const helpMaxFields = 25
+
+// HandleHelpCommand lists every command, or explains the one named after help
+func HandleHelpCommand(session *discordgo.Session, message *discordgo.MessageCreate, args []string) {
+	loc := localizer(message)
+	if len(args) < 3 {
+		SendHelpMessage(session, message.ChannelID, database.GetGuildSettings(database.DBInit(), message.GuildID).Prefix, loc)
+		return
+	}
+
+	path, ok := commandRegistry.Lookup(args[2:])
+	if !ok {
+		session.ChannelMessageSend(message.ChannelID, loc.T("help.unknown", i18n.Args{"command": args[2]}))
+		return
+	}
+
+	session.ChannelMessageSendEmbed(message.ChannelID, createCommandHelpEmbed(path, loc))
+}
+
+// SendHelpMessage sends the help message with every command, grouped by category
+func SendHelpMessage(session *discordgo.Session, channelID string, guildPrefix string, loc i18n.Localizer) {
+	helpEmbed := &discordgo.MessageEmbed{
+		Title:       loc.T("help.title"),
+		Description: loc.T("help.description"),
+		Color:       0x00AAFF,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: loc.T("help.footer"),
+		},
+	}
+
+	for _, category := range commandRegistry.Categories() {
+		lines := []string{}
+		for _, command := range commandRegistry.Commands() {
+			if command.Category == category {
+				lines = append(lines, loc.T("help.command_line", i18n.Args{
+					"command":     command.Name,
+					"description": commandDescription([]*commands.Command{command}, loc),
+				}))
+			}
+		}
+		helpEmbed.Fields = append(helpEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:  loc.Or("help.category."+strings.ToLower(category), category),
+			Value: strings.Join(lines, "\n"),
+		})
+	}
+
+	if guildPrefix != "" {
+		helpEmbed.Footer.Text = loc.T("help.footer_prefix", i18n.Args{"footer": helpEmbed.Footer.Text, "prefix": guildPrefix})
+	}
+
+	session.ChannelMessageSendEmbed(channelID, helpEmbed)
+}
+
+// createCommandHelpEmbed explains a command's usage, arguments and restrictions
+func createCommandHelpEmbed(path []*commands.Command, loc i18n.Localizer) *discordgo.MessageEmbed {
+	command := path[len(path)-1]
+	helpEmbed := &discordgo.MessageEmbed{
+		Title:       loc.T("help.command_title", i18n.Args{"command": commands.Name(path)}),
+		Description: commandDescription(path, loc),
+		Color:       0x00AAFF,
+	}
+
+	if len(command.Aliases) > 0 {
+		helpEmbed.Description = loc.T("help.aliases", i18n.Args{"description": helpEmbed.Description, "aliases": strings.Join(command.Aliases, ", ")})
+	}
+	if notes := restrictionNotes(path, loc); notes != "" {
+		helpEmbed.Description += "\n" + notes
+	}
+
+	// Commands with subcommands list each one, the rest list their arguments
+	if len(command.Subcommands) > 0 {
+		for _, leaf := range commands.Leaves(path) {
+			value := commandDescription(leaf, loc)
+			if notes := restrictionNotes(leaf[len(path):], loc); notes != "" {
+				value += "\n" + notes
+			}
+			helpEmbed.Fields = append(helpEmbed.Fields, &discordgo.MessageEmbedField{
+				Name:  strings.Trim(commandRegistry.Usage(leaf)[0], "`"),
+				Value: value,
+			})
+		}
+	} else {
+		helpEmbed.Fields = append(helpEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:  loc.T("help.usage"),
+			Value: commandRegistry.Usage(path)[0],
+		})
+		for _, arg := range command.Args {
+			value := argDescription(path, arg, loc)
+			if arg.Type == commands.ArgChoice {
+				value = loc.T("help.choices", i18n.Args{"description": value, "choices": strings.Join(arg.Choices, ", ")})
+			}
+			if !arg.Required {
+				value = loc.T("help.optional", i18n.Args{"description": value})
+			}
+			helpEmbed.Fields = append(helpEmbed.Fields, &discordgo.MessageEmbedField{
+				Name:   arg.Name,
+				Value:  value,
+				Inline: true,
+			})
+		}
+	}
+
+	if len(helpEmbed.Fields) > helpMaxFields {
+		helpEmbed.Fields = helpEmbed.Fields[:helpMaxFields]
+	}
+	return helpEmbed
+}
+
+// restrictionNotes describes who can use part of a command path, where, and how often
+func restrictionNotes(path []*commands.Command, loc i18n.Localizer) string {
+	notes := []string{}
+	for _, command := range path {
+		if key, ok := permissionNames[command.Permission]; ok {
+			notes = append(notes, loc.T("help.only", i18n.Args{"who": loc.T(key)}))
+		}
+		if command.GuildOnly {
+			notes = append(notes, loc.T("help.guild_only"))
+		}
+		if command.Cooldown > 0 {
+			notes = append(notes, loc.T("help.cooldown", i18n.Args{"cooldown": command.Cooldown}))
+		}
+	}
+	return strings.Join(notes, " · ")
+}
+
+// commandKey is the catalogue key of a command's description, implicit subcommands included
+func commandKey(path []*commands.Command) string {
+	names := make([]string, len(path))
+	for i, command := range path {
+		names[i] = command.Name
+	}
+	return "command." + strings.Join(names, ".")
+}
+
+// commandDescription translates a command's description, the registry's English is the fallback
+func commandDescription(path []*commands.Command, loc i18n.Localizer) string {
+	return loc.Or(commandKey(path), path[len(path)-1].Description)
+}
+
+// argDescription translates an argument's description, the registry's English is the fallback
+func argDescription(path []*commands.Command, arg commands.Arg, loc i18n.Localizer) string {
+	return loc.Or(commandKey(path)+":"+arg.Name, arg.Description)
+}
@@ -0,0 +1,183 @@
+package bugouhandlers
+
+import (
+	combathandlers "CrispyBot/bugou/combathandlers"
+	"CrispyBot/database"
+	"CrispyBot/database/models"
+	"CrispyBot/i18n"
+	"CrispyBot/progression"
+	"CrispyBot/variables"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// snapshotSourceLabels are the catalogue keys of the history titles for each kind of roll
+var snapshotSourceLabels = map[string]string{
+	database.SnapshotRoll:       "history.source.roll",
+	database.SnapshotReroll:     "history.source.reroll",
+	database.SnapshotRerollStat: "history.source.rerollstat",
+	database.SnapshotRebirth:    "history.source.rebirth",
+	database.SnapshotUndo:       "history.source.undo",
+}
+
+// HandleHistoryCommand pages through the user's past rolls and rerolls
+func HandleHistoryCommand(session *discordgo.Session, message *discordgo.MessageCreate) {
+	loc := localizer(message)
+	page := 0
+	historyEmbed, totalPages, err := createHistoryEmbed(message.Author, page, loc)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	// Add a unique identifier to track this specific history message
+	historyID := fmt.Sprintf("history_%s_%d", message.Author.ID, time.Now().UnixNano())
+
+	msg, err := session.ChannelMessageSendComplex(message.ChannelID, &discordgo.MessageSend{
+		Embed:      historyEmbed,
+		Components: leaderboardButtons(historyID, page, totalPages, loc),
+	})
+	if err != nil {
+		fmt.Printf("Error sending roll history: %v\n", err)
+		return
+	}
+
+	// Set up a temporary handler for the page buttons
+	removeHandler := session.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		if i.Type != discordgo.InteractionMessageComponent {
+			return
+		}
+
+		customID := i.MessageComponentData().CustomID
+		if !strings.HasPrefix(customID, historyID) {
+			return
+		}
+
+		if strings.HasSuffix(customID, "_prev") && page > 0 {
+			page--
+		} else if strings.HasSuffix(customID, "_next") && page < totalPages-1 {
+			page++
+		}
+
+		pageEmbed, pages, err := createHistoryEmbed(message.Author, page, loc)
+		if err != nil {
+			fmt.Printf("Error updating roll history: %v\n", err)
+			return
+		}
+		totalPages = pages
+
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Embeds:     []*discordgo.MessageEmbed{pageEmbed},
+				Components: leaderboardButtons(historyID, page, totalPages, loc),
+			},
+		})
+	})
+
+	// Remove the buttons after 5 minutes
+	time.AfterFunc(5*time.Minute, func() {
+		removeHandler()
+		session.ChannelMessageEditComplex(&discordgo.MessageEdit{
+			Channel:    msg.ChannelID,
+			ID:         msg.ID,
+			Components: &[]discordgo.MessageComponent{},
+		})
+	})
+}
+
+// createHistoryEmbed builds one page of a user's roll history
+func createHistoryEmbed(author *discordgo.User, page int, loc i18n.Localizer) (*discordgo.MessageEmbed, int, error) {
+	snapshots, total, err := database.GetCharacterSnapshots(database.DBInit(), author.ID, page, variables.SnapshotPageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalPages := (total + variables.SnapshotPageSize - 1) / variables.SnapshotPageSize
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	historyEmbed := &discordgo.MessageEmbed{
+		Title: loc.T("history.title", i18n.Args{"user": author.Username}),
+		Color: 0x9B59B6,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: loc.T("history.footer", i18n.Args{"page": page + 1, "pages": totalPages, "count": total}),
+		},
+	}
+
+	if len(snapshots) == 0 {
+		historyEmbed.Description = loc.T("history.none")
+		return historyEmbed, totalPages, nil
+	}
+
+	for i, snapshot := range snapshots {
+		label := loc.Or(snapshotSourceLabels[snapshot.Source], snapshot.Source)
+
+		name := fmt.Sprintf("#%d %s · <t:%d:R>", total-page*variables.SnapshotPageSize-i, label, snapshot.CreatedAt.Unix())
+		if snapshot.Seed != 0 {
+			name = fmt.Sprintf("%s · %s", name, loc.T("history.seed", i18n.Args{"seed": snapshot.Seed}))
+		}
+
+		historyEmbed.Fields = append(historyEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:  name,
+			Value: formatSnapshotChange(snapshot, loc),
+		})
+	}
+
+	return historyEmbed, totalPages, nil
+}
+
+// formatSnapshotChange describes what a roll changed
+func formatSnapshotChange(snapshot models.CharacterSnapshot, loc i18n.Localizer) string {
+	// Stat rerolls only touch one stat
+	if snapshot.Stat != "" && snapshot.Before != nil {
+		before := progression.StatByName(&snapshot.Before.Stats, snapshot.Stat)
+		after := progression.StatByName(&snapshot.After.Stats, snapshot.Stat)
+		if before != nil && after != nil {
+			return fmt.Sprintf("%s: %s (%s) → **%s (%s)**", statLabel(snapshot.Stat, loc), before.Stat_Name, before.Rarity, after.Stat_Name, after.Rarity)
+		}
+	}
+
+	line := formatSnapshotCharacter(snapshot.After, loc)
+	if snapshot.Before != nil {
+		line = fmt.Sprintf("%s → **%s**", formatSnapshotCharacter(*snapshot.Before, loc), line)
+	}
+	if len(snapshot.Locks) > 0 {
+		line = fmt.Sprintf("%s\n%s", line, loc.T("history.kept", i18n.Args{"locks": strings.Join(snapshot.Locks, ", ")}))
+	}
+	return line
+}
+
+// formatSnapshotCharacter summarizes a snapshotted character in one line
+func formatSnapshotCharacter(character models.Character, loc i18n.Localizer) string {
+	return loc.T("history.character", i18n.Args{
+		"level":   character.Level,
+		"element": character.Characteristics.Element.Trait_Name,
+		"race":    character.Characteristics.Race.Trait_Name,
+		"rarity":  character.RarityScore,
+	})
+}
+
+// HandleUndoCommand restores the active character to how it was before its last reroll
+func HandleUndoCommand(session *discordgo.Session, message *discordgo.MessageCreate) {
+	loc := localizer(message)
+
+	// The restored character would swap the fighter out from under the battle
+	if combathandlers.IsPlayerInBattle(message.Author.ID) {
+		session.ChannelMessageSend(message.ChannelID, loc.T("undo.in_battle"))
+		return
+	}
+
+	character, undone, err := database.UndoLastReroll(database.DBInit(), message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("undo.failed", i18n.Args{"error": err}))
+		return
+	}
+
+	session.ChannelMessageSend(message.ChannelID, loc.T("undo.done", i18n.Args{"time": undone.CreatedAt.Unix()}))
+	session.ChannelMessageSendEmbed(message.ChannelID, CreateCharacterEmbed(character, message.Author, loc))
+}
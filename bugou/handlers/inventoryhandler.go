@@ -2,6 +2,8 @@ package bugouhandlers
 
 import (
 	"CrispyBot/database"
+	"CrispyBot/i18n"
+	"CrispyBot/variables"
 	"fmt"
 
 	"github.com/bwmarrin/discordgo"
@@ -11,29 +13,30 @@ import (
 func HandleInventoryCommand(session *discordgo.Session, message *discordgo.MessageCreate) {
 	// Get the database singleton
 	db := database.DBInit()
+	loc := localizer(message)
 
 	// Get user info
 	user, err := database.GetUserByID(db, message.Author.ID)
 	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("Error: %v", err))
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
 		return
 	}
 
 	// Create an inventory embed
 	inventoryEmbed := &discordgo.MessageEmbed{
-		Title:       "🎒 Your Inventory",
-		Description: fmt.Sprintf("%s's collection of items", message.Author.Username),
+		Title:       loc.T("inventory.title"),
+		Description: loc.T("inventory.description", i18n.Args{"user": message.Author.Username}),
 		Color:       0x964B00,
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: "Use !cb shop to find more items",
+			Text: loc.T("inventory.footer"),
 		},
 	}
 
 	// Add inventory items to the embed
 	if len(user.Inventory) == 0 {
 		inventoryEmbed.Fields = append(inventoryEmbed.Fields, &discordgo.MessageEmbedField{
-			Name:  "Empty Inventory",
-			Value: "You don't have any items yet. Visit the shop with `!cb shop` to buy some!",
+			Name:  loc.T("inventory.empty"),
+			Value: loc.T("inventory.empty_detail"),
 		})
 	} else {
 		// Group items by type for cleaner display
@@ -46,7 +49,7 @@ func HandleInventoryCommand(session *discordgo.Session, message *discordgo.Messa
 		}
 
 		inventoryEmbed.Fields = append(inventoryEmbed.Fields, &discordgo.MessageEmbedField{
-			Name:  fmt.Sprintf("Weapons (%d)", itemCount),
+			Name:  loc.T("inventory.weapons", i18n.Args{"count": itemCount, "max": variables.MaxInventorySize}),
 			Value: weaponsList,
 		})
 	}
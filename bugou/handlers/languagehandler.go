@@ -0,0 +1,80 @@
+package bugouhandlers
+
+import (
+	"CrispyBot/database"
+	"CrispyBot/i18n"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// localizer returns the translator for the author of a message
+func localizer(message *discordgo.MessageCreate) i18n.Localizer {
+	return database.GetLocalizer(database.DBInit(), message.Author.ID, message.GuildID)
+}
+
+// HandleLanguageCommand shows the user's language, or picks a new one
+// Note: Reset goes back to the server's language.
+func HandleLanguageCommand(session *discordgo.Session, message *discordgo.MessageCreate, args []string) {
+	db := database.DBInit()
+	loc := localizer(message)
+
+	if len(args) < 3 {
+		session.ChannelMessageSendEmbed(message.ChannelID, createLanguageEmbed(loc, database.GetGuildSettings(db, message.GuildID).Locale))
+		return
+	}
+
+	choice := strings.Join(args[2:], " ")
+	if strings.EqualFold(choice, "reset") {
+		if err := database.SetUserLocale(db, message.Author.ID, ""); err != nil {
+			session.ChannelMessageSend(message.ChannelID, loc.T("language.failed", i18n.Args{"error": err}))
+			return
+		}
+
+		loc = localizer(message)
+		session.ChannelMessageSend(message.ChannelID, loc.T("language.reset"))
+		return
+	}
+
+	locale, ok := i18n.Find(choice)
+	if !ok {
+		session.ChannelMessageSend(message.ChannelID, loc.T("language.unknown", i18n.Args{"language": choice, "languages": formatLanguages()}))
+		return
+	}
+
+	if err := database.SetUserLocale(db, message.Author.ID, locale.Code); err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("language.failed", i18n.Args{"error": err}))
+		return
+	}
+
+	// Confirm in the language just picked
+	session.ChannelMessageSend(message.ChannelID, i18n.New(locale.Code).T("language.set", i18n.Args{"language": locale.Name}))
+}
+
+// createLanguageEmbed shows the language in use and the ones that can be picked
+func createLanguageEmbed(loc i18n.Localizer, guildLocale string) *discordgo.MessageEmbed {
+	current, _ := i18n.Find(loc.Locale())
+	server, _ := i18n.Find(i18n.New(guildLocale).Locale())
+
+	return &discordgo.MessageEmbed{
+		Title:       loc.T("language.title"),
+		Description: loc.T("language.current", i18n.Args{"language": current.Name, "server": server.Name}),
+		Color:       0x00AAFF,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: loc.T("language.available"), Value: formatLanguages()},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: loc.T("language.footer"),
+		},
+	}
+}
+
+// formatLanguages lists every language with its code
+func formatLanguages() string {
+	lines := []string{}
+	for _, locale := range i18n.Locales {
+		lines = append(lines, fmt.Sprintf("`%s` %s", locale.Code, locale.Name))
+	}
+	return strings.Join(lines, "\n")
+}
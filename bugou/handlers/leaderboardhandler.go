@@ -0,0 +1,242 @@
+package bugouhandlers
+
+import (
+	"CrispyBot/database"
+	"CrispyBot/i18n"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// Number of players shown per leaderboard page
+	leaderboardPageSize = 10
+)
+
+// HandleLeaderboardCommand shows a paginated leaderboard for the requested category
+func HandleLeaderboardCommand(session *discordgo.Session, message *discordgo.MessageCreate, args []string) {
+	loc := localizer(message)
+
+	// Default to the level leaderboard for this server
+	category := database.LeaderboardLevel
+	guildID := message.GuildID
+
+	for _, arg := range args[2:] {
+		arg = strings.ToLower(arg)
+		if arg == "global" {
+			guildID = ""
+			continue
+		}
+
+		if !isLeaderboardCategory(arg) {
+			session.ChannelMessageSend(message.ChannelID, loc.T("leaderboard.unknown", i18n.Args{"categories": strings.Join(database.LeaderboardCategories, "|")}))
+			return
+		}
+		category = arg
+	}
+
+	// Build the first page
+	page := 0
+	leaderboardEmbed, totalPages, err := createLeaderboardEmbed(category, guildID, page, message.Author.ID, loc)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	// Add a unique identifier to track this specific leaderboard message
+	leaderboardID := fmt.Sprintf("leaderboard_%s_%d", message.Author.ID, time.Now().UnixNano())
+
+	msg, err := session.ChannelMessageSendComplex(message.ChannelID, &discordgo.MessageSend{
+		Embed:      leaderboardEmbed,
+		Components: leaderboardButtons(leaderboardID, page, totalPages, loc),
+	})
+	if err != nil {
+		fmt.Printf("Error sending leaderboard: %v\n", err)
+		return
+	}
+
+	// Button presses arrive on their own goroutines, so the page state needs a lock
+	var pageMutex sync.Mutex
+
+	// Set up a temporary handler for the page buttons
+	removeHandler := session.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		if i.Type != discordgo.InteractionMessageComponent {
+			return
+		}
+
+		customID := i.MessageComponentData().CustomID
+		if !strings.HasPrefix(customID, leaderboardID) {
+			return
+		}
+
+		pageMutex.Lock()
+		defer pageMutex.Unlock()
+
+		// Move to the requested page
+		if strings.HasSuffix(customID, "_prev") && page > 0 {
+			page--
+		} else if strings.HasSuffix(customID, "_next") && page < totalPages-1 {
+			page++
+		}
+
+		// Show the page from the point of view of whoever pressed the button
+		viewerID := message.Author.ID
+		if i.Member != nil && i.Member.User != nil {
+			viewerID = i.Member.User.ID
+		} else if i.User != nil {
+			viewerID = i.User.ID
+		}
+
+		viewerLoc := database.GetLocalizer(database.DBInit(), viewerID, message.GuildID)
+		pageEmbed, pages, err := createLeaderboardEmbed(category, guildID, page, viewerID, viewerLoc)
+		if err != nil {
+			fmt.Printf("Error updating leaderboard: %v\n", err)
+			return
+		}
+		totalPages = pages
+
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Embeds:     []*discordgo.MessageEmbed{pageEmbed},
+				Components: leaderboardButtons(leaderboardID, page, totalPages, viewerLoc),
+			},
+		})
+	})
+
+	// Remove the buttons after 5 minutes
+	time.AfterFunc(5*time.Minute, func() {
+		removeHandler()
+		session.ChannelMessageEditComplex(&discordgo.MessageEdit{
+			Channel:    msg.ChannelID,
+			ID:         msg.ID,
+			Components: &[]discordgo.MessageComponent{},
+		})
+	})
+}
+
+// createLeaderboardEmbed builds one page of a leaderboard along with the viewer's own rank
+func createLeaderboardEmbed(category string, guildID string, page int, viewerID string, loc i18n.Localizer) (*discordgo.MessageEmbed, int, error) {
+	db := database.DBInit()
+
+	entries, total, err := database.GetLeaderboard(db, category, guildID, page, leaderboardPageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalPages := (total + leaderboardPageSize - 1) / leaderboardPageSize
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	scope := loc.T("leaderboard.scope.server")
+	if guildID == "" {
+		scope = loc.T("leaderboard.scope.global")
+	}
+
+	// List the players on this page
+	rankings := ""
+	viewerOnPage := false
+	for _, entry := range entries {
+		line := fmt.Sprintf("%s <@%s> - %s\n", formatRank(entry.Rank), entry.DiscordID, formatLeaderboardValue(category, entry.Value, loc))
+		if entry.DiscordID == viewerID {
+			line = "**" + strings.TrimSuffix(line, "\n") + "**\n"
+			viewerOnPage = true
+		}
+		rankings += line
+	}
+	if rankings == "" {
+		rankings = loc.T("leaderboard.empty")
+	}
+
+	leaderboardEmbed := &discordgo.MessageEmbed{
+		Title:       loc.T("leaderboard.title", i18n.Args{"category": loc.T("leaderboard.category." + category), "scope": scope}),
+		Description: rankings,
+		Color:       0xFFD700,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: loc.T("leaderboard.footer", i18n.Args{"page": page + 1, "pages": totalPages, "count": total}),
+		},
+	}
+
+	// Show the viewer's own rank when they're not on this page
+	if !viewerOnPage {
+		ownRank := loc.T("leaderboard.unranked")
+		entry, err := database.GetLeaderboardRank(db, category, guildID, viewerID)
+		if err == nil {
+			ownRank = fmt.Sprintf("%s <@%s> - %s", formatRank(entry.Rank), viewerID, formatLeaderboardValue(category, entry.Value, loc))
+		}
+
+		leaderboardEmbed.Fields = append(leaderboardEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:  loc.T("leaderboard.your_rank"),
+			Value: ownRank,
+		})
+	}
+
+	return leaderboardEmbed, totalPages, nil
+}
+
+// leaderboardButtons creates the previous/next page buttons
+func leaderboardButtons(leaderboardID string, page int, totalPages int, loc i18n.Localizer) []discordgo.MessageComponent {
+	prevButton := discordgo.Button{
+		Label:    loc.T("common.previous"),
+		Style:    discordgo.SecondaryButton,
+		CustomID: leaderboardID + "_prev",
+		Disabled: page <= 0,
+	}
+
+	nextButton := discordgo.Button{
+		Label:    loc.T("common.next"),
+		Style:    discordgo.SecondaryButton,
+		CustomID: leaderboardID + "_next",
+		Disabled: page >= totalPages-1,
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{prevButton, nextButton},
+		},
+	}
+}
+
+// isLeaderboardCategory checks if a category name is supported
+func isLeaderboardCategory(category string) bool {
+	for _, c := range database.LeaderboardCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// formatRank shows a medal for the top three and a number for everyone else
+func formatRank(rank int) string {
+	switch rank {
+	case 1:
+		return "🥇"
+	case 2:
+		return "🥈"
+	case 3:
+		return "🥉"
+	default:
+		return fmt.Sprintf("**#%d**", rank)
+	}
+}
+
+// formatLeaderboardValue formats a leaderboard value with its unit
+func formatLeaderboardValue(category string, value int, loc i18n.Localizer) string {
+	switch category {
+	case database.LeaderboardLevel:
+		return loc.T("leaderboard.value.level", i18n.Args{"count": value})
+	case database.LeaderboardWins:
+		return loc.T("leaderboard.value.wins", i18n.Args{"count": value})
+	case database.LeaderboardCoins:
+		return loc.T("common.coins", i18n.Args{"count": value})
+	case database.LeaderboardRarity:
+		return loc.T("leaderboard.value.rarity", i18n.Args{"count": value})
+	default:
+		return fmt.Sprintf("%d", value)
+	}
+}
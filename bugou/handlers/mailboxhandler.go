@@ -0,0 +1,118 @@
+package bugouhandlers
+
+import (
+	"CrispyBot/database"
+	"CrispyBot/database/models"
+	"CrispyBot/i18n"
+	"CrispyBot/variables"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// maxMailboxListed is how many mailbox items are shown at once
+const maxMailboxListed = 10
+
+// HandleMailboxCommand shows or claims items that didn't fit in the user's inventory
+func HandleMailboxCommand(session *discordgo.Session, message *discordgo.MessageCreate, args []string) {
+	db := database.DBInit()
+	loc := localizer(message)
+
+	items, err := database.GetMailbox(db, message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	// No subcommand lists the mailbox
+	if len(args) < 3 || strings.ToLower(args[2]) != "claim" {
+		sendMailboxEmbed(session, message, items, loc)
+		return
+	}
+
+	if len(items) == 0 {
+		session.ChannelMessageSend(message.ChannelID, loc.T("mailbox.empty_message"))
+		return
+	}
+	if len(args) < 4 {
+		session.ChannelMessageSend(message.ChannelID, loc.T("mailbox.usage"))
+		return
+	}
+
+	// Claim everything that fits, oldest first
+	if strings.ToLower(args[3]) == "all" {
+		claimed := 0
+		for _, entry := range items {
+			_, err := database.ClaimMailboxItem(db, message.Author.ID, entry)
+			if err != nil {
+				if claimed == 0 {
+					session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+					return
+				}
+				break
+			}
+			claimed++
+		}
+
+		response := loc.T("mailbox.claimed_all", i18n.Args{"count": claimed})
+		if remaining := len(items) - claimed; remaining > 0 {
+			response += " " + loc.T("mailbox.still_waiting", i18n.Args{"count": remaining})
+		}
+		session.ChannelMessageSend(message.ChannelID, response)
+		return
+	}
+
+	number, err := strconv.Atoi(args[3])
+	if err != nil || number < 1 || number > len(items) {
+		session.ChannelMessageSend(message.ChannelID, loc.T("mailbox.invalid_number", i18n.Args{"max": len(items)}))
+		return
+	}
+
+	entry := items[number-1]
+	inventoryKey, err := database.ClaimMailboxItem(db, message.Author.ID, entry)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	session.ChannelMessageSend(message.ChannelID,
+		loc.T("mailbox.claimed", i18n.Args{"name": entry.Item.Name, "rarity": entry.Item.Rarity, "key": inventoryKey}))
+}
+
+// sendMailboxEmbed lists the items waiting in the user's mailbox
+func sendMailboxEmbed(session *discordgo.Session, message *discordgo.MessageCreate, items []models.MailboxItem, loc i18n.Localizer) {
+	mailboxEmbed := &discordgo.MessageEmbed{
+		Title:       loc.T("mailbox.title"),
+		Description: loc.T("mailbox.description", i18n.Args{"max": variables.MaxInventorySize, "days": variables.MailboxExpiryDays}),
+		Color:       0x964B00,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: loc.T("mailbox.footer"),
+		},
+	}
+
+	if len(items) == 0 {
+		mailboxEmbed.Fields = append(mailboxEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:  loc.T("mailbox.empty"),
+			Value: loc.T("mailbox.empty_detail"),
+		})
+	}
+
+	for i, entry := range items {
+		if i >= maxMailboxListed {
+			mailboxEmbed.Fields = append(mailboxEmbed.Fields, &discordgo.MessageEmbedField{
+				Name:  loc.T("mailbox.more"),
+				Value: loc.T("mailbox.more_detail", i18n.Args{"count": len(items) - maxMailboxListed}),
+			})
+			break
+		}
+
+		mailboxEmbed.Fields = append(mailboxEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("%d. %s (%s)", i+1, entry.Item.Name, entry.Item.Rarity),
+			Value: loc.T("mailbox.from", i18n.Args{"source": entry.Source, "stats": formatItemStats(entry.Item.Stats, loc)}),
+		})
+	}
+
+	session.ChannelMessageSendEmbed(message.ChannelID, mailboxEmbed)
+}
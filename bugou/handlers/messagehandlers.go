@@ -1,14 +1,23 @@
 package bugouhandlers
 
 import (
-	combathandlers "CrispyBot/bugou/combathandlers"
+	"CrispyBot/commands"
+	"CrispyBot/database"
+	"CrispyBot/i18n"
+	"CrispyBot/ratelimit"
+	"CrispyBot/settings"
+	"CrispyBot/variables"
+	"fmt"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 )
 
 const (
-	prefixCommand       = "!cb"
+	prefixCommand       = settings.DefaultPrefix
 	helpCommand         = "help"
 	rollCommand         = "roll"
 	statCommand         = "stats"
@@ -24,6 +33,25 @@ const (
 	rerollStatusCommand = "rerolls"
 	deleteCommand       = "delete"
 	battleCommand       = "battle" // Added battle command
+	leaderboardCommand  = "leaderboard"
+	rankCommand         = "rank"
+	queueCommand        = "queue"
+	tournamentCommand   = "tournament"
+	dungeonCommand      = "dungeon"
+	mailboxCommand      = "mailbox"
+	allocateCommand     = "allocate"
+	rebirthCommand      = "rebirth"
+	achievementsCommand = "achievements"
+	questsCommand       = "quests"
+	clanCommand         = "clan"
+	charactersCommand   = "characters"
+	switchCommand       = "switch"
+	historyCommand      = "history"
+	undoCommand         = "undo"
+	bannerCommand       = "banner"
+	configCommand       = "config"
+	adminCommand        = "admin"
+	languageCommand     = "language"
 )
 
 // MessageCreate handles incoming Discord messages
@@ -33,134 +61,235 @@ func MessageCreate(session *discordgo.Session, message *discordgo.MessageCreate)
 		return
 	}
 
-	// Check if message starts with the command prefix
-	if !strings.HasPrefix(message.Content, prefixCommand) {
+	// Every guild answers to the default prefix as well as its own
+	db := database.DBInit()
+	guildSettings := database.GetGuildSettings(db, message.GuildID)
+	commandParts, ok := parseCommand(message.Content, guildSettings.Prefix)
+	if !ok {
+		return
+	}
+
+	// The user and guild buckets count every prefixed message, so help and refusals can't be spammed either
+	if !checkSharedRateLimit(session, message) {
 		return
 	}
 
-	// Parse the command
-	commandParts := strings.Fields(message.Content)
 	if len(commandParts) < 2 {
 		// Just the prefix, show help message
-		SendHelpMessage(session, message.ChannelID)
+		SendHelpMessage(session, message.ChannelID, guildSettings.Prefix, localizer(message))
+		return
+	}
+
+	invocation, err := commandRegistry.Parse(commandParts)
+	if err != nil {
+		sendUsageError(session, message, err)
+		return
+	}
+
+	// Remember which guild the user plays in for guild leaderboards
+	database.TrackUserGuild(db, message.Author.ID, message.GuildID)
+
+	if !commandAllowed(session, message, guildSettings, invocation) {
+		return
+	}
+
+	invocation.Path[0].Run(session, message, invocation.Parts)
+}
+
+// sendUsageError tells the user what was wrong with a command and how to type it
+func sendUsageError(session *discordgo.Session, message *discordgo.MessageCreate, err error) {
+	loc := localizer(message)
+	usageErr, ok := err.(*commands.UsageError)
+	if !ok || len(usageErr.Path) == 0 {
+		session.ChannelMessageSend(message.ChannelID, loc.T("dispatch.unknown_command"))
 		return
 	}
 
-	command := strings.ToLower(commandParts[1])
-
-	// Handle commands
-	switch command {
-	case helpCommand:
-		SendHelpMessage(session, message.ChannelID)
-	case rollCommand:
-		HandleRollCommand(session, message)
-	case statCommand:
-		HandleStatsCommand(session, message)
-	case shopCommand:
-		HandleShopCommand(session, message)
-	case buyCommand:
-		HandleBuyCommand(session, message, commandParts)
-	case walletCommand:
-		HandleWalletCommand(session, message)
-	case dailyCommand:
-		HandleDailyCommand(session, message)
-	case inventoryCommand:
-		HandleInventoryCommand(session, message)
-	case equipCommand:
-		HandleEquipCommand(session, message, commandParts)
-	case unequipCommand:
-		HandleUnequipCommand(session, message)
-	case rerollCommand:
-		HandleFullRerollCommand(session, message)
-	case rerollStatCommand:
-		HandleStatRerollCommand(session, message, commandParts)
-	case rerollStatusCommand:
-		HandleRerollStatusCommand(session, message)
-	case deleteCommand:
-		HandleDeleteCharacterRequest(session, message)
-	case battleCommand:
-		combathandlers.HandleBattleCommand(session, message, commandParts)
-	default:
-		session.ChannelMessageSend(message.ChannelID, "Unknown command. Try `!cb help` for a list of commands.")
+	reason := loc.T(usageErr.Key, usageErr.Args)
+	session.ChannelMessageSend(message.ChannelID, loc.T("dispatch.usage", i18n.Args{
+		"reason":  strings.ToUpper(reason[:1]) + reason[1:],
+		"usage":   strings.Join(commandRegistry.Usage(usageErr.Path), ", "),
+		"command": commands.Name(usageErr.Path),
+	}))
+}
+
+// parseCommand splits a message into command parts if it starts with the default or the guild's prefix
+// Note: The first part is always the default prefix so handlers can index arguments the same way.
+func parseCommand(content string, guildPrefix string) ([]string, bool) {
+	for _, prefix := range []string{prefixCommand, guildPrefix} {
+		if prefix == "" || !strings.HasPrefix(content, prefix) {
+			continue
+		}
+		return append([]string{prefixCommand}, strings.Fields(strings.TrimPrefix(content, prefix))...), true
+	}
+
+	return nil, false
+}
+
+// commandCheck is one step of the dispatcher's middleware, it tells the user why a command was refused
+type commandCheck func(session *discordgo.Session, message *discordgo.MessageCreate, guildSettings settings.Settings, invocation commands.Invocation) bool
+
+// commandChecks run in order before every command
+var commandChecks = []commandCheck{
+	checkBanned,
+	checkGuildOnly,
+	checkPermission,
+	checkFeatures,
+	checkChannels,
+	checkRateLimit,
+}
+
+var (
+	// Shared cooldown buckets, built on the first command
+	commandLimiter     *ratelimit.Limiter
+	commandLimiterOnce sync.Once
+)
+
+// permissionNames are the catalogue keys describing who holds each permission
+var permissionNames = map[commands.Permission]string{
+	commands.PermissionStaff:        "dispatch.permission.staff",
+	commands.PermissionManageServer: "dispatch.permission.manage_server",
+}
+
+// commandAllowed runs a command through every check until one refuses it
+func commandAllowed(session *discordgo.Session, message *discordgo.MessageCreate, guildSettings settings.Settings, invocation commands.Invocation) bool {
+	for _, check := range commandChecks {
+		if !check(session, message, guildSettings, invocation) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkBanned refuses users banned in the guild, and in direct messages users banned in any guild
+func checkBanned(session *discordgo.Session, message *discordgo.MessageCreate, guildSettings settings.Settings, invocation commands.Invocation) bool {
+	if guildSettings.IsBanned(message.Author.ID) {
+		session.ChannelMessageSend(message.ChannelID, localizer(message).T("dispatch.banned"))
+		return false
+	}
+
+	if message.GuildID == "" {
+		banned, err := database.IsBannedInAnyGuild(database.DBInit(), message.Author.ID)
+		if err != nil {
+			fmt.Printf("Error checking bans: %v\n", err)
+		}
+		if banned {
+			session.ChannelMessageSend(message.ChannelID, localizer(message).T("dispatch.banned_dm"))
+			return false
+		}
+	}
+	return true
+}
+
+// checkGuildOnly refuses server commands sent in direct messages
+func checkGuildOnly(session *discordgo.Session, message *discordgo.MessageCreate, guildSettings settings.Settings, invocation commands.Invocation) bool {
+	if message.GuildID == "" && invocation.GuildOnly() {
+		session.ChannelMessageSend(message.ChannelID, localizer(message).T("dispatch.guild_only", i18n.Args{"command": commands.Name(invocation.Path)}))
+		return false
+	}
+	return true
+}
+
+// checkPermission refuses staff and server manager commands to everyone else
+func checkPermission(session *discordgo.Session, message *discordgo.MessageCreate, guildSettings settings.Settings, invocation commands.Invocation) bool {
+	allowed := true
+	switch invocation.Permission() {
+	case commands.PermissionStaff:
+		allowed = isStaff(session, message, guildSettings)
+	case commands.PermissionManageServer:
+		allowed = canManageServer(session, message)
+	}
+
+	if !allowed {
+		loc := localizer(message)
+		session.ChannelMessageSend(message.ChannelID, loc.T("dispatch.permission", i18n.Args{
+			"who":     loc.T(permissionNames[invocation.Permission()]),
+			"command": commands.Name(invocation.Path),
+		}))
+	}
+	return allowed
+}
+
+// checkFeatures refuses commands of features the guild switched off
+func checkFeatures(session *discordgo.Session, message *discordgo.MessageCreate, guildSettings settings.Settings, invocation commands.Invocation) bool {
+	for _, feature := range invocation.Features() {
+		if !guildSettings.Enabled(feature) {
+			session.ChannelMessageSend(message.ChannelID, localizer(message).T("dispatch.feature_off", i18n.Args{"feature": strings.ToUpper(feature[:1]) + feature[1:]}))
+			return false
+		}
+	}
+
+	// Ranked challenges are an argument of battle start
+	isRanked := func(arg string) bool { return strings.EqualFold(arg, "ranked") }
+	if invocation.Name() == battleCommand+" start" && slices.ContainsFunc(invocation.Parts[3:], isRanked) && !guildSettings.Enabled(settings.FeatureRanked) {
+		session.ChannelMessageSend(message.ChannelID, localizer(message).T("dispatch.ranked_off"))
+		return false
+	}
+
+	return true
+}
+
+// checkChannels refuses rolls and battles outside the guild's allowed channels
+func checkChannels(session *discordgo.Session, message *discordgo.MessageCreate, guildSettings settings.Settings, invocation commands.Invocation) bool {
+	switch invocation.Channel() {
+	case commands.ChannelRoll:
+		if !settings.AllowsChannel(guildSettings.RollChannels, message.ChannelID) {
+			loc := localizer(message)
+			session.ChannelMessageSend(message.ChannelID, loc.T("dispatch.roll_channels", i18n.Args{"channels": formatChannels(guildSettings.RollChannels, loc)}))
+			return false
+		}
+	case commands.ChannelBattle:
+		if !settings.AllowsChannel(guildSettings.BattleChannels, message.ChannelID) {
+			loc := localizer(message)
+			session.ChannelMessageSend(message.ChannelID, loc.T("dispatch.battle_channels", i18n.Args{"channels": formatChannels(guildSettings.BattleChannels, loc)}))
+			return false
+		}
+	}
+	return true
+}
+
+// checkSharedRateLimit refuses messages sent faster than the user and guild buckets allow
+func checkSharedRateLimit(session *discordgo.Session, message *discordgo.MessageCreate) bool {
+	return rateLimitAllowed(session, message, getCommandLimiter().AllowShared(message.Author.ID, message.GuildID, time.Now()))
+}
+
+// checkRateLimit refuses commands sent faster than their cooldown buckets allow
+func checkRateLimit(session *discordgo.Session, message *discordgo.MessageCreate, guildSettings settings.Settings, invocation commands.Invocation) bool {
+	return rateLimitAllowed(session, message, getCommandLimiter().AllowCommand(message.Author.ID, message.GuildID, invocation.Name(), time.Now()))
+}
+
+// rateLimitAllowed answers a refused rate limit decision
+// Note: Only the first refusal in a window is answered, so spamming doesn't make the bot spam back.
+func rateLimitAllowed(session *discordgo.Session, message *discordgo.MessageCreate, decision ratelimit.Decision) bool {
+	if decision.Allowed {
+		return true
+	}
+
+	if decision.FirstRefusal {
+		loc := localizer(message)
+		wait := ratelimit.RetrySeconds(decision.RetryAfter)
+		reply := loc.T("dispatch.slow_down", i18n.Args{"user": message.Author.ID, "seconds": wait})
+		if decision.Bucket.Scope == ratelimit.ScopeGuild {
+			reply = loc.T("dispatch.guild_busy", i18n.Args{"seconds": wait})
+		}
+		session.ChannelMessageSend(message.ChannelID, reply)
 	}
+	return false
 }
 
-// sendHelpMessage sends the help message with available commands
-func SendHelpMessage(session *discordgo.Session, channelID string) {
-	helpEmbed := &discordgo.MessageEmbed{
-		Title:       "CrispyBot Help",
-		Description: "Here are the commands you can use:",
-		Color:       0x00AAFF,
-		Fields: []*discordgo.MessageEmbedField{
-			{
-				Name:  "!cb help",
-				Value: "Shows this help message",
-			},
-			{
-				Name:  "!cb roll",
-				Value: "Rolls a new character for you",
-			},
-			{
-				Name:  "!cb stats",
-				Value: "Shows your character's stats",
-			},
-			{
-				Name:  "!cb shop",
-				Value: "Browse the item shop",
-			},
-			{
-				Name:  "!cb buy [item number]",
-				Value: "Buy an item from the shop",
-			},
-			{
-				Name:  "!cb wallet",
-				Value: "Check your currency balance",
-			},
-			{
-				Name:  "!cb daily",
-				Value: "Collect your daily currency reward",
-			},
-			{
-				Name:  "!cb inventory",
-				Value: "View your inventory of purchased items",
-			},
-			{
-				Name:  "!cb unequip",
-				Value: "Unequip your currently equipped item",
-			},
-			{
-				Name:  "!cb equip [item number]",
-				Value: "Equip an item from your inventory",
-			},
-			{
-				Name:  "!cb reroll",
-				Value: "Reroll your entire character (2 per day)",
-			},
-			{
-				Name:  "!cb rerollstat [stat name]",
-				Value: "Reroll a specific stat (1 per day)",
-			},
-			{
-				Name:  "!cb rerolls",
-				Value: "Check your remaining rerolls for the day",
-			},
-			{
-				Name:  "!cb delete",
-				Value: "Delete your current character (requires confirmation)",
-			},
-			{
-				Name:  "!cb battle start [opponent name/mention] [difficulty]",
-				Value: "Start a battle with an NPC or another player",
-			},
-			{
-				Name:  "!cb battle [action]",
-				Value: "Battle actions: attack, magic, defend, item, status, forfeit",
-			},
-		},
-		Footer: &discordgo.MessageEmbedFooter{
-			Text: "CrispyBot v1.0",
-		},
-	}
-
-	session.ChannelMessageSendEmbed(channelID, helpEmbed)
+// getCommandLimiter builds the limiter from the shared buckets, command cooldowns and any RATE_LIMITS overrides
+func getCommandLimiter() *ratelimit.Limiter {
+	commandLimiterOnce.Do(func() {
+		buckets := append(ratelimit.DefaultBuckets(), commandRegistry.Buckets()...)
+		if variables.Rate_limits != "" {
+			overrides, err := ratelimit.ParseBuckets(variables.Rate_limits)
+			if err != nil {
+				fmt.Printf("Ignoring RATE_LIMITS: %v\n", err)
+			} else {
+				buckets = ratelimit.Override(buckets, overrides)
+			}
+		}
+		commandLimiter = ratelimit.NewLimiter(database.NewRateLimitStore(database.DBInit()), buckets)
+	})
+	return commandLimiter
 }
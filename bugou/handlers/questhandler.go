@@ -0,0 +1,76 @@
+package bugouhandlers
+
+import (
+	"CrispyBot/achievements"
+	achievementhandlers "CrispyBot/bugou/achievementhandlers"
+	"CrispyBot/database"
+	"CrispyBot/database/models"
+	"CrispyBot/i18n"
+	"CrispyBot/quests"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// HandleQuestsCommand shows the user's daily and weekly quests with their progress
+func HandleQuestsCommand(session *discordgo.Session, message *discordgo.MessageCreate) {
+	loc := localizer(message)
+	log, err := database.GetQuestLog(database.DBInit(), message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	now := time.Now()
+	questEmbed := &discordgo.MessageEmbed{
+		Title: loc.T("quests.title", i18n.Args{"user": message.Author.Username}),
+		Color: 0x00AAFF,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:  loc.T("quests.daily", i18n.Args{"time": formatDuration(time.Until(quests.NextReset(quests.PeriodDaily, now)), loc)}),
+				Value: formatQuestSet(log.Daily, loc),
+			},
+			{
+				Name:  loc.T("quests.weekly", i18n.Args{"time": formatDuration(time.Until(quests.NextReset(quests.PeriodWeekly, now)), loc)}),
+				Value: formatQuestSet(log.Weekly, loc),
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: loc.T("quests.footer"),
+		},
+	}
+
+	session.ChannelMessageSendEmbed(message.ChannelID, questEmbed)
+}
+
+// formatQuestSet lists each quest with a progress bar and its reward
+func formatQuestSet(set models.QuestSet, loc i18n.Localizer) string {
+	if len(set.Quests) == 0 {
+		return loc.T("quests.none")
+	}
+
+	var lines []string
+	for _, quest := range set.Quests {
+		template, ok := quests.Find(quest.TemplateID)
+		if !ok {
+			continue
+		}
+
+		description := achievementhandlers.QuestDescription(template, loc)
+		if !quest.CompletedAt.IsZero() {
+			lines = append(lines, fmt.Sprintf("✅ ~~%s~~", description))
+			continue
+		}
+
+		progress := min(quest.Progress, template.Target)
+		lines = append(lines, fmt.Sprintf("**%s**\n%s %d/%d • %s",
+			description,
+			achievements.ProgressBar(progress, template.Target, 10),
+			progress, template.Target,
+			achievementhandlers.FormatReward(template.Reward, loc)))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,88 @@
+package bugouhandlers
+
+import (
+	"CrispyBot/database"
+	"CrispyBot/i18n"
+	"CrispyBot/ranked"
+	"CrispyBot/variables"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// HandleRankCommand shows the user's ranked standing for the current season
+func HandleRankCommand(session *discordgo.Session, message *discordgo.MessageCreate) {
+	// Get the database singleton
+	db := database.DBInit()
+	loc := localizer(message)
+
+	// Ratings belong to the character
+	character, err := database.GetCharacterByOwner(db, message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("rank.no_character"))
+		return
+	}
+
+	season, err := database.GetCurrentSeason(db)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	profile, err := database.GetRankedProfile(db, message.Author.ID, character.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	// Tier is hidden until placements are finished
+	tierText := loc.T("rank.unranked", i18n.Args{"played": profile.PlacementGames, "total": variables.RankedPlacementMatches})
+	if profile.PlacementGames >= variables.RankedPlacementMatches {
+		tier := ranked.GetTier(profile.Rating)
+		tierText = "**" + tier.Name + "**"
+
+		position, total, err := database.GetSeasonStanding(db, profile)
+		if err == nil {
+			tierText += "\n" + loc.T("rank.season_rank", i18n.Args{"position": position, "total": total})
+		}
+	}
+
+	// Show what the current tier pays at season end
+	reward := ranked.GetTier(profile.Rating)
+	rewardText := loc.T("common.coins", i18n.Args{"count": reward.SeasonCoins})
+	if reward.SeasonTokens > 0 {
+		rewardText += " + " + loc.T("common.reroll_tokens", i18n.Args{"count": reward.SeasonTokens})
+	}
+
+	rankEmbed := &discordgo.MessageEmbed{
+		Title:       loc.T("rank.title", i18n.Args{"user": message.Author.Username}),
+		Description: loc.T("rank.season_ends", i18n.Args{"season": season.Number, "time": formatDuration(time.Until(season.EndsAt), loc)}),
+		Color:       0x9B59B6,
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   loc.T("rank.rank"),
+				Value:  tierText,
+				Inline: true,
+			},
+			{
+				Name:   loc.T("rank.rating"),
+				Value:  loc.T("rank.rating_value", i18n.Args{"rating": profile.Rating, "peak": profile.PeakRating}),
+				Inline: true,
+			},
+			{
+				Name:   loc.T("rank.record"),
+				Value:  loc.T("rank.record_value", i18n.Args{"wins": profile.Wins, "losses": profile.Losses}),
+				Inline: true,
+			},
+			{
+				Name:  loc.T("rank.season_reward"),
+				Value: rewardText,
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: loc.T("rank.footer", i18n.Args{"days": variables.RankedDecayAfterDays}),
+		},
+	}
+
+	session.ChannelMessageSendEmbed(message.ChannelID, rankEmbed)
+}
@@ -1,10 +1,14 @@
 package bugouhandlers
 
 import (
+	"CrispyBot/achievements"
+	achievementhandlers "CrispyBot/bugou/achievementhandlers"
 	"CrispyBot/database"
 	"CrispyBot/database/models"
+	"CrispyBot/i18n"
 	"CrispyBot/roller"
 	"CrispyBot/variables"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -12,48 +16,87 @@ import (
 	"github.com/bwmarrin/discordgo"
 )
 
-// HandleFullRerollCommand completely rerolls a user's character
-func HandleFullRerollCommand(session *discordgo.Session, message *discordgo.MessageCreate) {
+// HandleFullRerollCommand rerolls a user's character, keeping any stats or characteristics locked with --lock
+func HandleFullRerollCommand(session *discordgo.Session, message *discordgo.MessageCreate, args []string) {
+	loc := localizer(message)
+	locks, err := parseRerollLocks(args[2:], loc)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("reroll.lock_usage", i18n.Args{"error": err}))
+		return
+	}
+
 	// Get the database singleton
 	db := database.DBInit()
 
+	// Check the locks are affordable before a reroll is used up
+	lockCost := roller.LockCost(len(locks))
+	if lockCost > 0 {
+		user, err := database.GetUserByID(db, message.Author.ID)
+		if err != nil {
+			session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+			return
+		}
+		if user.Wallet < lockCost {
+			session.ChannelMessageSend(message.ChannelID, loc.T("reroll.lock_too_expensive", i18n.Args{"locks": strings.Join(locks, ", "), "cost": lockCost, "wallet": user.Wallet}))
+			return
+		}
+	}
+
 	// Use one full reroll
-	remainingRerolls, err := database.UseFullReroll(db, message.Author.ID)
+	remainingRerolls, err := database.UseFullReroll(db, message.Author.ID, message.GuildID)
 	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("Reroll failed: %v", err))
+		session.ChannelMessageSend(message.ChannelID, loc.T("reroll.failed", i18n.Args{"error": err}))
 		return
 	}
 
-	// Delete existing character (if any)
-	_, err = database.GetCharacterByOwner(db, message.Author.ID)
-	if err == nil {
-		database.DeleteCharacter(db, message.Author.ID)
-	}
-
-	// Generate a new character
-	newCharacter := roller.GenerateCharacter(message.Author.ID)
-
-	// Save to the database
-	savedChar, err := database.SaveCharacter(db, newCharacter, message.Author.ID)
+	// Replace the active character (if any) with a new roll
+	savedChar, err := database.RerollCharacter(db, message.Author.ID, message.GuildID, locks)
 	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("Error generating character: %v", err))
+		session.ChannelMessageSend(message.ChannelID, loc.T("roll.failed", i18n.Args{"error": err}))
 		return
 	}
 
 	// Create an embed message with the character details
-	charEmbed := CreateCharacterEmbed(savedChar, message.Author)
+	charEmbed := CreateCharacterEmbed(savedChar, message.Author, loc)
 
 	// Add reroll info to the footer
-	charEmbed.Footer.Text = fmt.Sprintf("%s | Remaining full rerolls today: %d", charEmbed.Footer.Text, remainingRerolls)
+	charEmbed.Footer.Text += " | " + loc.T("reroll.footer", i18n.Args{"count": remainingRerolls})
+	if len(locks) > 0 {
+		charEmbed.Footer.Text += " | " + loc.T("reroll.footer_locks", i18n.Args{"locks": strings.Join(locks, ", "), "cost": lockCost})
+	}
 
 	session.ChannelMessageSendEmbed(message.ChannelID, charEmbed)
+
+	achievementhandlers.Track(session, message.ChannelID, message.Author.ID, achievements.RollEvent(savedChar, achievements.SourceReroll))
+}
+
+// parseRerollLocks reads the lock list from `--lock a,b` or `--lock=a,b`
+func parseRerollLocks(args []string, loc i18n.Localizer) ([]string, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	flag := strings.ToLower(args[0])
+	switch {
+	case flag == "--lock":
+		if len(args) < 2 {
+			return nil, errors.New(loc.T("reroll.lock_missing"))
+		}
+		return roller.ParseLocks(strings.Join(args[1:], ","))
+	case strings.HasPrefix(flag, "--lock="):
+		return roller.ParseLocks(strings.Join(append([]string{strings.TrimPrefix(flag, "--lock=")}, args[1:]...), ","))
+	default:
+		return nil, errors.New(loc.T("reroll.lock_unknown_option", i18n.Args{"option": args[0]}))
+	}
 }
 
 // HandleStatRerollCommand rerolls a single stat
 func HandleStatRerollCommand(session *discordgo.Session, message *discordgo.MessageCreate, args []string) {
+	loc := localizer(message)
+
 	// Check if stat type was specified
 	if len(args) < 3 {
-		session.ChannelMessageSend(message.ChannelID, "Please specify which stat to reroll. Usage: `!cb rerollstat [vitality|strength|speed|durability|intelligence|mana|mastery]`")
+		session.ChannelMessageSend(message.ChannelID, loc.T("rerollstat.missing"))
 		return
 	}
 
@@ -77,7 +120,7 @@ func HandleStatRerollCommand(session *discordgo.Session, message *discordgo.Mess
 	case "mastery":
 		statType = variables.Mastery
 	default:
-		session.ChannelMessageSend(message.ChannelID, "Invalid stat type. Valid stats are: vitality, strength, speed, durability, intelligence, mana, mastery")
+		session.ChannelMessageSend(message.ChannelID, loc.T("rerollstat.invalid"))
 		return
 	}
 
@@ -85,16 +128,16 @@ func HandleStatRerollCommand(session *discordgo.Session, message *discordgo.Mess
 	db := database.DBInit()
 
 	// Use a stat reroll
-	remainingRerolls, err := database.UseStatReroll(db, message.Author.ID)
+	remainingRerolls, err := database.UseStatReroll(db, message.Author.ID, message.GuildID)
 	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("Stat reroll failed: %v", err))
+		session.ChannelMessageSend(message.ChannelID, loc.T("rerollstat.failed", i18n.Args{"error": err}))
 		return
 	}
 
 	// Get the character before reroll for comparison
 	oldCharacter, err := database.GetCharacterByOwner(db, message.Author.ID)
 	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("Error: %v", err))
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
 		return
 	}
 
@@ -104,114 +147,145 @@ func HandleStatRerollCommand(session *discordgo.Session, message *discordgo.Mess
 	switch statType {
 	case variables.Vitality:
 		oldStat = oldCharacter.Stats.Vitality
-		oldStatName = "Vitality"
+		oldStatName = loc.T("stat.vitality")
 	case variables.Strength:
 		oldStat = oldCharacter.Stats.Strength
-		oldStatName = "Strength"
+		oldStatName = loc.T("stat.strength")
 	case variables.Speed:
 		oldStat = oldCharacter.Stats.Speed
-		oldStatName = "Speed"
+		oldStatName = loc.T("stat.speed")
 	case variables.Durability:
 		oldStat = oldCharacter.Stats.Durability
-		oldStatName = "Durability"
+		oldStatName = loc.T("stat.durability")
 	case variables.Intelligence:
 		oldStat = oldCharacter.Stats.Intelligence
-		oldStatName = "Intelligence"
+		oldStatName = loc.T("stat.intelligence")
 	case variables.Mana:
 		oldStat = oldCharacter.Stats.Mana
-		oldStatName = "Mana"
+		oldStatName = loc.T("stat.mana")
 	case variables.Mastery:
 		oldStat = oldCharacter.Stats.Mastery
-		oldStatName = "Mastery"
+		oldStatName = loc.T("stat.mastery")
 	}
 
 	// Reroll the stat
-	newStat, err := database.RerollSingleStat(db, message.Author.ID, statType)
+	newStat, err := database.RerollSingleStat(db, message.Author.ID, message.GuildID, statType)
 	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("Failed to reroll stat: %v", err))
+		session.ChannelMessageSend(message.ChannelID, loc.T("rerollstat.failed", i18n.Args{"error": err}))
 		return
 	}
 
 	// Get the updated character
 	updatedChar, err := database.GetCharacterByOwner(db, message.Author.ID)
 	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("Error: %v", err))
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
 		return
 	}
 
 	// Create a stat reroll embed
 	rerollEmbed := &discordgo.MessageEmbed{
-		Title:       "Stat Reroll Result",
-		Description: fmt.Sprintf("You rerolled your %s stat!", oldStatName),
+		Title:       loc.T("rerollstat.title"),
+		Description: loc.T("rerollstat.description", i18n.Args{"stat": oldStatName}),
 		Color:       0x00AAFF,
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "Before",
+				Name:   loc.T("rerollstat.before"),
 				Value:  fmt.Sprintf("%s: %d (%s) [%s]", oldStatName, oldStat.Value, oldStat.Stat_Name, oldStat.Rarity),
 				Inline: true,
 			},
 			{
-				Name:   "After",
+				Name:   loc.T("rerollstat.after"),
 				Value:  fmt.Sprintf("%s: %d (%s) [%s]", oldStatName, newStat.Value, newStat.Stat_Name, newStat.Rarity),
 				Inline: true,
 			},
 		},
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: fmt.Sprintf("Remaining stat rerolls today: %d", remainingRerolls),
+			Text: loc.T("rerollstat.footer", i18n.Args{"count": remainingRerolls}),
 		},
 	}
 
 	session.ChannelMessageSendEmbed(message.ChannelID, rerollEmbed)
 
 	// Also send the updated character sheet
-	charEmbed := CreateCharacterEmbed(updatedChar, message.Author)
+	charEmbed := CreateCharacterEmbed(updatedChar, message.Author, loc)
 	session.ChannelMessageSendEmbed(message.ChannelID, charEmbed)
+
+	achievementhandlers.Track(session, message.ChannelID, message.Author.ID, achievements.RollEvent(updatedChar, achievements.SourceRerollStat))
 }
 
 // HandleRerollStatusCommand shows remaining rerolls for the day
 func HandleRerollStatusCommand(session *discordgo.Session, message *discordgo.MessageCreate) {
 	// Get the database singleton
 	db := database.DBInit()
+	loc := localizer(message)
 
 	// Get the user
 	user, err := database.GetUserByID(db, message.Author.ID)
 	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("Error: %v", err))
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
 		return
 	}
 
 	// Get the shop (for timer)
 	shop, err := database.GetShop(db)
 	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("Error: %v", err))
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
 		return
 	}
 
+	// The allowance depends on the server
+	guildSettings := database.GetGuildSettings(db, message.GuildID)
+
 	// Create reroll status embed
 	rerollEmbed := &discordgo.MessageEmbed{
-		Title:       "🎲 Reroll Status",
-		Description: "Your daily reroll allowance",
+		Title:       loc.T("rerolls.title"),
+		Description: loc.T("rerolls.description"),
 		Color:       0x9B59B6,
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:   "Full Character Rerolls",
-				Value:  fmt.Sprintf("%d/2 remaining", user.FullRerolls),
+				Name:   loc.T("rerolls.full"),
+				Value:  loc.T("rerolls.remaining", i18n.Args{"left": guildSettings.FullRerollsLeft(user.FullRerolls), "total": guildSettings.FullRerolls + user.Prestige.BonusRerolls}),
+				Inline: true,
+			},
+			{
+				Name:   loc.T("rerolls.stat"),
+				Value:  loc.T("rerolls.remaining", i18n.Args{"left": guildSettings.StatRerollsLeft(user.StatRerolls), "total": guildSettings.StatRerolls}),
 				Inline: true,
 			},
 			{
-				Name:   "Single Stat Rerolls",
-				Value:  fmt.Sprintf("%d/1 remaining", user.StatRerolls),
+				Name:   loc.T("rerolls.tokens"),
+				Value:  loc.T("rerolls.tokens_available", i18n.Args{"count": user.RerollTokens}),
 				Inline: true,
 			},
 			{
-				Name:  "Refresh Time",
-				Value: fmt.Sprintf("Rerolls refresh in %s", formatDuration(time.Until(shop.Timer))),
+				Name:  loc.T("rerolls.pity"),
+				Value: formatPity(user.Pity, loc),
+			},
+			{
+				Name:  loc.T("rerolls.refresh"),
+				Value: loc.T("rerolls.refresh_in", i18n.Args{"duration": formatDuration(time.Until(shop.Timer), loc)}),
 			},
 		},
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: "Use !cb reroll to reroll your entire character or !cb rerollstat [stat] to reroll a specific stat",
+			Text: loc.T("rerolls.footer"),
 		},
 	}
 
 	session.ChannelMessageSendEmbed(message.ChannelID, rerollEmbed)
 }
+
+// formatPity shows how close each pity category is to a guaranteed Epic
+func formatPity(counters map[string]int, loc i18n.Localizer) string {
+	lines := ""
+	for _, category := range roller.PityCategories {
+		rule := roller.PityRules[category]
+		misses := counters[category]
+
+		line := fmt.Sprintf("%s: **%d/%d**", strings.ToUpper(category[:1])+category[1:], misses, rule.HardLimit)
+		if misses >= rule.SoftStart {
+			line += " " + loc.T("rerolls.pity_boosted")
+		}
+		lines += line + "\n"
+	}
+	return lines
+}
@@ -0,0 +1,127 @@
+package bugouhandlers
+
+import (
+	combathandlers "CrispyBot/bugou/combathandlers"
+	"CrispyBot/database"
+	"CrispyBot/i18n"
+	"CrispyBot/variables"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// HandleCharactersCommand lists every character in the user's roster
+func HandleCharactersCommand(session *discordgo.Session, message *discordgo.MessageCreate) {
+	db := database.DBInit()
+	loc := localizer(message)
+
+	characters, err := database.GetCharactersByOwner(db, message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+	if len(characters) == 0 {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.no_character"))
+		return
+	}
+
+	active, err := database.GetCharacterByOwner(db, message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	_, slots, err := database.GetCharacterSlots(db, message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
+		return
+	}
+
+	var lines strings.Builder
+	for i, character := range characters {
+		marker := "▫️"
+		if character.ID == active.ID {
+			marker = "▶️"
+		}
+
+		weapon := loc.T("roster.no_weapon")
+		if character.EquippedWeapon.ItemName != "" {
+			weapon = character.EquippedWeapon.ItemName
+		}
+
+		lines.WriteString(loc.T("roster.line", i18n.Args{
+			"marker":  marker,
+			"number":  i + 1,
+			"level":   character.Level,
+			"element": character.Characteristics.Element.Trait_Name,
+			"race":    character.Characteristics.Race.Trait_Name,
+			"weapon":  weapon,
+		}) + "\n")
+	}
+
+	rosterEmbed := &discordgo.MessageEmbed{
+		Title:       loc.T("roster.title", i18n.Args{"user": message.Author.Username}),
+		Description: lines.String(),
+		Color:       0xFF5500,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: loc.T("roster.footer", i18n.Args{"used": len(characters), "slots": slots}),
+		},
+	}
+
+	session.ChannelMessageSendEmbed(message.ChannelID, rosterEmbed)
+}
+
+// HandleSwitchCommand changes the user's active character
+func HandleSwitchCommand(session *discordgo.Session, message *discordgo.MessageCreate, args []string) {
+	loc := localizer(message)
+	if len(args) < 3 {
+		session.ChannelMessageSend(message.ChannelID, loc.T("switch.usage"))
+		return
+	}
+
+	position, err := strconv.Atoi(args[2])
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("switch.invalid_number"))
+		return
+	}
+
+	// The active character is locked in while it's fighting or queued
+	if combathandlers.IsPlayerInBattle(message.Author.ID) {
+		session.ChannelMessageSend(message.ChannelID, loc.T("switch.in_battle"))
+		return
+	}
+
+	db := database.DBInit()
+
+	if _, err := database.GetActiveDungeonRun(db, message.Author.ID); err == nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("switch.in_dungeon"))
+		return
+	}
+	if _, err := database.GetQueueEntry(db, message.Author.ID); err == nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("switch.queued"))
+		return
+	}
+
+	character, err := database.SetActiveCharacter(db, message.Author.ID, position)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("switch.failed", i18n.Args{"error": err}))
+		return
+	}
+
+	charEmbed := CreateCharacterEmbed(character, message.Author, loc)
+	charEmbed.Footer.Text = loc.T("switch.footer", i18n.Args{"footer": charEmbed.Footer.Text})
+	session.ChannelMessageSendEmbed(message.ChannelID, charEmbed)
+}
+
+// buyCharacterSlot handles `!cb buy slot`
+func buyCharacterSlot(session *discordgo.Session, message *discordgo.MessageCreate) {
+	loc := localizer(message)
+	slots, err := database.BuyCharacterSlot(database.DBInit(), message.Author.ID)
+	if err != nil {
+		session.ChannelMessageSend(message.ChannelID, loc.T("buy.failed", i18n.Args{"error": err}))
+		return
+	}
+
+	session.ChannelMessageSend(message.ChannelID, loc.T("buy.slot", i18n.Args{"price": variables.CharacterSlotPrice, "count": slots}))
+}
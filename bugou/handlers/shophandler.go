@@ -1,9 +1,15 @@
 package bugouhandlers
 
 import (
+	"CrispyBot/achievements"
+	achievementhandlers "CrispyBot/bugou/achievementhandlers"
 	"CrispyBot/database"
+	"CrispyBot/i18n"
+	"CrispyBot/shop"
+	"CrispyBot/variables"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
@@ -13,11 +19,12 @@ import (
 func HandleShopCommand(session *discordgo.Session, message *discordgo.MessageCreate) {
 	// Get the database singleton
 	db := database.DBInit()
+	loc := localizer(message)
 
 	// Get the current shop
 	shop, err := database.GetShop(db)
 	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("Error accessing the shop: %v", err))
+		session.ChannelMessageSend(message.ChannelID, loc.T("shop.error", i18n.Args{"error": err}))
 		return
 	}
 
@@ -29,28 +36,34 @@ func HandleShopCommand(session *discordgo.Session, message *discordgo.MessageCre
 
 	// Create an embed message with the shop details
 	shopEmbed := &discordgo.MessageEmbed{
-		Title:       "🛒 Item Shop",
-		Description: fmt.Sprintf("The shop will refresh in %s", formatDuration(time.Until(shop.Timer))),
+		Title:       loc.T("shop.title"),
+		Description: loc.T("shop.refresh", i18n.Args{"time": formatDuration(time.Until(shop.Timer), loc)}),
 		Color:       0x00AAFF,
 		Fields:      []*discordgo.MessageEmbedField{},
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: "Use !cb buy [number] to purchase an item",
+			Text: loc.T("shop.footer"),
 		},
 	}
 
 	// Add items to the embed
 	if len(shop.Inventory.Items) == 0 {
 		shopEmbed.Fields = append(shopEmbed.Fields, &discordgo.MessageEmbedField{
-			Name:  "No Items Available",
-			Value: "The shop is currently empty. Check back after it refreshes.",
+			Name:  loc.T("shop.empty"),
+			Value: loc.T("shop.empty_detail"),
 		})
 	} else {
+		// Guilds can show fewer or more of the generated stock
+		shopSize := database.GetGuildSettings(db, message.GuildID).ShopSize
 		for idx, item := range shop.Inventory.Items {
+			if idx >= shopSize {
+				continue
+			}
+
 			// Format item stats
-			statsText := formatItemStats(item.Stats)
+			statsText := formatItemStats(item.Stats, loc)
 
 			itemField := &discordgo.MessageEmbedField{
-				Name:  fmt.Sprintf("%d. %s (%s) - %d coins", idx, item.Name, item.Rarity, item.Price),
+				Name:  loc.T("shop.item", i18n.Args{"number": idx, "name": item.Name, "rarity": item.Rarity, "price": item.Price}),
 				Value: statsText,
 			}
 			shopEmbed.Fields = append(shopEmbed.Fields, itemField)
@@ -62,16 +75,35 @@ func HandleShopCommand(session *discordgo.Session, message *discordgo.MessageCre
 
 // HandleBuyCommand processes a purchase from the shop
 func HandleBuyCommand(session *discordgo.Session, message *discordgo.MessageCreate, args []string) {
+	loc := localizer(message)
+
 	// Check if the user provided an item number
 	if len(args) < 3 {
-		session.ChannelMessageSend(message.ChannelID, "Please specify an item number to buy. Usage: `!cb buy [number]`")
+		session.ChannelMessageSend(message.ChannelID, loc.T("buy.usage"))
+		return
+	}
+
+	// Character slots are a permanent upgrade rather than stock
+	if strings.ToLower(args[2]) == "slot" {
+		buyCharacterSlot(session, message)
+		return
+	}
+
+	// Respec tokens aren't part of the rotating stock
+	if strings.ToLower(args[2]) == "respec" {
+		tokens, err := database.BuyRespecToken(database.DBInit(), message.Author.ID)
+		if err != nil {
+			session.ChannelMessageSend(message.ChannelID, loc.T("buy.failed", i18n.Args{"error": err}))
+			return
+		}
+		session.ChannelMessageSend(message.ChannelID, loc.T("buy.respec", i18n.Args{"price": variables.RespecTokenPrice, "count": tokens}))
 		return
 	}
 
 	// Parse the item number
 	itemIdx, err := strconv.Atoi(args[2])
 	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, "Invalid item number. Please provide a valid number.")
+		session.ChannelMessageSend(message.ChannelID, loc.T("buy.invalid_number"))
 		return
 	}
 
@@ -79,50 +111,57 @@ func HandleBuyCommand(session *discordgo.Session, message *discordgo.MessageCrea
 	db := database.DBInit()
 
 	// Process the purchase
-	item, err := database.BuyItem(db, message.Author.ID, itemIdx)
+	item, err := database.BuyItem(db, message.Author.ID, message.GuildID, itemIdx)
 	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("Purchase failed: %v", err))
+		session.ChannelMessageSend(message.ChannelID, loc.T("buy.failed", i18n.Args{"error": err}))
 		return
 	}
 
 	// Format item stats
-	statsText := formatItemStats(item.Stats)
+	statsText := formatItemStats(item.Stats, loc)
 
 	// Create a purchase confirmation embed
 	purchaseEmbed := &discordgo.MessageEmbed{
-		Title:       "Purchase Successful",
-		Description: fmt.Sprintf("You bought **%s** for **%d** coins!", item.Name, item.Price),
+		Title:       loc.T("buy.title"),
+		Description: loc.T("buy.bought", i18n.Args{"name": item.Name, "price": item.Price}),
 		Color:       0x00FF00,
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:  "Item Details",
-				Value: fmt.Sprintf("**Rarity:** %s\n%s", item.Rarity, statsText),
+				Name:  loc.T("buy.details"),
+				Value: loc.T("buy.details_value", i18n.Args{"rarity": item.Rarity, "stats": statsText}),
 			},
 		},
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: "Type !cb wallet to check your remaining balance",
+			Text: loc.T("buy.footer"),
 		},
 	}
 
 	session.ChannelMessageSendEmbed(message.ChannelID, purchaseEmbed)
+
+	// Check the balance left for spending achievements
+	user, err := database.GetUserByID(db, message.Author.ID)
+	if err == nil {
+		achievementhandlers.Track(session, message.ChannelID, message.Author.ID, achievements.PurchaseEvent(item.Rarity, user.Wallet))
+	}
 }
 
 // HandleWalletCommand shows a user's currency balance
 func HandleWalletCommand(session *discordgo.Session, message *discordgo.MessageCreate) {
 	// Get the database singleton
 	db := database.DBInit()
+	loc := localizer(message)
 
 	// Initialize wallet if needed
 	err := database.InitializeUserWallet(db, message.Author.ID, 500)
 	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("Error: %v", err))
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
 		return
 	}
 
 	// Get user info
 	user, err := database.GetUserByID(db, message.Author.ID)
 	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("Error: %v", err))
+		session.ChannelMessageSend(message.ChannelID, loc.T("common.error", i18n.Args{"error": err}))
 		return
 	}
 
@@ -134,17 +173,17 @@ func HandleWalletCommand(session *discordgo.Session, message *discordgo.MessageC
 
 	// Create a wallet embed
 	walletEmbed := &discordgo.MessageEmbed{
-		Title:       "💰 Your Wallet",
-		Description: fmt.Sprintf("**Balance:** %d coins", user.Wallet),
+		Title:       loc.T("wallet.title"),
+		Description: loc.T("wallet.balance", i18n.Args{"count": user.Wallet}),
 		Color:       0xFFD700,
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:  "Inventory",
-				Value: fmt.Sprintf("You have %d items in your inventory", inventoryCount),
+				Name:  loc.T("wallet.inventory"),
+				Value: loc.T("wallet.inventory_count", i18n.Args{"count": inventoryCount}),
 			},
 		},
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: "Use !cb daily to earn daily rewards",
+			Text: loc.T("wallet.footer"),
 		},
 	}
 
@@ -155,59 +194,94 @@ func HandleWalletCommand(session *discordgo.Session, message *discordgo.MessageC
 func HandleDailyCommand(session *discordgo.Session, message *discordgo.MessageCreate) {
 	// Get the database singleton
 	db := database.DBInit()
+	loc := localizer(message)
 
-	// TODO: Implement daily reward cooldown
-	// For now, just give 100 coins every time
-	newBalance, err := database.AddCurrency(db, message.Author.ID, 100)
+	// Claim today's reward
+	reward, err := database.ClaimDailyReward(db, message.Author.ID, message.GuildID)
 	if err != nil {
-		session.ChannelMessageSend(message.ChannelID, fmt.Sprintf("Error: %v", err))
+		session.ChannelMessageSend(message.ChannelID, loc.T("daily.failed", i18n.Args{
+			"error": err,
+			"time":  formatDuration(time.Until(shop.NextRefreshTime(time.Now())), loc),
+		}))
 		return
 	}
 
+	// Describe the streak
+	streakText := loc.T("daily.streak", i18n.Args{"count": reward.Streak})
+	if reward.StreakReset {
+		streakText += loc.T("daily.streak_reset")
+	}
+
 	// Create a reward embed
 	rewardEmbed := &discordgo.MessageEmbed{
-		Title:       "Daily Reward",
-		Description: "You received **100** coins!",
+		Title:       loc.T("daily.title"),
+		Description: loc.T("daily.received", i18n.Args{"count": reward.Coins}),
 		Color:       0xFFD700,
 		Fields: []*discordgo.MessageEmbedField{
 			{
-				Name:  "New Balance",
-				Value: fmt.Sprintf("%d coins", newBalance),
+				Name:  loc.T("daily.streak_field"),
+				Value: streakText,
+			},
+			{
+				Name:  loc.T("daily.balance"),
+				Value: loc.T("common.coins", i18n.Args{"count": reward.NewBalance}),
 			},
 		},
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: "Come back tomorrow for more rewards",
+			Text: loc.T("daily.footer", i18n.Args{"time": formatDuration(time.Until(shop.NextRefreshTime(time.Now())), loc)}),
 		},
 	}
 
+	// Show milestone rewards if one was reached
+	if reward.Milestone != nil {
+		milestoneText := ""
+		if reward.Milestone.RerollTokens > 0 {
+			milestoneText += loc.T("daily.milestone_tokens", i18n.Args{"count": reward.Milestone.RerollTokens}) + "\n"
+		}
+		if reward.Item != nil {
+			milestoneText += fmt.Sprintf("• **%s** (%s)\n%s", reward.Item.Name, reward.Item.Rarity, formatItemStats(reward.Item.Stats, loc))
+			if reward.ItemMailed {
+				milestoneText += "\n" + loc.T("common.item_mailed")
+			}
+		}
+
+		rewardEmbed.Fields = append(rewardEmbed.Fields, &discordgo.MessageEmbedField{
+			Name:  loc.T("daily.milestone", i18n.Args{"day": reward.Milestone.Day}),
+			Value: milestoneText,
+		})
+	}
+
 	session.ChannelMessageSendEmbed(message.ChannelID, rewardEmbed)
+
+	achievementhandlers.Track(session, message.ChannelID, message.Author.ID, achievements.ValueEvent(achievements.EventDailyClaimed, reward.Streak))
 }
 
 // Helper function to format item stats
-func formatItemStats(stats map[string]int) string {
+func formatItemStats(stats map[string]int, loc i18n.Localizer) string {
 	if len(stats) == 0 {
-		return "No stat bonuses"
+		return loc.T("item.no_stats")
 	}
 
 	var statsText string
 	for stat, value := range stats {
-		if value > 0 {
-			statsText += fmt.Sprintf("• +%d to %s\n", value, stat)
-		} else {
-			statsText += fmt.Sprintf("• %d to %s\n", value, stat)
-		}
+		statsText += formatStatModifier(value, stat, loc) + "\n"
 	}
 
 	return statsText
 }
 
 // Helper function to format duration until shop refresh
-func formatDuration(d time.Duration) string {
+func formatDuration(d time.Duration, loc i18n.Localizer) string {
 	hours := int(d.Hours())
 	minutes := int(d.Minutes()) % 60
 
 	if hours > 0 {
-		return fmt.Sprintf("%d hours and %d minutes", hours, minutes)
+		return loc.T("duration.hours_minutes", i18n.Args{"hours": hours, "minutes": minutes})
 	}
-	return fmt.Sprintf("%d minutes", minutes)
+	return loc.T("duration.minutes", i18n.Args{"minutes": minutes})
+}
+
+// formatStatModifier describes a signed bonus or penalty to a stat as a bullet
+func formatStatModifier(value int, stat string, loc i18n.Localizer) string {
+	return loc.T("item.stat_modifier", i18n.Args{"value": fmt.Sprintf("%+d", value), "stat": stat})
 }
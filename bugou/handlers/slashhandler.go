@@ -0,0 +1,120 @@
+package bugouhandlers
+
+import (
+	"CrispyBot/commands"
+	"CrispyBot/database"
+	"CrispyBot/i18n"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// discordLocales are the Discord client languages each of our locales covers
+var discordLocales = map[string][]discordgo.Locale{
+	i18n.Spanish: {discordgo.SpanishES, discordgo.SpanishLATAM},
+}
+
+// RegisterSlashCommands publishes a slash command for every registry command, replacing any removed ones
+func RegisterSlashCommands(session *discordgo.Session) error {
+	slashCommands := commandRegistry.SlashCommands()
+	for _, slashCommand := range slashCommands {
+		path, _ := commandRegistry.Lookup([]string{slashCommand.Name})
+		localizations := localizeDescriptions(path, "")
+		slashCommand.DescriptionLocalizations = &localizations
+		localizeOptions(path, slashCommand.Options)
+	}
+
+	_, err := session.ApplicationCommandBulkOverwrite(session.State.User.ID, "", slashCommands)
+	if err != nil {
+		return fmt.Errorf("failed to register slash commands: %w", err)
+	}
+	return nil
+}
+
+// localizeOptions adds the translated descriptions of a command's subcommands and arguments
+func localizeOptions(path []*commands.Command, options []*discordgo.ApplicationCommandOption) {
+	command := path[len(path)-1]
+	for _, option := range options {
+		for _, subcommand := range command.Subcommands {
+			if subcommand.Name == option.Name {
+				subpath := append(append([]*commands.Command{}, path...), subcommand)
+				option.DescriptionLocalizations = localizeDescriptions(subpath, "")
+				localizeOptions(subpath, option.Options)
+			}
+		}
+		for _, arg := range command.Args {
+			if arg.Name == option.Name {
+				option.DescriptionLocalizations = localizeDescriptions(path, arg.Name)
+			}
+		}
+	}
+}
+
+// localizeDescriptions translates a command's description, or one of its arguments', for every Discord language we cover
+func localizeDescriptions(path []*commands.Command, argName string) map[discordgo.Locale]string {
+	key := commandKey(path)
+	if argName != "" {
+		key += ":" + argName
+	}
+
+	localizations := map[discordgo.Locale]string{}
+	for code, discordCodes := range discordLocales {
+		text, ok := i18n.New(code).Lookup(key)
+		if !ok {
+			continue
+		}
+		for _, discordCode := range discordCodes {
+			localizations[discordCode] = text
+		}
+	}
+	return localizations
+}
+
+// InteractionCreate runs slash commands through the same parser, checks and handlers as prefixed messages
+// Note: Button presses are left to the handlers that sent the buttons.
+func InteractionCreate(session *discordgo.Session, interaction *discordgo.InteractionCreate) {
+	if interaction.Type != discordgo.InteractionApplicationCommand {
+		return
+	}
+
+	// Guild interactions carry the member, direct messages only the user
+	author := interaction.User
+	member := interaction.Member
+	if member != nil {
+		author = member.User
+		member.GuildID = interaction.GuildID
+	}
+	loc := database.GetLocalizer(database.DBInit(), author.ID, interaction.GuildID)
+
+	parts, err := commandRegistry.FromSlash(interaction.ApplicationCommandData())
+	if err != nil {
+		session.InteractionRespond(interaction.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Content: loc.T("slash.failed", i18n.Args{"error": err}),
+				Flags:   discordgo.MessageFlagsEphemeral,
+			},
+		})
+		return
+	}
+
+	content := strings.Join(parts, " ")
+	session.InteractionRespond(interaction.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: loc.T("slash.used", i18n.Args{"user": author.ID, "command": content}),
+		},
+	})
+
+	MessageCreate(session, &discordgo.MessageCreate{
+		Message: &discordgo.Message{
+			ID:        interaction.ID,
+			ChannelID: interaction.ChannelID,
+			GuildID:   interaction.GuildID,
+			Author:    author,
+			Member:    member,
+			Content:   content,
+		},
+	})
+}
@@ -1,6 +1,7 @@
 package bugou
 
 import (
+	combathandlers "CrispyBot/bugou/combathandlers"
 	bugouhandlers "CrispyBot/bugou/handlers"
 	"CrispyBot/variables"
 	"fmt"
@@ -16,8 +17,9 @@ func StartBot() {
 		log.Fatalf("error creating Discord session: %v", err)
 	}
 
-	// Register message handler
+	// Register message and slash command handlers
 	session.AddHandler(bugouhandlers.MessageCreate)
+	session.AddHandler(bugouhandlers.InteractionCreate)
 	session.Identify.Intents = discordgo.IntentGuildMessages
 
 	// Open websocket connection to Discord
@@ -27,6 +29,20 @@ func StartBot() {
 	}
 	defer session.Close()
 
+	// Publish the slash commands built from the command registry
+	if err := bugouhandlers.RegisterSlashCommands(session); err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	// Start pairing players from the matchmaking queue
+	combathandlers.StartMatchmaker(session)
+
+	// Resolve tournament no-shows and advance brackets
+	combathandlers.StartTournamentScheduler(session)
+
+	// Score clan wars once their time runs out
+	combathandlers.StartClanWarScheduler(session)
+
 	fmt.Println("Discord bot is now running. Press CTRL+C to exit.")
 	select {}
 }
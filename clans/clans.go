@@ -0,0 +1,90 @@
+package clans
+
+import (
+	"CrispyBot/database/models"
+	"CrispyBot/variables"
+	"fmt"
+	"strings"
+)
+
+// Member roles, highest first
+const (
+	RoleLeader  = "leader"
+	RoleOfficer = "officer"
+	RoleMember  = "member"
+)
+
+// Rank orders roles so permissions can compare them, higher is more senior
+func Rank(role string) int {
+	switch role {
+	case RoleLeader:
+		return 3
+	case RoleOfficer:
+		return 2
+	case RoleMember:
+		return 1
+	}
+	return 0
+}
+
+// CanInvite checks if a role may invite new members
+func CanInvite(role string) bool {
+	return Rank(role) >= Rank(RoleOfficer)
+}
+
+// CanWithdraw checks if a role may take coins out of the clan bank
+func CanWithdraw(role string) bool {
+	return Rank(role) >= Rank(RoleOfficer)
+}
+
+// CanDeclareWar checks if a role may declare or accept clan wars
+func CanDeclareWar(role string) bool {
+	return Rank(role) >= Rank(RoleOfficer)
+}
+
+// CanKick checks if the actor outranks the target
+func CanKick(actorRole string, targetRole string) bool {
+	return Rank(actorRole) >= Rank(RoleOfficer) && Rank(actorRole) > Rank(targetRole)
+}
+
+// ParseRole reads a role name for promotions, rejecting unknown roles
+func ParseRole(name string) (string, error) {
+	role := strings.ToLower(name)
+	if Rank(role) == 0 {
+		return "", fmt.Errorf("unknown role %q (use leader, officer or member)", name)
+	}
+	return role, nil
+}
+
+// ValidateName checks a clan name is usable
+func ValidateName(name string) error {
+	if len(name) < variables.ClanNameMinLength || len(name) > variables.ClanNameMaxLength {
+		return fmt.Errorf("clan names must be %d to %d characters", variables.ClanNameMinLength, variables.ClanNameMaxLength)
+	}
+	for _, r := range name {
+		if !(r == ' ' || r == '-' || r == '\'' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return fmt.Errorf("clan names may only use letters, numbers, spaces, dashes and apostrophes")
+		}
+	}
+	return nil
+}
+
+// NameKey normalizes a clan name for case-insensitive lookups
+func NameKey(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// FindMember looks up a player in the clan's member list
+func FindMember(clan models.Clan, discordID string) (models.ClanMember, bool) {
+	for _, member := range clan.Members {
+		if member.DiscordID == discordID {
+			return member, true
+		}
+	}
+	return models.ClanMember{}, false
+}
+
+// Level returns the clan's level from its experience
+func Level(clan models.Clan) int {
+	return LevelForExperience(clan.Experience)
+}
@@ -0,0 +1,84 @@
+package clans
+
+import (
+	"CrispyBot/variables"
+	"testing"
+)
+
+func TestPermissions(t *testing.T) {
+	if CanInvite(RoleMember) || !CanInvite(RoleOfficer) {
+		t.Errorf("Only officers and above should invite")
+	}
+	if CanWithdraw(RoleMember) || !CanWithdraw(RoleLeader) {
+		t.Errorf("Only officers and above should withdraw")
+	}
+	if CanKick(RoleOfficer, RoleOfficer) || !CanKick(RoleOfficer, RoleMember) {
+		t.Errorf("Officers should only kick members")
+	}
+	if CanKick(RoleMember, RoleMember) || !CanKick(RoleLeader, RoleOfficer) {
+		t.Errorf("Leaders should kick officers and members should kick nobody")
+	}
+}
+
+func TestLevelForExperience(t *testing.T) {
+	tests := []struct {
+		experience int
+		level      int
+	}{
+		{0, 1},
+		{variables.ClanXPPerLevel - 1, 1},
+		{variables.ClanXPPerLevel, 2},
+		{ExperienceForLevel(5), 5},
+		{ExperienceForLevel(5) - 1, 4},
+		{ExperienceForLevel(variables.ClanMaxLevel) * 10, variables.ClanMaxLevel},
+	}
+
+	for _, test := range tests {
+		if level := LevelForExperience(test.experience); level != test.level {
+			t.Errorf("LevelForExperience(%d) = %d, want %d", test.experience, level, test.level)
+		}
+	}
+}
+
+func TestPerksReplaceLowerTiers(t *testing.T) {
+	if ShopDiscountPercent(1) != 0 || XPBoostPercent(1) != 0 {
+		t.Errorf("Level 1 clans should have no perks")
+	}
+	if ShopDiscountPercent(6) != 10 {
+		t.Errorf("Expected the 10%% discount at level 6, got %d", ShopDiscountPercent(6))
+	}
+	if XPBoostPercent(10) != 15 {
+		t.Errorf("Expected the 15%% boost at level 10, got %d", XPBoostPercent(10))
+	}
+
+	if price := ApplyDiscount(200, 10); price != 180 {
+		t.Errorf("Expected 180, got %d", price)
+	}
+	if price := ApplyDiscount(1, 15); price != 1 {
+		t.Errorf("Discounted price should not drop below 1, got %d", price)
+	}
+	if xp := ApplyBoost(100, 5); xp != 105 {
+		t.Errorf("Expected 105, got %d", xp)
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	if err := ValidateName("Iron Wolves"); err != nil {
+		t.Errorf("Expected a valid name, got %v", err)
+	}
+	if err := ValidateName("ab"); err == nil {
+		t.Errorf("Expected short names to be rejected")
+	}
+	if err := ValidateName("<@everyone>"); err == nil {
+		t.Errorf("Expected mentions to be rejected")
+	}
+	if NameKey("  Iron   WOLVES ") != "iron wolves" {
+		t.Errorf("Unexpected name key %q", NameKey("  Iron   WOLVES "))
+	}
+}
+
+func TestWarWinner(t *testing.T) {
+	if WarWinner("a", 3, "b", 1) != "a" || WarWinner("a", 1, "b", 2) != "b" || WarWinner("a", 2, "b", 2) != "" {
+		t.Errorf("Unexpected war winner")
+	}
+}
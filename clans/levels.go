@@ -0,0 +1,81 @@
+package clans
+
+import "CrispyBot/variables"
+
+// Perk is a passive bonus unlocked at a clan level
+type Perk struct {
+	Level               int
+	Name                string
+	Description         string
+	ShopDiscountPercent int
+	XPBoostPercent      int
+}
+
+// Perks lists every clan perk in unlock order
+// Note: Higher tiers replace lower ones rather than stacking.
+var Perks = []Perk{
+	{Level: 2, Name: "Bargain Hunters", Description: "5% off shop purchases", ShopDiscountPercent: 5},
+	{Level: 3, Name: "Battle Drills", Description: "5% more battle XP", XPBoostPercent: 5},
+	{Level: 5, Name: "Trade Network", Description: "10% off shop purchases", ShopDiscountPercent: 10},
+	{Level: 7, Name: "War College", Description: "10% more battle XP", XPBoostPercent: 10},
+	{Level: 10, Name: "Legendary Guild", Description: "15% off shop purchases and 15% more battle XP", ShopDiscountPercent: 15, XPBoostPercent: 15},
+}
+
+// ExperienceForLevel returns the total clan XP needed to reach a level
+func ExperienceForLevel(level int) int {
+	if level <= 1 {
+		return 0
+	}
+	return variables.ClanXPPerLevel * (level - 1) * level / 2
+}
+
+// LevelForExperience returns the clan level for a total amount of XP
+func LevelForExperience(experience int) int {
+	level := 1
+	for level < variables.ClanMaxLevel && experience >= ExperienceForLevel(level+1) {
+		level++
+	}
+	return level
+}
+
+// UnlockedPerks returns the perks a clan of the given level has
+func UnlockedPerks(level int) []Perk {
+	var unlocked []Perk
+	for _, perk := range Perks {
+		if perk.Level <= level {
+			unlocked = append(unlocked, perk)
+		}
+	}
+	return unlocked
+}
+
+// ShopDiscountPercent returns the best shop discount unlocked at a level
+func ShopDiscountPercent(level int) int {
+	best := 0
+	for _, perk := range UnlockedPerks(level) {
+		best = max(best, perk.ShopDiscountPercent)
+	}
+	return best
+}
+
+// XPBoostPercent returns the best XP boost unlocked at a level
+func XPBoostPercent(level int) int {
+	best := 0
+	for _, perk := range UnlockedPerks(level) {
+		best = max(best, perk.XPBoostPercent)
+	}
+	return best
+}
+
+// ApplyDiscount reduces a price by a percentage, never below 1 coin
+func ApplyDiscount(price int, percent int) int {
+	if percent <= 0 {
+		return price
+	}
+	return max(1, price*(100-percent)/100)
+}
+
+// ApplyBoost increases an amount by a percentage
+func ApplyBoost(amount int, percent int) int {
+	return amount + amount*percent/100
+}
@@ -0,0 +1,19 @@
+package clans
+
+// War states
+const (
+	WarPending  = "pending"
+	WarActive   = "active"
+	WarFinished = "finished"
+)
+
+// WarWinner returns the winning side's clan ID, or "" for a draw
+func WarWinner(attackerID string, attackerScore int, defenderID string, defenderScore int) string {
+	switch {
+	case attackerScore > defenderScore:
+		return attackerID
+	case defenderScore > attackerScore:
+		return defenderID
+	}
+	return ""
+}
@@ -0,0 +1,299 @@
+package commands
+
+import (
+	"CrispyBot/ratelimit"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Argument types
+const (
+	ArgString  = "string"  // One word
+	ArgInteger = "integer" // A whole number
+	ArgUser    = "user"    // A user mention
+	ArgChoice  = "choice"  // One of a fixed list of words
+	ArgText    = "text"    // Everything left on the line. Note: Only the last argument can be text.
+)
+
+// Permission a member needs to run a command
+type Permission int
+
+// Permissions from least to most restricted
+const (
+	PermissionEveryone Permission = iota
+	PermissionStaff
+	PermissionManageServer
+)
+
+// Channel restrictions a guild can configure
+const (
+	ChannelRoll   = "roll"
+	ChannelBattle = "battle"
+)
+
+// Slash command limits set by Discord
+const (
+	slashNameMaxLength        = 32
+	slashDescriptionMaxLength = 100
+	slashMaxOptions           = 25
+	slashMaxDepth             = 3
+)
+
+// Handler runs a command with the message split into words
+// Note: args[0] is the prefix and args[1] the command, so handlers index arguments from 2.
+type Handler func(session *discordgo.Session, message *discordgo.MessageCreate, args []string)
+
+var (
+	// Valid command and argument names, also what Discord accepts for slash commands
+	namePattern = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+	// User mention with or without the nickname marker
+	userMention = regexp.MustCompile(`^<@!?(\d+)>$`)
+)
+
+// Arg
+/*
+	Name - Lowercase name shown in usage and as the slash command option.
+	Type - One of the argument types.
+	Description - What the argument is for.
+	Required - True if the command can't run without it.
+	Choices - Accepted words for a choice argument. Note: Matched without case.
+	Min - Smallest integer accepted. Note: Only checked when Max is above Min.
+	Max - Largest integer accepted.
+*/
+type Arg struct {
+	Name        string
+	Type        string
+	Description string
+	Required    bool
+	Choices     []string
+	Min         int
+	Max         int
+}
+
+// Command
+/*
+	Name - What users type after the prefix, or after the parent command.
+	Aliases - Other names that run the command.
+	Category - Help section the command is listed in. Note: Top level commands only.
+	Description - One line shown in help and on the slash command.
+	Args - Arguments of a command without subcommands.
+	Subcommands - Commands nested under this one.
+	Default - Subcommand run when nothing follows its parent, like `queue` showing the status.
+	Implicit - Subcommand run when the next word isn't a subcommand. Note: Its name is never typed, so it also can't be matched by name.
+	Permission - Who can run it, inherited by subcommands.
+	Feature - Guild feature that must be switched on.
+	Channel - Guild channel list the command is limited to.
+	Cooldown - Time a user waits between uses. Note: Covers its subcommands too.
+	GuildOnly - True if it can't be used in direct messages.
+	Run - Handler of a top level command, subcommands are handled by their top level command.
+*/
+type Command struct {
+	Name        string
+	Aliases     []string
+	Category    string
+	Description string
+	Args        []Arg
+	Subcommands []*Command
+	Default     bool
+	Implicit    bool
+	Permission  Permission
+	Feature     string
+	Channel     string
+	Cooldown    time.Duration
+	GuildOnly   bool
+	Run         Handler
+}
+
+// Registry holds every command of the bot
+type Registry struct {
+	prefix   string
+	commands []*Command
+}
+
+// New checks the command definitions and builds a registry
+func New(prefix string, commands []*Command) (*Registry, error) {
+	registry := &Registry{prefix: prefix, commands: commands}
+
+	names := map[string]bool{}
+	for _, command := range commands {
+		if command.Run == nil {
+			return nil, fmt.Errorf("%s has no handler", command.Name)
+		}
+		if command.Category == "" {
+			return nil, fmt.Errorf("%s has no help category", command.Name)
+		}
+		for _, name := range append([]string{command.Name}, command.Aliases...) {
+			if names[name] {
+				return nil, fmt.Errorf("%s is used by two commands", name)
+			}
+			names[name] = true
+		}
+		if err := validate(command, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	return registry, nil
+}
+
+// MustNew is New for definitions fixed at compile time
+func MustNew(prefix string, commands []*Command) *Registry {
+	registry, err := New(prefix, commands)
+	if err != nil {
+		panic(fmt.Sprintf("invalid command definitions: %v", err))
+	}
+	return registry
+}
+
+// validate checks a command fits the parser and Discord's slash command limits
+func validate(command *Command, depth int) error {
+	if !namePattern.MatchString(command.Name) || len(command.Name) > slashNameMaxLength {
+		return fmt.Errorf("%q is not a valid command name", command.Name)
+	}
+	if command.Description == "" || len(command.Description) > slashDescriptionMaxLength {
+		return fmt.Errorf("%s needs a description of 1 to %d characters", command.Name, slashDescriptionMaxLength)
+	}
+	if len(command.Args) > 0 && len(command.Subcommands) > 0 {
+		return fmt.Errorf("%s can't have both arguments and subcommands", command.Name)
+	}
+	if len(command.Subcommands) > 0 && depth >= slashMaxDepth {
+		return fmt.Errorf("%s nests subcommands too deep", command.Name)
+	}
+	if len(command.Args) > slashMaxOptions || len(command.Subcommands) > slashMaxOptions {
+		return fmt.Errorf("%s has more than %d arguments or subcommands", command.Name, slashMaxOptions)
+	}
+
+	optional := false
+	for i, arg := range command.Args {
+		if !namePattern.MatchString(arg.Name) || len(arg.Name) > slashNameMaxLength {
+			return fmt.Errorf("%s has an invalid argument name %q", command.Name, arg.Name)
+		}
+		if arg.Description == "" || len(arg.Description) > slashDescriptionMaxLength {
+			return fmt.Errorf("%s %s needs a description of 1 to %d characters", command.Name, arg.Name, slashDescriptionMaxLength)
+		}
+		if arg.Required && optional {
+			return fmt.Errorf("%s %s is required after an optional argument", command.Name, arg.Name)
+		}
+		optional = optional || !arg.Required
+		if arg.Type == ArgText && i != len(command.Args)-1 {
+			return fmt.Errorf("%s %s takes the rest of the line so it must be last", command.Name, arg.Name)
+		}
+		if arg.Type == ArgChoice && (len(arg.Choices) == 0 || len(arg.Choices) > slashMaxOptions) {
+			return fmt.Errorf("%s %s needs 1 to %d choices", command.Name, arg.Name, slashMaxOptions)
+		}
+	}
+
+	names := map[string]bool{}
+	defaults, implicits := 0, 0
+	for _, subcommand := range command.Subcommands {
+		if subcommand.Default {
+			defaults++
+		}
+		if subcommand.Implicit {
+			implicits++
+			if len(subcommand.Subcommands) > 0 {
+				return fmt.Errorf("%s %s is implicit so it can't have subcommands", command.Name, subcommand.Name)
+			}
+		}
+		for _, name := range append([]string{subcommand.Name}, subcommand.Aliases...) {
+			if names[name] {
+				return fmt.Errorf("%s %s is used by two subcommands", command.Name, name)
+			}
+			names[name] = true
+		}
+		if err := validate(subcommand, depth+1); err != nil {
+			return err
+		}
+	}
+	if defaults > 1 || implicits > 1 {
+		return fmt.Errorf("%s has more than one default or implicit subcommand", command.Name)
+	}
+
+	return nil
+}
+
+// Prefix returns the prefix shown in usage
+func (registry *Registry) Prefix() string {
+	return registry.prefix
+}
+
+// Commands returns the top level commands in definition order
+func (registry *Registry) Commands() []*Command {
+	return registry.commands
+}
+
+// Categories returns the help categories in the order they first appear
+func (registry *Registry) Categories() []string {
+	categories := []string{}
+	for _, command := range registry.commands {
+		if !slices.Contains(categories, command.Category) {
+			categories = append(categories, command.Category)
+		}
+	}
+	return categories
+}
+
+// Find looks up a top level command by name or alias
+func (registry *Registry) Find(name string) (*Command, bool) {
+	return find(registry.commands, name)
+}
+
+// Lookup walks command and subcommand names, like `clan war`, as far as they match
+func (registry *Registry) Lookup(names []string) ([]*Command, bool) {
+	if len(names) == 0 {
+		return nil, false
+	}
+
+	command, ok := registry.Find(names[0])
+	if !ok {
+		return nil, false
+	}
+
+	path := []*Command{command}
+	for _, name := range names[1:] {
+		subcommand, ok := find(command.Subcommands, name)
+		if !ok {
+			break
+		}
+		path = append(path, subcommand)
+		command = subcommand
+	}
+	return path, true
+}
+
+// find matches a name or alias among commands, skipping implicit ones
+func find(commands []*Command, name string) (*Command, bool) {
+	name = strings.ToLower(name)
+	for _, command := range commands {
+		if command.Implicit {
+			continue
+		}
+		if command.Name == name || slices.Contains(command.Aliases, name) {
+			return command, true
+		}
+	}
+	return nil, false
+}
+
+// Buckets turns command cooldowns into rate limit buckets named by command path
+func (registry *Registry) Buckets() []ratelimit.Bucket {
+	buckets := []ratelimit.Bucket{}
+	var walk func(commands []*Command, parent string)
+	walk = func(commands []*Command, parent string) {
+		for _, command := range commands {
+			name := strings.TrimSpace(parent + " " + command.Name)
+			if command.Cooldown > 0 {
+				buckets = append(buckets, ratelimit.Bucket{Name: name, Scope: ratelimit.ScopeCommand, Limit: 1, Window: command.Cooldown})
+			}
+			walk(command.Subcommands, name)
+		}
+	}
+	walk(registry.commands, "")
+	return buckets
+}
@@ -0,0 +1,271 @@
+package commands
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func noop(*discordgo.Session, *discordgo.MessageCreate, []string) {}
+
+func newTestRegistry(t *testing.T) *Registry {
+	registry, err := New("!cb", []*Command{
+		{Name: "roll", Aliases: []string{"r"}, Category: "Rolls", Description: "Rolls a character", Cooldown: 3 * time.Second, Run: noop},
+		{
+			Name: "allocate", Category: "Characters", Description: "Spends stat points", Run: noop,
+			Subcommands: []*Command{
+				{Name: "auto", Description: "Toggles auto growth", Args: []Arg{
+					{Name: "state", Type: ArgChoice, Description: "On or off", Required: true, Choices: []string{"on", "off"}},
+				}},
+				{Name: "spend", Description: "Spends points", Implicit: true, Args: []Arg{
+					{Name: "stat", Type: ArgString, Description: "Stat to raise"},
+					{Name: "points", Type: ArgInteger, Description: "Points to spend", Min: 1, Max: 99},
+				}},
+			},
+		},
+		{
+			Name: "clan", Category: "Social", Description: "Clan commands", Feature: "clans", Run: noop,
+			Subcommands: []*Command{
+				{Name: "info", Description: "Shows a clan", Default: true, Args: []Arg{
+					{Name: "name", Type: ArgText, Description: "Clan name"},
+				}},
+				{Name: "kick", Description: "Kicks a member", Permission: PermissionStaff, Args: []Arg{
+					{Name: "member", Type: ArgUser, Description: "Member to kick", Required: true},
+				}},
+				{Name: "war", Description: "Clan wars", Subcommands: []*Command{
+					{Name: "status", Description: "Shows the war", Default: true},
+					{Name: "fight", Description: "Fights a member", Cooldown: time.Minute, Args: []Arg{
+						{Name: "opponent", Type: ArgUser, Description: "Who to fight", Required: true},
+					}},
+				}},
+			},
+		},
+		{
+			Name: "leaderboard", Aliases: []string{"lb"}, Category: "Social", Description: "Shows the top players", Run: noop,
+			Args: []Arg{
+				{Name: "category", Type: ArgChoice, Description: "What to rank by", Choices: []string{"level", "wins"}},
+				{Name: "scope", Type: ArgChoice, Description: "Where to rank", Choices: []string{"global"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error building the registry: %v", err)
+	}
+	return registry
+}
+
+func TestParse(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	cases := []struct {
+		words []string
+		path  string
+		parts []string
+		args  map[string]string
+	}{
+		{[]string{"!cb", "R"}, "roll", []string{"!cb", "roll"}, map[string]string{}},
+		{[]string{"!cb", "allocate"}, "allocate spend", []string{"!cb", "allocate"}, map[string]string{}},
+		{[]string{"!cb", "allocate", "str", "3"}, "allocate spend", []string{"!cb", "allocate", "str", "3"}, map[string]string{"stat": "str", "points": "3"}},
+		{[]string{"!cb", "allocate", "auto", "ON"}, "allocate auto", []string{"!cb", "allocate", "auto", "ON"}, map[string]string{"state": "on"}},
+		{[]string{"!cb", "clan"}, "clan info", []string{"!cb", "clan"}, map[string]string{}},
+		{[]string{"!cb", "clan", "info", "Red", "Dragons"}, "clan info", []string{"!cb", "clan", "info", "Red", "Dragons"}, map[string]string{"name": "Red Dragons"}},
+		{[]string{"!cb", "clan", "kick", "<@!42>"}, "clan kick", []string{"!cb", "clan", "kick", "<@!42>"}, map[string]string{"member": "42"}},
+		{[]string{"!cb", "clan", "war"}, "clan war status", []string{"!cb", "clan", "war"}, map[string]string{}},
+		{[]string{"!cb", "lb", "global"}, "leaderboard", []string{"!cb", "leaderboard", "global"}, map[string]string{"scope": "global"}},
+		{[]string{"!cb", "lb", "wins", "global"}, "leaderboard", []string{"!cb", "leaderboard", "wins", "global"}, map[string]string{"category": "wins", "scope": "global"}},
+	}
+
+	for _, c := range cases {
+		invocation, err := registry.Parse(c.words)
+		if err != nil {
+			t.Errorf("Parse(%v) failed: %v", c.words, err)
+			continue
+		}
+		if invocation.Name() != c.path || !reflect.DeepEqual(invocation.Parts, c.parts) || !reflect.DeepEqual(invocation.Args, c.args) {
+			t.Errorf("Parse(%v) = %s %v %v, expected %s %v %v", c.words, invocation.Name(), invocation.Parts, invocation.Args, c.path, c.parts, c.args)
+		}
+	}
+}
+
+func TestParse_UsageErrors(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	cases := map[string]string{
+		"!cb dance":                   "unknown command",
+		"!cb roll again":              "unexpected again",
+		"!cb allocate str 0":          "points must be from 1 to 99",
+		"!cb allocate str lots":       "points must be a whole number",
+		"!cb allocate auto":           "missing state",
+		"!cb allocate auto maybe":     "state must be one of on, off",
+		"!cb clan kick bob":           "member must be a mention",
+		"!cb clan war surrender":      "unknown subcommand surrender, choose one of status, fight",
+		"!cb leaderboard coins":       "category must be one of level, wins",
+		"!cb leaderboard wins wins":   "scope must be one of global",
+		"!cb leaderboard global wins": "unexpected wins",
+		"!cb clan war fight @nobody":  "opponent must be a mention",
+	}
+
+	for words, expected := range cases {
+		_, err := registry.Parse(strings.Fields(words))
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Parse(%q) error = %v, expected it to contain %q", words, err, expected)
+		}
+	}
+
+	_, err := registry.Parse(strings.Fields("!cb clan war fight"))
+	usageErr, ok := err.(*UsageError)
+	if !ok || Name(usageErr.Path) != "clan war fight" {
+		t.Errorf("Expected the usage error to carry the matched path, got %v", err)
+	}
+}
+
+func TestInvocation_Restrictions(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	invocation, _ := registry.Parse(strings.Fields("!cb clan kick <@1>"))
+	if invocation.Permission() != PermissionStaff || !reflect.DeepEqual(invocation.Features(), []string{"clans"}) {
+		t.Errorf("Expected kick to need staff and clans, got %v %v", invocation.Permission(), invocation.Features())
+	}
+
+	invocation, _ = registry.Parse(strings.Fields("!cb clan info"))
+	if invocation.Permission() != PermissionEveryone {
+		t.Errorf("Expected clan info to be open to everyone")
+	}
+}
+
+func TestNew_RejectsInvalidDefinitions(t *testing.T) {
+	cases := map[string]*Command{
+		"no handler":       {Name: "roll", Category: "Rolls", Description: "Rolls"},
+		"bad name":         {Name: "Roll Now", Category: "Rolls", Description: "Rolls", Run: noop},
+		"long description": {Name: "roll", Category: "Rolls", Description: strings.Repeat("a", 101), Run: noop},
+		"required after optional": {Name: "roll", Category: "Rolls", Description: "Rolls", Run: noop, Args: []Arg{
+			{Name: "a", Type: ArgString, Description: "A"},
+			{Name: "b", Type: ArgString, Description: "B", Required: true},
+		}},
+		"text not last": {Name: "roll", Category: "Rolls", Description: "Rolls", Run: noop, Args: []Arg{
+			{Name: "a", Type: ArgText, Description: "A"},
+			{Name: "b", Type: ArgString, Description: "B"},
+		}},
+		"args and subcommands": {Name: "roll", Category: "Rolls", Description: "Rolls", Run: noop,
+			Args:        []Arg{{Name: "a", Type: ArgString, Description: "A"}},
+			Subcommands: []*Command{{Name: "b", Description: "B"}},
+		},
+		"too deep": {Name: "a", Category: "Rolls", Description: "A", Run: noop, Subcommands: []*Command{
+			{Name: "b", Description: "B", Subcommands: []*Command{
+				{Name: "c", Description: "C", Subcommands: []*Command{{Name: "d", Description: "D"}}},
+			}},
+		}},
+	}
+
+	for name, command := range cases {
+		if _, err := New("!cb", []*Command{command}); err == nil {
+			t.Errorf("Expected %s to be rejected", name)
+		}
+	}
+
+	roll := &Command{Name: "roll", Category: "Rolls", Description: "Rolls", Run: noop}
+	if _, err := New("!cb", []*Command{roll, {Name: "reroll", Aliases: []string{"roll"}, Category: "Rolls", Description: "Rerolls", Run: noop}}); err == nil {
+		t.Errorf("Expected a clashing alias to be rejected")
+	}
+}
+
+func TestUsage(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	path, _ := registry.Lookup([]string{"allocate"})
+	expected := []string{"`!cb allocate auto <on|off>`", "`!cb allocate [stat] [points]`"}
+	if got := registry.Usage(path); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Usage(allocate) = %v, expected %v", got, expected)
+	}
+
+	path, _ = registry.Lookup([]string{"clan", "war", "fight"})
+	if got := registry.Usage(path); !reflect.DeepEqual(got, []string{"`!cb clan war fight <opponent>`"}) {
+		t.Errorf("Unexpected usage %v", got)
+	}
+	if leaves := Leaves(path[:1]); len(leaves) != 4 {
+		t.Errorf("Expected clan to lead to 4 runnable commands, got %d", len(leaves))
+	}
+}
+
+func TestBuckets(t *testing.T) {
+	buckets := newTestRegistry(t).Buckets()
+
+	names := []string{}
+	for _, bucket := range buckets {
+		names = append(names, bucket.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"roll", "clan war fight"}) {
+		t.Errorf("Expected buckets for roll and clan war fight, got %v", names)
+	}
+}
+
+func TestSlashCommands(t *testing.T) {
+	slashCommands := newTestRegistry(t).SlashCommands()
+	if len(slashCommands) != 4 {
+		t.Fatalf("Expected 4 slash commands, got %d", len(slashCommands))
+	}
+
+	clan := slashCommands[2]
+	war := clan.Options[2]
+	if war.Type != discordgo.ApplicationCommandOptionSubCommandGroup || war.Options[1].Type != discordgo.ApplicationCommandOptionSubCommand {
+		t.Errorf("Expected clan war to be a subcommand group, got %v", war.Type)
+	}
+	if opponent := war.Options[1].Options[0]; opponent.Type != discordgo.ApplicationCommandOptionUser || !opponent.Required {
+		t.Errorf("Expected the opponent to be a required user option, got %+v", opponent)
+	}
+
+	points := slashCommands[1].Options[1].Options[1]
+	if points.Type != discordgo.ApplicationCommandOptionInteger || *points.MinValue != 1 || points.MaxValue != 99 {
+		t.Errorf("Expected points to be a bounded integer option, got %+v", points)
+	}
+}
+
+func TestFromSlash(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	option := func(name string, optionType discordgo.ApplicationCommandOptionType, value interface{}, options ...*discordgo.ApplicationCommandInteractionDataOption) *discordgo.ApplicationCommandInteractionDataOption {
+		return &discordgo.ApplicationCommandInteractionDataOption{Name: name, Type: optionType, Value: value, Options: options}
+	}
+
+	cases := []struct {
+		data     discordgo.ApplicationCommandInteractionData
+		expected string
+	}{
+		{discordgo.ApplicationCommandInteractionData{Name: "roll"}, "!cb roll"},
+		{discordgo.ApplicationCommandInteractionData{Name: "allocate", Options: []*discordgo.ApplicationCommandInteractionDataOption{
+			option("spend", discordgo.ApplicationCommandOptionSubCommand, nil,
+				option("stat", discordgo.ApplicationCommandOptionString, "mana"),
+				option("points", discordgo.ApplicationCommandOptionInteger, float64(2))),
+		}}, "!cb allocate mana 2"},
+		{discordgo.ApplicationCommandInteractionData{Name: "clan", Options: []*discordgo.ApplicationCommandInteractionDataOption{
+			option("war", discordgo.ApplicationCommandOptionSubCommandGroup, nil,
+				option("fight", discordgo.ApplicationCommandOptionSubCommand, nil,
+					option("opponent", discordgo.ApplicationCommandOptionUser, "42"))),
+		}}, "!cb clan war fight <@42>"},
+		{discordgo.ApplicationCommandInteractionData{Name: "leaderboard", Options: []*discordgo.ApplicationCommandInteractionDataOption{
+			option("scope", discordgo.ApplicationCommandOptionString, "global"),
+		}}, "!cb leaderboard level global"},
+	}
+
+	for _, c := range cases {
+		parts, err := registry.FromSlash(c.data)
+		if err != nil || strings.Join(parts, " ") != c.expected {
+			t.Errorf("FromSlash(%s) = %v (%v), expected %q", c.data.Name, parts, err, c.expected)
+			continue
+		}
+		if _, err := registry.Parse(parts); err != nil {
+			t.Errorf("Expected %q to parse, got %v", c.expected, err)
+		}
+	}
+
+	_, err := registry.FromSlash(discordgo.ApplicationCommandInteractionData{Name: "allocate", Options: []*discordgo.ApplicationCommandInteractionDataOption{
+		option("spend", discordgo.ApplicationCommandOptionSubCommand, nil,
+			option("points", discordgo.ApplicationCommandOptionInteger, float64(2))),
+	}})
+	if err == nil {
+		t.Errorf("Expected points without a stat to be refused")
+	}
+}
@@ -0,0 +1,196 @@
+package commands
+
+import (
+	"CrispyBot/i18n"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Invocation
+/*
+	Path - The command followed by each subcommand matched, top level first.
+	Args - Argument values by name. Note: Users are stored as their ID.
+	Parts - The message words with the prefix and command names made canonical, the shape handlers expect.
+*/
+type Invocation struct {
+	Path  []*Command
+	Args  map[string]string
+	Parts []string
+}
+
+// UsageError explains why a message doesn't fit a command
+/*
+	Path - The command as far as it was matched. Note: Empty for unknown commands.
+	Key - Catalogue key of what was wrong with it.
+	Args - Values for the message's placeholders.
+*/
+type UsageError struct {
+	Path []*Command
+	Key  string
+	Args i18n.Args
+}
+
+func (err *UsageError) Error() string {
+	return i18n.New(i18n.Default).T(err.Key, err.Args)
+}
+
+// usageError builds the reason for a usage error, the path is filled in by Parse
+func usageError(key string, args i18n.Args) *UsageError {
+	return &UsageError{Key: key, Args: args}
+}
+
+// Parse matches message words, starting with the prefix, against the registry and checks the arguments
+func (registry *Registry) Parse(parts []string) (Invocation, error) {
+	if len(parts) < 2 {
+		return Invocation{}, usageError("usage.missing_command", nil)
+	}
+
+	command, ok := registry.Find(parts[1])
+	if !ok {
+		return Invocation{}, usageError("usage.unknown_command", i18n.Args{"command": parts[1]})
+	}
+
+	invocation := Invocation{
+		Path:  []*Command{command},
+		Args:  map[string]string{},
+		Parts: []string{registry.prefix, command.Name},
+	}
+	tokens := parts[2:]
+
+	for len(command.Subcommands) > 0 {
+		subcommand, consumed := pickSubcommand(command, tokens)
+		if subcommand == nil {
+			err := usageError("usage.choose_subcommand", i18n.Args{"choices": strings.Join(subcommandNames(command), ", ")})
+			if len(tokens) > 0 {
+				err = usageError("usage.unknown_subcommand", i18n.Args{"word": tokens[0], "choices": strings.Join(subcommandNames(command), ", ")})
+			}
+			err.Path = invocation.Path
+			return Invocation{}, err
+		}
+
+		if consumed {
+			invocation.Parts = append(invocation.Parts, subcommand.Name)
+			tokens = tokens[1:]
+		}
+		invocation.Path = append(invocation.Path, subcommand)
+		command = subcommand
+	}
+
+	if err := parseArgs(command, tokens, invocation.Args); err != nil {
+		err.Path = invocation.Path
+		return Invocation{}, err
+	}
+
+	invocation.Parts = append(invocation.Parts, tokens...)
+	return invocation, nil
+}
+
+// pickSubcommand chooses the subcommand for the next word and whether that word was its name
+func pickSubcommand(command *Command, tokens []string) (*Command, bool) {
+	if len(tokens) > 0 {
+		if subcommand, ok := find(command.Subcommands, tokens[0]); ok {
+			return subcommand, true
+		}
+	}
+
+	var fallback *Command
+	for _, subcommand := range command.Subcommands {
+		if subcommand.Default && len(tokens) == 0 {
+			return subcommand, false
+		}
+		if subcommand.Implicit {
+			fallback = subcommand
+		}
+	}
+	return fallback, false
+}
+
+// subcommandNames lists the subcommands that can be typed
+func subcommandNames(command *Command) []string {
+	names := []string{}
+	for _, subcommand := range command.Subcommands {
+		if !subcommand.Implicit {
+			names = append(names, subcommand.Name)
+		}
+	}
+	return names
+}
+
+// parseArgs checks words against a command's arguments and stores their values
+// Note: An optional choice that doesn't match is skipped, so the word can fill the next argument.
+func parseArgs(command *Command, tokens []string, values map[string]string) *UsageError {
+	next := 0
+
+	// The first skipped choice explains a word that fits nothing better than later arguments do
+	var skipped *UsageError
+	skippedAt := -1
+	explain := func(err *UsageError) *UsageError {
+		if skipped != nil && skippedAt == next {
+			return skipped
+		}
+		return err
+	}
+
+	for i, arg := range command.Args {
+		if next >= len(tokens) {
+			if arg.Required {
+				return usageError("usage.missing_arg", i18n.Args{"arg": arg.Name})
+			}
+			continue
+		}
+
+		if arg.Type == ArgText {
+			values[arg.Name] = strings.Join(tokens[next:], " ")
+			next = len(tokens)
+			break
+		}
+
+		value, err := parseArg(arg, tokens[next])
+		if err != nil {
+			if arg.Type == ArgChoice && !arg.Required && i < len(command.Args)-1 {
+				if skippedAt != next {
+					skipped, skippedAt = err, next
+				}
+				continue
+			}
+			return explain(err)
+		}
+		values[arg.Name] = value
+		next++
+	}
+
+	if next < len(tokens) {
+		return explain(usageError("usage.unexpected", i18n.Args{"word": tokens[next]}))
+	}
+	return nil
+}
+
+// parseArg checks one word against its argument type
+func parseArg(arg Arg, token string) (string, *UsageError) {
+	switch arg.Type {
+	case ArgInteger:
+		number, err := strconv.Atoi(token)
+		if err != nil {
+			return "", usageError("usage.not_integer", i18n.Args{"arg": arg.Name})
+		}
+		if arg.Max > arg.Min && (number < arg.Min || number > arg.Max) {
+			return "", usageError("usage.out_of_range", i18n.Args{"arg": arg.Name, "min": arg.Min, "max": arg.Max})
+		}
+		return token, nil
+	case ArgUser:
+		match := userMention.FindStringSubmatch(token)
+		if match == nil {
+			return "", usageError("usage.not_user", i18n.Args{"arg": arg.Name})
+		}
+		return match[1], nil
+	case ArgChoice:
+		index := slices.IndexFunc(arg.Choices, func(choice string) bool { return strings.EqualFold(choice, token) })
+		if index < 0 {
+			return "", usageError("usage.not_choice", i18n.Args{"arg": arg.Name, "choices": strings.Join(arg.Choices, ", ")})
+		}
+		return arg.Choices[index], nil
+	}
+
+	return token, nil
+}
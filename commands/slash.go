@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// SlashCommands builds the slash command of every top level command
+func (registry *Registry) SlashCommands() []*discordgo.ApplicationCommand {
+	slashCommands := []*discordgo.ApplicationCommand{}
+	for _, command := range registry.commands {
+		allowDMs := !command.GuildOnly
+		slashCommands = append(slashCommands, &discordgo.ApplicationCommand{
+			Name:         command.Name,
+			Description:  command.Description,
+			Options:      slashOptions(command),
+			DMPermission: &allowDMs,
+		})
+	}
+	return slashCommands
+}
+
+// slashOptions turns a command's subcommands or arguments into slash command options
+func slashOptions(command *Command) []*discordgo.ApplicationCommandOption {
+	options := []*discordgo.ApplicationCommandOption{}
+	for _, subcommand := range command.Subcommands {
+		optionType := discordgo.ApplicationCommandOptionSubCommand
+		if len(subcommand.Subcommands) > 0 {
+			optionType = discordgo.ApplicationCommandOptionSubCommandGroup
+		}
+		options = append(options, &discordgo.ApplicationCommandOption{
+			Type:        optionType,
+			Name:        subcommand.Name,
+			Description: subcommand.Description,
+			Options:     slashOptions(subcommand),
+		})
+	}
+
+	for _, arg := range command.Args {
+		option := &discordgo.ApplicationCommandOption{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        arg.Name,
+			Description: arg.Description,
+			Required:    arg.Required,
+		}
+
+		switch arg.Type {
+		case ArgInteger:
+			option.Type = discordgo.ApplicationCommandOptionInteger
+			if arg.Max > arg.Min {
+				minimum := float64(arg.Min)
+				option.MinValue = &minimum
+				option.MaxValue = float64(arg.Max)
+			}
+		case ArgUser:
+			option.Type = discordgo.ApplicationCommandOptionUser
+		case ArgChoice:
+			for _, choice := range arg.Choices {
+				option.Choices = append(option.Choices, &discordgo.ApplicationCommandOptionChoice{Name: choice, Value: choice})
+			}
+		}
+		options = append(options, option)
+	}
+
+	return options
+}
+
+// FromSlash rebuilds the message words a slash command stands for, so it runs through the same parser and handlers
+// Note: An optional choice left out before a filled in argument takes its first choice, since words are positional.
+func (registry *Registry) FromSlash(data discordgo.ApplicationCommandInteractionData) ([]string, error) {
+	command, ok := registry.Find(data.Name)
+	if !ok {
+		return nil, fmt.Errorf("unknown command %s", data.Name)
+	}
+
+	parts := []string{registry.prefix, command.Name}
+	options := data.Options
+	for len(command.Subcommands) > 0 {
+		if len(options) != 1 {
+			return nil, fmt.Errorf("%s needs a subcommand", command.Name)
+		}
+
+		var subcommand *Command
+		for _, candidate := range command.Subcommands {
+			if candidate.Name == options[0].Name {
+				subcommand = candidate
+			}
+		}
+		if subcommand == nil {
+			return nil, fmt.Errorf("unknown subcommand %s", options[0].Name)
+		}
+
+		if !subcommand.Implicit {
+			parts = append(parts, subcommand.Name)
+		}
+		options = options[0].Options
+		command = subcommand
+	}
+
+	values := map[string]string{}
+	for _, option := range options {
+		switch value := option.Value.(type) {
+		case string:
+			values[option.Name] = value
+			if option.Type == discordgo.ApplicationCommandOptionUser {
+				values[option.Name] = fmt.Sprintf("<@%s>", value)
+			}
+		case float64:
+			values[option.Name] = fmt.Sprintf("%d", int64(value))
+		}
+	}
+
+	words := []string{}
+	skipped := []Arg{}
+	for _, arg := range command.Args {
+		value, ok := values[arg.Name]
+		if !ok {
+			skipped = append(skipped, arg)
+			continue
+		}
+
+		for _, missing := range skipped {
+			if missing.Type != ArgChoice {
+				return nil, fmt.Errorf("fill in %s to use %s", missing.Name, arg.Name)
+			}
+			words = append(words, missing.Choices[0])
+		}
+		skipped = nil
+		words = append(words, value)
+	}
+
+	return append(parts, words...), nil
+}
@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxInlineChoices is how many choices usage spells out before showing the argument name instead
+const maxInlineChoices = 4
+
+// Name joins a command path into what users type, like `clan war`
+func Name(path []*Command) string {
+	names := []string{}
+	for _, command := range path {
+		if !command.Implicit {
+			names = append(names, command.Name)
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+// Usage lists how to type a command, one line for each subcommand it leads to
+func (registry *Registry) Usage(path []*Command) []string {
+	if len(path) == 0 {
+		return nil
+	}
+
+	command := path[len(path)-1]
+	if len(command.Subcommands) == 0 {
+		line := strings.TrimSpace(fmt.Sprintf("%s %s %s", registry.prefix, Name(path), FormatArgs(command.Args)))
+		return []string{fmt.Sprintf("`%s`", line)}
+	}
+
+	lines := []string{}
+	for _, subcommand := range command.Subcommands {
+		lines = append(lines, registry.Usage(append(append([]*Command{}, path...), subcommand))...)
+	}
+	return lines
+}
+
+// FormatArgs writes arguments the way usage shows them, `<required>` and `[optional]`
+func FormatArgs(args []Arg) string {
+	formatted := []string{}
+	for _, arg := range args {
+		label := arg.Name
+		if arg.Type == ArgChoice && len(arg.Choices) <= maxInlineChoices {
+			label = strings.Join(arg.Choices, "|")
+		}
+		if arg.Type == ArgText {
+			label += "..."
+		}
+
+		if arg.Required {
+			formatted = append(formatted, fmt.Sprintf("<%s>", label))
+		} else {
+			formatted = append(formatted, fmt.Sprintf("[%s]", label))
+		}
+	}
+	return strings.Join(formatted, " ")
+}
+
+// Leaves lists every runnable path under a command, itself included when it has no subcommands
+func Leaves(path []*Command) [][]*Command {
+	command := path[len(path)-1]
+	if len(command.Subcommands) == 0 {
+		return [][]*Command{path}
+	}
+
+	leaves := [][]*Command{}
+	for _, subcommand := range command.Subcommands {
+		leaves = append(leaves, Leaves(append(append([]*Command{}, path...), subcommand))...)
+	}
+	return leaves
+}
+
+// Permission returns the strictest permission along a path
+func (invocation Invocation) Permission() Permission {
+	return PathPermission(invocation.Path)
+}
+
+// Features returns every guild feature a path needs switched on
+func (invocation Invocation) Features() []string {
+	features := []string{}
+	for _, command := range invocation.Path {
+		if command.Feature != "" {
+			features = append(features, command.Feature)
+		}
+	}
+	return features
+}
+
+// Channel returns the channel list the path is limited to, the most specific one winning
+func (invocation Invocation) Channel() string {
+	channel := ""
+	for _, command := range invocation.Path {
+		if command.Channel != "" {
+			channel = command.Channel
+		}
+	}
+	return channel
+}
+
+// GuildOnly reports whether any command on the path is limited to servers
+func (invocation Invocation) GuildOnly() bool {
+	for _, command := range invocation.Path {
+		if command.GuildOnly {
+			return true
+		}
+	}
+	return false
+}
+
+// Name returns the full path of the command run, including implicit subcommands, as cooldown buckets name it
+func (invocation Invocation) Name() string {
+	names := []string{}
+	for _, command := range invocation.Path {
+		names = append(names, command.Name)
+	}
+	return strings.Join(names, " ")
+}
+
+// PathPermission returns the strictest permission along a path
+func PathPermission(path []*Command) Permission {
+	permission := PermissionEveryone
+	for _, command := range path {
+		permission = max(permission, command.Permission)
+	}
+	return permission
+}
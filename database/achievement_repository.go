@@ -0,0 +1,125 @@
+package database
+
+import (
+	"CrispyBot/achievements"
+	"CrispyBot/database/models"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	achievementsCollection = "achievements"
+
+	// How many times to retry when another event updated the same progress concurrently
+	achievementSaveAttempts = 3
+)
+
+// GetAchievementProgress retrieves a user's achievement progress, empty if they have none yet
+func GetAchievementProgress(db *DB, userID string) (models.AchievementProgress, error) {
+	if db == nil {
+		return models.AchievementProgress{}, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var progress models.AchievementProgress
+	err := db.GetCollection(achievementsCollection).FindOne(ctx, bson.M{"discordID": userID}).Decode(&progress)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.AchievementProgress{DiscordID: userID}, nil
+		}
+		return models.AchievementProgress{}, fmt.Errorf("failed to get achievement progress: %w", err)
+	}
+
+	return progress, nil
+}
+
+// RecordAchievementEvent applies a game event to the user's progress and pays out any achievements it unlocked
+func RecordAchievementEvent(db *DB, userID string, event achievements.Event) ([]achievements.Definition, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is nil")
+	}
+
+	for attempt := 0; attempt < achievementSaveAttempts; attempt++ {
+		progress, err := GetAchievementProgress(db, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		version := progress.Version
+		unlocked := achievements.Evaluate(&progress, event, time.Now())
+		progress.Version++
+
+		saved, err := saveAchievementProgress(db, progress, version)
+		if err != nil {
+			return nil, err
+		}
+		if !saved {
+			// Another event got there first, evaluate again against the fresh progress
+			continue
+		}
+
+		for _, definition := range unlocked {
+			err = grantReward(db, userID, definition.Reward)
+			if err != nil {
+				fmt.Printf("Error granting reward for achievement %s: %v\n", definition.ID, err)
+			}
+		}
+
+		return unlocked, nil
+	}
+
+	return nil, fmt.Errorf("failed to record achievement event: progress kept changing")
+}
+
+// saveAchievementProgress writes progress only if it is still at the expected version
+func saveAchievementProgress(db *DB, progress models.AchievementProgress, expectedVersion int) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := db.GetCollection(achievementsCollection).UpdateOne(
+		ctx,
+		bson.M{"discordID": progress.DiscordID, "version": expectedVersion},
+		bson.M{"$set": bson.M{
+			"counters":  progress.Counters,
+			"collected": progress.Collected,
+			"unlocked":  progress.Unlocked,
+			"version":   progress.Version,
+		}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		// A newer version makes the filter miss and the upsert collide with the unique index
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to save achievement progress: %w", err)
+	}
+
+	return true, nil
+}
+
+// grantReward pays an achievement or quest reward's coins and reroll tokens
+func grantReward(db *DB, userID string, reward achievements.Reward) error {
+	if reward.Coins > 0 {
+		_, err := AddCurrency(db, userID, reward.Coins)
+		if err != nil {
+			return err
+		}
+	}
+
+	if reward.RerollTokens > 0 {
+		err := AddRerollTokens(db, userID, reward.RerollTokens)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
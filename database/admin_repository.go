@@ -0,0 +1,189 @@
+package database
+
+import (
+	"CrispyBot/database/models"
+	"CrispyBot/progression"
+	"CrispyBot/settings"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Collection names
+const (
+	adminAuditCollection = "adminAuditLog"
+)
+
+// Admin actions
+const (
+	AdminCoins       = "coins"
+	AdminRerolls     = "rerolls"
+	AdminItem        = "item"
+	AdminResetChar   = "resetchar"
+	AdminDeleteChar  = "deletechar"
+	AdminEndBattle   = "endbattle"
+	AdminRefreshShop = "refreshshop"
+	AdminBan         = "ban"
+	AdminUnban       = "unban"
+)
+
+// RecordAdminAction writes an admin action to the audit log
+func RecordAdminAction(db *DB, action models.AdminAction) error {
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	action.CreatedAt = time.Now()
+	_, err := db.GetCollection(adminAuditCollection).InsertOne(ctx, action)
+	if err != nil {
+		return fmt.Errorf("failed to record admin action: %w", err)
+	}
+
+	return nil
+}
+
+// GetAdminActions returns one page of a guild's audit log, newest first, and the total number of entries
+func GetAdminActions(db *DB, guildID string, page int, pageSize int) ([]models.AdminAction, int, error) {
+	if db == nil {
+		return nil, 0, fmt.Errorf("database connection is nil")
+	}
+
+	if page < 0 {
+		page = 0
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(adminAuditCollection)
+	filter := bson.M{"guildID": guildID}
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count admin actions: %w", err)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}}).
+		SetSkip(int64(page * pageSize)).
+		SetLimit(int64(pageSize))
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get admin actions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	actions := []models.AdminAction{}
+	if err := cursor.All(ctx, &actions); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode admin actions: %w", err)
+	}
+
+	return actions, int(total), nil
+}
+
+// AdjustWallet adds coins to a user's wallet, or takes them when negative, and returns the new balance
+// Note: A deduction never takes a wallet below zero.
+func AdjustWallet(db *DB, userID string, amount int) (int, error) {
+	return adjustUserCounter(db, userID, "wallet", amount, "coins", func(user models.User) int { return user.Wallet })
+}
+
+// AdjustRerollTokens adds reroll tokens to a user, or takes them when negative, and returns the new count
+func AdjustRerollTokens(db *DB, userID string, amount int) (int, error) {
+	return adjustUserCounter(db, userID, "rerollTokens", amount, "reroll tokens", func(user models.User) int { return user.RerollTokens })
+}
+
+// adjustUserCounter changes a non-negative user counter and returns its new value
+func adjustUserCounter(db *DB, userID string, field string, amount int, name string, current func(models.User) int) (int, error) {
+	if db == nil {
+		return 0, fmt.Errorf("database connection is nil")
+	}
+
+	// Staff can fix up users who never played yet
+	if _, err := CreateUser(db, userID); err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"discordID": userID}
+	if amount < 0 {
+		filter[field] = bson.M{"$gte": -amount}
+	}
+
+	userCollection := db.GetCollection(usersCollection)
+	result, err := userCollection.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{field: amount}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to adjust %s: %w", name, err)
+	}
+
+	user, err := GetUserByID(db, userID)
+	if err != nil {
+		return 0, err
+	}
+	if result.MatchedCount == 0 {
+		return current(user), fmt.Errorf("they only have %d %s", current(user), name)
+	}
+
+	return current(user), nil
+}
+
+// ResetCharacterProgress puts a user's active character back to level 1, removing its experience and allocated points
+func ResetCharacterProgress(db *DB, userID string) (models.Character, error) {
+	if db == nil {
+		return models.Character{}, fmt.Errorf("database connection is nil")
+	}
+
+	character, err := findActiveCharacter(db, userID)
+	if err != nil {
+		return models.Character{}, fmt.Errorf("no character found for this user: %w", err)
+	}
+
+	reset := bson.M{"Level": 1, "Experience": 0}
+	for _, statName := range progression.StatNames {
+		reset[fmt.Sprintf("Stats.%s.LevelBonus", statName)] = 0
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = db.GetCollection(charactersCollection).UpdateOne(ctx, bson.M{"_id": character.ID}, bson.M{"$set": reset})
+	if err != nil {
+		return models.Character{}, fmt.Errorf("failed to reset character: %w", err)
+	}
+
+	return GetCharacterByOwner(db, userID)
+}
+
+// SetUserBanned bans or unbans a user from playing in a guild
+func SetUserBanned(db *DB, guildID string, adminID string, userID string, banned bool) error {
+	_, err := UpdateGuildConfig(db, guildID, adminID, func(config *models.GuildConfig) error {
+		return settings.SetBanned(config, userID, banned)
+	})
+	return err
+}
+
+// IsBannedInAnyGuild reports whether any guild banned a user
+// Note: Direct messages have no guild of their own, so a ban from any server applies there.
+func IsBannedInAnyGuild(db *DB, userID string) (bool, error) {
+	if db == nil {
+		return false, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	count, err := db.GetCollection(guildConfigsCollection).CountDocuments(ctx, bson.M{"bannedUsers": userID}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("failed to check bans: %w", err)
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,214 @@
+package database
+
+import (
+	"CrispyBot/database/models"
+	"CrispyBot/roller"
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Collection names
+const (
+	bannersCollection = "banners"
+)
+
+// CreateBanner schedules a banner for a guild
+// Note: Banners in the same guild can't overlap, so a roll only ever has one banner.
+func CreateBanner(db *DB, banner models.Banner) (models.Banner, error) {
+	if db == nil {
+		return models.Banner{}, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(bannersCollection)
+
+	var overlapping models.Banner
+	err := collection.FindOne(ctx, bson.M{
+		"guildID":  banner.GuildID,
+		"startsAt": bson.M{"$lt": banner.EndsAt},
+		"endsAt":   bson.M{"$gt": banner.StartsAt},
+	}).Decode(&overlapping)
+	if err == nil {
+		return models.Banner{}, fmt.Errorf("it would overlap with **%s** (<t:%d:f> to <t:%d:f>)",
+			overlapping.Name, overlapping.StartsAt.Unix(), overlapping.EndsAt.Unix())
+	}
+	if err != mongo.ErrNoDocuments {
+		return models.Banner{}, fmt.Errorf("failed to check banner schedule: %w", err)
+	}
+
+	banner.CreatedAt = time.Now()
+	result, err := collection.InsertOne(ctx, banner)
+	if err != nil {
+		return models.Banner{}, fmt.Errorf("failed to create banner: %w", err)
+	}
+
+	banner.ID = result.InsertedID.(primitive.ObjectID)
+	return banner, nil
+}
+
+// GetActiveBanner returns the banner running in a guild right now
+func GetActiveBanner(db *DB, guildID string) (models.Banner, error) {
+	if db == nil {
+		return models.Banner{}, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	var banner models.Banner
+	err := db.GetCollection(bannersCollection).FindOne(ctx, bson.M{
+		"guildID":  guildID,
+		"startsAt": bson.M{"$lte": now},
+		"endsAt":   bson.M{"$gt": now},
+	}).Decode(&banner)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.Banner{}, fmt.Errorf("no banner is running")
+		}
+		return models.Banner{}, fmt.Errorf("failed to get banner: %w", err)
+	}
+
+	return banner, nil
+}
+
+// activeBannerContext returns the roll context and ID of the guild's running banner, or nil when there is none
+func activeBannerContext(db *DB, guildID string) (*roller.Banner, primitive.ObjectID) {
+	if guildID == "" {
+		return nil, primitive.NilObjectID
+	}
+
+	banner, err := GetActiveBanner(db, guildID)
+	if err != nil {
+		return nil, primitive.NilObjectID
+	}
+
+	return roller.NewBanner(banner), banner.ID
+}
+
+// GetUpcomingBanners returns a guild's running and scheduled banners, soonest first
+func GetUpcomingBanners(db *DB, guildID string) ([]models.Banner, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := db.GetCollection(bannersCollection).Find(ctx,
+		bson.M{"guildID": guildID, "endsAt": bson.M{"$gt": time.Now()}},
+		options.Find().SetSort(bson.D{{Key: "startsAt", Value: 1}}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get banners: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	banners := []models.Banner{}
+	if err := cursor.All(ctx, &banners); err != nil {
+		return nil, fmt.Errorf("failed to decode banners: %w", err)
+	}
+
+	return banners, nil
+}
+
+// GetBannerByName finds a guild's most recent banner with a name, ignoring case
+func GetBannerByName(db *DB, guildID string, name string) (models.Banner, error) {
+	if db == nil {
+		return models.Banner{}, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var banner models.Banner
+	err := db.GetCollection(bannersCollection).FindOne(ctx,
+		bson.M{
+			"guildID": guildID,
+			"name":    primitive.Regex{Pattern: "^" + regexp.QuoteMeta(name) + "$", Options: "i"},
+		},
+		options.FindOne().SetSort(bson.D{{Key: "startsAt", Value: -1}}),
+	).Decode(&banner)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.Banner{}, fmt.Errorf("no banner named %s", name)
+		}
+		return models.Banner{}, fmt.Errorf("failed to get banner: %w", err)
+	}
+
+	return banner, nil
+}
+
+// EndBanner stops a running or scheduled banner right away
+func EndBanner(db *DB, bannerID primitive.ObjectID) error {
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// A banner that hasn't started yet ends before it begins
+	now := time.Now()
+	result, err := db.GetCollection(bannersCollection).UpdateOne(ctx,
+		bson.M{"_id": bannerID, "endsAt": bson.M{"$gt": now}},
+		bson.A{bson.M{"$set": bson.M{
+			"endsAt":   now,
+			"startsAt": bson.M{"$min": bson.A{"$startsAt", now}},
+		}}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to end banner: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("that banner has already ended")
+	}
+
+	return nil
+}
+
+// GetBannerAudit returns how many rolls were made under a banner, by how many players, and the latest rolls
+func GetBannerAudit(db *DB, bannerID primitive.ObjectID, limit int) (int, int, []models.CharacterSnapshot, error) {
+	if db == nil {
+		return 0, 0, nil, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(characterSnapshotsCollection)
+	filter := bson.M{"bannerID": bannerID}
+
+	rolls, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("failed to count banner rolls: %w", err)
+	}
+
+	players, err := collection.Distinct(ctx, "discordID", filter)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("failed to count banner players: %w", err)
+	}
+
+	cursor, err := collection.Find(ctx, filter,
+		options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}}).SetLimit(int64(limit)))
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("failed to get banner rolls: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	recent := []models.CharacterSnapshot{}
+	if err := cursor.All(ctx, &recent); err != nil {
+		return 0, 0, nil, fmt.Errorf("failed to decode banner rolls: %w", err)
+	}
+
+	return int(rolls), len(players), recent, nil
+}
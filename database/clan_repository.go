@@ -0,0 +1,528 @@
+package database
+
+import (
+	"CrispyBot/clans"
+	"CrispyBot/database/models"
+	"CrispyBot/variables"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	clansCollection      = "clans"
+	clanLedgerCollection = "clanLedger"
+)
+
+// CreateClan founds a new clan led by the user, paying the creation cost
+func CreateClan(db *DB, leaderID string, name string) (models.Clan, error) {
+	if db == nil {
+		return models.Clan{}, fmt.Errorf("database connection is nil")
+	}
+
+	name = strings.Join(strings.Fields(name), " ")
+	if err := clans.ValidateName(name); err != nil {
+		return models.Clan{}, err
+	}
+
+	if _, err := GetClanByMember(db, leaderID); err == nil {
+		return models.Clan{}, fmt.Errorf("you are already in a clan")
+	}
+	if _, err := GetClanByName(db, name); err == nil {
+		return models.Clan{}, fmt.Errorf("a clan named %s already exists", name)
+	}
+
+	err := spendCurrency(db, leaderID, variables.ClanCreationCost)
+	if err != nil {
+		return models.Clan{}, fmt.Errorf("founding a clan costs %d coins: %w", variables.ClanCreationCost, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	clan := models.Clan{
+		Name:     name,
+		NameKey:  clans.NameKey(name),
+		LeaderID: leaderID,
+		Members: []models.ClanMember{
+			{DiscordID: leaderID, Role: clans.RoleLeader, JoinedAt: now},
+		},
+		Invites:   []string{},
+		CreatedAt: now,
+	}
+
+	result, err := db.GetCollection(clansCollection).InsertOne(ctx, clan)
+	if err != nil {
+		// Refund if someone took the name or the user joined a clan in the meantime
+		AddCurrency(db, leaderID, variables.ClanCreationCost)
+		if mongo.IsDuplicateKeyError(err) {
+			return models.Clan{}, fmt.Errorf("that clan name is taken or you are already in a clan")
+		}
+		return models.Clan{}, fmt.Errorf("failed to create clan: %w", err)
+	}
+
+	clan.ID = result.InsertedID.(primitive.ObjectID)
+	return clan, nil
+}
+
+// GetClan retrieves a clan by ID
+func GetClan(db *DB, clanID primitive.ObjectID) (models.Clan, error) {
+	return findClan(db, bson.M{"_id": clanID}, "clan not found")
+}
+
+// GetClanByMember retrieves the clan the user belongs to
+func GetClanByMember(db *DB, userID string) (models.Clan, error) {
+	return findClan(db, bson.M{"members.discordID": userID}, "you are not in a clan")
+}
+
+// GetClanByName retrieves a clan by name, ignoring case
+func GetClanByName(db *DB, name string) (models.Clan, error) {
+	return findClan(db, bson.M{"nameKey": clans.NameKey(name)}, fmt.Sprintf("no clan named %s", name))
+}
+
+// findClan loads a single clan, using notFound as the error when nothing matches
+func findClan(db *DB, filter bson.M, notFound string) (models.Clan, error) {
+	if db == nil {
+		return models.Clan{}, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var clan models.Clan
+	err := db.GetCollection(clansCollection).FindOne(ctx, filter).Decode(&clan)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.Clan{}, fmt.Errorf("%s", notFound)
+		}
+		return models.Clan{}, fmt.Errorf("failed to get clan: %w", err)
+	}
+
+	return clan, nil
+}
+
+// InviteToClan lets an officer or the leader invite a player to their clan
+func InviteToClan(db *DB, actorID string, targetID string) (models.Clan, error) {
+	clan, err := GetClanByMember(db, actorID)
+	if err != nil {
+		return models.Clan{}, err
+	}
+
+	actor, _ := clans.FindMember(clan, actorID)
+	if !clans.CanInvite(actor.Role) {
+		return models.Clan{}, fmt.Errorf("only officers and the leader can invite players")
+	}
+	if _, err := GetClanByMember(db, targetID); err == nil {
+		return models.Clan{}, fmt.Errorf("that player is already in a clan")
+	}
+	if len(clan.Members) >= variables.ClanMaxMembers {
+		return models.Clan{}, fmt.Errorf("your clan is full (%d members)", variables.ClanMaxMembers)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = db.GetCollection(clansCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": clan.ID},
+		bson.M{"$addToSet": bson.M{"invites": targetID}},
+	)
+	if err != nil {
+		return models.Clan{}, fmt.Errorf("failed to invite player: %w", err)
+	}
+
+	return clan, nil
+}
+
+// JoinClan accepts an invite to the named clan
+func JoinClan(db *DB, userID string, name string) (models.Clan, error) {
+	clan, err := GetClanByName(db, name)
+	if err != nil {
+		return models.Clan{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The filter checks the invite and the member cap so concurrent joins can't overfill the clan
+	result, err := db.GetCollection(clansCollection).UpdateOne(
+		ctx,
+		bson.M{
+			"_id":     clan.ID,
+			"invites": userID,
+			fmt.Sprintf("members.%d", variables.ClanMaxMembers-1): bson.M{"$exists": false},
+		},
+		bson.M{
+			"$push": bson.M{"members": models.ClanMember{DiscordID: userID, Role: clans.RoleMember, JoinedAt: time.Now()}},
+			"$pull": bson.M{"invites": userID},
+		},
+	)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return models.Clan{}, fmt.Errorf("you are already in a clan")
+		}
+		return models.Clan{}, fmt.Errorf("failed to join clan: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return models.Clan{}, fmt.Errorf("you need an invite from %s and the clan must have room", clan.Name)
+	}
+
+	return clan, nil
+}
+
+// LeaveClan removes the user from their clan, disbanding it if they were the last member
+// Note: Returns true if the clan was disbanded.
+func LeaveClan(db *DB, userID string) (models.Clan, bool, error) {
+	clan, err := GetClanByMember(db, userID)
+	if err != nil {
+		return models.Clan{}, false, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(clansCollection)
+
+	if clan.LeaderID == userID {
+		if len(clan.Members) > 1 {
+			return models.Clan{}, false, fmt.Errorf("promote a new leader with `!cb clan promote @member leader` before leaving")
+		}
+
+		// Coins left in the bank would vanish with the clan
+		if clan.Bank > 0 {
+			return models.Clan{}, false, fmt.Errorf("withdraw the %d coins in the clan bank with `!cb clan withdraw` before disbanding", clan.Bank)
+		}
+
+		// The filter re-checks both so a late join or deposit stops the disband
+		result, err := collection.DeleteOne(ctx, bson.M{"_id": clan.ID, "members": bson.M{"$size": 1}, "bank": 0})
+		if err != nil {
+			return models.Clan{}, false, fmt.Errorf("failed to disband clan: %w", err)
+		}
+		if result.DeletedCount == 0 {
+			return models.Clan{}, false, fmt.Errorf("the clan changed while disbanding, check its members and bank and try again")
+		}
+		return clan, true, nil
+	}
+
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": clan.ID}, bson.M{"$pull": bson.M{"members": bson.M{"discordID": userID}}})
+	if err != nil {
+		return models.Clan{}, false, fmt.Errorf("failed to leave clan: %w", err)
+	}
+
+	return clan, false, nil
+}
+
+// KickFromClan removes a lower-ranked member from the actor's clan
+func KickFromClan(db *DB, actorID string, targetID string) (models.Clan, error) {
+	clan, err := GetClanByMember(db, actorID)
+	if err != nil {
+		return models.Clan{}, err
+	}
+
+	actor, _ := clans.FindMember(clan, actorID)
+	target, ok := clans.FindMember(clan, targetID)
+	if !ok {
+		return models.Clan{}, fmt.Errorf("that player is not in your clan")
+	}
+	if !clans.CanKick(actor.Role, target.Role) {
+		return models.Clan{}, fmt.Errorf("you can only kick members ranked below you")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = db.GetCollection(clansCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": clan.ID},
+		bson.M{"$pull": bson.M{"members": bson.M{"discordID": targetID}}},
+	)
+	if err != nil {
+		return models.Clan{}, fmt.Errorf("failed to kick member: %w", err)
+	}
+
+	return clan, nil
+}
+
+// SetClanRole lets the leader promote or demote a member
+// Note: Promoting someone to leader hands over leadership and makes the old leader an officer.
+func SetClanRole(db *DB, actorID string, targetID string, role string) error {
+	clan, err := GetClanByMember(db, actorID)
+	if err != nil {
+		return err
+	}
+
+	if clan.LeaderID != actorID {
+		return fmt.Errorf("only the clan leader can change roles")
+	}
+	if actorID == targetID {
+		return fmt.Errorf("you can't change your own role")
+	}
+	if _, ok := clans.FindMember(clan, targetID); !ok {
+		return fmt.Errorf("that player is not in your clan")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(clansCollection)
+
+	update := bson.M{"$set": bson.M{"members.$[target].role": role}}
+	arrayFilters := []interface{}{bson.M{"target.discordID": targetID}}
+	if role == clans.RoleLeader {
+		update = bson.M{"$set": bson.M{
+			"members.$[target].role": clans.RoleLeader,
+			"members.$[actor].role":  clans.RoleOfficer,
+			"leaderID":               targetID,
+		}}
+		arrayFilters = append(arrayFilters, bson.M{"actor.discordID": actorID})
+	}
+
+	// Filter on the leader so two handovers can't race
+	result, err := collection.UpdateOne(
+		ctx,
+		bson.M{"_id": clan.ID, "leaderID": actorID},
+		update,
+		options.Update().SetArrayFilters(options.ArrayFilters{Filters: arrayFilters}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to change role: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("you are no longer the clan leader")
+	}
+
+	return nil
+}
+
+// DepositToClanBank moves coins from the user's wallet into their clan's bank
+// Note: Returns the new bank balance.
+func DepositToClanBank(db *DB, userID string, amount int) (int, error) {
+	if amount <= 0 {
+		return 0, fmt.Errorf("deposit must be a positive amount")
+	}
+
+	clan, err := GetClanByMember(db, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	err = spendCurrency(db, userID, amount)
+	if err != nil {
+		return 0, err
+	}
+
+	balance, err := adjustClanBank(db, bson.M{"_id": clan.ID, "members.discordID": userID}, amount)
+	if err != nil {
+		// The user left the clan in the meantime, give the coins back
+		AddCurrency(db, userID, amount)
+		return 0, err
+	}
+
+	recordClanLedger(db, clan.ID, userID, "deposit", amount, balance)
+	return balance, nil
+}
+
+// WithdrawFromClanBank lets an officer or the leader take coins from the clan bank
+// Note: Returns the new bank balance.
+func WithdrawFromClanBank(db *DB, userID string, amount int) (int, error) {
+	if amount <= 0 {
+		return 0, fmt.Errorf("withdrawal must be a positive amount")
+	}
+
+	clan, err := GetClanByMember(db, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	member, _ := clans.FindMember(clan, userID)
+	if !clans.CanWithdraw(member.Role) {
+		return 0, fmt.Errorf("only officers and the leader can withdraw from the bank")
+	}
+
+	// Filter on the balance and role so concurrent withdrawals can't overdraw the bank
+	balance, err := adjustClanBank(db, bson.M{
+		"_id":  clan.ID,
+		"bank": bson.M{"$gte": amount},
+		"members": bson.M{"$elemMatch": bson.M{
+			"discordID": userID,
+			"role":      bson.M{"$in": []string{clans.RoleLeader, clans.RoleOfficer}},
+		}},
+	}, -amount)
+	if err == errClanBankUnchanged {
+		return 0, clanWithdrawRefusal(db, userID, amount)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = AddCurrency(db, userID, amount)
+	if err != nil {
+		// The bank was already debited, put the coins back
+		if _, refundErr := adjustClanBank(db, bson.M{"_id": clan.ID}, amount); refundErr != nil {
+			fmt.Printf("Error returning %d coins to clan bank: %v\n", amount, refundErr)
+		}
+		return 0, err
+	}
+
+	recordClanLedger(db, clan.ID, userID, "withdrawal", -amount, balance)
+	return balance, nil
+}
+
+// clanWithdrawRefusal explains which check stopped a withdrawal, using the clan's current state
+func clanWithdrawRefusal(db *DB, userID string, amount int) error {
+	clan, err := GetClanByMember(db, userID)
+	if err != nil {
+		return err
+	}
+
+	member, _ := clans.FindMember(clan, userID)
+	if !clans.CanWithdraw(member.Role) {
+		return fmt.Errorf("only officers and the leader can withdraw from the bank")
+	}
+	if clan.Bank < amount {
+		return fmt.Errorf("not enough coins in the clan bank (%d available)", clan.Bank)
+	}
+
+	return fmt.Errorf("the clan bank changed during the withdrawal, try again")
+}
+
+// GetClanLedger retrieves the clan bank's most recent entries, newest first
+func GetClanLedger(db *DB, clanID primitive.ObjectID, limit int) ([]models.ClanLedgerEntry, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := db.GetCollection(clanLedgerCollection).Find(
+		ctx,
+		bson.M{"clanID": clanID},
+		options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}}).SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clan ledger: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var entries []models.ClanLedgerEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, fmt.Errorf("failed to decode clan ledger: %w", err)
+	}
+
+	return entries, nil
+}
+
+// AddClanExperience credits battle XP to the user's clan, if they have one
+func AddClanExperience(db *DB, userID string, amount int) error {
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+	if amount <= 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := db.GetCollection(clansCollection).UpdateOne(
+		ctx,
+		bson.M{"members.discordID": userID},
+		bson.M{"$inc": bson.M{"experience": amount, "members.$.contributed": amount}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add clan experience: %w", err)
+	}
+
+	return nil
+}
+
+// ClanShopDiscount returns the shop discount percent from the user's clan perks
+func ClanShopDiscount(db *DB, userID string) int {
+	clan, err := GetClanByMember(db, userID)
+	if err != nil {
+		return 0
+	}
+	return clans.ShopDiscountPercent(clans.Level(clan))
+}
+
+// ApplyClanXPBoost increases battle XP by the user's clan perks
+func ApplyClanXPBoost(db *DB, userID string, experience int) int {
+	clan, err := GetClanByMember(db, userID)
+	if err != nil {
+		return experience
+	}
+	return clans.ApplyBoost(experience, clans.XPBoostPercent(clans.Level(clan)))
+}
+
+// spendCurrency takes coins from the user's wallet, failing if they can't afford it
+func spendCurrency(db *DB, userID string, amount int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Filter on the wallet so concurrent spending can't overdraw it
+	result, err := db.GetCollection(usersCollection).UpdateOne(
+		ctx,
+		bson.M{"discordID": userID, "wallet": bson.M{"$gte": amount}},
+		bson.M{"$inc": bson.M{"wallet": -amount}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update wallet: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("not enough currency")
+	}
+
+	return nil
+}
+
+// errClanBankUnchanged is returned when no clan matched a bank update's filter
+var errClanBankUnchanged = errors.New("clan bank could not be updated")
+
+// adjustClanBank changes the bank of the clan matching the filter and returns the new balance
+func adjustClanBank(db *DB, filter bson.M, amount int) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var clan models.Clan
+	err := db.GetCollection(clansCollection).FindOneAndUpdate(
+		ctx,
+		filter,
+		bson.M{"$inc": bson.M{"bank": amount}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&clan)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return 0, errClanBankUnchanged
+		}
+		return 0, fmt.Errorf("failed to update clan bank: %w", err)
+	}
+
+	return clan.Bank, nil
+}
+
+// recordClanLedger appends an entry to the clan bank's ledger
+func recordClanLedger(db *DB, clanID primitive.ObjectID, userID string, kind string, amount int, balance int) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := db.GetCollection(clanLedgerCollection).InsertOne(ctx, models.ClanLedgerEntry{
+		ClanID:    clanID,
+		DiscordID: userID,
+		Kind:      kind,
+		Amount:    amount,
+		Balance:   balance,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		fmt.Printf("Error recording clan ledger entry: %v\n", err)
+	}
+}
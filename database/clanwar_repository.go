@@ -0,0 +1,269 @@
+package database
+
+import (
+	"CrispyBot/clans"
+	"CrispyBot/database/models"
+	"CrispyBot/variables"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	clanWarsCollection = "clanWars"
+)
+
+// DeclareClanWar has an officer or the leader challenge another clan to a war
+func DeclareClanWar(db *DB, actorID string, defenderName string, channelID string) (models.ClanWar, error) {
+	attacker, err := GetClanByMember(db, actorID)
+	if err != nil {
+		return models.ClanWar{}, err
+	}
+
+	actor, _ := clans.FindMember(attacker, actorID)
+	if !clans.CanDeclareWar(actor.Role) {
+		return models.ClanWar{}, fmt.Errorf("only officers and the leader can declare war")
+	}
+
+	defender, err := GetClanByName(db, defenderName)
+	if err != nil {
+		return models.ClanWar{}, err
+	}
+	if defender.ID == attacker.ID {
+		return models.ClanWar{}, fmt.Errorf("you can't declare war on your own clan")
+	}
+
+	for _, clanID := range []primitive.ObjectID{attacker.ID, defender.ID} {
+		if _, err := GetCurrentClanWar(db, clanID); err == nil {
+			return models.ClanWar{}, fmt.Errorf("one of the clans is already at war")
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	war := models.ClanWar{
+		AttackerID:   attacker.ID,
+		AttackerName: attacker.Name,
+		DefenderID:   defender.ID,
+		DefenderName: defender.Name,
+		Status:       clans.WarPending,
+		ChannelID:    channelID,
+		DeclaredAt:   time.Now(),
+	}
+
+	result, err := db.GetCollection(clanWarsCollection).InsertOne(ctx, war)
+	if err != nil {
+		return models.ClanWar{}, fmt.Errorf("failed to declare war: %w", err)
+	}
+
+	war.ID = result.InsertedID.(primitive.ObjectID)
+	return war, nil
+}
+
+// AcceptClanWar has an officer or the leader of the defending clan start a pending war
+func AcceptClanWar(db *DB, actorID string) (models.ClanWar, error) {
+	defender, err := GetClanByMember(db, actorID)
+	if err != nil {
+		return models.ClanWar{}, err
+	}
+
+	actor, _ := clans.FindMember(defender, actorID)
+	if !clans.CanDeclareWar(actor.Role) {
+		return models.ClanWar{}, fmt.Errorf("only officers and the leader can accept a war")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	var war models.ClanWar
+	err = db.GetCollection(clanWarsCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"defenderID": defender.ID, "status": clans.WarPending, "declaredAt": bson.M{"$gt": pendingWarCutoff(now)}},
+		bson.M{"$set": bson.M{"status": clans.WarActive, "endsAt": now.Add(variables.ClanWarHours * time.Hour)}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&war)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.ClanWar{}, fmt.Errorf("no clan has declared war on you")
+		}
+		return models.ClanWar{}, fmt.Errorf("failed to accept war: %w", err)
+	}
+
+	return war, nil
+}
+
+// GetCurrentClanWar retrieves the pending or active war the clan is part of
+func GetCurrentClanWar(db *DB, clanID primitive.ObjectID) (models.ClanWar, error) {
+	if db == nil {
+		return models.ClanWar{}, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var war models.ClanWar
+	err := db.GetCollection(clanWarsCollection).FindOne(ctx, bson.M{
+		"$or": bson.A{bson.M{"attackerID": clanID}, bson.M{"defenderID": clanID}},
+		"$and": bson.A{bson.M{"$or": bson.A{
+			bson.M{"status": clans.WarActive},
+			bson.M{"status": clans.WarPending, "declaredAt": bson.M{"$gt": pendingWarCutoff(time.Now())}},
+		}}},
+	}).Decode(&war)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.ClanWar{}, fmt.Errorf("your clan is not at war")
+		}
+		return models.ClanWar{}, fmt.Errorf("failed to get clan war: %w", err)
+	}
+
+	return war, nil
+}
+
+// RecordClanWarWin scores a battle won by a member of the given clan
+func RecordClanWarWin(db *DB, warID primitive.ObjectID, winnerClanID primitive.ObjectID) (models.ClanWar, error) {
+	war, err := GetClanWar(db, warID)
+	if err != nil {
+		return models.ClanWar{}, err
+	}
+
+	var score string
+	switch winnerClanID {
+	case war.AttackerID:
+		score = "attackerScore"
+	case war.DefenderID:
+		score = "defenderScore"
+	default:
+		return models.ClanWar{}, fmt.Errorf("clan is not part of this war")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Only active wars that haven't run out of time take new results
+	err = db.GetCollection(clanWarsCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": warID, "status": clans.WarActive, "endsAt": bson.M{"$gt": time.Now()}},
+		bson.M{"$inc": bson.M{score: 1}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&war)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.ClanWar{}, fmt.Errorf("the war is over")
+		}
+		return models.ClanWar{}, fmt.Errorf("failed to record war result: %w", err)
+	}
+
+	return war, nil
+}
+
+// GetClanWar retrieves a war by ID
+func GetClanWar(db *DB, warID primitive.ObjectID) (models.ClanWar, error) {
+	if db == nil {
+		return models.ClanWar{}, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var war models.ClanWar
+	err := db.GetCollection(clanWarsCollection).FindOne(ctx, bson.M{"_id": warID}).Decode(&war)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.ClanWar{}, fmt.Errorf("clan war not found")
+		}
+		return models.ClanWar{}, fmt.Errorf("failed to get clan war: %w", err)
+	}
+
+	return war, nil
+}
+
+// GetEndedClanWars retrieves active wars whose time has run out
+func GetEndedClanWars(db *DB) ([]models.ClanWar, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := db.GetCollection(clanWarsCollection).Find(ctx, bson.M{
+		"status": clans.WarActive,
+		"endsAt": bson.M{"$lte": time.Now()},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ended clan wars: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var wars []models.ClanWar
+	if err := cursor.All(ctx, &wars); err != nil {
+		return nil, fmt.Errorf("failed to decode clan wars: %w", err)
+	}
+
+	return wars, nil
+}
+
+// FinishClanWar scores an ended war and pays the prize into the winner's bank
+// Note: Returns false if another caller already finished the war.
+func FinishClanWar(db *DB, war models.ClanWar) (models.ClanWar, bool, error) {
+	if db == nil {
+		return models.ClanWar{}, false, fmt.Errorf("database connection is nil")
+	}
+
+	winnerID := clans.WarWinner(war.AttackerID.Hex(), war.AttackerScore, war.DefenderID.Hex(), war.DefenderScore)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Filter on the status so the prize is only paid once
+	result, err := db.GetCollection(clanWarsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": war.ID, "status": clans.WarActive},
+		bson.M{"$set": bson.M{"status": clans.WarFinished, "winnerID": winnerID}},
+	)
+	if err != nil {
+		return models.ClanWar{}, false, fmt.Errorf("failed to finish clan war: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return war, false, nil
+	}
+
+	war.Status = clans.WarFinished
+	war.WinnerID = winnerID
+	if winnerID == "" {
+		return war, true, nil
+	}
+
+	clanID, err := primitive.ObjectIDFromHex(winnerID)
+	if err != nil {
+		return war, true, fmt.Errorf("failed to read winning clan: %w", err)
+	}
+
+	balance, err := adjustClanBank(db, bson.M{"_id": clanID}, variables.ClanWarPrize)
+	if err != nil {
+		return war, true, err
+	}
+	recordClanLedger(db, clanID, "", "war prize", variables.ClanWarPrize, balance)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = db.GetCollection(clansCollection).UpdateOne(ctx, bson.M{"_id": clanID}, bson.M{"$inc": bson.M{"experience": variables.ClanWarXP}})
+	if err != nil {
+		return war, true, fmt.Errorf("failed to add clan war experience: %w", err)
+	}
+
+	return war, true, nil
+}
+
+// pendingWarCutoff returns the declaration time before which unanswered wars lapse
+func pendingWarCutoff(now time.Time) time.Time {
+	return now.Add(-variables.ClanWarHours * time.Hour)
+}
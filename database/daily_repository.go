@@ -0,0 +1,168 @@
+package database
+
+import (
+	"CrispyBot/database/models"
+	"CrispyBot/shop"
+	"CrispyBot/variables"
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Daily Milestone Model
+/*
+	Day - Streak length that unlocks the milestone.
+	RerollTokens - Bonus reroll tokens granted.
+	ItemRarity - Rarity of the bonus item granted. Note: Empty means no item.
+*/
+type DailyMilestone struct {
+	Day          int
+	RerollTokens int
+	ItemRarity   string
+}
+
+// DailyMilestones are the streak lengths that pay out extra rewards
+var DailyMilestones = []DailyMilestone{
+	{Day: 7, RerollTokens: 1},
+	{Day: 30, RerollTokens: 3, ItemRarity: "Rare"},
+	{Day: 100, RerollTokens: 5, ItemRarity: "Legendary"},
+}
+
+// Daily Reward Model
+/*
+	Coins - Coins paid for this claim.
+	NewBalance - Wallet balance after the claim.
+	Streak - Streak length after this claim.
+	StreakReset - True if a missed day reset the streak.
+	Milestone - Milestone reached by this claim. Note: nil if none.
+	Item - Bonus item granted by the milestone. Note: nil if none.
+	ItemMailed - True if the bonus item went to the mailbox because the inventory was full.
+*/
+type DailyReward struct {
+	Coins       int
+	NewBalance  int
+	Streak      int
+	StreakReset bool
+	Milestone   *DailyMilestone
+	Item        *models.Item
+	ItemMailed  bool
+}
+
+// ClaimDailyReward pays out the daily reward once per reset period and advances the streak
+// Note: The base reward is the one set by the guild the claim is made in.
+func ClaimDailyReward(db *DB, userID string, guildID string) (DailyReward, error) {
+	if db == nil {
+		return DailyReward{}, fmt.Errorf("database connection is nil")
+	}
+
+	// Make sure the user exists before claiming
+	user, err := GetUserByID(db, userID)
+	if err != nil {
+		user, err = CreateUser(db, userID)
+		if err != nil {
+			return DailyReward{}, fmt.Errorf("failed to create user: %w", err)
+		}
+	}
+
+	now := time.Now()
+	currentPeriod := shop.CurrentPeriodStart(now)
+
+	// Only one claim per reset period
+	if !user.LastDailyClaim.IsZero() && !shop.CurrentPeriodStart(user.LastDailyClaim).Before(currentPeriod) {
+		return DailyReward{}, fmt.Errorf("daily reward already claimed")
+	}
+
+	streak, reset := nextDailyStreak(user.LastDailyClaim, user.DailyStreak, now)
+	coins := dailyCoinReward(GetGuildSettings(db, guildID).DailyReward, streak)
+
+	reward := DailyReward{
+		Coins:       coins,
+		NewBalance:  user.Wallet + coins,
+		Streak:      streak,
+		StreakReset: reset,
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"lastDailyClaim": now,
+			"dailyStreak":    streak,
+		},
+		"$inc": bson.M{"wallet": coins},
+	}
+
+	// Check for a milestone payout
+	for i := range DailyMilestones {
+		if DailyMilestones[i].Day == streak {
+			reward.Milestone = &DailyMilestones[i]
+			break
+		}
+	}
+	if reward.Milestone != nil && reward.Milestone.RerollTokens > 0 {
+		update["$inc"].(bson.M)["rerollTokens"] = reward.Milestone.RerollTokens
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	userCollection := db.GetCollection(usersCollection)
+
+	// Filter on the previous claim time so concurrent claims can't both pay out
+	filter := bson.M{"discordID": userID, "lastDailyClaim": user.LastDailyClaim}
+	if user.LastDailyClaim.IsZero() {
+		// Older user documents don't have the field at all
+		filter["lastDailyClaim"] = bson.M{"$in": bson.A{user.LastDailyClaim, nil}}
+	}
+	result, err := userCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return DailyReward{}, fmt.Errorf("failed to claim daily reward: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return DailyReward{}, fmt.Errorf("daily reward already claimed")
+	}
+
+	// Grant the milestone item after the claim is recorded
+	if reward.Milestone != nil && reward.Milestone.ItemRarity != "" {
+		rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+		item := shop.GenerateItem(reward.Milestone.ItemRarity, rng)
+		_, mailed, err := GrantItem(db, userID, item, fmt.Sprintf("Day %d streak", reward.Milestone.Day))
+		if err != nil {
+			fmt.Printf("Error granting daily milestone item: %v\n", err)
+		} else {
+			reward.Item = &item
+			reward.ItemMailed = mailed
+		}
+	}
+
+	return reward, nil
+}
+
+// nextDailyStreak returns the streak after a claim at now and whether a missed day reset it
+func nextDailyStreak(lastClaim time.Time, streak int, now time.Time) (int, bool) {
+	if lastClaim.IsZero() {
+		return 1, false
+	}
+
+	// Claimed during the previous period keeps the streak going
+	previousPeriod := shop.CurrentPeriodStart(now).AddDate(0, 0, -1)
+	if shop.CurrentPeriodStart(lastClaim).Equal(previousPeriod) {
+		return streak + 1, false
+	}
+
+	return 1, streak > 0
+}
+
+// dailyCoinReward returns the coins paid for a claim at the given base reward and streak length
+func dailyCoinReward(base int, streak int) int {
+	bonus := (streak - 1) * variables.DailyStreakBonus
+	if bonus > variables.DailyMaxStreakBonus {
+		bonus = variables.DailyMaxStreakBonus
+	}
+	if bonus < 0 {
+		bonus = 0
+	}
+
+	return base + bonus
+}
@@ -0,0 +1,125 @@
+package database
+
+import (
+	"CrispyBot/database/models"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	dungeonRunsCollection = "dungeonRuns"
+)
+
+// Dungeon run states
+const (
+	DungeonExploring = "exploring"
+	DungeonFighting  = "fighting"
+	DungeonDefeated  = "defeated"
+	DungeonRetreated = "retreated"
+)
+
+// activeDungeonStates are the states of a run that hasn't ended
+var activeDungeonStates = []string{DungeonExploring, DungeonFighting}
+
+// CreateDungeonRun starts a new run, failing if the player already has one in progress
+func CreateDungeonRun(db *DB, run models.DungeonRun) (models.DungeonRun, error) {
+	if db == nil {
+		return models.DungeonRun{}, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(dungeonRunsCollection)
+
+	count, err := collection.CountDocuments(ctx, bson.M{
+		"discordID": run.DiscordID,
+		"state":     bson.M{"$in": activeDungeonStates},
+	})
+	if err != nil {
+		return models.DungeonRun{}, fmt.Errorf("failed to check existing runs: %w", err)
+	}
+	if count > 0 {
+		return models.DungeonRun{}, fmt.Errorf("you are already in the dungeon")
+	}
+
+	result, err := collection.InsertOne(ctx, run)
+	if err != nil {
+		return models.DungeonRun{}, fmt.Errorf("failed to start dungeon run: %w", err)
+	}
+
+	run.ID = result.InsertedID.(primitive.ObjectID)
+	return run, nil
+}
+
+// GetActiveDungeonRun retrieves the player's run in progress
+func GetActiveDungeonRun(db *DB, discordID string) (models.DungeonRun, error) {
+	if db == nil {
+		return models.DungeonRun{}, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(dungeonRunsCollection)
+
+	var run models.DungeonRun
+	err := collection.FindOne(ctx, bson.M{
+		"discordID": discordID,
+		"state":     bson.M{"$in": activeDungeonStates},
+	}).Decode(&run)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.DungeonRun{}, fmt.Errorf("you are not in the dungeon")
+		}
+		return models.DungeonRun{}, fmt.Errorf("failed to get dungeon run: %w", err)
+	}
+
+	return run, nil
+}
+
+// SaveDungeonRun replaces the stored run with the given state
+func SaveDungeonRun(db *DB, run models.DungeonRun) error {
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	run.UpdatedAt = time.Now()
+
+	collection := db.GetCollection(dungeonRunsCollection)
+	_, err := collection.ReplaceOne(ctx, bson.M{"_id": run.ID}, run)
+	if err != nil {
+		return fmt.Errorf("failed to save dungeon run: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateDeepestFloor raises the character's deepest floor if the new one is lower
+func UpdateDeepestFloor(db *DB, characterID primitive.ObjectID, floor int) (bool, error) {
+	if db == nil {
+		return false, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(charactersCollection)
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"_id": characterID, "DeepestFloor": bson.M{"$not": bson.M{"$gte": floor}}},
+		bson.M{"$set": bson.M{"DeepestFloor": floor}},
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to update deepest floor: %w", err)
+	}
+
+	return result.ModifiedCount > 0, nil
+}
@@ -9,6 +9,9 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 )
 
+// How many times to retry when another grant took the same inventory key concurrently
+const inventorySaveAttempts = 3
+
 // EquipItem equips an item to a character
 func EquipItem(db *DB, userID string, itemKey string) error {
 	if db == nil {
@@ -33,6 +36,15 @@ func EquipItem(db *DB, userID string, itemKey string) error {
 		return fmt.Errorf("no character found for this user")
 	}
 
+	// Inventory is shared across the roster, but an item can only be held by one character
+	equipped, err := isEquippedByOtherCharacter(db, character, itemKey)
+	if err != nil {
+		return err
+	}
+	if equipped {
+		return fmt.Errorf("%s is already equipped by another of your characters", itemName)
+	}
+
 	// Set up context
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -46,8 +58,8 @@ func EquipItem(db *DB, userID string, itemKey string) error {
 	// Set the equipped weapon and item name
 	updates := bson.M{
 		"$set": bson.M{
-			"equippedWeapon.itemKey":  itemKey,
-			"equippedWeapon.itemName": itemName,
+			"EquippedWeapon.itemKey":  itemKey,
+			"EquippedWeapon.itemName": itemName,
 		},
 	}
 
@@ -87,7 +99,7 @@ func UnequipItem(db *DB, userID string) error {
 	// Clear the equipped weapon
 	updates := bson.M{
 		"$set": bson.M{
-			"equippedWeapon": models.EquippedItem{},
+			"EquippedWeapon": models.EquippedItem{},
 		},
 	}
 
@@ -181,3 +193,64 @@ func GetItem(db *DB, userID string, inventoryKey string) (models.Item, error) {
 
 	return itemRecord.Item, nil
 }
+
+// AddItemToInventory stores a granted item in the user's inventory and returns its inventory key
+func AddItemToInventory(db *DB, userID string, item models.Item) (string, error) {
+	if db == nil {
+		return "", fmt.Errorf("database connection is nil")
+	}
+
+	for attempt := 0; attempt < inventorySaveAttempts; attempt++ {
+		user, err := GetUserByID(db, userID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get user: %w", err)
+		}
+
+		// Find the next free slot so the key stays usable with !cb equip
+		slot := len(user.Inventory) + 1
+		inventoryKey := fmt.Sprintf("weapon_%d", slot)
+		for {
+			if _, taken := user.Inventory[inventoryKey]; !taken {
+				break
+			}
+			slot++
+			inventoryKey = fmt.Sprintf("weapon_%d", slot)
+		}
+
+		claimed, err := claimInventoryKey(db, userID, inventoryKey, item.Name)
+		if err != nil {
+			return "", err
+		}
+		if !claimed {
+			// Another grant took the key first, look for the next free one
+			continue
+		}
+
+		err = SaveItem(db, item, inventoryKey, userID)
+		if err != nil {
+			return "", fmt.Errorf("failed to save item: %w", err)
+		}
+
+		return inventoryKey, nil
+	}
+
+	return "", fmt.Errorf("failed to update user inventory: inventory kept changing")
+}
+
+// claimInventoryKey puts an item under a key if the key is still free, or returns false
+func claimInventoryKey(db *DB, userID string, inventoryKey string, itemName string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := db.GetCollection(usersCollection).UpdateOne(
+		ctx,
+		bson.M{"discordID": userID, "inventory." + inventoryKey: bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"inventory." + inventoryKey: itemName}},
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to update user inventory: %w", err)
+	}
+
+	return result.MatchedCount > 0, nil
+
+}
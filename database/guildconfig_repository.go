@@ -0,0 +1,150 @@
+package database
+
+import (
+	"CrispyBot/database/models"
+	"CrispyBot/settings"
+	"CrispyBot/variables"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Collection names
+const (
+	guildConfigsCollection = "guildConfigs"
+
+	// How many times to retry when another admin changed the same config concurrently
+	guildConfigSaveAttempts = 3
+)
+
+// Cached Guild Settings
+/*
+	Settings - Resolved settings of the guild.
+	LoadedAt - When they were read from the database.
+*/
+type cachedGuildSettings struct {
+	Settings settings.Settings
+	LoadedAt time.Time
+}
+
+var (
+	// Cache of resolved settings by guild ID, every message reads it
+	guildSettingsCache sync.Map
+)
+
+// GetGuildConfig returns a guild's stored config
+// Note: A guild that never changed anything gets an empty config.
+func GetGuildConfig(db *DB, guildID string) (models.GuildConfig, error) {
+	if db == nil {
+		return models.GuildConfig{}, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var config models.GuildConfig
+	err := db.GetCollection(guildConfigsCollection).FindOne(ctx, bson.M{"guildID": guildID}).Decode(&config)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.GuildConfig{GuildID: guildID}, nil
+		}
+		return models.GuildConfig{}, fmt.Errorf("failed to get guild config: %w", err)
+	}
+
+	return config, nil
+}
+
+// GetGuildSettings returns a guild's resolved settings, cached for a few minutes
+// Note: Direct messages and lookup failures get the defaults so commands keep working.
+func GetGuildSettings(db *DB, guildID string) settings.Settings {
+	if guildID == "" || db == nil {
+		return settings.Defaults()
+	}
+
+	if cached, ok := guildSettingsCache.Load(guildID); ok {
+		entry := cached.(cachedGuildSettings)
+		if time.Since(entry.LoadedAt) < variables.GuildSettingsCacheMinutes*time.Minute {
+			return entry.Settings
+		}
+	}
+
+	config, err := GetGuildConfig(db, guildID)
+	if err != nil {
+		fmt.Printf("Error loading guild settings: %v\n", err)
+		return settings.Defaults()
+	}
+
+	resolved := settings.Resolve(config)
+	guildSettingsCache.Store(guildID, cachedGuildSettings{Settings: resolved, LoadedAt: time.Now()})
+	return resolved
+}
+
+// UpdateGuildConfig applies a change to a guild's config and refreshes its cached settings
+// Note: The change may run more than once, it's applied again to the fresh config when another change got there first.
+func UpdateGuildConfig(db *DB, guildID string, adminID string, change func(*models.GuildConfig) error) (settings.Settings, error) {
+	if db == nil {
+		return settings.Settings{}, fmt.Errorf("database connection is nil")
+	}
+
+	for attempt := 0; attempt < guildConfigSaveAttempts; attempt++ {
+		config, err := GetGuildConfig(db, guildID)
+		if err != nil {
+			return settings.Settings{}, err
+		}
+
+		if err := change(&config); err != nil {
+			return settings.Settings{}, err
+		}
+		config.UpdatedBy = adminID
+		config.UpdatedAt = time.Now()
+
+		saved, err := saveGuildConfig(db, config)
+		if err != nil {
+			return settings.Settings{}, err
+		}
+		if !saved {
+			continue
+		}
+
+		resolved := settings.Resolve(config)
+		guildSettingsCache.Store(guildID, cachedGuildSettings{Settings: resolved, LoadedAt: time.Now()})
+		return resolved, nil
+	}
+
+	return settings.Settings{}, fmt.Errorf("failed to save guild config: config kept changing")
+}
+
+// saveGuildConfig replaces the config if it's still at the version it was read at, or returns false
+func saveGuildConfig(db *DB, config models.GuildConfig) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Configs saved before versions existed have none, which matches null
+	filter := bson.M{"guildID": config.GuildID, "version": config.Version}
+	if config.Version == 0 {
+		filter["version"] = bson.M{"$in": bson.A{0, nil}}
+	}
+	config.Version++
+
+	// The _id is left to the upsert so a first change creates the document
+	config.ID = primitive.NilObjectID
+	_, err := db.GetCollection(guildConfigsCollection).ReplaceOne(ctx,
+		filter,
+		config,
+		options.Replace().SetUpsert(true),
+	)
+	if mongo.IsDuplicateKeyError(err) {
+		// The version moved on, so the upsert tried to add a second config for the guild
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to save guild config: %w", err)
+	}
+
+	return true, nil
+}
@@ -2,6 +2,8 @@ package database
 
 import (
 	"CrispyBot/database/models"
+	"CrispyBot/progression"
+	"CrispyBot/variables"
 	"context"
 	"fmt"
 	"time"
@@ -24,11 +26,7 @@ func resetAllUsersRerolls(db *DB) {
 	_, err := userCollection.UpdateMany(
 		ctx,
 		bson.M{}, // Match all documents
-		bson.M{"$set": bson.M{
-			"fullRerolls":     2,
-			"statRerolls":     1,
-			"lastRerollReset": time.Now(),
-		}},
+		rerollResetPipeline(),
 	)
 
 	if err != nil {
@@ -38,6 +36,17 @@ func resetAllUsersRerolls(db *DB) {
 	}
 }
 
+// rerollResetPipeline restores the daily reroll allowance, adding each user's prestige bonus rerolls
+func rerollResetPipeline() bson.A {
+	return bson.A{
+		bson.M{"$set": bson.M{
+			"fullRerolls":     bson.M{"$add": bson.A{variables.DailyFullRerolls, bson.M{"$ifNull": bson.A{"$prestige.bonusRerolls", 0}}}},
+			"statRerolls":     variables.DailyStatRerolls,
+			"lastRerollReset": time.Now(),
+		}},
+	}
+}
+
 // StartShopRefreshScheduler starts a goroutine to check and refresh the shop periodically
 func StartShopRefreshScheduler(db *DB) {
 	go func() {
@@ -79,6 +88,9 @@ func checkAndRefreshShop(db *DB) error {
 
 		// Reset all users' reroll counts
 		resetAllUsersRerolls(db)
+
+		// Hand out new quests on the same schedule
+		resetAllUsersQuests(db)
 	}
 
 	return nil
@@ -118,21 +130,21 @@ func applyEquipmentBonuses(db *DB, character models.Character) models.Character
 		}
 	}
 
-	// Calculate total values
-	character.Stats.Vitality.TotalValue = character.Stats.Vitality.Value + character.Stats.Vitality.EquipBonus
-	character.Stats.Strength.TotalValue = character.Stats.Strength.Value + character.Stats.Strength.EquipBonus
-	character.Stats.Speed.TotalValue = character.Stats.Speed.Value + character.Stats.Speed.EquipBonus
-	character.Stats.Durability.TotalValue = character.Stats.Durability.Value + character.Stats.Durability.EquipBonus
-	character.Stats.Intelligence.TotalValue = character.Stats.Intelligence.Value + character.Stats.Intelligence.EquipBonus
-	character.Stats.Mana.TotalValue = character.Stats.Mana.Value + character.Stats.Mana.EquipBonus
-	character.Stats.Mastery.TotalValue = character.Stats.Mastery.Value + character.Stats.Mastery.EquipBonus
+	// Calculate total values, keeping the trait and level bonuses already applied
+	character.Stats.Vitality.TotalValue = character.Stats.Vitality.Value + character.Stats.Vitality.EquipBonus + character.Stats.Vitality.TraitBonus + character.Stats.Vitality.LevelBonus
+	character.Stats.Strength.TotalValue = character.Stats.Strength.Value + character.Stats.Strength.EquipBonus + character.Stats.Strength.TraitBonus + character.Stats.Strength.LevelBonus
+	character.Stats.Speed.TotalValue = character.Stats.Speed.Value + character.Stats.Speed.EquipBonus + character.Stats.Speed.TraitBonus + character.Stats.Speed.LevelBonus
+	character.Stats.Durability.TotalValue = character.Stats.Durability.Value + character.Stats.Durability.EquipBonus + character.Stats.Durability.TraitBonus + character.Stats.Durability.LevelBonus
+	character.Stats.Intelligence.TotalValue = character.Stats.Intelligence.Value + character.Stats.Intelligence.EquipBonus + character.Stats.Intelligence.TraitBonus + character.Stats.Intelligence.LevelBonus
+	character.Stats.Mana.TotalValue = character.Stats.Mana.Value + character.Stats.Mana.EquipBonus + character.Stats.Mana.TraitBonus + character.Stats.Mana.LevelBonus
+	character.Stats.Mastery.TotalValue = character.Stats.Mastery.Value + character.Stats.Mastery.EquipBonus + character.Stats.Mastery.TraitBonus + character.Stats.Mastery.LevelBonus
 
 	return character
 }
 
 // clearEquipmentBonuses removes all bonuses from character stats
 func clearEquipmentBonuses(character models.Character) models.Character {
-	// Reset all equipment and trait bonuses to 0
+	// Reset all equipment, trait and prestige bonuses to 0
 	character.Stats.Vitality.EquipBonus = 0
 	character.Stats.Strength.EquipBonus = 0
 	character.Stats.Speed.EquipBonus = 0
@@ -149,14 +161,22 @@ func clearEquipmentBonuses(character models.Character) models.Character {
 	character.Stats.Mana.TraitBonus = 0
 	character.Stats.Mastery.TraitBonus = 0
 
-	// Set total values equal to base values
-	character.Stats.Vitality.TotalValue = character.Stats.Vitality.Value
-	character.Stats.Strength.TotalValue = character.Stats.Strength.Value
-	character.Stats.Speed.TotalValue = character.Stats.Speed.Value
-	character.Stats.Durability.TotalValue = character.Stats.Durability.Value
-	character.Stats.Intelligence.TotalValue = character.Stats.Intelligence.Value
-	character.Stats.Mana.TotalValue = character.Stats.Mana.Value
-	character.Stats.Mastery.TotalValue = character.Stats.Mastery.Value
+	character.Stats.Vitality.PrestigeBonus = 0
+	character.Stats.Strength.PrestigeBonus = 0
+	character.Stats.Speed.PrestigeBonus = 0
+	character.Stats.Durability.PrestigeBonus = 0
+	character.Stats.Intelligence.PrestigeBonus = 0
+	character.Stats.Mana.PrestigeBonus = 0
+	character.Stats.Mastery.PrestigeBonus = 0
+
+	// Set total values to base values plus allocated level points
+	character.Stats.Vitality.TotalValue = character.Stats.Vitality.Value + character.Stats.Vitality.LevelBonus
+	character.Stats.Strength.TotalValue = character.Stats.Strength.Value + character.Stats.Strength.LevelBonus
+	character.Stats.Speed.TotalValue = character.Stats.Speed.Value + character.Stats.Speed.LevelBonus
+	character.Stats.Durability.TotalValue = character.Stats.Durability.Value + character.Stats.Durability.LevelBonus
+	character.Stats.Intelligence.TotalValue = character.Stats.Intelligence.Value + character.Stats.Intelligence.LevelBonus
+	character.Stats.Mana.TotalValue = character.Stats.Mana.Value + character.Stats.Mana.LevelBonus
+	character.Stats.Mastery.TotalValue = character.Stats.Mastery.Value + character.Stats.Mastery.LevelBonus
 
 	return character
 }
@@ -229,14 +249,29 @@ func applyTraitBonuses(character models.Character) models.Character {
 		}
 	}
 
-	// Calculate total values including trait bonuses
-	character.Stats.Vitality.TotalValue = character.Stats.Vitality.Value + character.Stats.Vitality.EquipBonus + character.Stats.Vitality.TraitBonus
-	character.Stats.Strength.TotalValue = character.Stats.Strength.Value + character.Stats.Strength.EquipBonus + character.Stats.Strength.TraitBonus
-	character.Stats.Speed.TotalValue = character.Stats.Speed.Value + character.Stats.Speed.EquipBonus + character.Stats.Speed.TraitBonus
-	character.Stats.Durability.TotalValue = character.Stats.Durability.Value + character.Stats.Durability.EquipBonus + character.Stats.Durability.TraitBonus
-	character.Stats.Intelligence.TotalValue = character.Stats.Intelligence.Value + character.Stats.Intelligence.EquipBonus + character.Stats.Intelligence.TraitBonus
-	character.Stats.Mana.TotalValue = character.Stats.Mana.Value + character.Stats.Mana.EquipBonus + character.Stats.Mana.TraitBonus
-	character.Stats.Mastery.TotalValue = character.Stats.Mastery.Value + character.Stats.Mastery.EquipBonus + character.Stats.Mastery.TraitBonus
+	// Calculate total values including trait and level bonuses
+	character.Stats.Vitality.TotalValue = character.Stats.Vitality.Value + character.Stats.Vitality.EquipBonus + character.Stats.Vitality.TraitBonus + character.Stats.Vitality.LevelBonus
+	character.Stats.Strength.TotalValue = character.Stats.Strength.Value + character.Stats.Strength.EquipBonus + character.Stats.Strength.TraitBonus + character.Stats.Strength.LevelBonus
+	character.Stats.Speed.TotalValue = character.Stats.Speed.Value + character.Stats.Speed.EquipBonus + character.Stats.Speed.TraitBonus + character.Stats.Speed.LevelBonus
+	character.Stats.Durability.TotalValue = character.Stats.Durability.Value + character.Stats.Durability.EquipBonus + character.Stats.Durability.TraitBonus + character.Stats.Durability.LevelBonus
+	character.Stats.Intelligence.TotalValue = character.Stats.Intelligence.Value + character.Stats.Intelligence.EquipBonus + character.Stats.Intelligence.TraitBonus + character.Stats.Intelligence.LevelBonus
+	character.Stats.Mana.TotalValue = character.Stats.Mana.Value + character.Stats.Mana.EquipBonus + character.Stats.Mana.TraitBonus + character.Stats.Mana.LevelBonus
+	character.Stats.Mastery.TotalValue = character.Stats.Mastery.Value + character.Stats.Mastery.EquipBonus + character.Stats.Mastery.TraitBonus + character.Stats.Mastery.LevelBonus
+
+	return character
+}
+
+// applyPrestigeBonus adds the owner's prestige stat bonus and records their prestige level
+func applyPrestigeBonus(db *DB, character models.Character) models.Character {
+	user, err := GetUserByID(db, character.Owner)
+	if err != nil {
+		return character
+	}
+
+	character.Prestige = user.Prestige.Level
+	if user.Prestige.StatBonusPercent > 0 {
+		progression.ApplyPrestigeBonus(&character.Stats, user.Prestige.StatBonusPercent)
+	}
 
 	return character
 }
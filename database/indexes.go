@@ -0,0 +1,111 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EnsureIndexes creates the indexes used by lookups and leaderboards
+func EnsureIndexes(db *DB) {
+	if db == nil {
+		return
+	}
+
+	indexes := map[string][]mongo.IndexModel{
+		usersCollection: {
+			{Keys: bson.D{{Key: "discordID", Value: 1}}},
+			{Keys: bson.D{{Key: "wallet", Value: -1}}},
+			{Keys: bson.D{{Key: "guilds", Value: 1}}},
+		},
+		charactersCollection: {
+			{Keys: bson.D{{Key: "Owner", Value: 1}}},
+			{Keys: bson.D{{Key: "Level", Value: -1}, {Key: "Experience", Value: -1}}},
+			{Keys: bson.D{{Key: "RarityScore", Value: -1}}},
+		},
+		battleStatsCollection: {
+			{Keys: bson.D{{Key: "discordID", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "wins", Value: -1}}},
+		},
+		rankedProfilesCollection: {
+			// One profile per character each season
+			{Keys: bson.D{{Key: "characterID", Value: 1}, {Key: "season", Value: -1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "season", Value: 1}, {Key: "rating", Value: -1}}},
+		},
+		dungeonRunsCollection: {
+			{Keys: bson.D{{Key: "discordID", Value: 1}, {Key: "state", Value: 1}}},
+		},
+		tournamentsCollection: {
+			{Keys: bson.D{{Key: "guildID", Value: 1}, {Key: "status", Value: 1}}},
+		},
+		achievementsCollection: {
+			{Keys: bson.D{{Key: "discordID", Value: 1}}, Options: options.Index().SetUnique(true)},
+		},
+		questsCollection: {
+			{Keys: bson.D{{Key: "discordID", Value: 1}}, Options: options.Index().SetUnique(true)},
+		},
+		clansCollection: {
+			{Keys: bson.D{{Key: "nameKey", Value: 1}}, Options: options.Index().SetUnique(true)},
+			// Also stops a player from being in two clans at once
+			{Keys: bson.D{{Key: "members.discordID", Value: 1}}, Options: options.Index().SetUnique(true)},
+		},
+		guildConfigsCollection: {
+			{Keys: bson.D{{Key: "guildID", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "bannedUsers", Value: 1}}},
+		},
+		adminAuditCollection: {
+			{Keys: bson.D{{Key: "guildID", Value: 1}, {Key: "createdAt", Value: -1}}},
+		},
+		rateLimitsCollection: {
+			// Drops finished cooldown windows
+			{Keys: bson.D{{Key: "expiresAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+		},
+		clanLedgerCollection: {
+			{Keys: bson.D{{Key: "clanID", Value: 1}, {Key: "createdAt", Value: -1}}},
+		},
+		clanWarsCollection: {
+			{Keys: bson.D{{Key: "attackerID", Value: 1}, {Key: "status", Value: 1}}},
+			{Keys: bson.D{{Key: "defenderID", Value: 1}, {Key: "status", Value: 1}}},
+			{Keys: bson.D{{Key: "status", Value: 1}, {Key: "endsAt", Value: 1}}},
+		},
+		characterSnapshotsCollection: {
+			{Keys: bson.D{{Key: "discordID", Value: 1}, {Key: "createdAt", Value: -1}}},
+			{Keys: bson.D{{Key: "characterID", Value: 1}, {Key: "createdAt", Value: -1}}},
+			{Keys: bson.D{{Key: "bannerID", Value: 1}, {Key: "createdAt", Value: -1}}},
+			// Lets each reroll be undone only once
+			{
+				Keys:    bson.D{{Key: "undoOf", Value: 1}},
+				Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"undoOf": bson.M{"$exists": true}}),
+			},
+		},
+		bannersCollection: {
+			{Keys: bson.D{{Key: "guildID", Value: 1}, {Key: "endsAt", Value: 1}}},
+		},
+		mailboxCollection: {
+			{Keys: bson.D{{Key: "ownerID", Value: 1}, {Key: "receivedAt", Value: 1}}},
+			{Keys: bson.D{{Key: "expiresAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+		},
+		matchmakingQueueCollection: {
+			{Keys: bson.D{{Key: "discordID", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "mode", Value: 1}, {Key: "joinedAt", Value: 1}}},
+			// Backstop so stale entries disappear even if no bot instance is running
+			{Keys: bson.D{{Key: "expiresAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(300)},
+		},
+	}
+
+	for collectionName, models := range indexes {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		_, err := db.GetCollection(collectionName).Indexes().CreateMany(ctx, models)
+		cancel()
+
+		if err != nil {
+			fmt.Printf("Error creating indexes for %s: %v\n", collectionName, err)
+		}
+	}
+
+	fmt.Println("Database indexes ensured")
+}
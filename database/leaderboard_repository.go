@@ -0,0 +1,361 @@
+package database
+
+import (
+	"CrispyBot/database/models"
+	"CrispyBot/roller"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Leaderboard categories
+const (
+	LeaderboardLevel  = "level"
+	LeaderboardWins   = "wins"
+	LeaderboardCoins  = "coins"
+	LeaderboardRarity = "rarity"
+)
+
+// LeaderboardCategories lists the supported categories in display order
+var LeaderboardCategories = []string{LeaderboardLevel, LeaderboardWins, LeaderboardCoins, LeaderboardRarity}
+
+// Leaderboard Entry Model
+/*
+	Rank - Position on the leaderboard, starting at 1.
+	DiscordID - Discord ID of the ranked player.
+	Value - Value the leaderboard is sorted by.
+*/
+type LeaderboardEntry struct {
+	Rank      int
+	DiscordID string
+	Value     int
+}
+
+// leaderboardQuery describes where a category's data lives
+type leaderboardQuery struct {
+	collection string
+	idField    string
+	valueField string
+	sort       bson.D
+	// perOwner ranks each player once by their best document. Note: Used for character rosters.
+	perOwner bool
+}
+
+// getLeaderboardQuery returns the query settings for a category
+func getLeaderboardQuery(category string) (leaderboardQuery, error) {
+	switch category {
+	case LeaderboardLevel:
+		return leaderboardQuery{
+			collection: charactersCollection,
+			idField:    "Owner",
+			valueField: "Level",
+			sort:       bson.D{{Key: "Level", Value: -1}, {Key: "Experience", Value: -1}},
+			perOwner:   true,
+		}, nil
+	case LeaderboardWins:
+		return leaderboardQuery{
+			collection: battleStatsCollection,
+			idField:    "discordID",
+			valueField: "wins",
+			sort:       bson.D{{Key: "wins", Value: -1}},
+		}, nil
+	case LeaderboardCoins:
+		return leaderboardQuery{
+			collection: usersCollection,
+			idField:    "discordID",
+			valueField: "wallet",
+			sort:       bson.D{{Key: "wallet", Value: -1}},
+		}, nil
+	case LeaderboardRarity:
+		return leaderboardQuery{
+			collection: charactersCollection,
+			idField:    "Owner",
+			valueField: "RarityScore",
+			sort:       bson.D{{Key: "RarityScore", Value: -1}},
+			perOwner:   true,
+		}, nil
+	default:
+		return leaderboardQuery{}, fmt.Errorf("unknown leaderboard category: %s", category)
+	}
+}
+
+// leaderboardScope builds the filter limiting a leaderboard to a guild's players
+func leaderboardScope(ctx context.Context, db *DB, query leaderboardQuery, guildID string) (bson.M, error) {
+	if guildID == "" {
+		return bson.M{}, nil
+	}
+
+	// The users collection stores guild membership directly
+	if query.collection == usersCollection {
+		return bson.M{"guilds": guildID}, nil
+	}
+
+	// Other collections are filtered by the guild's known players
+	memberIDs, err := db.GetCollection(usersCollection).Distinct(ctx, "discordID", bson.M{"guilds": guildID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guild members: %w", err)
+	}
+
+	return bson.M{query.idField: bson.M{"$in": memberIDs}}, nil
+}
+
+// GetLeaderboard returns one page of a leaderboard and the total number of ranked players
+func GetLeaderboard(db *DB, category string, guildID string, page int, pageSize int) ([]LeaderboardEntry, int, error) {
+	if db == nil {
+		return nil, 0, fmt.Errorf("database connection is nil")
+	}
+
+	query, err := getLeaderboardQuery(category)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if page < 0 {
+		page = 0
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter, err := leaderboardScope(ctx, db, query, guildID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	collection := db.GetCollection(query.collection)
+
+	if query.perOwner {
+		return getOwnerLeaderboard(ctx, collection, query, filter, page, pageSize)
+	}
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count leaderboard: %w", err)
+	}
+
+	opts := options.Find().
+		SetSort(query.sort).
+		SetSkip(int64(page * pageSize)).
+		SetLimit(int64(pageSize)).
+		SetProjection(bson.M{query.idField: 1, query.valueField: 1})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query leaderboard: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	entries := []LeaderboardEntry{}
+	rank := page*pageSize + 1
+	for cursor.Next(ctx) {
+		var doc bson.M
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, 0, fmt.Errorf("failed to decode leaderboard entry: %w", err)
+		}
+
+		entries = append(entries, LeaderboardEntry{
+			Rank:      rank,
+			DiscordID: fmt.Sprint(doc[query.idField]),
+			Value:     leaderboardValue(doc[query.valueField]),
+		})
+		rank++
+	}
+
+	return entries, int(total), nil
+}
+
+// GetLeaderboardRank returns a single player's position on a leaderboard
+func GetLeaderboardRank(db *DB, category string, guildID string, discordID string) (LeaderboardEntry, error) {
+	if db == nil {
+		return LeaderboardEntry{}, fmt.Errorf("database connection is nil")
+	}
+
+	query, err := getLeaderboardQuery(category)
+	if err != nil {
+		return LeaderboardEntry{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	scope, err := leaderboardScope(ctx, db, query, guildID)
+	if err != nil {
+		return LeaderboardEntry{}, err
+	}
+
+	collection := db.GetCollection(query.collection)
+
+	// Find the player's own best entry, only if they're on this leaderboard
+	var doc bson.M
+	viewer := bson.M{"$and": bson.A{scope, bson.M{query.idField: discordID}}}
+	err = collection.FindOne(ctx, viewer, options.FindOne().SetSort(query.sort)).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return LeaderboardEntry{}, fmt.Errorf("you are not ranked on this leaderboard yet")
+		}
+		return LeaderboardEntry{}, fmt.Errorf("failed to get leaderboard entry: %w", err)
+	}
+	value := leaderboardValue(doc[query.valueField])
+
+	// Rank is one more than the number of players ahead
+	filter := bson.M{"$and": bson.A{scope, leaderboardAhead(query.sort, doc)}}
+
+	ahead, err := countLeaderboard(ctx, collection, query, filter)
+	if err != nil {
+		return LeaderboardEntry{}, err
+	}
+
+	return LeaderboardEntry{
+		Rank:      int(ahead) + 1,
+		DiscordID: discordID,
+		Value:     value,
+	}, nil
+}
+
+// leaderboardAhead builds the filter for entries sorted before a document
+// Note: Each sort key only counts once the keys before it tie, the same way the leaderboard breaks ties.
+func leaderboardAhead(sort bson.D, doc bson.M) bson.M {
+	ahead := bson.A{}
+	for i, key := range sort {
+		condition := bson.M{}
+		for _, tied := range sort[:i] {
+			condition[tied.Key] = doc[tied.Key]
+		}
+
+		operator := "$gt"
+		if key.Value == 1 {
+			operator = "$lt"
+		}
+		condition[key.Key] = bson.M{operator: doc[key.Key]}
+		ahead = append(ahead, condition)
+	}
+
+	return bson.M{"$or": ahead}
+}
+
+// getOwnerLeaderboard returns one page of a leaderboard ranking each owner by their best document
+func getOwnerLeaderboard(ctx context.Context, collection *mongo.Collection, query leaderboardQuery, filter bson.M, page int, pageSize int) ([]LeaderboardEntry, int, error) {
+	total, err := countLeaderboard(ctx, collection, query, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Keep every sort key of the owner's best document so ties break the same way
+	group := bson.M{"_id": "$" + query.idField}
+	for _, key := range query.sort {
+		group[key.Key] = bson.M{"$first": "$" + key.Key}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$sort", Value: query.sort}},
+		{{Key: "$group", Value: group}},
+		{{Key: "$sort", Value: query.sort}},
+		{{Key: "$skip", Value: int64(page * pageSize)}},
+		{{Key: "$limit", Value: int64(pageSize)}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query leaderboard: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	entries := []LeaderboardEntry{}
+	rank := page*pageSize + 1
+	for cursor.Next(ctx) {
+		var doc bson.M
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, 0, fmt.Errorf("failed to decode leaderboard entry: %w", err)
+		}
+
+		entries = append(entries, LeaderboardEntry{
+			Rank:      rank,
+			DiscordID: fmt.Sprint(doc["_id"]),
+			Value:     leaderboardValue(doc[query.valueField]),
+		})
+		rank++
+	}
+
+	return entries, total, nil
+}
+
+// countLeaderboard counts the ranked players matching a filter
+func countLeaderboard(ctx context.Context, collection *mongo.Collection, query leaderboardQuery, filter bson.M) (int, error) {
+	if query.perOwner {
+		owners, err := collection.Distinct(ctx, query.idField, filter)
+		if err != nil {
+			return 0, fmt.Errorf("failed to count leaderboard: %w", err)
+		}
+		return len(owners), nil
+	}
+
+	count, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count leaderboard: %w", err)
+	}
+	return int(count), nil
+}
+
+// leaderboardValue converts a decoded numeric field to an int
+func leaderboardValue(value interface{}) int {
+	switch v := value.(type) {
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	default:
+		return 0
+	}
+}
+
+// BackfillRarityScores scores characters rolled before the rarity leaderboard existed
+// Note: Runs at startup and only touches characters without a score, so it's cheap after the first run.
+func BackfillRarityScores(db *DB) {
+	if db == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(charactersCollection)
+
+	cursor, err := collection.Find(ctx, bson.M{"RarityScore": bson.M{"$in": bson.A{nil, 0}}})
+	if err != nil {
+		fmt.Printf("Error finding unscored characters: %v\n", err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	updated := 0
+	for cursor.Next(ctx) {
+		var character models.Character
+		if err := cursor.Decode(&character); err != nil {
+			fmt.Printf("Error decoding character: %v\n", err)
+			continue
+		}
+
+		score := roller.CalculateRarityScore(character)
+		if score == 0 {
+			continue
+		}
+
+		_, err := collection.UpdateOne(ctx, bson.M{"_id": character.ID}, bson.M{"$set": bson.M{"RarityScore": score}})
+		if err != nil {
+			fmt.Printf("Error backfilling rarity score: %v\n", err)
+			continue
+		}
+		updated++
+	}
+
+	if updated > 0 {
+		fmt.Printf("Backfilled rarity scores for %d characters\n", updated)
+	}
+}
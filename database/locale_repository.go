@@ -0,0 +1,94 @@
+package database
+
+import (
+	"CrispyBot/i18n"
+	"CrispyBot/variables"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Cached User Locale
+/*
+	Locale - Language code the user picked. Note: Empty when they follow the guild.
+	LoadedAt - When it was read from the database.
+*/
+type cachedUserLocale struct {
+	Locale   string
+	LoadedAt time.Time
+}
+
+var (
+	// Cache of user languages by Discord ID, every reply reads it
+	userLocaleCache sync.Map
+)
+
+// GetUserLocale returns the language a user picked, cached for a few minutes
+// Note: Users who never picked one, and lookup failures, get an empty locale so the guild's applies.
+func GetUserLocale(db *DB, userID string) string {
+	if userID == "" || db == nil {
+		return ""
+	}
+
+	if cached, ok := userLocaleCache.Load(userID); ok {
+		entry := cached.(cachedUserLocale)
+		if time.Since(entry.LoadedAt) < variables.UserLocaleCacheMinutes*time.Minute {
+			return entry.Locale
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var user struct {
+		Locale string `bson:"locale"`
+	}
+	err := db.GetCollection(usersCollection).FindOne(ctx, bson.M{"discordID": userID}).Decode(&user)
+	if err != nil && err != mongo.ErrNoDocuments {
+		fmt.Printf("Error loading user locale: %v\n", err)
+		return ""
+	}
+
+	userLocaleCache.Store(userID, cachedUserLocale{Locale: user.Locale, LoadedAt: time.Now()})
+	return user.Locale
+}
+
+// SetUserLocale stores the language a user picked and refreshes the cache
+// Note: An empty locale goes back to following the guild.
+func SetUserLocale(db *DB, userID string, locale string) error {
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+
+	// Make sure the user exists so the choice has a document to live on
+	if _, err := GetUserByID(db, userID); err != nil {
+		if _, err := CreateUser(db, userID); err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{"locale": locale}}
+	if locale == "" {
+		update = bson.M{"$unset": bson.M{"locale": ""}}
+	}
+	_, err := db.GetCollection(usersCollection).UpdateOne(ctx, bson.M{"discordID": userID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to save language: %w", err)
+	}
+
+	userLocaleCache.Store(userID, cachedUserLocale{Locale: locale, LoadedAt: time.Now()})
+	return nil
+}
+
+// GetLocalizer returns the translator for a user in a guild
+// Note: The user's language wins over the guild's, pass an empty user for messages to the whole channel.
+func GetLocalizer(db *DB, userID string, guildID string) i18n.Localizer {
+	return i18n.New(GetUserLocale(db, userID), GetGuildSettings(db, guildID).Locale)
+}
@@ -0,0 +1,135 @@
+package database
+
+import (
+	"CrispyBot/database/models"
+	"CrispyBot/variables"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	mailboxCollection = "mailbox"
+)
+
+// GrantItem puts an item in the user's inventory, or in their mailbox if the inventory is full
+// Note: Returns the inventory key, or "" with mailed set when the item went to the mailbox.
+func GrantItem(db *DB, userID string, item models.Item, source string) (string, bool, error) {
+	if db == nil {
+		return "", false, fmt.Errorf("database connection is nil")
+	}
+
+	user, err := GetUserByID(db, userID)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if len(user.Inventory) >= variables.MaxInventorySize {
+		err = SendToMailbox(db, userID, item, source)
+		if err != nil {
+			return "", false, err
+		}
+		return "", true, nil
+	}
+
+	inventoryKey, err := AddItemToInventory(db, userID, item)
+	if err != nil {
+		return "", false, err
+	}
+
+	return inventoryKey, false, nil
+}
+
+// SendToMailbox stores an item for the user to claim later
+func SendToMailbox(db *DB, userID string, item models.Item, source string) error {
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	entry := models.MailboxItem{
+		OwnerID:    userID,
+		Item:       item,
+		Source:     source,
+		ReceivedAt: now,
+		ExpiresAt:  now.AddDate(0, 0, variables.MailboxExpiryDays),
+	}
+
+	_, err := db.GetCollection(mailboxCollection).InsertOne(ctx, entry)
+	if err != nil {
+		return fmt.Errorf("failed to send item to mailbox: %w", err)
+	}
+
+	return nil
+}
+
+// GetMailbox retrieves the user's unclaimed items, oldest first
+func GetMailbox(db *DB, userID string) ([]models.MailboxItem, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "receivedAt", Value: 1}})
+	cursor, err := db.GetCollection(mailboxCollection).Find(ctx, bson.M{"ownerID": userID}, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mailbox: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var items []models.MailboxItem
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, fmt.Errorf("failed to decode mailbox: %w", err)
+	}
+
+	return items, nil
+}
+
+// ClaimMailboxItem moves a mailbox item into the user's inventory and returns its inventory key
+func ClaimMailboxItem(db *DB, userID string, entry models.MailboxItem) (string, error) {
+	if db == nil {
+		return "", fmt.Errorf("database connection is nil")
+	}
+
+	user, err := GetUserByID(db, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+	if len(user.Inventory) >= variables.MaxInventorySize {
+		return "", fmt.Errorf("your inventory is full (%d items)", variables.MaxInventorySize)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(mailboxCollection)
+
+	// Remove the entry first so a double claim can't duplicate the item
+	result, err := collection.DeleteOne(ctx, bson.M{"_id": entry.ID, "ownerID": userID})
+	if err != nil {
+		return "", fmt.Errorf("failed to claim mailbox item: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return "", fmt.Errorf("mailbox item already claimed")
+	}
+
+	inventoryKey, err := AddItemToInventory(db, userID, entry.Item)
+	if err != nil {
+		// Put the item back so it isn't lost
+		_, restoreErr := collection.InsertOne(ctx, entry)
+		if restoreErr != nil {
+			fmt.Printf("Error restoring mailbox item for %s: %v\n", userID, restoreErr)
+		}
+		return "", err
+	}
+
+	return inventoryKey, nil
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Achievement Progress Model
+/*
+	ID - ObjectID for the progress record.
+	DiscordID - Discord ID of the player.
+	Counters - Progress toward each achievement. Note: Key is the achievement ID.
+	Collected - Distinct values seen for collection achievements. Note: Key is the achievement ID.
+	Unlocked - When each achievement was earned. Note: Key is the achievement ID.
+	Version - Incremented on every save so concurrent updates can be detected.
+*/
+type AchievementProgress struct {
+	ID        primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	DiscordID string               `bson:"discordID" json:"discordID"`
+	Counters  map[string]int       `bson:"counters" json:"counters"`
+	Collected map[string][]string  `bson:"collected" json:"collected"`
+	Unlocked  map[string]time.Time `bson:"unlocked" json:"unlocked"`
+	Version   int                  `bson:"version" json:"version"`
+}
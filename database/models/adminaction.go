@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Admin Action Model
+/*
+	ID - ObjectID for the log entry.
+	GuildID - Discord guild the action was taken in.
+	AdminID - Discord ID of the staff member who took it.
+	TargetID - Discord ID of the user it was taken against. Note: Empty for actions like refreshing the shop.
+	Action - Which admin command was used.
+	Amount - Coins or rerolls granted, negative when deducted.
+	Detail - What changed, such as the item given.
+	Reason - Why, as given by the staff member.
+	CreatedAt - When the action was taken.
+*/
+type AdminAction struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	GuildID   string             `bson:"guildID" json:"guildID"`
+	AdminID   string             `bson:"adminID" json:"adminID"`
+	TargetID  string             `bson:"targetID,omitempty" json:"targetID,omitempty"`
+	Action    string             `bson:"action" json:"action"`
+	Amount    int                `bson:"amount,omitempty" json:"amount,omitempty"`
+	Detail    string             `bson:"detail,omitempty" json:"detail,omitempty"`
+	Reason    string             `bson:"reason,omitempty" json:"reason,omitempty"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+}
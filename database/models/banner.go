@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Banner Model
+/*
+	ID - ObjectID for the banner.
+	GuildID - Discord guild the banner runs in.
+	Name - Display name of the banner.
+	Description - Text shown on the banner's embed.
+	RaceBoosts - Extra weight percent for featured races within their tier.
+	InnateBoosts - Extra weight percent for featured innate traits within their tier.
+	WeaponBoosts - Extra weight percent for featured starting weapons.
+	Odds - Rarity odds used instead of the default ones. Note: Nil keeps the default odds.
+	StartsAt - When rolls start using the banner.
+	EndsAt - When the banner stops applying.
+	CreatedBy - Discord ID of the admin who scheduled it.
+	CreatedAt - When the banner was scheduled.
+*/
+type Banner struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	GuildID      string             `bson:"guildID" json:"guildID"`
+	Name         string             `bson:"name" json:"name"`
+	Description  string             `bson:"description" json:"description"`
+	RaceBoosts   map[string]int     `bson:"raceBoosts,omitempty" json:"raceBoosts,omitempty"`
+	InnateBoosts map[string]int     `bson:"innateBoosts,omitempty" json:"innateBoosts,omitempty"`
+	WeaponBoosts map[string]int     `bson:"weaponBoosts,omitempty" json:"weaponBoosts,omitempty"`
+	Odds         *RarityOdds        `bson:"odds,omitempty" json:"odds,omitempty"`
+	StartsAt     time.Time          `bson:"startsAt" json:"startsAt"`
+	EndsAt       time.Time          `bson:"endsAt" json:"endsAt"`
+	CreatedBy    string             `bson:"createdBy" json:"createdBy"`
+	CreatedAt    time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+// Rarity Odds Model
+/*
+	Common - Weight of the Common tier.
+	Uncommon - Weight of the Uncommon tier.
+	Rare - Weight of the Rare tier.
+	Epic - Weight of the Epic tier.
+	Legendary - Weight of the Legendary tier.
+*/
+type RarityOdds struct {
+	Common    int `bson:"common" json:"common"`
+	Uncommon  int `bson:"uncommon" json:"uncommon"`
+	Rare      int `bson:"rare" json:"rare"`
+	Epic      int `bson:"epic" json:"epic"`
+	Legendary int `bson:"legendary" json:"legendary"`
+}
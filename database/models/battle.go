@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Battle Statistics Model
+/*
+	ID - ObjectID for the statistics record.
+	DiscordID - Discord ID of the player.
+	Wins - Total battles won.
+	Losses - Total battles lost.
+	NPCWins - Battles won against NPCs.
+	PvPWins - Battles won against other players.
+	LastBattle - Time of the most recent battle.
+*/
+type BattleStats struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	DiscordID  string             `bson:"discordID" json:"discordID"`
+	Wins       int                `bson:"wins" json:"wins"`
+	Losses     int                `bson:"losses" json:"losses"`
+	NPCWins    int                `bson:"npcWins" json:"npcWins"`
+	PvPWins    int                `bson:"pvpWins" json:"pvpWins"`
+	LastBattle time.Time          `bson:"lastBattle" json:"lastBattle"`
+}
+
+// Queue Entry Model
+/*
+	ID - ObjectID for the queue entry.
+	DiscordID - Discord ID of the queued player.
+	UserName - Display name used in the battle.
+	CharacterID - Character the player queued with.
+	Mode - Queue mode ("ranked" or "casual").
+	Rating - Ranked rating at queue time. Note: 0 for casual.
+	Level - Character level at queue time.
+	GuildID - Guild the player queued from.
+	ChannelID - Channel the player queued from.
+	JoinedAt - When the player joined the queue.
+	ExpiresAt - When the entry is dropped from the queue.
+*/
+type QueueEntry struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	DiscordID   string             `bson:"discordID" json:"discordID"`
+	UserName    string             `bson:"userName" json:"userName"`
+	CharacterID primitive.ObjectID `bson:"characterID" json:"characterID"`
+	Mode        string             `bson:"mode" json:"mode"`
+	Rating      int                `bson:"rating" json:"rating"`
+	Level       int                `bson:"level" json:"level"`
+	GuildID     string             `bson:"guildID" json:"guildID"`
+	ChannelID   string             `bson:"channelID" json:"channelID"`
+	JoinedAt    time.Time          `bson:"joinedAt" json:"joinedAt"`
+	ExpiresAt   time.Time          `bson:"expiresAt" json:"expiresAt"`
+}
@@ -17,6 +17,10 @@ import (
 	EquippedWeapon - EquippedWeapon. Note: Can Boost or Nerf Stats.
 	Level - Character level.
 	Experience - How much until next level.
+	RarityScore - Combined rarity of the character's rolls. Note: Used for the rarity leaderboard.
+	DeepestFloor - Deepest dungeon floor the character has reached.
+	AutoGrowth - Spend level-up stat points automatically along the race's growth curve.
+	Prestige - Owner's prestige level. Note: Loaded from the user, not stored on the character.
 */
 type Character struct {
 	ID              primitive.ObjectID `bson:"_id,omitempty" json:"id"`
@@ -27,6 +31,10 @@ type Character struct {
 	EquippedWeapon  EquippedItem       `bson:"EquippedWeapon" json:"equippedWeapon"`
 	Level           int                `bson:"Level" json:"level"`
 	Experience      int                `bson:"Experience" json:"experience"`
+	RarityScore     int                `bson:"RarityScore" json:"rarityScore"`
+	DeepestFloor    int                `bson:"DeepestFloor" json:"deepestFloor"`
+	AutoGrowth      bool               `bson:"AutoGrowth" json:"autoGrowth"`
+	Prestige        int                `bson:"-" json:"prestige"`
 }
 
 // Equipped Item Model
@@ -109,16 +117,20 @@ type Characteristics struct {
 	Value - Base stat value.
 	EquipBonus - Bonus from equipped items.
 	TraitBonus - Bonus from character traits.
-	TotalValue - Final calculated stat value. Note: Sum of Value + EquipBonus + TraitBonus.
+	LevelBonus - Stat points allocated from level-ups. Note: Kept across stat rerolls.
+	PrestigeBonus - Bonus from the owner's prestige. Note: A percent of the other components.
+	TotalValue - Final calculated stat value. Note: Sum of Value + EquipBonus + TraitBonus + LevelBonus + PrestigeBonus.
 */
 type Stat struct {
-	Rarity     string             `bson:"Rarity" json:"rarity"`
-	Stat_Name  string             `bson:"StatName" json:"statName"`
-	Type       variables.StatType `bson:"Type" json:"type"`
-	Value      int                `bson:"Value" json:"value"`
-	EquipBonus int                `bson:"EquipBonus" json:"equipBonus"`
-	TraitBonus int                `json:"TraitBonus"`
-	TotalValue int                `bson:"TotalValue" json:"totalValue"`
+	Rarity        string             `bson:"Rarity" json:"rarity"`
+	Stat_Name     string             `bson:"StatName" json:"statName"`
+	Type          variables.StatType `bson:"Type" json:"type"`
+	Value         int                `bson:"Value" json:"value"`
+	EquipBonus    int                `bson:"EquipBonus" json:"equipBonus"`
+	TraitBonus    int                `json:"TraitBonus"`
+	LevelBonus    int                `bson:"LevelBonus" json:"levelBonus"`
+	PrestigeBonus int                `bson:"PrestigeBonus" json:"prestigeBonus"`
+	TotalValue    int                `bson:"TotalValue" json:"totalValue"`
 }
 
 // Individual Trait Model
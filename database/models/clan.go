@@ -0,0 +1,95 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Clan Model
+/*
+	ID - ObjectID for the clan.
+	Name - Display name of the clan.
+	NameKey - Lowercased name used for unique, case-insensitive lookups.
+	LeaderID - Discord ID of the clan leader.
+	Members - Everyone in the clan, including the leader.
+	Invites - Discord IDs of players invited to join.
+	Bank - Coins in the shared clan bank.
+	Experience - Clan XP earned from members' battles and wars. Note: The clan level is derived from this.
+	CreatedAt - When the clan was founded.
+*/
+type Clan struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name       string             `bson:"name" json:"name"`
+	NameKey    string             `bson:"nameKey" json:"nameKey"`
+	LeaderID   string             `bson:"leaderID" json:"leaderID"`
+	Members    []ClanMember       `bson:"members" json:"members"`
+	Invites    []string           `bson:"invites" json:"invites"`
+	Bank       int                `bson:"bank" json:"bank"`
+	Experience int                `bson:"experience" json:"experience"`
+	CreatedAt  time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+// Clan Member Model
+/*
+	DiscordID - Discord ID of the member.
+	Role - Member role ("leader", "officer" or "member").
+	Contributed - Clan XP the member has earned for the clan.
+	JoinedAt - When the member joined.
+*/
+type ClanMember struct {
+	DiscordID   string    `bson:"discordID" json:"discordID"`
+	Role        string    `bson:"role" json:"role"`
+	Contributed int       `bson:"contributed" json:"contributed"`
+	JoinedAt    time.Time `bson:"joinedAt" json:"joinedAt"`
+}
+
+// Clan Ledger Entry Model
+/*
+	ID - ObjectID for the entry.
+	ClanID - Clan whose bank changed.
+	DiscordID - Member who moved the coins. Note: Empty for war prizes.
+	Kind - What happened ("deposit", "withdrawal" or "war prize").
+	Amount - Coins moved, negative for withdrawals.
+	Balance - Bank balance after the entry.
+	CreatedAt - When the coins moved.
+*/
+type ClanLedgerEntry struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ClanID    primitive.ObjectID `bson:"clanID" json:"clanID"`
+	DiscordID string             `bson:"discordID,omitempty" json:"discordID,omitempty"`
+	Kind      string             `bson:"kind" json:"kind"`
+	Amount    int                `bson:"amount" json:"amount"`
+	Balance   int                `bson:"balance" json:"balance"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+// Clan War Model
+/*
+	ID - ObjectID for the war.
+	AttackerID - Clan that declared the war.
+	AttackerName - Display name of the attacking clan.
+	DefenderID - Clan the war was declared on.
+	DefenderName - Display name of the defending clan.
+	AttackerScore - Battles won by the attacking clan.
+	DefenderScore - Battles won by the defending clan.
+	Status - War state ("pending", "active" or "finished").
+	ChannelID - Channel where war results are announced.
+	DeclaredAt - When the war was declared.
+	EndsAt - When an active war is scored. Note: Zero until the war is accepted.
+	WinnerID - Clan that won. Note: Empty for a draw or an unfinished war.
+*/
+type ClanWar struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	AttackerID    primitive.ObjectID `bson:"attackerID" json:"attackerID"`
+	AttackerName  string             `bson:"attackerName" json:"attackerName"`
+	DefenderID    primitive.ObjectID `bson:"defenderID" json:"defenderID"`
+	DefenderName  string             `bson:"defenderName" json:"defenderName"`
+	AttackerScore int                `bson:"attackerScore" json:"attackerScore"`
+	DefenderScore int                `bson:"defenderScore" json:"defenderScore"`
+	Status        string             `bson:"status" json:"status"`
+	ChannelID     string             `bson:"channelID" json:"channelID"`
+	DeclaredAt    time.Time          `bson:"declaredAt" json:"declaredAt"`
+	EndsAt        time.Time          `bson:"endsAt,omitempty" json:"endsAt,omitempty"`
+	WinnerID      string             `bson:"winnerID,omitempty" json:"winnerID,omitempty"`
+}
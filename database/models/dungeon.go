@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Dungeon Run Model
+/*
+	ID - ObjectID for the run.
+	DiscordID - Discord ID of the player.
+	CharacterID - Character descending the dungeon.
+	State - Run state ("exploring", "fighting", "defeated" or "retreated").
+	Floor - Current floor. Note: 0 at the entrance.
+	Room - Type of the current floor's room.
+	EnemyName - Enemy guarding the current floor, if any.
+	EnemyLevel - Level of the enemy guarding the current floor.
+	CurrentHP - HP carried between fights.
+	CurrentMP - MP carried between fights.
+	StatusEffects - Status effects carried between fights.
+	LootCoins - Coins collected since the last boss.
+	LootExperience - Experience collected since the last boss.
+	SecuredCoins - Coins secured by defeating bosses. Note: Kept in full on defeat or retreat.
+	SecuredExperience - Experience secured by defeating bosses.
+	StartedAt - When the run began.
+	UpdatedAt - Last time the run changed.
+*/
+type DungeonRun struct {
+	ID                primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	DiscordID         string             `bson:"discordID" json:"discordID"`
+	CharacterID       primitive.ObjectID `bson:"characterID" json:"characterID"`
+	State             string             `bson:"state" json:"state"`
+	Floor             int                `bson:"floor" json:"floor"`
+	Room              string             `bson:"room" json:"room"`
+	EnemyName         string             `bson:"enemyName" json:"enemyName"`
+	EnemyLevel        int                `bson:"enemyLevel" json:"enemyLevel"`
+	CurrentHP         int                `bson:"currentHP" json:"currentHP"`
+	CurrentMP         int                `bson:"currentMP" json:"currentMP"`
+	StatusEffects     map[string]int     `bson:"statusEffects" json:"statusEffects"`
+	LootCoins         int                `bson:"lootCoins" json:"lootCoins"`
+	LootExperience    int                `bson:"lootExperience" json:"lootExperience"`
+	SecuredCoins      int                `bson:"securedCoins" json:"securedCoins"`
+	SecuredExperience int                `bson:"securedExperience" json:"securedExperience"`
+	StartedAt         time.Time          `bson:"startedAt" json:"startedAt"`
+	UpdatedAt         time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Guild Config Model
+/*
+	ID - ObjectID for the config.
+	GuildID - Discord guild the settings apply to.
+	Prefix - Command prefix used instead of !cb. Note: Empty keeps the default.
+	RollChannels - Channels where rolls and rerolls are allowed. Note: Empty allows every channel.
+	BattleChannels - Channels where battles, queues, tournaments and dungeons are allowed. Note: Empty allows every channel.
+	DailyReward - Base coins for a daily claim. Note: Nil keeps the default.
+	FullRerolls - Full rerolls granted every reset. Note: Nil keeps the default.
+	StatRerolls - Stat rerolls granted every reset. Note: Nil keeps the default.
+	ShopSize - Shop items shown. Note: Nil keeps the default.
+	DisabledFeatures - Features switched off in the guild.
+	AdminRoles - Roles allowed to use admin commands. Note: Manage Server always is.
+	BannedUsers - Discord IDs banned from playing in the guild.
+	Locale - Language the bot answers in. Note: Empty keeps English, members can pick their own.
+	UpdatedBy - Discord ID of the admin who last changed a setting.
+	UpdatedAt - When a setting was last changed.
+	Version - Bumped on every change so concurrent changes don't overwrite each other.
+*/
+type GuildConfig struct {
+	ID               primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	GuildID          string             `bson:"guildID" json:"guildID"`
+	Prefix           string             `bson:"prefix,omitempty" json:"prefix,omitempty"`
+	RollChannels     []string           `bson:"rollChannels,omitempty" json:"rollChannels,omitempty"`
+	BattleChannels   []string           `bson:"battleChannels,omitempty" json:"battleChannels,omitempty"`
+	DailyReward      *int               `bson:"dailyReward,omitempty" json:"dailyReward,omitempty"`
+	FullRerolls      *int               `bson:"fullRerolls,omitempty" json:"fullRerolls,omitempty"`
+	StatRerolls      *int               `bson:"statRerolls,omitempty" json:"statRerolls,omitempty"`
+	ShopSize         *int               `bson:"shopSize,omitempty" json:"shopSize,omitempty"`
+	DisabledFeatures []string           `bson:"disabledFeatures,omitempty" json:"disabledFeatures,omitempty"`
+	AdminRoles       []string           `bson:"adminRoles,omitempty" json:"adminRoles,omitempty"`
+	BannedUsers      []string           `bson:"bannedUsers,omitempty" json:"bannedUsers,omitempty"`
+	Locale           string             `bson:"locale,omitempty" json:"locale,omitempty"`
+	UpdatedBy        string             `bson:"updatedBy" json:"updatedBy"`
+	UpdatedAt        time.Time          `bson:"updatedAt" json:"updatedAt"`
+	Version          int                `bson:"version" json:"version"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Mailbox Item Model
+/*
+	ID - ObjectID for the mailbox entry.
+	OwnerID - Discord ID of the recipient.
+	Item - The item waiting to be claimed.
+	Source - Where the item came from (e.g. "Goblin loot").
+	ReceivedAt - When the item arrived in the mailbox.
+	ExpiresAt - When the unclaimed item is discarded.
+*/
+type MailboxItem struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	OwnerID    string             `bson:"ownerID" json:"ownerID"`
+	Item       Item               `bson:"item" json:"item"`
+	Source     string             `bson:"source" json:"source"`
+	ReceivedAt time.Time          `bson:"receivedAt" json:"receivedAt"`
+	ExpiresAt  time.Time          `bson:"expiresAt" json:"expiresAt"`
+}
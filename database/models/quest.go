@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Quest Log Model
+/*
+	ID - ObjectID for the quest log.
+	DiscordID - Discord ID of the player.
+	Daily - Quests assigned for the current day.
+	Weekly - Quests assigned for the current week.
+	Version - Incremented on every save so concurrent updates can be detected.
+*/
+type QuestLog struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	DiscordID string             `bson:"discordID" json:"discordID"`
+	Daily     QuestSet           `bson:"daily" json:"daily"`
+	Weekly    QuestSet           `bson:"weekly" json:"weekly"`
+	Version   int                `bson:"version" json:"version"`
+}
+
+// Quest Set Model
+/*
+	PeriodStart - Reset boundary the quests were assigned for.
+	Quests - The assigned quests and their progress.
+*/
+type QuestSet struct {
+	PeriodStart time.Time `bson:"periodStart" json:"periodStart"`
+	Quests      []Quest   `bson:"quests" json:"quests"`
+}
+
+// Quest Model
+/*
+	TemplateID - ID of the quest template.
+	Progress - Matching events counted so far.
+	CompletedAt - When the quest was completed and rewarded. Note: Zero while in progress.
+*/
+type Quest struct {
+	TemplateID  string    `bson:"templateID" json:"templateID"`
+	Progress    int       `bson:"progress" json:"progress"`
+	CompletedAt time.Time `bson:"completedAt,omitempty" json:"completedAt,omitempty"`
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Ranked Profile Model
+/*
+	ID - ObjectID for the profile.
+	DiscordID - Discord ID of the character's owner.
+	CharacterID - Character the rating belongs to.
+	Season - Season number the rating is for.
+	Rating - Current Elo rating.
+	PeakRating - Highest rating reached this season.
+	Wins - Ranked matches won this season.
+	Losses - Ranked matches lost this season.
+	PlacementGames - Ranked matches played so far. Note: Tier is hidden until placements are done.
+	LastMatch - Time of the most recent ranked match. Note: Used for inactivity decay.
+	Rewarded - Whether season-end rewards have been paid.
+*/
+type RankedProfile struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	DiscordID      string             `bson:"discordID" json:"discordID"`
+	CharacterID    primitive.ObjectID `bson:"characterID" json:"characterID"`
+	Season         int                `bson:"season" json:"season"`
+	Rating         int                `bson:"rating" json:"rating"`
+	PeakRating     int                `bson:"peakRating" json:"peakRating"`
+	Wins           int                `bson:"wins" json:"wins"`
+	Losses         int                `bson:"losses" json:"losses"`
+	PlacementGames int                `bson:"placementGames" json:"placementGames"`
+	LastMatch      time.Time          `bson:"lastMatch" json:"lastMatch"`
+	Rewarded       bool               `bson:"rewarded" json:"rewarded"`
+}
+
+// Season Model
+/*
+	ID - ObjectID for the season.
+	Number - Season number, starting at 1.
+	StartedAt - When the season started.
+	EndsAt - When the season ends and rewards are paid.
+	LastDecay - Last time inactivity decay was applied.
+	Active - Whether this is the current season.
+*/
+type Season struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Number    int                `bson:"number" json:"number"`
+	StartedAt time.Time          `bson:"startedAt" json:"startedAt"`
+	EndsAt    time.Time          `bson:"endsAt" json:"endsAt"`
+	LastDecay time.Time          `bson:"lastDecay" json:"lastDecay"`
+	Active    bool               `bson:"active" json:"active"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Character Snapshot Model
+/*
+	ID - ObjectID for the snapshot.
+	DiscordID - Discord ID of the character's owner.
+	CharacterID - ID of the character after the roll.
+	Source - What produced the roll (roll, reroll, rerollstat, rebirth or undo).
+	Seed - RNG seed the roll was generated from. Note: Zero for undos, which restore instead of rolling.
+	Pity - Pity counters the roll started from. Note: Together with the seed this reproduces the roll.
+	Stat - Name of the stat rerolled. Note: Only set for single stat rerolls.
+	Locks - Stats and characteristics kept from the previous character by a full reroll.
+	BannerID - Banner that was running when the roll happened. Note: Used for the banner's roll audit.
+	Before - The character as it was before the roll. Note: Nil for a first roll.
+	After - The character as the roll left it.
+	UndoOf - Snapshot reverted by an undo. Note: Unique, so a reroll can only be undone once.
+	CreatedAt - When the roll happened.
+*/
+type CharacterSnapshot struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	DiscordID   string             `bson:"discordID" json:"discordID"`
+	CharacterID primitive.ObjectID `bson:"characterID" json:"characterID"`
+	Source      string             `bson:"source" json:"source"`
+	Seed        int64              `bson:"seed" json:"seed"`
+	Pity        map[string]int     `bson:"pity,omitempty" json:"pity,omitempty"`
+	Stat        string             `bson:"stat,omitempty" json:"stat,omitempty"`
+	Locks       []string           `bson:"locks,omitempty" json:"locks,omitempty"`
+	BannerID    primitive.ObjectID `bson:"bannerID,omitempty" json:"bannerID,omitempty"`
+	Before      *Character         `bson:"before,omitempty" json:"before,omitempty"`
+	After       Character          `bson:"after" json:"after"`
+	UndoOf      primitive.ObjectID `bson:"undoOf,omitempty" json:"undoOf,omitempty"`
+	CreatedAt   time.Time          `bson:"createdAt" json:"createdAt"`
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Tournament Model
+/*
+	ID - ObjectID for the tournament.
+	Name - Display name of the tournament.
+	GuildID - Guild the tournament runs in.
+	ChannelID - Channel where matches are announced.
+	OrganizerID - Discord ID of the player who created it.
+	Format - Bracket format ("single", "double" or "swiss").
+	Status - Tournament state ("signup", "running", "finished" or "cancelled").
+	Round - Current round number. Note: 0 until the tournament starts.
+	SwissRounds - Number of rounds to play in a Swiss tournament.
+	Players - Signed-up players.
+	Matches - Every match scheduled so far.
+	PrizePool - Coins paid out to the top finishers. Note: Built from the players' entry fees.
+	CreatedAt - When the tournament was created.
+	FinishedAt - When the final match was decided.
+*/
+type Tournament struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name        string             `bson:"name" json:"name"`
+	GuildID     string             `bson:"guildID" json:"guildID"`
+	ChannelID   string             `bson:"channelID" json:"channelID"`
+	OrganizerID string             `bson:"organizerID" json:"organizerID"`
+	Format      string             `bson:"format" json:"format"`
+	Status      string             `bson:"status" json:"status"`
+	Round       int                `bson:"round" json:"round"`
+	SwissRounds int                `bson:"swissRounds" json:"swissRounds"`
+	Players     []TournamentPlayer `bson:"players" json:"players"`
+	Matches     []TournamentMatch  `bson:"matches" json:"matches"`
+	PrizePool   int                `bson:"prizePool" json:"prizePool"`
+	CreatedAt   time.Time          `bson:"createdAt" json:"createdAt"`
+	FinishedAt  time.Time          `bson:"finishedAt,omitempty" json:"finishedAt,omitempty"`
+}
+
+// Tournament Player Model
+/*
+	DiscordID - Discord ID of the player.
+	UserName - Display name of the player.
+	CharacterID - Character the player signed up with.
+	Seed - Seed number, starting at 1. Note: Lower seeds win no-show ties.
+	Wins - Matches won.
+	Losses - Matches lost.
+	Opponents - Discord IDs already played. Note: Used to avoid Swiss rematches.
+	EntryFee - Coins the player paid to sign up. Note: Refunded if the tournament is cancelled.
+*/
+type TournamentPlayer struct {
+	DiscordID   string             `bson:"discordID" json:"discordID"`
+	UserName    string             `bson:"userName" json:"userName"`
+	CharacterID primitive.ObjectID `bson:"characterID" json:"characterID"`
+	Seed        int                `bson:"seed" json:"seed"`
+	Wins        int                `bson:"wins" json:"wins"`
+	Losses      int                `bson:"losses" json:"losses"`
+	Opponents   []string           `bson:"opponents" json:"opponents"`
+	EntryFee    int                `bson:"entryFee" json:"entryFee"`
+}
+
+// Tournament Match Model
+/*
+	ID - Match number within the tournament.
+	Round - Round the match belongs to.
+	Bracket - Bracket label ("W" winners, "L" losers, "GF" grand final, "S" Swiss).
+	PlayerA - Discord ID of the first player.
+	PlayerB - Discord ID of the second player. Note: Empty for a bye.
+	ReadyA - Whether the first player has checked in.
+	ReadyB - Whether the second player has checked in.
+	Deadline - Check-in deadline before a no-show forfeit.
+	Status - Match state ("waiting", "playing" or "done").
+	Winner - Discord ID of the winner.
+	Forfeit - Whether the match was decided by a no-show.
+*/
+type TournamentMatch struct {
+	ID       int       `bson:"id" json:"id"`
+	Round    int       `bson:"round" json:"round"`
+	Bracket  string    `bson:"bracket" json:"bracket"`
+	PlayerA  string    `bson:"playerA" json:"playerA"`
+	PlayerB  string    `bson:"playerB" json:"playerB"`
+	ReadyA   bool      `bson:"readyA" json:"readyA"`
+	ReadyB   bool      `bson:"readyB" json:"readyB"`
+	Deadline time.Time `bson:"deadline" json:"deadline"`
+	Status   string    `bson:"status" json:"status"`
+	Winner   string    `bson:"winner" json:"winner"`
+	Forfeit  bool      `bson:"forfeit" json:"forfeit"`
+}
@@ -12,18 +12,54 @@ import (
 	DiscordID - User's Discord ID for identification.
 	Wallet - User's current currency/money balance.
 	Inventory - User's item storage. Note: Key is inventory slot, value is item identifier.
-	Character - User's active character data.
-	FullRerolls - Number of complete character rerolls available.
-	StatRerolls - Number of stat-only rerolls available.
+	ActiveCharacter - ID of the character used for battles, equipment and XP. Note: Falls back to the oldest character when unset.
+	CharacterSlots - Extra character slots bought from the shop.
+	FullRerolls - Number of complete character rerolls available. Note: Counted against the default allowance, a guild's allowance shifts it.
+	StatRerolls - Number of stat-only rerolls available. Note: Counted against the default allowance, a guild's allowance shifts it.
 	LastRerollReset - Timestamp of last reroll counter reset. Note: Used for daily/periodic reroll refresh.
+	RerollTokens - Bonus rerolls earned from rewards. Note: Spent once the daily allowance runs out.
+	LastDailyClaim - Timestamp of the last daily reward claim.
+	DailyStreak - Number of consecutive days the daily reward was claimed.
+	Guilds - Discord guild IDs the user has played in. Note: Used to scope leaderboards.
+	RespecTokens - Tokens that refund all of a character's allocated stat points.
+	Prestige - Permanent perks earned by rebirthing characters.
+	Pity - Rolls since the last Epic or better, per pity category (race, stat, innate).
+	Locale - Language the bot answers the user in. Note: Empty follows the guild's language.
+	RollingUntil - Holds the user's slots while a new character is rolled. Note: Expires on its own if a roll never finishes.
 */
 type User struct {
 	ID              primitive.ObjectID `bson:"_id,omitempty" json:"id"`
 	DiscordID       string             `bson:"discordID" json:"discordID"`
 	Wallet          int                `bson:"wallet" json:"wallet"`
 	Inventory       map[string]string  `bson:"inventory" json:"invertory"`
-	Character       Character          `bson:"character,omitempty" json:"character,omitempty"`
+	ActiveCharacter primitive.ObjectID `bson:"activeCharacter,omitempty" json:"activeCharacter,omitempty"`
+	CharacterSlots  int                `bson:"characterSlots" json:"characterSlots"`
 	FullRerolls     int                `bson:"fullRerolls" json:"fullRerolls"`
 	StatRerolls     int                `bson:"statRerolls" json:"statRerolls"`
 	LastRerollReset time.Time          `bson:"lastRerollReset" json:"lastRerollReset"`
+	RerollTokens    int                `bson:"rerollTokens" json:"rerollTokens"`
+	LastDailyClaim  time.Time          `bson:"lastDailyClaim" json:"lastDailyClaim"`
+	DailyStreak     int                `bson:"dailyStreak" json:"dailyStreak"`
+	Guilds          []string           `bson:"guilds" json:"guilds"`
+	RespecTokens    int                `bson:"respecTokens" json:"respecTokens"`
+	Prestige        Prestige           `bson:"prestige" json:"prestige"`
+	Pity            map[string]int     `bson:"pity" json:"pity"`
+	Locale          string             `bson:"locale,omitempty" json:"locale,omitempty"`
+	RollingUntil    time.Time          `bson:"rollingUntil,omitempty" json:"rollingUntil,omitempty"`
+}
+
+// Prestige Model
+/*
+	Level - Number of times the user has rebirthed a character.
+	StatBonusPercent - Percent added to every stat of the user's characters.
+	BonusRerolls - Extra full rerolls added to the daily allowance.
+	WeaponLuck - Chance moved from Common to Epic and Legendary starting weapons.
+	LastRebirth - When the user last rebirthed.
+*/
+type Prestige struct {
+	Level            int       `bson:"level" json:"level"`
+	StatBonusPercent int       `bson:"statBonusPercent" json:"statBonusPercent"`
+	BonusRerolls     int       `bson:"bonusRerolls" json:"bonusRerolls"`
+	WeaponLuck       int       `bson:"weaponLuck" json:"weaponLuck"`
+	LastRebirth      time.Time `bson:"lastRebirth" json:"lastRebirth"`
 }
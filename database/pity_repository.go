@@ -0,0 +1,45 @@
+package database
+
+import (
+	"CrispyBot/roller"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// loadPity returns the user's pity counters as stored and a copy for the next roll to update
+func loadPity(db *DB, userID string) (map[string]int, roller.Pity) {
+	user, err := GetUserByID(db, userID)
+	if err != nil || user.Pity == nil {
+		return map[string]int{}, roller.Pity{}
+	}
+
+	return user.Pity, copyPity(user.Pity)
+}
+
+// copyPity copies pity counters so a roll can update them without touching the originals
+func copyPity(counters map[string]int) roller.Pity {
+	pity := roller.Pity{}
+	for category, misses := range counters {
+		pity[category] = misses
+	}
+	return pity
+}
+
+// savePity stores the user's pity counters after a roll
+func savePity(db *DB, userID string, pity roller.Pity) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := db.GetCollection(usersCollection).UpdateOne(ctx,
+		bson.M{"discordID": userID},
+		bson.M{"$set": bson.M{"pity": map[string]int(pity)}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save pity: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package database
+
+import (
+	"CrispyBot/database/models"
+	"CrispyBot/progression"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// RebirthCharacter trades a high-level character for the next prestige level and a freshly rolled character
+// Note: Returns the new character and the user's new perks.
+func RebirthCharacter(db *DB, userID string, guildID string) (models.Character, models.Prestige, error) {
+	if db == nil {
+		return models.Character{}, models.Prestige{}, fmt.Errorf("database connection is nil")
+	}
+
+	character, err := findActiveCharacter(db, userID)
+	if err != nil {
+		return models.Character{}, models.Prestige{}, fmt.Errorf("no character found for this user: %w", err)
+	}
+
+	err = progression.CanRebirth(character.Level)
+	if err != nil {
+		return models.Character{}, models.Prestige{}, err
+	}
+
+	user, err := GetUserByID(db, userID)
+	if err != nil {
+		return models.Character{}, models.Prestige{}, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	perks := progression.PerksFor(user.Prestige.Level + 1)
+	perks.LastRebirth = time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Filter on the current prestige level so a double rebirth can't skip a level
+	filter := bson.M{"discordID": userID, "prestige.level": user.Prestige.Level}
+	if user.Prestige.Level == 0 {
+		// Older user documents don't have the field at all
+		filter["prestige.level"] = bson.M{"$in": bson.A{0, nil}}
+	}
+
+	// New bonus rerolls are usable right away instead of waiting for the reset
+	userCollection := db.GetCollection(usersCollection)
+	result, err := userCollection.UpdateOne(ctx, filter, bson.M{
+		"$set": bson.M{"prestige": perks},
+		"$inc": bson.M{"fullRerolls": perks.BonusRerolls - user.Prestige.BonusRerolls},
+	})
+	if err != nil {
+		return models.Character{}, models.Prestige{}, fmt.Errorf("failed to update prestige: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return models.Character{}, models.Prestige{}, fmt.Errorf("rebirth already in progress")
+	}
+
+	// The reborn character is saved before the old one goes, so a failed roll leaves the user with their character
+	_, err = rollCharacter(db, userID, guildID, SnapshotRebirth, &character, nil)
+	if err != nil {
+		revertPrestige(db, userID, user.Prestige, perks)
+		return models.Character{}, models.Prestige{}, fmt.Errorf("failed to roll reborn character: %w", err)
+	}
+
+	_, err = db.GetCollection(charactersCollection).DeleteOne(ctx, bson.M{"_id": character.ID})
+	if err != nil {
+		fmt.Printf("Error deleting reborn character %s: %v\n", character.ID.Hex(), err)
+	}
+
+	reborn, err := GetCharacterByOwner(db, userID)
+	if err != nil {
+		return models.Character{}, models.Prestige{}, err
+	}
+
+	return reborn, perks, nil
+}
+
+// revertPrestige puts back the prestige a failed rebirth had already granted
+func revertPrestige(db *DB, userID string, previous models.Prestige, granted models.Prestige) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := db.GetCollection(usersCollection).UpdateOne(ctx,
+		bson.M{"discordID": userID, "prestige.level": granted.Level},
+		bson.M{
+			"$set": bson.M{"prestige": previous},
+			"$inc": bson.M{"fullRerolls": previous.BonusRerolls - granted.BonusRerolls},
+		})
+	if err != nil {
+		fmt.Printf("Error reverting prestige: %v\n", err)
+	}
+}
@@ -0,0 +1,177 @@
+package database
+
+import (
+	"CrispyBot/database/models"
+	"CrispyBot/progression"
+	"CrispyBot/variables"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// AllocateStatPoints spends unspent level-up points on a stat and returns the updated character
+func AllocateStatPoints(db *DB, userID string, statName string, points int) (models.Character, error) {
+	if db == nil {
+		return models.Character{}, fmt.Errorf("database connection is nil")
+	}
+
+	character, err := GetCharacterByOwner(db, userID)
+	if err != nil {
+		return models.Character{}, fmt.Errorf("no character found for this user: %w", err)
+	}
+
+	err = progression.ValidateAllocation(character, statName, points)
+	if err != nil {
+		return models.Character{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Filter on the current bonus so two allocations can't both spend the same points
+	field := fmt.Sprintf("Stats.%s.LevelBonus", statName)
+	current := progression.StatByName(&character.Stats, statName).LevelBonus
+
+	charCollection := db.GetCollection(charactersCollection)
+	result, err := charCollection.UpdateOne(
+		ctx,
+		bson.M{"_id": character.ID, field: current},
+		bson.M{"$inc": bson.M{field: points}},
+	)
+	if err != nil {
+		return models.Character{}, fmt.Errorf("failed to allocate stat points: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return models.Character{}, fmt.Errorf("your stats changed, please try again")
+	}
+
+	return GetCharacterByOwner(db, userID)
+}
+
+// SetAutoGrowth toggles automatic point allocation and spends any unspent points when enabled
+// Note: Returns the points added to each stat.
+func SetAutoGrowth(db *DB, userID string, enabled bool) (map[string]int, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is nil")
+	}
+
+	character, err := GetCharacterByOwner(db, userID)
+	if err != nil {
+		return nil, fmt.Errorf("no character found for this user: %w", err)
+	}
+
+	update := bson.M{"$set": bson.M{"AutoGrowth": enabled}}
+
+	allocation := map[string]int{}
+	if enabled {
+		allocation = progression.AutoAllocate(character, progression.UnspentPoints(character))
+		if len(allocation) > 0 {
+			update["$inc"] = levelBonusIncrements(allocation)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	charCollection := db.GetCollection(charactersCollection)
+	_, err = charCollection.UpdateOne(ctx, bson.M{"_id": character.ID}, update)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update auto growth: %w", err)
+	}
+
+	return allocation, nil
+}
+
+// BuyRespecToken spends coins on a respec token and returns the user's token count
+func BuyRespecToken(db *DB, userID string) (int, error) {
+	if db == nil {
+		return 0, fmt.Errorf("database connection is nil")
+	}
+
+	user, err := GetUserByID(db, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Wallet < variables.RespecTokenPrice {
+		return 0, fmt.Errorf("not enough currency, a respec token costs %d coins", variables.RespecTokenPrice)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Filter on the wallet so concurrent purchases can't overdraw it
+	userCollection := db.GetCollection(usersCollection)
+	result, err := userCollection.UpdateOne(
+		ctx,
+		bson.M{"discordID": userID, "wallet": bson.M{"$gte": variables.RespecTokenPrice}},
+		bson.M{"$inc": bson.M{"wallet": -variables.RespecTokenPrice, "respecTokens": 1}},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to buy respec token: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return 0, fmt.Errorf("not enough currency, a respec token costs %d coins", variables.RespecTokenPrice)
+	}
+
+	return user.RespecTokens + 1, nil
+}
+
+// RespecCharacter spends a respec token to refund every allocated stat point and returns the points refunded
+func RespecCharacter(db *DB, userID string) (int, error) {
+	if db == nil {
+		return 0, fmt.Errorf("database connection is nil")
+	}
+
+	character, err := GetCharacterByOwner(db, userID)
+	if err != nil {
+		return 0, fmt.Errorf("no character found for this user: %w", err)
+	}
+
+	refunded := progression.PointsSpent(character.Stats)
+	if refunded == 0 {
+		return 0, fmt.Errorf("you haven't allocated any stat points")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	userCollection := db.GetCollection(usersCollection)
+	result, err := userCollection.UpdateOne(
+		ctx,
+		bson.M{"discordID": userID, "respecTokens": bson.M{"$gt": 0}},
+		bson.M{"$inc": bson.M{"respecTokens": -1}},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to use respec token: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return 0, fmt.Errorf("you don't have a respec token, buy one with `!cb buy respec` (%d coins)", variables.RespecTokenPrice)
+	}
+
+	reset := bson.M{}
+	for _, statName := range progression.StatNames {
+		reset[fmt.Sprintf("Stats.%s.LevelBonus", statName)] = 0
+	}
+
+	// A respec turns auto growth off so the refunded points can be placed by hand
+	reset["AutoGrowth"] = false
+
+	charCollection := db.GetCollection(charactersCollection)
+	_, err = charCollection.UpdateOne(ctx, bson.M{"_id": character.ID}, bson.M{"$set": reset})
+	if err != nil {
+		return 0, fmt.Errorf("failed to reset stat points: %w", err)
+	}
+
+	return refunded, nil
+}
+
+// levelBonusIncrements builds the $inc document for an allocation
+func levelBonusIncrements(allocation map[string]int) bson.M {
+	increments := bson.M{}
+	for statName, points := range allocation {
+		increments[fmt.Sprintf("Stats.%s.LevelBonus", statName)] = points
+	}
+	return increments
+}
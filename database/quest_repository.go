@@ -0,0 +1,194 @@
+package database
+
+import (
+	"CrispyBot/achievements"
+	"CrispyBot/database/models"
+	"CrispyBot/quests"
+	"CrispyBot/variables"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	questsCollection = "quests"
+
+	// How many times to retry when another event updated the same quest log concurrently
+	questSaveAttempts = 3
+)
+
+// GetQuestLog retrieves a user's quests, assigning new ones for any period that has reset
+func GetQuestLog(db *DB, userID string) (models.QuestLog, error) {
+	if db == nil {
+		return models.QuestLog{}, fmt.Errorf("database connection is nil")
+	}
+
+	for attempt := 0; attempt < questSaveAttempts; attempt++ {
+		log, err := loadQuestLog(db, userID)
+		if err != nil {
+			return models.QuestLog{}, err
+		}
+
+		if !refreshQuestLog(&log, time.Now()) {
+			return log, nil
+		}
+
+		saved, err := saveQuestLog(db, log)
+		if err != nil {
+			return models.QuestLog{}, err
+		}
+		if saved {
+			return log, nil
+		}
+	}
+
+	return models.QuestLog{}, fmt.Errorf("failed to assign quests: quest log kept changing")
+}
+
+// RecordQuestEvent applies a game event to the user's quests and pays out any it completed
+func RecordQuestEvent(db *DB, userID string, event achievements.Event) ([]quests.Template, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is nil")
+	}
+
+	for attempt := 0; attempt < questSaveAttempts; attempt++ {
+		log, err := loadQuestLog(db, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		refreshed := refreshQuestLog(&log, now)
+		completed := append(quests.Advance(&log.Daily, event, now), quests.Advance(&log.Weekly, event, now)...)
+		if !refreshed && len(completed) == 0 && !questProgressed(log, event) {
+			return nil, nil
+		}
+
+		saved, err := saveQuestLog(db, log)
+		if err != nil {
+			return nil, err
+		}
+		if !saved {
+			// Another event got there first, apply this one to the fresh quest log
+			continue
+		}
+
+		for _, template := range completed {
+			err = grantReward(db, userID, template.Reward)
+			if err != nil {
+				fmt.Printf("Error granting reward for quest %s: %v\n", template.ID, err)
+			}
+		}
+
+		return completed, nil
+	}
+
+	return nil, fmt.Errorf("failed to record quest event: quest log kept changing")
+}
+
+// loadQuestLog reads the user's quest log without refreshing it, empty if they have none yet
+func loadQuestLog(db *DB, userID string) (models.QuestLog, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var log models.QuestLog
+	err := db.GetCollection(questsCollection).FindOne(ctx, bson.M{"discordID": userID}).Decode(&log)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.QuestLog{DiscordID: userID}, nil
+		}
+		return models.QuestLog{}, fmt.Errorf("failed to get quests: %w", err)
+	}
+
+	return log, nil
+}
+
+// refreshQuestLog assigns new quests for any period that has reset since they were given
+// Note: Returns true if the log changed.
+func refreshQuestLog(log *models.QuestLog, now time.Time) bool {
+	changed := false
+
+	dailyStart := quests.PeriodStart(quests.PeriodDaily, now)
+	if !log.Daily.PeriodStart.Equal(dailyStart) {
+		log.Daily = quests.Assign(log.DiscordID, quests.PeriodDaily, dailyStart, variables.DailyQuestCount)
+		changed = true
+	}
+
+	weeklyStart := quests.PeriodStart(quests.PeriodWeekly, now)
+	if !log.Weekly.PeriodStart.Equal(weeklyStart) {
+		log.Weekly = quests.Assign(log.DiscordID, quests.PeriodWeekly, weeklyStart, variables.WeeklyQuestCount)
+		changed = true
+	}
+
+	return changed
+}
+
+// questProgressed checks if the event counted toward any open quest
+func questProgressed(log models.QuestLog, event achievements.Event) bool {
+	for _, quest := range append(log.Daily.Quests, log.Weekly.Quests...) {
+		template, ok := quests.Find(quest.TemplateID)
+		if ok && quest.CompletedAt.IsZero() && template.Matches(event) {
+			return true
+		}
+	}
+	return false
+}
+
+// saveQuestLog writes the quest log only if nobody else saved it since it was loaded
+func saveQuestLog(db *DB, log models.QuestLog) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := db.GetCollection(questsCollection).UpdateOne(
+		ctx,
+		bson.M{"discordID": log.DiscordID, "version": log.Version},
+		bson.M{"$set": bson.M{
+			"daily":   log.Daily,
+			"weekly":  log.Weekly,
+			"version": log.Version + 1,
+		}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		// A newer version makes the filter miss and the upsert collide with the unique index
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to save quests: %w", err)
+	}
+
+	return true, nil
+}
+
+// resetAllUsersQuests clears assigned quests so everyone gets a new set on their next action
+// Note: Weekly quests are only cleared when the daily reset starts a new week.
+func resetAllUsersQuests(db *DB) {
+	if db == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	unset := bson.M{"daily": ""}
+	if quests.PeriodStart(quests.PeriodWeekly, now).Equal(quests.PeriodStart(quests.PeriodDaily, now)) {
+		unset["weekly"] = ""
+	}
+
+	_, err := db.GetCollection(questsCollection).UpdateMany(
+		ctx,
+		bson.M{},
+		bson.M{"$unset": unset, "$inc": bson.M{"version": 1}},
+	)
+
+	if err != nil {
+		fmt.Printf("Error resetting quests: %v\n", err)
+	} else {
+		fmt.Println("Successfully reset quests for all users")
+	}
+}
@@ -0,0 +1,179 @@
+package database
+
+import (
+	"CrispyBot/database/models"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	matchmakingQueueCollection = "matchmakingQueue"
+)
+
+// JoinQueue adds a player to the matchmaking queue, replacing any existing entry
+func JoinQueue(db *DB, entry models.QueueEntry) (models.QueueEntry, error) {
+	if db == nil {
+		return models.QueueEntry{}, fmt.Errorf("database connection is nil")
+	}
+
+	if entry.DiscordID == "" {
+		return models.QueueEntry{}, fmt.Errorf("discord ID is required")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(matchmakingQueueCollection)
+
+	// Each player can only be queued once
+	opts := options.FindOneAndReplace().SetUpsert(true).SetReturnDocument(options.After)
+	var saved models.QueueEntry
+	err := collection.FindOneAndReplace(ctx, bson.M{"discordID": entry.DiscordID}, entry, opts).Decode(&saved)
+	if err != nil {
+		return models.QueueEntry{}, fmt.Errorf("failed to join queue: %w", err)
+	}
+
+	return saved, nil
+}
+
+// LeaveQueue removes a player from the matchmaking queue
+func LeaveQueue(db *DB, discordID string) (bool, error) {
+	if db == nil {
+		return false, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(matchmakingQueueCollection)
+	result, err := collection.DeleteOne(ctx, bson.M{"discordID": discordID})
+	if err != nil {
+		return false, fmt.Errorf("failed to leave queue: %w", err)
+	}
+
+	return result.DeletedCount > 0, nil
+}
+
+// GetQueueEntry retrieves a player's queue entry
+func GetQueueEntry(db *DB, discordID string) (models.QueueEntry, error) {
+	if db == nil {
+		return models.QueueEntry{}, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(matchmakingQueueCollection)
+
+	var entry models.QueueEntry
+	err := collection.FindOne(ctx, bson.M{"discordID": discordID}).Decode(&entry)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.QueueEntry{}, fmt.Errorf("you are not in the queue")
+		}
+		return models.QueueEntry{}, fmt.Errorf("failed to get queue entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// GetQueueEntries lists the live entries for a queue mode, oldest first
+func GetQueueEntries(db *DB, mode string) ([]models.QueueEntry, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(matchmakingQueueCollection)
+	filter := bson.M{"mode": mode, "expiresAt": bson.M{"$gt": time.Now()}}
+	opts := options.Find().SetSort(bson.D{{Key: "joinedAt", Value: 1}})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query queue: %w", err)
+	}
+
+	var entries []models.QueueEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, fmt.Errorf("failed to decode queue: %w", err)
+	}
+
+	return entries, nil
+}
+
+// ClaimQueuePair removes two matched entries, failing if either was already taken
+func ClaimQueuePair(db *DB, first models.QueueEntry, second models.QueueEntry) (bool, error) {
+	if db == nil {
+		return false, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(matchmakingQueueCollection)
+
+	// Claim the first entry
+	result, err := collection.DeleteOne(ctx, bson.M{"_id": first.ID})
+	if err != nil {
+		return false, fmt.Errorf("failed to claim queue entry: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return false, nil
+	}
+
+	// Claim the second, putting the first back if another instance got there first
+	result, err = collection.DeleteOne(ctx, bson.M{"_id": second.ID})
+	if err != nil || result.DeletedCount == 0 {
+		_, restoreErr := collection.InsertOne(ctx, first)
+		if restoreErr != nil {
+			fmt.Printf("Error restoring queue entry: %v\n", restoreErr)
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to claim queue entry: %w", err)
+		}
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// PopExpiredQueueEntries removes and returns entries whose timeout has passed
+func PopExpiredQueueEntries(db *DB) ([]models.QueueEntry, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(matchmakingQueueCollection)
+	filter := bson.M{"expiresAt": bson.M{"$lte": time.Now()}}
+
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expired entries: %w", err)
+	}
+
+	var entries []models.QueueEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, fmt.Errorf("failed to decode expired entries: %w", err)
+	}
+
+	// Only report the entries this call actually removed
+	expired := []models.QueueEntry{}
+	for _, entry := range entries {
+		result, err := collection.DeleteOne(ctx, bson.M{"_id": entry.ID})
+		if err == nil && result.DeletedCount > 0 {
+			expired = append(expired, entry)
+		}
+	}
+
+	return expired, nil
+}
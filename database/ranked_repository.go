@@ -0,0 +1,419 @@
+package database
+
+import (
+	"CrispyBot/database/models"
+	"CrispyBot/ranked"
+	"CrispyBot/shop"
+	"CrispyBot/variables"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	rankedProfilesCollection = "rankedProfiles"
+	seasonsCollection        = "seasons"
+
+	// How many times to retry when another match changed the same rating concurrently
+	rankedSaveAttempts = 3
+)
+
+// Ranked Match Result Model
+/*
+	Winner - Winner's profile after the match.
+	Loser - Loser's profile after the match.
+	WinnerDelta - Rating gained by the winner.
+	LoserDelta - Rating lost by the loser. Note: Negative.
+*/
+type RankedMatchResult struct {
+	Winner      models.RankedProfile
+	Loser       models.RankedProfile
+	WinnerDelta int
+	LoserDelta  int
+}
+
+// GetCurrentSeason retrieves the active ranked season or starts the first one
+func GetCurrentSeason(db *DB) (models.Season, error) {
+	if db == nil {
+		return models.Season{}, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(seasonsCollection)
+
+	var season models.Season
+	err := collection.FindOne(ctx, bson.M{"active": true}).Decode(&season)
+	if err == nil {
+		return season, nil
+	} else if err != mongo.ErrNoDocuments {
+		return models.Season{}, fmt.Errorf("failed to query season: %w", err)
+	}
+
+	// No season yet, start the first one
+	return startSeason(db, 1)
+}
+
+// startSeason creates a new active season
+func startSeason(db *DB, number int) (models.Season, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	season := models.Season{
+		Number:    number,
+		StartedAt: now,
+		EndsAt:    shop.CurrentPeriodStart(now).AddDate(0, 0, variables.RankedSeasonLengthDays),
+		LastDecay: now,
+		Active:    true,
+	}
+
+	collection := db.GetCollection(seasonsCollection)
+	result, err := collection.InsertOne(ctx, season)
+	if err != nil {
+		return models.Season{}, fmt.Errorf("failed to start season: %w", err)
+	}
+
+	season.ID = result.InsertedID.(primitive.ObjectID)
+	fmt.Printf("Ranked season %d started, ends %s\n", season.Number, season.EndsAt.Format(time.RFC1123))
+
+	return season, nil
+}
+
+// GetRankedProfile retrieves a character's rating for the current season, creating it if needed
+func GetRankedProfile(db *DB, discordID string, characterID primitive.ObjectID) (models.RankedProfile, error) {
+	if db == nil {
+		return models.RankedProfile{}, fmt.Errorf("database connection is nil")
+	}
+
+	season, err := GetCurrentSeason(db)
+	if err != nil {
+		return models.RankedProfile{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(rankedProfilesCollection)
+
+	var profile models.RankedProfile
+	err = collection.FindOne(ctx, bson.M{"characterID": characterID, "season": season.Number}).Decode(&profile)
+	if err == nil {
+		return profile, nil
+	} else if err != mongo.ErrNoDocuments {
+		return models.RankedProfile{}, fmt.Errorf("failed to query ranked profile: %w", err)
+	}
+
+	// Start from the previous season's rating if this character played before
+	rating := variables.RankedStartingRating
+	var previous models.RankedProfile
+	opts := options.FindOne().SetSort(bson.D{{Key: "season", Value: -1}})
+	err = collection.FindOne(ctx, bson.M{"characterID": characterID, "season": bson.M{"$lt": season.Number}}, opts).Decode(&previous)
+	if err == nil {
+		rating = ranked.SoftReset(previous.Rating)
+	}
+
+	profile = models.RankedProfile{
+		DiscordID:   discordID,
+		CharacterID: characterID,
+		Season:      season.Number,
+		Rating:      rating,
+		PeakRating:  rating,
+	}
+
+	result, err := collection.InsertOne(ctx, profile)
+	if mongo.IsDuplicateKeyError(err) {
+		// Another command created it first, use theirs
+		err = collection.FindOne(ctx, bson.M{"characterID": characterID, "season": season.Number}).Decode(&profile)
+		if err != nil {
+			return models.RankedProfile{}, fmt.Errorf("failed to query ranked profile: %w", err)
+		}
+		return profile, nil
+	} else if err != nil {
+		return models.RankedProfile{}, fmt.Errorf("failed to create ranked profile: %w", err)
+	}
+	profile.ID = result.InsertedID.(primitive.ObjectID)
+
+	return profile, nil
+}
+
+// RecordRankedMatch updates both characters' ratings after a ranked battle
+func RecordRankedMatch(db *DB, winnerID string, winnerCharacterID primitive.ObjectID, loserID string, loserCharacterID primitive.ObjectID) (RankedMatchResult, error) {
+	if db == nil {
+		return RankedMatchResult{}, fmt.Errorf("database connection is nil")
+	}
+
+	for attempt := 0; attempt < rankedSaveAttempts; attempt++ {
+		winner, err := GetRankedProfile(db, winnerID, winnerCharacterID)
+		if err != nil {
+			return RankedMatchResult{}, fmt.Errorf("failed to get winner's ranked profile: %w", err)
+		}
+
+		loser, err := GetRankedProfile(db, loserID, loserCharacterID)
+		if err != nil {
+			return RankedMatchResult{}, fmt.Errorf("failed to get loser's ranked profile: %w", err)
+		}
+
+		// Calculate the rating changes
+		winnerDelta, loserDelta := ranked.CalculateRatingChange(
+			winner.Rating,
+			loser.Rating,
+			winner.PlacementGames < variables.RankedPlacementMatches,
+			loser.PlacementGames < variables.RankedPlacementMatches,
+		)
+
+		now := time.Now()
+		winner, saved, err := applyRankedResult(db, winner, winnerDelta, true, now)
+		if err != nil {
+			return RankedMatchResult{}, err
+		}
+		if !saved {
+			// Another match moved the winner's rating, recalculate from the fresh ratings
+			continue
+		}
+
+		// The winner is recorded, so the loser takes the same delta even if their rating moved since
+		for loserAttempt := 0; loserAttempt < rankedSaveAttempts; loserAttempt++ {
+			if loserAttempt > 0 {
+				loser, err = GetRankedProfile(db, loserID, loserCharacterID)
+				if err != nil {
+					return RankedMatchResult{}, fmt.Errorf("failed to get loser's ranked profile: %w", err)
+				}
+			}
+
+			loser, saved, err = applyRankedResult(db, loser, loserDelta, false, now)
+			if err != nil {
+				return RankedMatchResult{}, err
+			}
+			if saved {
+				return RankedMatchResult{
+					Winner:      winner,
+					Loser:       loser,
+					WinnerDelta: winnerDelta,
+					LoserDelta:  loserDelta,
+				}, nil
+			}
+		}
+
+		return RankedMatchResult{}, fmt.Errorf("failed to update loser's ranked profile: rating kept changing")
+	}
+
+	return RankedMatchResult{}, fmt.Errorf("failed to update winner's ranked profile: rating kept changing")
+}
+
+// applyRankedResult adds one match to a profile, or returns false if its rating changed since it was read
+// Note: Filtering on the old rating keeps a concurrent match from being overwritten, wins and losses are counted with $inc.
+func applyRankedResult(db *DB, profile models.RankedProfile, delta int, won bool, now time.Time) (models.RankedProfile, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rating := max(0, profile.Rating+delta)
+	counter := "losses"
+	if won {
+		counter = "wins"
+	}
+
+	var updated models.RankedProfile
+	err := db.GetCollection(rankedProfilesCollection).FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": profile.ID, "rating": profile.Rating},
+		bson.M{
+			"$set": bson.M{"rating": rating, "lastMatch": now},
+			"$max": bson.M{"peakRating": rating},
+			"$inc": bson.M{counter: 1, "placementGames": 1},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+	if err == mongo.ErrNoDocuments {
+		return models.RankedProfile{}, false, nil
+	} else if err != nil {
+		return models.RankedProfile{}, false, fmt.Errorf("failed to update ranked profile: %w", err)
+	}
+
+	return updated, true, nil
+}
+
+// StartRankedScheduler starts a goroutine that handles rating decay and season rollover
+func StartRankedScheduler(db *DB) {
+	go func() {
+		for {
+			err := checkRankedSeason(db)
+			if err != nil {
+				fmt.Printf("Error checking ranked season: %v\n", err)
+			}
+
+			// Check on the same cadence as the shop
+			time.Sleep(time.Minute * 5)
+		}
+	}()
+
+	fmt.Println("Ranked season scheduler started")
+}
+
+// checkRankedSeason ends the season when it expires and applies daily decay otherwise
+func checkRankedSeason(db *DB) error {
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+
+	season, err := GetCurrentSeason(db)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if now.After(season.EndsAt) {
+		return endSeason(db, season)
+	}
+
+	// Decay once per reset period
+	if shop.CurrentPeriodStart(now).After(season.LastDecay) {
+		return applyRatingDecay(db, season)
+	}
+
+	return nil
+}
+
+// applyRatingDecay lowers the rating of inactive high-rated characters
+func applyRatingDecay(db *DB, season models.Season) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	inactiveSince := time.Now().AddDate(0, 0, -variables.RankedDecayAfterDays)
+
+	collection := db.GetCollection(rankedProfilesCollection)
+	cursor, err := collection.Find(ctx, bson.M{
+		"season":    season.Number,
+		"lastMatch": bson.M{"$lt": inactiveSince},
+		"rating":    bson.M{"$gt": variables.RankedDecayFloor},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query inactive profiles: %w", err)
+	}
+
+	var profiles []models.RankedProfile
+	if err := cursor.All(ctx, &profiles); err != nil {
+		return fmt.Errorf("failed to decode inactive profiles: %w", err)
+	}
+
+	decayed := 0
+	for _, profile := range profiles {
+		// Filter on the old rating so a match played meanwhile isn't overwritten
+		result, err := collection.UpdateOne(
+			ctx,
+			bson.M{"_id": profile.ID, "rating": profile.Rating},
+			bson.M{"$set": bson.M{"rating": ranked.DecayRating(profile.Rating)}},
+		)
+		if err != nil {
+			fmt.Printf("Error applying rating decay: %v\n", err)
+			continue
+		}
+		decayed += int(result.ModifiedCount)
+	}
+
+	// Remember that today's decay has run
+	_, err = db.GetCollection(seasonsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": season.ID},
+		bson.M{"$set": bson.M{"lastDecay": time.Now()}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update season decay time: %w", err)
+	}
+
+	fmt.Printf("Ranked decay applied to %d inactive profiles\n", decayed)
+	return nil
+}
+
+// endSeason pays out season rewards and starts the next season
+func endSeason(db *DB, season models.Season) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(rankedProfilesCollection)
+
+	// Only characters that finished placements earn rewards
+	filter := bson.M{
+		"season":         season.Number,
+		"rewarded":       bson.M{"$ne": true},
+		"placementGames": bson.M{"$gte": variables.RankedPlacementMatches},
+	}
+
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to query season profiles: %w", err)
+	}
+
+	var profiles []models.RankedProfile
+	if err := cursor.All(ctx, &profiles); err != nil {
+		return fmt.Errorf("failed to decode season profiles: %w", err)
+	}
+
+	for _, profile := range profiles {
+		tier := ranked.GetTier(profile.Rating)
+
+		// Mark as rewarded first so a crash can't pay twice
+		result, err := collection.UpdateOne(
+			ctx,
+			bson.M{"_id": profile.ID, "rewarded": bson.M{"$ne": true}},
+			bson.M{"$set": bson.M{"rewarded": true}},
+		)
+		if err != nil || result.ModifiedCount == 0 {
+			continue
+		}
+
+		if _, err := AddCurrency(db, profile.DiscordID, tier.SeasonCoins); err != nil {
+			fmt.Printf("Error paying season coins: %v\n", err)
+		}
+		if tier.SeasonTokens > 0 {
+			if err := AddRerollTokens(db, profile.DiscordID, tier.SeasonTokens); err != nil {
+				fmt.Printf("Error paying season tokens: %v\n", err)
+			}
+		}
+	}
+
+	// Close this season and open the next
+	_, err = db.GetCollection(seasonsCollection).UpdateOne(
+		ctx,
+		bson.M{"_id": season.ID},
+		bson.M{"$set": bson.M{"active": false}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to close season: %w", err)
+	}
+
+	fmt.Printf("Ranked season %d ended, rewarded %d profiles\n", season.Number, len(profiles))
+
+	_, err = startSeason(db, season.Number+1)
+	return err
+}
+
+// GetSeasonStanding returns a profile's position among rated characters in its season
+func GetSeasonStanding(db *DB, profile models.RankedProfile) (int, int, error) {
+	if db == nil {
+		return 0, 0, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(rankedProfilesCollection)
+
+	ahead, err := collection.CountDocuments(ctx, bson.M{"season": profile.Season, "rating": bson.M{"$gt": profile.Rating}})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count standings: %w", err)
+	}
+
+	total, err := collection.CountDocuments(ctx, bson.M{"season": profile.Season})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count standings: %w", err)
+	}
+
+	return int(ahead) + 1, int(total), nil
+}
@@ -0,0 +1,99 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Collection names
+const (
+	rateLimitsCollection = "rateLimits"
+)
+
+// RateLimitStore counts command hits and cooldowns in the database so every bot instance shares the same cooldowns
+type RateLimitStore struct {
+	db *DB
+}
+
+// NewRateLimitStore builds a rate limit store on a database connection
+func NewRateLimitStore(db *DB) *RateLimitStore {
+	return &RateLimitStore{db: db}
+}
+
+// Hit counts one use of a key in a window and returns the count so far
+// Note: Windows are removed by a TTL index once they expire.
+func (store *RateLimitStore) Hit(key string, windowStart time.Time, window time.Duration) (int, error) {
+	if store.db == nil {
+		return 0, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": fmt.Sprintf("%s:%d", key, windowStart.Unix())}
+	update := bson.M{
+		"$inc":         bson.M{"count": 1},
+		"$setOnInsert": bson.M{"expiresAt": windowStart.Add(window)},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var counter struct {
+		Count int `bson:"count"`
+	}
+	err := store.db.GetCollection(rateLimitsCollection).FindOneAndUpdate(ctx, filter, update, opts).Decode(&counter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count rate limit hit: %w", err)
+	}
+
+	return counter.Count, nil
+}
+
+// Use records a use of a key unless limit uses already happened in the cooldown
+// Note: Uses are kept newest first, so the use at index limit-1 decides. A refusal shows up as a duplicate key when the guarded upsert finds the key busy.
+func (store *RateLimitStore) Use(key string, now time.Time, cooldown time.Duration, limit int) (time.Duration, bool, error) {
+	if store.db == nil {
+		return 0, false, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := store.db.GetCollection(rateLimitsCollection)
+	filter := bson.M{
+		"_id":                           key,
+		fmt.Sprintf("uses.%d", limit-1): bson.M{"$not": bson.M{"$gt": now.Add(-cooldown)}},
+	}
+	update := bson.M{
+		"$push": bson.M{"uses": bson.M{"$each": []time.Time{now}, "$position": 0, "$slice": limit}},
+		"$set":  bson.M{"refused": false, "expiresAt": now.Add(cooldown)},
+	}
+	_, err := collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err == nil {
+		return 0, false, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return 0, false, fmt.Errorf("failed to record cooldown use: %w", err)
+	}
+
+	// Still cooling down, flag the refusal so only the first one is reported
+	var cooldownState struct {
+		Uses    []time.Time `bson:"uses"`
+		Refused bool        `bson:"refused"`
+	}
+	err = collection.FindOneAndUpdate(ctx, bson.M{"_id": key}, bson.M{"$set": bson.M{"refused": true}},
+		options.FindOneAndUpdate().SetReturnDocument(options.Before)).Decode(&cooldownState)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read cooldown: %w", err)
+	}
+	if len(cooldownState.Uses) < limit {
+		return 0, false, fmt.Errorf("cooldown %s changed while it was checked", key)
+	}
+
+	wait := cooldownState.Uses[limit-1].Add(cooldown).Sub(now)
+	return max(wait, time.Millisecond), !cooldownState.Refused, nil
+}
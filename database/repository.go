@@ -2,11 +2,13 @@ package database
 
 import (
 	"CrispyBot/database/models"
+	"CrispyBot/progression"
 	"CrispyBot/roller"
 	"CrispyBot/variables"
 	"context"
 	"fmt"
 	"math/rand"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -52,8 +54,8 @@ func CreateUser(db *DB, userID string) (models.User, error) {
 	newUser := models.User{
 		DiscordID:       userID,
 		Wallet:          0,
-		FullRerolls:     2,
-		StatRerolls:     1,
+		FullRerolls:     variables.DailyFullRerolls,
+		StatRerolls:     variables.DailyStatRerolls,
 		LastRerollReset: time.Now(),
 	}
 
@@ -96,8 +98,9 @@ func GetUserByID(db *DB, discordID string) (models.User, error) {
 	return user, nil
 }
 
-// SaveCharacter saves a character to the database
-func SaveCharacter(db *DB, character models.Character, discordID string) (models.Character, error) {
+// SaveCharacter saves a character to the database along with its starting weapon
+// Note: The starting weapon is rolled under the banner, if one is running.
+func SaveCharacter(db *DB, character models.Character, discordID string, banner *roller.Banner) (models.Character, error) {
 	if db == nil {
 		return models.Character{}, fmt.Errorf("database connection is nil")
 	}
@@ -111,16 +114,27 @@ func SaveCharacter(db *DB, character models.Character, discordID string) (models
 
 	// Associate character with owner
 	character.Owner = discordID
+	character.RarityScore = roller.CalculateRarityScore(character)
 
-	// Create an initial weapon for the character
+	// Initialize user if they don't exist
+	user, err := GetUserByID(db, discordID)
+	if err != nil {
+		// Create new user
+		user, err = CreateUser(db, discordID)
+		if err != nil {
+			return models.Character{}, fmt.Errorf("failed to create user: %w", err)
+		}
+	}
+
+	// Create an initial weapon for the character, with better odds for prestiged users
 	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
-	initialWeapon := roller.GenerateInitialWeaponItem(character.Characteristics.Alignment.Trait_Name, rng)
+	initialWeapon := roller.GenerateInitialWeaponItem(character.Characteristics.Alignment.Trait_Name, user.Prestige.WeaponLuck, banner, rng)
 
 	// Generate a unique inventory key for the weapon
 	inventoryKey := fmt.Sprintf("weapon_%d", time.Now().UnixNano())
 
 	// Save the item to the database
-	err := SaveItem(db, initialWeapon, inventoryKey, discordID)
+	err = SaveItem(db, initialWeapon, inventoryKey, discordID)
 	if err != nil {
 		return models.Character{}, fmt.Errorf("failed to save initial weapon: %w", err)
 	}
@@ -131,16 +145,6 @@ func SaveCharacter(db *DB, character models.Character, discordID string) (models
 		ItemName: initialWeapon.Name,
 	}
 
-	// Initialize user if they don't exist
-	user, err := GetUserByID(db, discordID)
-	if err != nil {
-		// Create new user
-		user, err = CreateUser(db, discordID)
-		if err != nil {
-			return models.Character{}, fmt.Errorf("failed to create user: %w", err)
-		}
-	}
-
 	// Initialize user inventory if it doesn't exist
 	if user.Inventory == nil {
 		user.Inventory = make(map[string]string)
@@ -170,10 +174,10 @@ func SaveCharacter(db *DB, character models.Character, discordID string) (models
 	// Get the ID of the inserted document
 	character.ID = result.InsertedID.(primitive.ObjectID)
 
-	// Also update the user document to reference this character
+	// A freshly rolled character becomes the active one
 	userCollection = db.GetCollection(usersCollection)
 	filter := bson.M{"discordID": discordID}
-	update := bson.M{"$set": bson.M{"character": character}}
+	update := bson.M{"$set": bson.M{"activeCharacter": character.ID}}
 	opts := options.Update().SetUpsert(true)
 
 	_, err = userCollection.UpdateOne(ctx, filter, update, opts)
@@ -205,18 +209,23 @@ func DeleteCharacter(db *DB, userID string) error {
 
 	// Delete the character from the characters collection
 	charCollection := db.GetCollection(charactersCollection)
-	_, err = charCollection.DeleteOne(ctx, character)
+	_, err = charCollection.DeleteOne(ctx, bson.M{"_id": character.ID})
 	if err != nil {
 		return fmt.Errorf("failed to delete character: %w", err)
 	}
 
-	// Also remove the character reference from the user document
+	// Hand the active slot to the oldest remaining character, if any
+	update := bson.M{"$unset": bson.M{"activeCharacter": ""}}
+	var next models.Character
+	err = charCollection.FindOne(ctx, bson.M{"Owner": userID}, options.FindOne().SetSort(bson.M{"_id": 1})).Decode(&next)
+	if err == nil {
+		update = bson.M{"$set": bson.M{"activeCharacter": next.ID}}
+	} else if err != mongo.ErrNoDocuments {
+		return fmt.Errorf("failed to find remaining characters: %w", err)
+	}
+
 	userCollection := db.GetCollection(usersCollection)
-	_, err = userCollection.UpdateOne(
-		ctx,
-		bson.M{"discordID": userID},
-		bson.M{"$unset": bson.M{"character": ""}},
-	)
+	_, err = userCollection.UpdateOne(ctx, bson.M{"discordID": userID}, update)
 	if err != nil {
 		return fmt.Errorf("failed to update user after character deletion: %w", err)
 	}
@@ -224,7 +233,7 @@ func DeleteCharacter(db *DB, userID string) error {
 	return nil
 }
 
-// GetCharacterByOwner retrieves a character by owner ID with equipment stats applied
+// GetCharacterByOwner retrieves the owner's active character with equipment stats applied
 func GetCharacterByOwner(db *DB, ownerID string) (models.Character, error) {
 	if db == nil {
 		return models.Character{}, fmt.Errorf("database connection is nil")
@@ -234,19 +243,9 @@ func GetCharacterByOwner(db *DB, ownerID string) (models.Character, error) {
 		return models.Character{}, fmt.Errorf("owner ID is required")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	collection := db.GetCollection(charactersCollection)
-	filter := bson.M{"owner": ownerID}
-
-	var character models.Character
-	err := collection.FindOne(ctx, filter).Decode(&character)
+	character, err := findActiveCharacter(db, ownerID)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return models.Character{}, fmt.Errorf("no character found for user: %s", ownerID)
-		}
-		return models.Character{}, fmt.Errorf("failed to query character: %w", err)
+		return models.Character{}, err
 	}
 
 	// Clear any existing bonuses
@@ -260,6 +259,9 @@ func GetCharacterByOwner(db *DB, ownerID string) (models.Character, error) {
 		character = applyEquipmentBonuses(db, character)
 	}
 
+	// Prestige scales everything else, so it goes last
+	character = applyPrestigeBonus(db, character)
+
 	return character, nil
 }
 
@@ -304,6 +306,9 @@ func GetCharacter(db *DB, characterID string) (models.Character, error) {
 		character = applyEquipmentBonuses(db, character)
 	}
 
+	// Prestige scales everything else, so it goes last
+	character = applyPrestigeBonus(db, character)
+
 	return character, nil
 }
 
@@ -318,15 +323,11 @@ func ResetRerollCounts(db *DB, userID string) error {
 
 	userCollection := db.GetCollection(usersCollection)
 
-	// Update the user's reroll counts
+	// Update the user's reroll counts, including prestige bonus rerolls
 	_, err := userCollection.UpdateOne(
 		ctx,
 		bson.M{"discordID": userID},
-		bson.M{"$set": bson.M{
-			"fullRerolls":     2,
-			"statRerolls":     1,
-			"lastRerollReset": time.Now(),
-		}},
+		rerollResetPipeline(),
 	)
 
 	if err != nil {
@@ -336,20 +337,46 @@ func ResetRerollCounts(db *DB, userID string) error {
 	return nil
 }
 
-// UseFullReroll decrements a user's full reroll count
-func UseFullReroll(db *DB, userID string) (int, error) {
+// UseFullReroll decrements a user's full reroll count and returns how many are left in the guild
+// Note: Stored counts follow the default allowance, the guild's allowance is applied on top when spending.
+func UseFullReroll(db *DB, userID string, guildID string) (int, error) {
 	if db == nil {
 		return 0, fmt.Errorf("database connection is nil")
 	}
 
+	guildSettings := GetGuildSettings(db, guildID)
+	return useDailyReroll(db, userID, "fullRerolls", guildSettings.FullRerolls-variables.DailyFullRerolls, "full")
+}
+
+// UseStatReroll decrements a user's stat reroll count and returns how many are left in the guild
+func UseStatReroll(db *DB, userID string, guildID string) (int, error) {
+	if db == nil {
+		return 0, fmt.Errorf("database connection is nil")
+	}
+
+	guildSettings := GetGuildSettings(db, guildID)
+	return useDailyReroll(db, userID, "statRerolls", guildSettings.StatRerolls-variables.DailyStatRerolls, "stat")
+}
+
+// useDailyReroll spends one reroll from a daily counter, shifted by the guild's allowance
+func useDailyReroll(db *DB, userID string, field string, shift int, kind string) (int, error) {
 	// Get the user to check current reroll count
 	user, err := GetUserByID(db, userID)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	if user.FullRerolls <= 0 {
-		return 0, fmt.Errorf("no full rerolls remaining today")
+	stored := user.FullRerolls
+	if field == "statRerolls" {
+		stored = user.StatRerolls
+	}
+
+	if stored+shift <= 0 {
+		// Fall back to bonus reroll tokens once the daily allowance is spent
+		if user.RerollTokens > 0 {
+			return 0, UseRerollToken(db, userID)
+		}
+		return 0, fmt.Errorf("no %s rerolls remaining today", kind)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -357,61 +384,80 @@ func UseFullReroll(db *DB, userID string) (int, error) {
 
 	userCollection := db.GetCollection(usersCollection)
 
-	// Decrement full reroll count
-	remainingRerolls := user.FullRerolls - 1
-	_, err = userCollection.UpdateOne(
+	// Filter on the allowance so two rerolls can't spend the last one together
+	result, err := userCollection.UpdateOne(
 		ctx,
-		bson.M{"discordID": userID},
-		bson.M{"$set": bson.M{"fullRerolls": remainingRerolls}},
+		bson.M{"discordID": userID, field: bson.M{"$gt": -shift}},
+		bson.M{"$inc": bson.M{field: -1}},
 	)
-
 	if err != nil {
-		return user.FullRerolls, fmt.Errorf("failed to use full reroll: %w", err)
+		return stored + shift, fmt.Errorf("failed to use %s reroll: %w", kind, err)
+	}
+	if result.MatchedCount == 0 {
+		return 0, fmt.Errorf("no %s rerolls remaining today", kind)
 	}
 
-	return remainingRerolls, nil
+	return stored + shift - 1, nil
 }
 
-// UseStatReroll decrements a user's stat reroll count
-func UseStatReroll(db *DB, userID string) (int, error) {
+// UseRerollToken spends one of the user's bonus reroll tokens
+func UseRerollToken(db *DB, userID string) error {
 	if db == nil {
-		return 0, fmt.Errorf("database connection is nil")
+		return fmt.Errorf("database connection is nil")
 	}
 
-	// Get the user to check current reroll count
-	user, err := GetUserByID(db, userID)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	userCollection := db.GetCollection(usersCollection)
+
+	// Only decrement when a token is available
+	result, err := userCollection.UpdateOne(
+		ctx,
+		bson.M{"discordID": userID, "rerollTokens": bson.M{"$gt": 0}},
+		bson.M{"$inc": bson.M{"rerollTokens": -1}},
+	)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get user: %w", err)
+		return fmt.Errorf("failed to use reroll token: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("no reroll tokens remaining")
 	}
 
-	if user.StatRerolls <= 0 {
-		return 0, fmt.Errorf("no stat rerolls remaining today")
+	return nil
+}
+
+// AddRerollTokens grants bonus reroll tokens to a user
+func AddRerollTokens(db *DB, userID string, amount int) error {
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	userCollection := db.GetCollection(usersCollection)
-
-	// Decrement stat reroll count
-	remainingRerolls := user.StatRerolls - 1
-	_, err = userCollection.UpdateOne(
+	_, err := userCollection.UpdateOne(
 		ctx,
 		bson.M{"discordID": userID},
-		bson.M{"$set": bson.M{"statRerolls": remainingRerolls}},
+		bson.M{"$inc": bson.M{"rerollTokens": amount}},
 	)
-
 	if err != nil {
-		return user.StatRerolls, fmt.Errorf("failed to use stat reroll: %w", err)
+		return fmt.Errorf("failed to add reroll tokens: %w", err)
 	}
 
-	return remainingRerolls, nil
+	return nil
 }
 
 // RerollSingleStat rerolls a specific stat for a character
-func RerollSingleStat(db *DB, userID string, statType variables.StatType) (models.Stat, error) {
-	// Create RNG for reroll
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+func RerollSingleStat(db *DB, userID string, guildID string, statType variables.StatType) (models.Stat, error) {
+	// Create RNG for reroll, keeping the seed for the roll history
+	seed := roller.NewSeed()
+	rng := rand.New(rand.NewSource(seed))
+
+	// Stat rerolls build up the stat pity and use the guild's banner like any other roll
+	startPity, pity := loadPity(db, userID)
+	banner, bannerID := activeBannerContext(db, guildID)
 
 	// Generate the new stat based on type
 	var newStat models.Stat
@@ -419,38 +465,47 @@ func RerollSingleStat(db *DB, userID string, statType variables.StatType) (model
 
 	switch statType {
 	case variables.Vitality:
-		newStat = roller.GenerateStat(variables.Vitality, roller.VitalityRarity, rng)
-		statField = "stats.vitality"
+		newStat = roller.GenerateStat(variables.Vitality, roller.VitalityRarity, rng, pity, banner)
+		statField = "Stats.Vitality"
 	case variables.Durability:
-		newStat = roller.GenerateStat(variables.Durability, roller.DurabilityRarity, rng)
-		statField = "stats.durability"
+		newStat = roller.GenerateStat(variables.Durability, roller.DurabilityRarity, rng, pity, banner)
+		statField = "Stats.Durability"
 	case variables.Speed:
-		newStat = roller.GenerateStat(variables.Speed, roller.SpeedRarity, rng)
-		statField = "stats.speed"
+		newStat = roller.GenerateStat(variables.Speed, roller.SpeedRarity, rng, pity, banner)
+		statField = "Stats.Speed"
 	case variables.Strength:
-		newStat = roller.GenerateStat(variables.Strength, roller.StrengthRarity, rng)
-		statField = "stats.strength"
+		newStat = roller.GenerateStat(variables.Strength, roller.StrengthRarity, rng, pity, banner)
+		statField = "Stats.Strength"
 	case variables.Intelligence:
-		newStat = roller.GenerateStat(variables.Intelligence, roller.IntelligenceRarity, rng)
-		statField = "stats.intelligence"
+		newStat = roller.GenerateStat(variables.Intelligence, roller.IntelligenceRarity, rng, pity, banner)
+		statField = "Stats.Intelligence"
 	case variables.Mana:
-		newStat = roller.GenerateStat(variables.Mana, roller.ManaRarity, rng)
-		statField = "stats.mana"
+		newStat = roller.GenerateStat(variables.Mana, roller.ManaRarity, rng, pity, banner)
+		statField = "Stats.Mana"
 	case variables.Mastery:
-		newStat = roller.GenerateStat(variables.Mastery, roller.MasteryRarity, rng)
-		statField = "stats.mastery"
+		newStat = roller.GenerateStat(variables.Mastery, roller.MasteryRarity, rng, pity, banner)
+		statField = "Stats.Mastery"
 	default:
 		return models.Stat{}, fmt.Errorf("invalid stat type")
 	}
 
+	// Keep the points allocated to the stat across the reroll
+	oldCharacter, err := findActiveCharacter(db, userID)
+	if err != nil {
+		return models.Stat{}, fmt.Errorf("no character found for this user: %w", err)
+	}
+	if oldStat := progression.StatByName(&oldCharacter.Stats, strings.TrimPrefix(statField, "Stats.")); oldStat != nil {
+		newStat.LevelBonus = oldStat.LevelBonus
+	}
+
 	// Update the character in the database
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	charCollection := db.GetCollection(charactersCollection)
-	_, err := charCollection.UpdateOne(
+	_, err = charCollection.UpdateOne(
 		ctx,
-		bson.M{"owner": userID},
+		bson.M{"_id": oldCharacter.ID},
 		bson.M{"$set": bson.M{statField: newStat}},
 	)
 
@@ -458,6 +513,42 @@ func RerollSingleStat(db *DB, userID string, statType variables.StatType) (model
 		return models.Stat{}, fmt.Errorf("failed to update character: %w", err)
 	}
 
+	err = savePity(db, userID, pity)
+	if err != nil {
+		fmt.Printf("Error saving pity: %v\n", err)
+	}
+
+	// Keep the rarity leaderboard score in sync with the new roll
+	character, err := GetCharacterByOwner(db, userID)
+	if err == nil {
+		_, err = charCollection.UpdateOne(
+			ctx,
+			bson.M{"_id": character.ID},
+			bson.M{"$set": bson.M{"RarityScore": roller.CalculateRarityScore(character)}},
+		)
+		if err != nil {
+			fmt.Printf("Error updating rarity score: %v\n", err)
+		}
+	}
+
+	// Record what the reroll replaced so it can be reviewed or undone
+	rerolled, err := findActiveCharacter(db, userID)
+	if err == nil {
+		err = recordSnapshot(db, models.CharacterSnapshot{
+			DiscordID: userID,
+			Source:    SnapshotRerollStat,
+			Seed:      seed,
+			Pity:      startPity,
+			BannerID:  bannerID,
+			Stat:      strings.TrimPrefix(statField, "Stats."),
+			Before:    &oldCharacter,
+			After:     rerolled,
+		})
+	}
+	if err != nil {
+		fmt.Printf("Error recording stat reroll snapshot: %v\n", err)
+	}
+
 	return newStat, nil
 }
 
@@ -487,20 +578,33 @@ func AddExperience(db *DB, userID string, expAmount int) (int, int, bool, error)
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	update := bson.M{"$set": bson.M{
+		"Experience": newExp,
+		"Level":      newLevel,
+	}}
+
+	// Characters on auto growth spend their new points along the race's curve
+	if leveledUp && character.AutoGrowth {
+		character.Level = newLevel
+		allocation := progression.AutoAllocate(character, progression.UnspentPoints(character))
+		if len(allocation) > 0 {
+			update["$inc"] = levelBonusIncrements(allocation)
+		}
+	}
+
 	charCollection := db.GetCollection(charactersCollection)
-	_, err = charCollection.UpdateOne(
-		ctx,
-		bson.M{"owner": userID},
-		bson.M{"$set": bson.M{
-			"experience": newExp,
-			"level":      newLevel,
-		}},
-	)
+	_, err = charCollection.UpdateOne(ctx, bson.M{"_id": character.ID}, update)
 
 	if err != nil {
 		return character.Experience, character.Level, false, fmt.Errorf("failed to update experience: %w", err)
 	}
 
+	// Members' battle XP also levels up their clan
+	err = AddClanExperience(db, userID, expAmount)
+	if err != nil {
+		fmt.Printf("Error adding clan experience: %v\n", err)
+	}
+
 	return newExp, newLevel, leveledUp, nil
 }
 
@@ -0,0 +1,233 @@
+package database
+
+import (
+	"CrispyBot/database/models"
+	"CrispyBot/progression"
+	"CrispyBot/variables"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// How long a roll holds the user's slots before another roll can take over
+const characterRollHold = 30 * time.Second
+
+// findActiveCharacter loads the owner's active character without any bonuses applied
+// Note: Falls back to the oldest character for users who never switched.
+func findActiveCharacter(db *DB, ownerID string) (models.Character, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(charactersCollection)
+
+	var character models.Character
+	user, err := GetUserByID(db, ownerID)
+	if err == nil && !user.ActiveCharacter.IsZero() {
+		err = collection.FindOne(ctx, bson.M{"_id": user.ActiveCharacter, "Owner": ownerID}).Decode(&character)
+		if err == nil {
+			return character, nil
+		}
+		if err != mongo.ErrNoDocuments {
+			return models.Character{}, fmt.Errorf("failed to query character: %w", err)
+		}
+	}
+
+	err = collection.FindOne(ctx, bson.M{"Owner": ownerID}, options.FindOne().SetSort(bson.M{"_id": 1})).Decode(&character)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.Character{}, fmt.Errorf("no character found for user: %s", ownerID)
+		}
+		return models.Character{}, fmt.Errorf("failed to query character: %w", err)
+	}
+
+	return character, nil
+}
+
+// GetCharactersByOwner returns every character a user owns, oldest first
+func GetCharactersByOwner(db *DB, ownerID string) ([]models.Character, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(charactersCollection)
+	cursor, err := collection.Find(ctx, bson.M{"Owner": ownerID}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query characters: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	characters := []models.Character{}
+	if err := cursor.All(ctx, &characters); err != nil {
+		return nil, fmt.Errorf("failed to decode characters: %w", err)
+	}
+
+	return characters, nil
+}
+
+// GetCharacterSlots returns how many characters a user owns and how many they can hold
+func GetCharacterSlots(db *DB, userID string) (int, int, error) {
+	if db == nil {
+		return 0, 0, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	used, err := db.GetCollection(charactersCollection).CountDocuments(ctx, bson.M{"Owner": userID})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count characters: %w", err)
+	}
+
+	// Users who never played yet start with the base slots
+	user, err := GetUserByID(db, userID)
+	if err != nil {
+		return int(used), progression.CharacterSlots(0, 0), nil
+	}
+
+	return int(used), progression.CharacterSlots(user.Prestige.Level, user.CharacterSlots), nil
+}
+
+// SetActiveCharacter switches the user's active character to a 1-based roster position
+func SetActiveCharacter(db *DB, userID string, position int) (models.Character, error) {
+	if db == nil {
+		return models.Character{}, fmt.Errorf("database connection is nil")
+	}
+
+	characters, err := GetCharactersByOwner(db, userID)
+	if err != nil {
+		return models.Character{}, err
+	}
+	if len(characters) == 0 {
+		return models.Character{}, fmt.Errorf("you don't have any characters yet")
+	}
+
+	index, err := progression.RosterIndex(position, len(characters))
+	if err != nil {
+		return models.Character{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	userCollection := db.GetCollection(usersCollection)
+	_, err = userCollection.UpdateOne(ctx,
+		bson.M{"discordID": userID},
+		bson.M{"$set": bson.M{"activeCharacter": characters[index].ID}},
+	)
+	if err != nil {
+		return models.Character{}, fmt.Errorf("failed to switch character: %w", err)
+	}
+
+	return GetCharacterByOwner(db, userID)
+}
+
+// reserveCharacterSlot holds the user's slots for one roll and checks one is free
+// Note: Only one roll holds them at a time, so two rolls can't both fill the last slot.
+func reserveCharacterSlot(db *DB, userID string) error {
+	// The hold lives on the user document, so it has to exist first
+	if _, err := CreateUser(db, userID); err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	result, err := db.GetCollection(usersCollection).UpdateOne(ctx,
+		bson.M{"discordID": userID, "rollingUntil": bson.M{"$not": bson.M{"$gt": now}}},
+		bson.M{"$set": bson.M{"rollingUntil": now.Add(characterRollHold)}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to reserve character slot: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("you're already rolling a character, try again in a moment")
+	}
+
+	used, slots, err := GetCharacterSlots(db, userID)
+	if err != nil {
+		releaseCharacterSlot(db, userID)
+		return err
+	}
+	if used >= slots {
+		releaseCharacterSlot(db, userID)
+		return fmt.Errorf("all %d of your character slots are full", slots)
+	}
+
+	return nil
+}
+
+// releaseCharacterSlot lets the user roll again
+func releaseCharacterSlot(db *DB, userID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := db.GetCollection(usersCollection).UpdateOne(ctx, bson.M{"discordID": userID}, bson.M{"$unset": bson.M{"rollingUntil": ""}})
+	if err != nil {
+		fmt.Printf("Error releasing character slot: %v\n", err)
+	}
+}
+
+// BuyCharacterSlot spends coins on an extra character slot and returns the user's new slot total
+func BuyCharacterSlot(db *DB, userID string) (int, error) {
+	if db == nil {
+		return 0, fmt.Errorf("database connection is nil")
+	}
+
+	user, err := GetUserByID(db, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user: %w", err)
+	}
+	if err := progression.CanBuySlot(user.CharacterSlots); err != nil {
+		return 0, err
+	}
+	if user.Wallet < variables.CharacterSlotPrice {
+		return 0, fmt.Errorf("not enough currency, a character slot costs %d coins", variables.CharacterSlotPrice)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Filter on the purchased count too so two purchases can't pass the cap together
+	userCollection := db.GetCollection(usersCollection)
+	result, err := userCollection.UpdateOne(ctx,
+		bson.M{
+			"discordID":      userID,
+			"wallet":         bson.M{"$gte": variables.CharacterSlotPrice},
+			"characterSlots": bson.M{"$not": bson.M{"$gte": variables.CharacterMaxPurchasedSlots}},
+		},
+		bson.M{"$inc": bson.M{"wallet": -variables.CharacterSlotPrice, "characterSlots": 1}},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to buy character slot: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return 0, fmt.Errorf("not enough currency, a character slot costs %d coins", variables.CharacterSlotPrice)
+	}
+
+	return progression.CharacterSlots(user.Prestige.Level, user.CharacterSlots+1), nil
+}
+
+// isEquippedByOtherCharacter reports whether another of the owner's characters holds an item
+func isEquippedByOtherCharacter(db *DB, character models.Character, itemKey string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	count, err := db.GetCollection(charactersCollection).CountDocuments(ctx, bson.M{
+		"Owner":                  character.Owner,
+		"_id":                    bson.M{"$ne": character.ID},
+		"EquippedWeapon.itemKey": itemKey,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to check equipped items: %w", err)
+	}
+
+	return count > 0, nil
+}
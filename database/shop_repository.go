@@ -1,8 +1,10 @@
 package database
 
 import (
+	"CrispyBot/clans"
 	"CrispyBot/database/models"
 	"CrispyBot/shop"
+	"CrispyBot/variables"
 
 	"context"
 	"fmt"
@@ -119,7 +121,8 @@ func UpdateShopIfExpired(db *DB) {
 }
 
 // BuyItem handles the purchase of an item by a user
-func BuyItem(db *DB, userID string, itemIndex int) (models.Item, error) {
+// Note: Only the items shown in the guild's shop can be bought there.
+func BuyItem(db *DB, userID string, guildID string, itemIndex int) (models.Item, error) {
 	if db == nil {
 		return models.Item{}, fmt.Errorf("database connection is nil")
 	}
@@ -132,7 +135,7 @@ func BuyItem(db *DB, userID string, itemIndex int) (models.Item, error) {
 
 	// Check if item exists
 	item, ok := shop.Inventory.Items[itemIndex]
-	if !ok {
+	if !ok || itemIndex >= GetGuildSettings(db, guildID).ShopSize {
 		return models.Item{}, fmt.Errorf("item not found in shop")
 	}
 
@@ -142,11 +145,19 @@ func BuyItem(db *DB, userID string, itemIndex int) (models.Item, error) {
 		return models.Item{}, fmt.Errorf("failed to get user: %w", err)
 	}
 
+	// Clan perks lower the price the user pays
+	item.Price = clans.ApplyDiscount(item.Price, ClanShopDiscount(db, userID))
+
 	// Check if user has enough money
 	if user.Wallet < item.Price {
 		return models.Item{}, fmt.Errorf("not enough currency to buy this item")
 	}
 
+	// Purchases don't overflow into the mailbox
+	if len(user.Inventory) >= variables.MaxInventorySize {
+		return models.Item{}, fmt.Errorf("your inventory is full (%d items)", variables.MaxInventorySize)
+	}
+
 	// Update user's wallet and add item to inventory
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
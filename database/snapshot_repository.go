@@ -0,0 +1,353 @@
+package database
+
+import (
+	"CrispyBot/database/models"
+	"CrispyBot/progression"
+	"CrispyBot/roller"
+	"CrispyBot/variables"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Collection names
+const (
+	characterSnapshotsCollection = "characterSnapshots"
+)
+
+// Snapshot sources
+const (
+	SnapshotRoll       = "roll"
+	SnapshotReroll     = "reroll"
+	SnapshotRerollStat = "rerollstat"
+	SnapshotRebirth    = "rebirth"
+	SnapshotUndo       = "undo"
+)
+
+// recordSnapshot stores an immutable record of a roll
+func recordSnapshot(db *DB, snapshot models.CharacterSnapshot) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	snapshot.CharacterID = snapshot.After.ID
+	snapshot.CreatedAt = time.Now()
+
+	_, err := db.GetCollection(characterSnapshotsCollection).InsertOne(ctx, snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to record snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// rollCharacter generates a character from a fresh seed, saves it as the active character and records the roll
+// Note: Locks keep those stats and characteristics of the character being replaced. The guild's running banner, if any, applies.
+func rollCharacter(db *DB, userID string, guildID string, source string, before *models.Character, locks []string) (models.Character, error) {
+	seed := roller.NewSeed()
+	startPity, pity := loadPity(db, userID)
+	banner, bannerID := activeBannerContext(db, guildID)
+
+	var generated models.Character
+	if before != nil && len(locks) > 0 {
+		generated = roller.GenerateLockedCharacter(*before, locks, seed, pity, banner)
+	} else {
+		generated = roller.GenerateCharacter(userID, seed, pity, banner)
+	}
+
+	character, err := SaveCharacter(db, generated, userID, banner)
+	if err != nil {
+		return models.Character{}, err
+	}
+
+	err = savePity(db, userID, pity)
+	if err != nil {
+		fmt.Printf("Error saving pity: %v\n", err)
+	}
+
+	err = recordSnapshot(db, models.CharacterSnapshot{
+		DiscordID: userID,
+		Source:    source,
+		Seed:      seed,
+		Pity:      startPity,
+		Locks:     locks,
+		BannerID:  bannerID,
+		Before:    before,
+		After:     character,
+	})
+	if err != nil {
+		fmt.Printf("Error recording %s snapshot: %v\n", source, err)
+	}
+
+	return GetCharacterByOwner(db, userID)
+}
+
+// RollCharacter rolls a brand new character into one of the user's free slots
+func RollCharacter(db *DB, userID string, guildID string) (models.Character, error) {
+	if db == nil {
+		return models.Character{}, fmt.Errorf("database connection is nil")
+	}
+
+	if err := reserveCharacterSlot(db, userID); err != nil {
+		return models.Character{}, err
+	}
+	defer releaseCharacterSlot(db, userID)
+
+	return rollCharacter(db, userID, guildID, SnapshotRoll, nil, nil)
+}
+
+// RerollCharacter replaces the user's active character with a freshly rolled one, keeping any locked fields
+// Note: The replaced character is kept in the snapshot so the reroll can be undone.
+func RerollCharacter(db *DB, userID string, guildID string, locks []string) (models.Character, error) {
+	if db == nil {
+		return models.Character{}, fmt.Errorf("database connection is nil")
+	}
+
+	var before *models.Character
+	if character, err := findActiveCharacter(db, userID); err == nil {
+		before = &character
+	} else if len(locks) > 0 {
+		return models.Character{}, fmt.Errorf("you need a character to lock anything, use `!cb roll` first")
+	}
+
+	// Locks are paid for before anything is replaced
+	cost := roller.LockCost(len(locks))
+	if cost > 0 {
+		if err := spendCurrency(db, userID, cost); err != nil {
+			return models.Character{}, fmt.Errorf("locking %d fields costs %d coins: %w", len(locks), cost, err)
+		}
+	}
+
+	// The new character is saved first so a failed roll leaves the old one in place
+	character, err := rollCharacter(db, userID, guildID, SnapshotReroll, before, locks)
+	if err != nil {
+		if cost > 0 {
+			if _, refundErr := AddCurrency(db, userID, cost); refundErr != nil {
+				fmt.Printf("Error refunding lock cost: %v\n", refundErr)
+			}
+		}
+		return models.Character{}, err
+	}
+
+	if before != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if _, err := db.GetCollection(charactersCollection).DeleteOne(ctx, bson.M{"_id": before.ID}); err != nil {
+			fmt.Printf("Error deleting rerolled character %s: %v\n", before.ID.Hex(), err)
+		}
+	}
+
+	return character, nil
+}
+
+// GetCharacterSnapshots returns one page of a user's roll history, newest first, and the total number of snapshots
+func GetCharacterSnapshots(db *DB, userID string, page int, pageSize int) ([]models.CharacterSnapshot, int, error) {
+	if db == nil {
+		return nil, 0, fmt.Errorf("database connection is nil")
+	}
+
+	if page < 0 {
+		page = 0
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(characterSnapshotsCollection)
+	filter := bson.M{"discordID": userID}
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count snapshots: %w", err)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}}).
+		SetSkip(int64(page * pageSize)).
+		SetLimit(int64(pageSize))
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get snapshots: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	snapshots := []models.CharacterSnapshot{}
+	if err := cursor.All(ctx, &snapshots); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode snapshots: %w", err)
+	}
+
+	return snapshots, int(total), nil
+}
+
+// UndoLastReroll spends a reroll token to restore the active character to how it was before its latest reroll
+// Note: Returns the restored character and the snapshot that was undone.
+func UndoLastReroll(db *DB, userID string) (models.Character, models.CharacterSnapshot, error) {
+	if db == nil {
+		return models.Character{}, models.CharacterSnapshot{}, fmt.Errorf("database connection is nil")
+	}
+
+	current, err := findActiveCharacter(db, userID)
+	if err != nil {
+		return models.Character{}, models.CharacterSnapshot{}, fmt.Errorf("no character found for this user: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Only the character's latest roll can be undone
+	collection := db.GetCollection(characterSnapshotsCollection)
+	var last models.CharacterSnapshot
+	err = collection.FindOne(ctx,
+		bson.M{"characterID": current.ID},
+		options.FindOne().SetSort(bson.D{{Key: "createdAt", Value: -1}}),
+	).Decode(&last)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.Character{}, models.CharacterSnapshot{}, fmt.Errorf("there is no reroll to undo")
+		}
+		return models.Character{}, models.CharacterSnapshot{}, fmt.Errorf("failed to get last snapshot: %w", err)
+	}
+
+	if (last.Source != SnapshotReroll && last.Source != SnapshotRerollStat) || last.Before == nil {
+		return models.Character{}, models.CharacterSnapshot{}, fmt.Errorf("your character's last change wasn't a reroll")
+	}
+	if time.Since(last.CreatedAt) > variables.UndoWindowHours*time.Hour {
+		return models.Character{}, models.CharacterSnapshot{}, fmt.Errorf("rerolls can only be undone within %d hours", variables.UndoWindowHours)
+	}
+
+	// A stat reroll only changed that stat, so everything gained since stays
+	restored := *last.Before
+	if last.Source == SnapshotRerollStat {
+		restored, err = restoreStat(current, *last.Before, last.Stat)
+		if err != nil {
+			return models.Character{}, models.CharacterSnapshot{}, err
+		}
+	}
+	restored.Owner = userID
+
+	// The undo snapshot goes in first, its unique undoOf stops the same reroll being undone twice
+	undo := models.CharacterSnapshot{
+		DiscordID: userID,
+		Source:    SnapshotUndo,
+		Before:    &current,
+		After:     restored,
+		UndoOf:    last.ID,
+	}
+	err = recordSnapshot(db, undo)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return models.Character{}, models.CharacterSnapshot{}, fmt.Errorf("that reroll was already undone")
+		}
+		return models.Character{}, models.CharacterSnapshot{}, err
+	}
+
+	err = UseRerollToken(db, userID)
+	if err != nil {
+		collection.DeleteOne(ctx, bson.M{"undoOf": last.ID})
+		return models.Character{}, models.CharacterSnapshot{}, fmt.Errorf("undoing a reroll costs a reroll token: %w", err)
+	}
+
+	if last.Source == SnapshotRerollStat {
+		err = restoreStatField(db, restored, last.Stat)
+	} else {
+		err = restoreCharacter(db, userID, current, restored)
+	}
+	if err != nil {
+		// Give the token back and free the reroll so the undo can be retried
+		if refundErr := AddRerollTokens(db, userID, 1); refundErr != nil {
+			fmt.Printf("Error refunding undo token: %v\n", refundErr)
+		}
+		collection.DeleteOne(ctx, bson.M{"undoOf": last.ID})
+		return models.Character{}, models.CharacterSnapshot{}, err
+	}
+
+	character, err := GetCharacterByOwner(db, userID)
+	if err != nil {
+		return models.Character{}, models.CharacterSnapshot{}, err
+	}
+
+	return character, last, nil
+}
+
+// restoreStat returns the current character with one stat as it was before a stat reroll
+// Note: Points allocated to the stat since then are kept.
+func restoreStat(current models.Character, before models.Character, statName string) (models.Character, error) {
+	previous := progression.StatByName(&before.Stats, statName)
+	stat := progression.StatByName(&current.Stats, statName)
+	if previous == nil || stat == nil {
+		return models.Character{}, fmt.Errorf("unknown rerolled stat %q", statName)
+	}
+
+	restored := current
+	restoredStat := progression.StatByName(&restored.Stats, statName)
+	*restoredStat = *previous
+	restoredStat.LevelBonus = stat.LevelBonus
+	return restored, nil
+}
+
+// restoreStatField writes back the one stat a stat reroll changed
+func restoreStatField(db *DB, restored models.Character, statName string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := db.GetCollection(charactersCollection).UpdateOne(ctx,
+		bson.M{"_id": restored.ID},
+		bson.M{"$set": bson.M{
+			"Stats." + statName: *progression.StatByName(&restored.Stats, statName),
+			"RarityScore":       roller.CalculateRarityScore(restored),
+		}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to restore %s: %w", statName, err)
+	}
+
+	return nil
+}
+
+// restoreCharacter puts a snapshotted character back in place of the current one
+func restoreCharacter(db *DB, userID string, current models.Character, restored models.Character) error {
+	// Inventory is shared, so the old weapon may have gone to another character since
+	if restored.EquippedWeapon.ItemKey != "" {
+		equipped, err := isEquippedByOtherCharacter(db, restored, restored.EquippedWeapon.ItemKey)
+		if err != nil {
+			return err
+		}
+		if equipped {
+			restored.EquippedWeapon = models.EquippedItem{}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// A full reroll replaced the character document, so the old one comes back under its own ID
+	charCollection := db.GetCollection(charactersCollection)
+	_, err := charCollection.ReplaceOne(ctx, bson.M{"_id": restored.ID}, restored, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to restore character: %w", err)
+	}
+
+	if current.ID == restored.ID {
+		return nil
+	}
+
+	_, err = charCollection.DeleteOne(ctx, bson.M{"_id": current.ID})
+	if err != nil {
+		return fmt.Errorf("failed to remove rerolled character: %w", err)
+	}
+
+	_, err = db.GetCollection(usersCollection).UpdateOne(ctx,
+		bson.M{"discordID": userID},
+		bson.M{"$set": bson.M{"activeCharacter": restored.ID}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update active character: %w", err)
+	}
+
+	return nil
+}
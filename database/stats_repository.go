@@ -0,0 +1,127 @@
+package database
+
+import (
+	"CrispyBot/database/models"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	battleStatsCollection = "battleStats"
+)
+
+var (
+	// Cache of user/guild pairs already recorded this session
+	trackedGuildMembers sync.Map
+)
+
+// RecordBattleResult updates the win/loss statistics of both battle participants
+func RecordBattleResult(db *DB, winnerID string, loserID string, pvp bool) error {
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(battleStatsCollection)
+	opts := options.Update().SetUpsert(true)
+	now := time.Now()
+
+	// Record the win
+	winnerInc := bson.M{"wins": 1}
+	if pvp {
+		winnerInc["pvpWins"] = 1
+	} else {
+		winnerInc["npcWins"] = 1
+	}
+
+	if winnerID != "" {
+		_, err := collection.UpdateOne(
+			ctx,
+			bson.M{"discordID": winnerID},
+			bson.M{"$inc": winnerInc, "$set": bson.M{"lastBattle": now}},
+			opts,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record win: %w", err)
+		}
+	}
+
+	// Record the loss
+	if loserID != "" {
+		_, err := collection.UpdateOne(
+			ctx,
+			bson.M{"discordID": loserID},
+			bson.M{"$inc": bson.M{"losses": 1}, "$set": bson.M{"lastBattle": now}},
+			opts,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record loss: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetBattleStats retrieves a player's battle statistics
+func GetBattleStats(db *DB, discordID string) (models.BattleStats, error) {
+	if db == nil {
+		return models.BattleStats{}, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(battleStatsCollection)
+
+	var stats models.BattleStats
+	err := collection.FindOne(ctx, bson.M{"discordID": discordID}).Decode(&stats)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			// No battles yet
+			return models.BattleStats{DiscordID: discordID}, nil
+		}
+		return models.BattleStats{}, fmt.Errorf("failed to get battle stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// TrackUserGuild records that a user plays in a guild so guild leaderboards can include them
+func TrackUserGuild(db *DB, userID string, guildID string) {
+	if db == nil || userID == "" || guildID == "" {
+		return
+	}
+
+	// Skip the write if we've already recorded this pair
+	cacheKey := guildID + ":" + userID
+	if _, seen := trackedGuildMembers.Load(cacheKey); seen {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	userCollection := db.GetCollection(usersCollection)
+	result, err := userCollection.UpdateOne(
+		ctx,
+		bson.M{"discordID": userID},
+		bson.M{"$addToSet": bson.M{"guilds": guildID}},
+	)
+	if err != nil {
+		fmt.Printf("Error tracking user guild: %v\n", err)
+		return
+	}
+
+	// Only cache once the user exists, otherwise try again next time
+	if result.MatchedCount > 0 {
+		trackedGuildMembers.Store(cacheKey, true)
+	}
+}
@@ -0,0 +1,186 @@
+package database
+
+import (
+	"CrispyBot/database/models"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	tournamentsCollection = "tournaments"
+)
+
+// CreateTournament inserts a new tournament open for signups
+func CreateTournament(db *DB, tournament models.Tournament) (models.Tournament, error) {
+	if db == nil {
+		return models.Tournament{}, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(tournamentsCollection)
+
+	// Only one tournament per guild at a time
+	count, err := collection.CountDocuments(ctx, bson.M{
+		"guildID": tournament.GuildID,
+		"status":  bson.M{"$in": []string{"signup", "running"}},
+	})
+	if err != nil {
+		return models.Tournament{}, fmt.Errorf("failed to check existing tournaments: %w", err)
+	}
+	if count > 0 {
+		return models.Tournament{}, fmt.Errorf("a tournament is already in progress in this server")
+	}
+
+	result, err := collection.InsertOne(ctx, tournament)
+	if err != nil {
+		return models.Tournament{}, fmt.Errorf("failed to create tournament: %w", err)
+	}
+
+	tournament.ID = result.InsertedID.(primitive.ObjectID)
+	return tournament, nil
+}
+
+// GetActiveTournament retrieves the guild's tournament that is in signup or running
+func GetActiveTournament(db *DB, guildID string) (models.Tournament, error) {
+	if db == nil {
+		return models.Tournament{}, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(tournamentsCollection)
+
+	var tournament models.Tournament
+	err := collection.FindOne(ctx, bson.M{
+		"guildID": guildID,
+		"status":  bson.M{"$in": []string{"signup", "running"}},
+	}).Decode(&tournament)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return models.Tournament{}, fmt.Errorf("there is no tournament in this server")
+		}
+		return models.Tournament{}, fmt.Errorf("failed to get tournament: %w", err)
+	}
+
+	return tournament, nil
+}
+
+// GetRunningTournaments retrieves every tournament with matches in play
+func GetRunningTournaments(db *DB) ([]models.Tournament, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(tournamentsCollection)
+
+	cursor, err := collection.Find(ctx, bson.M{"status": "running"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find running tournaments: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var tournaments []models.Tournament
+	if err := cursor.All(ctx, &tournaments); err != nil {
+		return nil, fmt.Errorf("failed to decode tournaments: %w", err)
+	}
+
+	return tournaments, nil
+}
+
+// GetTournament retrieves a tournament by ID
+func GetTournament(db *DB, tournamentID primitive.ObjectID) (models.Tournament, error) {
+	if db == nil {
+		return models.Tournament{}, fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(tournamentsCollection)
+
+	var tournament models.Tournament
+	err := collection.FindOne(ctx, bson.M{"_id": tournamentID}).Decode(&tournament)
+	if err != nil {
+		return models.Tournament{}, fmt.Errorf("failed to get tournament: %w", err)
+	}
+
+	return tournament, nil
+}
+
+// SaveTournament replaces the stored tournament with the given state
+func SaveTournament(db *DB, tournament models.Tournament) error {
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(tournamentsCollection)
+
+	_, err := collection.ReplaceOne(ctx, bson.M{"_id": tournament.ID}, tournament)
+	if err != nil {
+		return fmt.Errorf("failed to save tournament: %w", err)
+	}
+
+	return nil
+}
+
+// PayTournamentEntryFee takes the entry fee from a player's wallet
+func PayTournamentEntryFee(db *DB, userID string, fee int) error {
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+
+	if err := spendCurrency(db, userID, fee); err != nil {
+		return fmt.Errorf("the entry fee is %d coins: %w", fee, err)
+	}
+
+	return nil
+}
+
+// CancelTournament closes a tournament that hasn't started and refunds every entry fee
+func CancelTournament(db *DB, tournament models.Tournament) error {
+	if db == nil {
+		return fmt.Errorf("database connection is nil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := db.GetCollection(tournamentsCollection)
+
+	// Only the first cancel wins, so fees can't be refunded twice
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"_id": tournament.ID, "status": "signup"},
+		bson.M{"$set": bson.M{"status": "cancelled", "prizePool": 0, "finishedAt": time.Now()}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to cancel tournament: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("only a tournament still taking signups can be cancelled")
+	}
+
+	for _, player := range tournament.Players {
+		if player.EntryFee <= 0 {
+			continue
+		}
+		if _, err := AddCurrency(db, player.DiscordID, player.EntryFee); err != nil {
+			fmt.Printf("Error refunding tournament entry fee to %s: %v\n", player.DiscordID, err)
+		}
+	}
+
+	return nil
+}
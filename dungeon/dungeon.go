@@ -0,0 +1,111 @@
+package dungeon
+
+import (
+	"CrispyBot/variables"
+	"math/rand"
+)
+
+// Room types
+const (
+	RoomCombat   = "combat"
+	RoomRest     = "rest"
+	RoomTreasure = "treasure"
+	RoomBoss     = "boss"
+)
+
+// Room is a generated dungeon floor
+type Room struct {
+	Type       string
+	EnemyName  string
+	EnemyLevel int
+	Coins      int
+	Experience int
+}
+
+// Enemy pools, from shallow floors to deep ones
+var (
+	enemyTiers = [][]string{
+		{"Cave Rat", "Goblin", "Slime"},
+		{"Bandit", "Wolf Pack", "Skeleton"},
+		{"Dark Knight", "Troll", "Ghoul"},
+		{"Dragon Whelp", "Necromancer", "Wraith"},
+		{"Ancient Guardian", "Lich", "Shadow Drake"},
+	}
+
+	bossNames = []string{"Goblin King", "Bone Colossus", "Troll Warlord", "Elder Necromancer", "Dragon Lord"}
+)
+
+// IsBossFloor checks if a floor is guarded by a boss
+func IsBossFloor(floor int) bool {
+	return floor > 0 && floor%variables.DungeonBossEvery == 0
+}
+
+// GenerateRoom rolls the contents of a floor
+func GenerateRoom(floor int, rng *rand.Rand) Room {
+	if floor < 1 {
+		floor = 1
+	}
+
+	// Deeper floors draw from tougher enemy pools
+	tier := (floor - 1) / variables.DungeonBossEvery
+	if tier >= len(enemyTiers) {
+		tier = len(enemyTiers) - 1
+	}
+
+	coins, experience := FightRewards(floor)
+
+	if IsBossFloor(floor) {
+		return Room{
+			Type:       RoomBoss,
+			EnemyName:  bossNames[tier],
+			EnemyLevel: floor + variables.DungeonBossLevelBonus,
+			Coins:      coins,
+			Experience: experience,
+		}
+	}
+
+	roll := rng.Intn(100)
+	switch {
+	case roll < variables.DungeonRestChance:
+		return Room{Type: RoomRest}
+	case roll < variables.DungeonRestChance+variables.DungeonTreasureChance:
+		return Room{
+			Type:  RoomTreasure,
+			Coins: floor * variables.DungeonTreasureCoinsPerFloor,
+		}
+	}
+
+	pool := enemyTiers[tier]
+	return Room{
+		Type:       RoomCombat,
+		EnemyName:  pool[rng.Intn(len(pool))],
+		EnemyLevel: floor,
+		Coins:      coins,
+		Experience: experience,
+	}
+}
+
+// FightRewards returns the loot for winning the fight on a floor, doubled for bosses
+func FightRewards(floor int) (int, int) {
+	coins := floor * variables.DungeonCoinsPerFloor
+	experience := floor * variables.DungeonXPPerFloor
+
+	if IsBossFloor(floor) {
+		return coins * 2, experience * 2
+	}
+	return coins, experience
+}
+
+// RestHeal returns the restored value of a resource after a rest room
+func RestHeal(current int, max int) int {
+	healed := current + max*variables.DungeonRestHealPercent/100
+	if healed > max {
+		return max
+	}
+	return healed
+}
+
+// RetreatLoot returns how much of the unsecured loot is kept when retreating
+func RetreatLoot(unsecured int) int {
+	return unsecured * variables.DungeonRetreatLootPercent / 100
+}
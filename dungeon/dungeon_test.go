@@ -0,0 +1,68 @@
+package dungeon
+
+import (
+	"CrispyBot/npc"
+	"CrispyBot/variables"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// Helper to create a new rng for each test
+func newTestRNG() *rand.Rand {
+	return rand.New(rand.NewSource(time.Now().UnixNano()))
+}
+
+func TestGenerateRoom_BossFloors(t *testing.T) {
+	rng := newTestRNG()
+	for floor := 1; floor <= variables.DungeonBossEvery*6; floor++ {
+		room := GenerateRoom(floor, rng)
+		if IsBossFloor(floor) != (room.Type == RoomBoss) {
+			t.Errorf("Floor %d: expected boss=%v, got room %s", floor, IsBossFloor(floor), room.Type)
+		}
+		if room.Type == RoomBoss && room.EnemyLevel != floor+variables.DungeonBossLevelBonus {
+			t.Errorf("Floor %d: boss level %d", floor, room.EnemyLevel)
+		}
+	}
+}
+
+func TestGenerateRoom_AllTypesPresent(t *testing.T) {
+	rng := newTestRNG()
+	counts := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		room := GenerateRoom(1+i%(variables.DungeonBossEvery-1), rng)
+		counts[room.Type]++
+		if room.Type == RoomCombat && room.EnemyName == "" {
+			t.Errorf("Combat room without an enemy")
+		}
+	}
+	for _, roomType := range []string{RoomCombat, RoomRest, RoomTreasure} {
+		if counts[roomType] == 0 {
+			t.Errorf("Room type %s was never generated", roomType)
+		}
+	}
+}
+
+func TestRestHeal_CapsAtMax(t *testing.T) {
+	if healed := RestHeal(95, 100); healed != 100 {
+		t.Errorf("Expected heal to cap at 100, got %d", healed)
+	}
+	if healed := RestHeal(0, 100); healed != variables.DungeonRestHealPercent {
+		t.Errorf("Expected %d, got %d", variables.DungeonRestHealPercent, healed)
+	}
+}
+
+func TestEnemies_OnNPCRoster(t *testing.T) {
+	for _, tier := range enemyTiers {
+		for _, name := range tier {
+			if _, ok := npc.Find(name); !ok {
+				t.Errorf("Dungeon enemy %s has no NPC definition", name)
+			}
+		}
+	}
+	for _, name := range bossNames {
+		if _, ok := npc.Find(name); !ok {
+			t.Errorf("Dungeon boss %s has no NPC definition", name)
+		}
+	}
+}
@@ -0,0 +1,693 @@
+package i18n
+
+// english is the source catalogue, every other locale translates its keys
+var english = Catalogue{
+	// Usage errors
+	"usage.missing_command":    "missing command",
+	"usage.unknown_command":    "unknown command {command}",
+	"usage.choose_subcommand":  "choose one of {choices}",
+	"usage.unknown_subcommand": "unknown subcommand {word}, choose one of {choices}",
+	"usage.missing_arg":        "missing {arg}",
+	"usage.unexpected":         "unexpected {word}",
+	"usage.not_integer":        "{arg} must be a whole number",
+	"usage.out_of_range":       "{arg} must be from {min} to {max}",
+	"usage.not_user":           "{arg} must be a mention like @user",
+	"usage.not_choice":         "{arg} must be one of {choices}",
+
+	// Dispatcher
+	"dispatch.unknown_command":          "Unknown command. Try `!cb help` for a list of commands.",
+	"dispatch.usage":                    "{reason}.\nUsage: {usage}\nSee `!cb help {command}` for details.",
+	"dispatch.banned":                   "You are banned from playing in this server.",
+	"dispatch.banned_dm":                "You are banned from playing in a server, so you can't play in direct messages either.",
+	"dispatch.guild_only":               "`!cb {command}` only works in a server channel.",
+	"dispatch.permission":               "Only {who} can use `!cb {command}`.",
+	"dispatch.permission.staff":         "members with the Manage Server permission or an admin role",
+	"dispatch.permission.manage_server": "members with the Manage Server permission",
+	"dispatch.feature_off":              "{feature} are turned off in this server.",
+	"dispatch.ranked_off":               "Ranked matches are turned off in this server.",
+	"dispatch.roll_channels":            "Rolls are only allowed in {channels}.",
+	"dispatch.battle_channels":          "Battles are only allowed in {channels}.",
+	"dispatch.slow_down":                "⏳ Slow down, <@{user}>! Try again in {seconds}s.",
+	"dispatch.guild_busy":               "⏳ This server is sending a lot of commands, try again in {seconds}s.",
+	"slash.failed":                      "Couldn't run that: {error}",
+	"slash.used":                        "<@{user}> used `{command}`",
+
+	// Language
+	"language.title":     "🌐 Language",
+	"language.current":   "I answer you in **{language}**. This server uses **{server}**.",
+	"language.available": "Available languages",
+	"language.footer":    "!cb language <code> picks yours, !cb language reset follows the server",
+	"language.set":       "🌐 I'll answer you in **{language}** from now on.",
+	"language.reset":     "🌐 You now follow the server's language.",
+	"language.unknown":   "There's no {language} language. Choose from:\n{languages}",
+	"language.failed":    "Language not changed: {error}",
+
+	// Help
+	"help.unknown":             "There's no {command} command. Try `!cb help` for a list of commands.",
+	"help.title":               "CrispyBot Help",
+	"help.description":         "Here are the commands you can use. Every one of them also works as a slash command.\nUse `!cb help <command>` to see its arguments.",
+	"help.footer":              "CrispyBot v1.0",
+	"help.footer_prefix":       "{footer} | This server also uses {prefix} instead of !cb",
+	"help.command_line":        "`!cb {command}` {description}",
+	"help.category.general":    "General",
+	"help.category.characters": "Characters",
+	"help.category.rolls":      "Rolls",
+	"help.category.economy":    "Economy",
+	"help.category.battles":    "Battles",
+	"help.category.social":     "Social",
+	"help.category.server":     "Server",
+	"help.command_title":       "!cb {command}",
+	"help.aliases":             "{description}\nAlso: {aliases}",
+	"help.usage":               "Usage",
+	"help.choices":             "{description}: {choices}",
+	"help.optional":            "{description} (optional)",
+	"help.only":                "🛡️ Only {who}",
+	"help.guild_only":          "🏠 Server channels only",
+	"help.cooldown":            "⏳ {cooldown} cooldown",
+
+	// Common
+	"common.coins": "{count, plural, one {# coin} other {# coins}}",
+	"common.error": "Error: {error}",
+
+	// Config
+	"config.usage_set":            "Usage: `!cb config set <setting> <value>`, settings: {settings}",
+	"config.usage_reset":          "Usage: `!cb config reset <setting>`, settings: {settings}",
+	"config.usage_feature":        "Usage: `!cb config {action} <feature>`, features: {features}",
+	"config.unknown":              "Unknown config command. Available: show, set, reset, enable, disable",
+	"config.failed":               "Settings not changed: {error}",
+	"config.updated":              "⚙️ Settings updated!",
+	"config.prefix_value":         "{prefix} (and !cb)",
+	"config.title":                "⚙️ Server Settings",
+	"config.field.prefix":         "Prefix (prefix)",
+	"config.field.daily":          "Daily Reward (daily)",
+	"config.field.shopsize":       "Shop Size (shopsize)",
+	"config.field.rerolls":        "Full Rerolls (rerolls)",
+	"config.field.statrerolls":    "Stat Rerolls (statrerolls)",
+	"config.field.language":       "Language (language)",
+	"config.field.rollchannels":   "Roll Channels (rollchannels)",
+	"config.field.battlechannels": "Battle Channels (battlechannels)",
+	"config.field.adminroles":     "Admin Roles (adminroles)",
+	"config.field.features":       "Features",
+	"config.items":                "{count, plural, one {# item} other {# items}}",
+	"config.per_day":              "{count} per day",
+	"config.footer":               "Server managers: !cb config set|reset <setting> [value] or !cb config enable|disable <feature>",
+	"config.any_channel":          "any channel",
+	"config.manage_server_only":   "Manage Server only",
+
+	// Common
+	"common.item_mailed":     "📬 Your inventory is full, so it was sent to your mailbox (`!cb mailbox`)",
+	"duration.hours_minutes": "{hours, plural, one {# hour} other {# hours}} and {minutes, plural, one {# minute} other {# minutes}}",
+	"duration.minutes":       "{minutes, plural, one {# minute} other {# minutes}}",
+	"item.no_stats":          "No stat bonuses",
+	"item.stat_modifier":     "• {value} to {stat}",
+
+	// Shop
+	"shop.error":             "Error accessing the shop: {error}",
+	"shop.title":             "🛒 Item Shop",
+	"shop.refresh":           "The shop will refresh in {time}",
+	"shop.footer":            "Use !cb buy [number] to purchase an item",
+	"shop.empty":             "No Items Available",
+	"shop.empty_detail":      "The shop is currently empty. Check back after it refreshes.",
+	"shop.item":              "{number}. {name} ({rarity}) - {price, plural, one {# coin} other {# coins}}",
+	"buy.usage":              "Please specify an item number to buy. Usage: `!cb buy [number|respec|slot]`",
+	"buy.failed":             "Purchase failed: {error}",
+	"buy.respec":             "🔄 You bought a respec token for **{price}** coins! You now have **{count}**. Use `!cb allocate reset` to refund your stat points.",
+	"buy.invalid_number":     "Invalid item number. Please provide a valid number.",
+	"buy.title":              "Purchase Successful",
+	"buy.bought":             "You bought **{name}** for **{price}** coins!",
+	"buy.details":            "Item Details",
+	"buy.details_value":      "**Rarity:** {rarity}\n{stats}",
+	"buy.footer":             "Type !cb wallet to check your remaining balance",
+	"wallet.title":           "💰 Your Wallet",
+	"wallet.balance":         "**Balance:** {count, plural, one {# coin} other {# coins}}",
+	"wallet.inventory":       "Inventory",
+	"wallet.inventory_count": "You have {count, plural, one {# item} other {# items}} in your inventory",
+	"wallet.footer":          "Use !cb daily to earn daily rewards",
+	"daily.failed":           "Daily reward failed: {error}. Next reward available in {time}.",
+	"daily.streak":           "🔥 {count} day streak",
+	"daily.streak_reset":     " (you missed a day, so your streak was reset)",
+	"daily.title":            "Daily Reward",
+	"daily.received":         "You received **{count}** coins!",
+	"daily.streak_field":     "Streak",
+	"daily.balance":          "New Balance",
+	"daily.footer":           "Come back in {time} for more rewards",
+	"daily.milestone_tokens": "• {count, plural, one {**#** reroll token} other {**#** reroll tokens}}",
+	"daily.milestone":        "🎉 {day} Day Milestone!",
+
+	// Equipment
+	"equip.usage":          "Please specify which item to equip. Usage: `!cb equip [item number]`",
+	"equip.not_found":      "Item not found in your inventory.",
+	"equip.failed":         "Failed to equip item: {error}",
+	"equip.equipped_short": "Successfully equipped **{name}**!",
+	"equip.title":          "Item Equipped",
+	"equip.equipped":       "You equipped **{name}**!",
+	"equip.footer":         "Type !cb stats to see your updated character stats",
+	"unequip.nothing":      "You don't have any item equipped.",
+	"unequip.failed":       "Failed to unequip item: {error}",
+	"unequip.title":        "Item Unequipped",
+	"unequip.unequipped":   "You unequipped **{name}**.",
+
+	// Inventory
+	"inventory.title":        "🎒 Your Inventory",
+	"inventory.description":  "{user}'s collection of items",
+	"inventory.footer":       "Use !cb shop to find more items",
+	"inventory.empty":        "Empty Inventory",
+	"inventory.empty_detail": "You don't have any items yet. Visit the shop with `!cb shop` to buy some!",
+	"inventory.weapons":      "Weapons ({count}/{max})",
+
+	// Mailbox
+	"mailbox.empty_message":  "Your mailbox is empty.",
+	"mailbox.usage":          "Usage: `!cb mailbox claim <number|all>`",
+	"mailbox.claimed_all":    "📬 Claimed {count, plural, one {**#** item} other {**#** items}} from your mailbox.",
+	"mailbox.still_waiting":  "{count, plural, one {# item is} other {# items are}} still waiting because your inventory is full.",
+	"mailbox.invalid_number": "Invalid mailbox number. Choose 1-{max}.",
+	"mailbox.claimed":        "📬 Claimed **{name}** ({rarity}) into your inventory as `{key}`.",
+	"mailbox.title":          "📬 Your Mailbox",
+	"mailbox.description":    "Items that arrived while your inventory was full (max {max} items). Unclaimed items expire after {days} days.",
+	"mailbox.footer":         "Use !cb mailbox claim <number|all> to move items into your inventory",
+	"mailbox.empty":          "Empty Mailbox",
+	"mailbox.empty_detail":   "Nothing waiting for you.",
+	"mailbox.more":           "More Items",
+	"mailbox.more_detail":    "...and {count} more. Claim some to see the rest.",
+	"mailbox.from":           "From: {source}\n{stats}",
+
+	// Common
+	"common.reroll_tokens": "{count, plural, one {# reroll token} other {# reroll tokens}}",
+
+	// Achievements and quests
+	"achievement.unlocked":    "🏆 Achievement Unlocked: {icon} {name}",
+	"achievement.reward":      "Reward",
+	"achievement.reward_line": "Reward: {reward}",
+	"achievement.unlocked_on": "{description}\nUnlocked {date}",
+	"achievement.title":       "{user}'s Achievements ({unlocked}/{total})",
+	"quest.complete":          "📜 Quest Complete!",
+
+	// Common
+	"common.no_character": "You don't have a character yet! Use `!cb roll` to create one.",
+
+	// Quests
+	"quests.title":  "{user}'s Quests",
+	"quests.daily":  "📅 Daily (resets in {time})",
+	"quests.weekly": "🗓️ Weekly (resets in {time})",
+	"quests.footer": "Quests progress from battles, shop purchases and rerolls",
+	"quests.none":   "No quests assigned.",
+
+	// Rank
+	"rank.no_character":  "You need a character to play ranked! Use `!cb roll` to create one.",
+	"rank.unranked":      "Unranked ({played}/{total} placement matches)",
+	"rank.season_rank":   "Season rank: #{position} of {total}",
+	"rank.title":         "🏅 {user}'s Ranked Standing",
+	"rank.season_ends":   "Season {season} ends in {time}",
+	"rank.rank":          "Rank",
+	"rank.rating":        "Rating",
+	"rank.rating_value":  "{rating} (peak {peak})",
+	"rank.record":        "Record",
+	"rank.record_value":  "{wins}W - {losses}L",
+	"rank.season_reward": "Season Reward",
+	"rank.footer":        "Use !cb battle start @user ranked to play a ranked match | Ratings decay after {days} days of inactivity",
+
+	// Roster
+	"roster.no_weapon":      "no weapon",
+	"roster.line":           "{marker} **{number}.** Level {level} {element} {race} · {weapon}",
+	"roster.title":          "{user}'s Characters",
+	"roster.footer":         "{used}/{slots} slots used | Use !cb switch [number] to change your active character",
+	"switch.usage":          "Please specify which character to switch to. Usage: `!cb switch [number]` (see `!cb characters`)",
+	"switch.invalid_number": "Invalid character number. Please provide a valid number.",
+	"switch.in_battle":      "You can't switch characters during a battle!",
+	"switch.in_dungeon":     "You can't switch characters in the dungeon! Use `!cb dungeon retreat` first.",
+	"switch.queued":         "You can't switch characters while queued! Use `!cb queue leave` first.",
+	"switch.failed":         "Switch failed: {error}",
+	"switch.footer":         "{footer} | Now your active character",
+	"buy.slot":              "🧑‍🤝‍🧑 You bought a character slot for **{price}** coins! You can now hold **{count}** characters. Use `!cb roll` to fill it.",
+
+	// Character
+	"character.equipped_weapon": "**Equipped Weapon:** {name}",
+	"character.no_weapon":       "No weapon equipped",
+	"character.level":           "**Level {level}** ({xp} XP)",
+	"character.unspent":         " | {count, plural, one {**#** unspent stat point} other {**#** unspent stat points}} (`!cb allocate`)",
+	"character.title":           "{user}'s Character",
+	"character.summary":         "Race: **{race}** | Element: **{element}** | Alignment: **{alignment}** | Height: **{height}**",
+	"character.characteristics": "Characteristics",
+	"character.stats":           "Stats",
+	"character.traits":          "Traits",
+	"character.equipment":       "Equipment",
+	"character.id":              "Character ID: {id}",
+	"character.race":            "**Race:** {name} ({rarity})",
+	"character.element":         "**Element:** {name} ({rarity})",
+	"character.alignment":       "**Alignment:** {name} ({rarity})",
+	"character.height":          "**Height:** {name}",
+	"character.race_bonuses":    "**Race Bonuses/Penalties:**",
+	"character.innate":          "**Innate Trait:** {name} ({rarity})",
+	"character.bonuses":         "**Bonuses:**",
+	"character.weakness":        "**Weakness:** {name}",
+	"character.penalties":       "**Penalties:**",
+	"character.x_factor":        "**X-Factor:** {name}",
+	"character.effects":         "**Effects:**",
+	"character.no_traits":       "No special traits",
+	"stat.vitality":             "Vitality",
+	"stat.strength":             "Strength",
+	"stat.speed":                "Speed",
+	"stat.durability":           "Durability",
+	"stat.intelligence":         "Intelligence",
+	"stat.mana":                 "Mana",
+	"stat.mastery":              "Mastery",
+
+	// Common
+	"common.cancel":          "Cancel",
+	"common.confirm_expires": "This confirmation will expire in 60 seconds",
+
+	// Characters
+	"roll.slots_full":     "All **{count}** of your character slots are full! Use `!cb characters` to see them, `!cb delete` to free one or `!cb buy slot` for another ({price} coins).",
+	"roll.in_battle":      "You can't roll a new character during a battle!",
+	"roll.failed":         "Error generating character: {error}",
+	"delete.title":        "⚠️ Delete Character",
+	"delete.confirm":      "Are you sure you want to delete your active character? This action cannot be undone!",
+	"delete.button":       "Yes, Delete Character",
+	"delete.failed":       "Failed to delete character: {error}",
+	"delete.deleted":      "Your character has been deleted. Use `!cb characters` to see the rest of your roster or `!cb roll` to create a new one.",
+	"delete.canceled":     "Character deletion canceled.",
+	"rebirth.failed":      "Rebirth failed: {error}",
+	"rebirth.title":       "✨ Rebirth",
+	"rebirth.description": "Your character will be reset to level 1 and rerolled from scratch. Your inventory and wallet are kept.",
+	"rebirth.perks":       "Prestige {level} Perks",
+	"rebirth.perks_value": "• **+{stats}%** to every stat\n• **+{rerolls}** daily full rerolls\n• **+{luck}** starting weapon luck\n• Badge: {badge}",
+	"rebirth.button":      "Rebirth",
+	"rebirth.canceled":    "Rebirth canceled.",
+	"rebirth.reborn":      "✨ You have been reborn! You are now **{badge}**.",
+
+	// Rerolls
+	"reroll.lock_usage":          "{error}. Usage: `!cb reroll [--lock strength,race]`",
+	"reroll.lock_missing":        "list what to lock after --lock",
+	"reroll.lock_unknown_option": "unknown option {option}",
+	"reroll.lock_too_expensive":  "Locking {locks} costs **{cost}** coins, but you only have **{wallet}**.",
+	"reroll.failed":              "Reroll failed: {error}",
+	"reroll.footer":              "Remaining full rerolls today: {count} | Regret it? !cb undo",
+	"reroll.footer_locks":        "Kept {locks} for {cost} coins",
+	"rerollstat.missing":         "Please specify which stat to reroll. Usage: `!cb rerollstat [vitality|strength|speed|durability|intelligence|mana|mastery]`",
+	"rerollstat.invalid":         "Invalid stat type. Valid stats are: vitality, strength, speed, durability, intelligence, mana, mastery",
+	"rerollstat.failed":          "Stat reroll failed: {error}",
+	"rerollstat.title":           "Stat Reroll Result",
+	"rerollstat.description":     "You rerolled your {stat} stat!",
+	"rerollstat.before":          "Before",
+	"rerollstat.after":           "After",
+	"rerollstat.footer":          "Remaining stat rerolls today: {count} | Regret it? !cb undo",
+	"rerolls.title":              "🎲 Reroll Status",
+	"rerolls.description":        "Your daily reroll allowance",
+	"rerolls.full":               "Full Character Rerolls",
+	"rerolls.stat":               "Single Stat Rerolls",
+	"rerolls.remaining":          "{left}/{total} remaining",
+	"rerolls.tokens":             "Reroll Tokens",
+	"rerolls.tokens_available":   "{count} available",
+	"rerolls.pity":               "Pity (rolls without an Epic)",
+	"rerolls.pity_boosted":       "(odds boosted)",
+	"rerolls.refresh":            "Refresh Time",
+	"rerolls.refresh_in":         "Rerolls refresh in {duration}",
+	"rerolls.footer":             "Use !cb reroll to reroll your entire character or !cb rerollstat [stat] to reroll a specific stat",
+
+	// History
+	"history.source.roll":       "🎲 Rolled",
+	"history.source.reroll":     "🔁 Full Reroll",
+	"history.source.rerollstat": "🎯 Stat Reroll",
+	"history.source.rebirth":    "✨ Rebirth",
+	"history.source.undo":       "↩️ Undo",
+	"history.title":             "📜 {user}'s Roll History",
+	"history.footer":            "Page {page}/{pages} | {count, plural, one {# roll} other {# rolls}} | !cb undo restores your last reroll for a reroll token",
+	"history.none":              "No rolls recorded yet. Use `!cb roll` to create a character.",
+	"history.seed":              "Seed {seed}",
+	"history.kept":              "🔒 Kept {locks}",
+	"history.character":         "Lv {level} {element} {race} (rarity {rarity})",
+	"undo.in_battle":            "You can't undo a reroll during a battle!",
+	"undo.failed":               "Undo failed: {error}",
+	"undo.done":                 "↩️ Reroll from <t:{time}:R> undone! One reroll token was spent.",
+
+	// Leaderboard
+	"common.previous":             "◀ Previous",
+	"common.next":                 "Next ▶",
+	"leaderboard.unknown":         "Unknown leaderboard. Usage: `!cb leaderboard [{categories}] [global]`",
+	"leaderboard.scope.server":    "This Server",
+	"leaderboard.scope.global":    "Global",
+	"leaderboard.empty":           "Nobody is ranked yet. Be the first!",
+	"leaderboard.title":           "🏆 {category} Leaderboard ({scope})",
+	"leaderboard.category.level":  "Level",
+	"leaderboard.category.wins":   "Wins",
+	"leaderboard.category.coins":  "Coins",
+	"leaderboard.category.rarity": "Rarity",
+	"leaderboard.footer":          "Page {page}/{pages} | {count, plural, one {# ranked player} other {# ranked players}}",
+	"leaderboard.unranked":        "Not ranked yet",
+	"leaderboard.your_rank":       "Your Rank",
+	"leaderboard.value.level":     "Level {count}",
+	"leaderboard.value.wins":      "{count, plural, one {# win} other {# wins}}",
+	"leaderboard.value.rarity":    "{count, plural, one {# rarity point} other {# rarity points}}",
+
+	// Allocation
+	"common.on":               "On",
+	"common.off":              "Off",
+	"allocate.respec_failed":  "Respec failed: {error}",
+	"allocate.respec_done":    "🔄 Respec complete! {count, plural, one {**#** stat point was} other {**#** stat points were}} refunded. Spend them with `!cb allocate <stat> <points>`.",
+	"allocate.invalid_stat":   "Invalid stat. Valid stats are: vitality, strength, speed, durability, intelligence, mana, mastery",
+	"allocate.invalid_points": "Invalid number of points. Usage: `!cb allocate <stat> <points>`",
+	"allocate.failed":         "Allocation failed: {error}",
+	"allocate.added":          "⬆️ Added {points, plural, one {**#** point} other {**#** points}} to **{stat}** (now {total}). You have {count, plural, one {**#** unspent stat point} other {**#** unspent stat points}} left.",
+	"allocate.auto_usage":     "Usage: `!cb allocate auto <on|off>`",
+	"allocate.auto_off":       "Auto growth is **off**. New stat points will wait for `!cb allocate`.",
+	"allocate.auto_on":        "Auto growth is **on**. New stat points will follow your race's growth curve.",
+	"allocate.auto_spent":     "Spent your unspent points: {allocation}",
+	"allocate.title":          "⬆️ Stat Points",
+	"allocate.description":    "**Level {level}** | **{unspent}** unspent of {earned} earned ({perLevel} per level)\nAuto growth: **{auto}** | Respec tokens: **{tokens}**",
+	"allocate.allocated":      "Allocated",
+	"allocate.curve":          "{race} Growth Curve",
+	"allocate.footer":         "!cb allocate <stat> <points> | !cb allocate auto <on|off> | !cb allocate reset (uses a respec token, {price} coins via !cb buy respec)",
+
+	// Admin
+	"admin.usage":            "Usage: `!cb admin coins|rerolls @user <amount> [reason]`, `!cb admin item @user <rarity> [reason]`, `!cb admin resetchar|deletechar|endbattle|ban|unban @user [reason]`, `!cb admin refreshshop [reason]` or `!cb admin log [page]`",
+	"admin.shop_refreshed":   "🛒 The shop has been restocked.",
+	"admin.mention":          "Mention the user to {action}. {usage}",
+	"admin.owner_only":       "Only the bot owners can use `{action}`, it changes players and the shop in every server.",
+	"admin.self_blocked":     "Staff can't use that on themselves.",
+	"admin.not_member":       "<@{user}> isn't a member of this server.",
+	"admin.failed":           "Admin action failed: {error}",
+	"admin.amount_missing":   "give an amount, negative to deduct",
+	"admin.amount_invalid":   "the amount must be a number from -{limit} to {limit} other than 0",
+	"admin.coins":            "{amount} coins for <@{user}>, their balance is now **{balance}**.",
+	"admin.rerolls":          "{amount} reroll tokens for <@{user}>, they now have **{tokens}**.",
+	"admin.rarity_missing":   "give a rarity: {rarities}",
+	"admin.rarity_invalid":   "{rarity} is not a rarity, choose from {rarities}",
+	"admin.item":             "<@{user}> was given **{item}** ({rarity}).",
+	"admin.item_mailed":      "Their inventory was full, so it went to their mailbox.",
+	"admin.in_battle":        "<@{user}> is in a battle, end it first with `!cb admin endbattle`",
+	"admin.reset_character":  "<@{user}>'s active character was reset to level 1.",
+	"admin.delete_character": "<@{user}>'s active character was deleted.",
+	"admin.battle_ended_by":  "The battle was ended by {admin}.",
+	"admin.end_battle":       "<@{user}>'s battle was ended with no winner.",
+	"admin.ban":              "<@{user}> is banned from playing in this server.",
+	"admin.unban":            "<@{user}> can play in this server again.",
+	"admin.log.title":        "🛡️ Admin Audit Log",
+	"admin.log.footer":       "Page {page}/{pages} | {count, plural, one {# action} other {# actions}} | !cb admin log [page]",
+	"admin.log.none":         "No admin actions recorded.",
+	"admin.log.by":           "by <@{admin}>",
+	"admin.log.reason":       "Reason: {reason}",
+
+	// Banners
+	"banner.unknown_command":   "Unknown banner command. Available: list, create, end, audit",
+	"banner.none":              "No banners are running or scheduled. Rolls use the standard odds.",
+	"banner.scheduled":         "🕒 Starts <t:{start}:R>, runs until <t:{end}:f>",
+	"banner.live":              "🔥 Live now, ends <t:{end}:R>",
+	"banner.featured_races":    "Featured Races",
+	"banner.featured_innates":  "Featured Innate Traits",
+	"banner.featured_weapons":  "Featured Weapons",
+	"banner.odds":              "Rarity Odds",
+	"banner.odds_value":        "Common {common} · Uncommon {uncommon} · Rare {rare} · Epic {epic} · Legendary {legendary}",
+	"banner.create_usage":      "Usage: `!cb banner create <spec>`, for example {example}\nStart times use the daily reset timezone.",
+	"banner.invalid":           "Invalid banner: {error}",
+	"banner.create_failed":     "Couldn't schedule the banner: {error}",
+	"banner.created":           "🎏 **{banner}** has been scheduled by <@{user}>!",
+	"banner.end_usage":         "Usage: `!cb banner end <name>`",
+	"banner.ended":             "🏁 **{banner}** has ended. Rolls are back to the standard odds.",
+	"banner.audit_usage":       "Usage: `!cb banner audit <name>`",
+	"banner.audit_title":       "📋 {banner} Audit",
+	"banner.audit_description": "{rolls, plural, one {**#** roll} other {**#** rolls}} by {players, plural, one {**#** player} other {**#** players}}\nScheduled by <@{user}>, <t:{start}:f> to <t:{end}:f>",
+	"banner.audit_footer":      "Seeds let any roll be replayed and checked",
+
+	// Clans
+	"clan.create_usage":           "Usage: `!cb clan create <name>` (costs {price} coins)",
+	"clan.created":                "🏰 **{clan}** has been founded! Invite players with `!cb clan invite @player`.",
+	"clan.invite_usage":           "Usage: `!cb clan invite @player`",
+	"clan.invited":                "✉️ <@{user}> has been invited to **{clan}**! Accept with `!cb clan join {clan}`.",
+	"clan.join_usage":             "Usage: `!cb clan join <name>`",
+	"clan.joined":                 "🎉 <@{user}> joined **{clan}**!",
+	"clan.disbanded":              "**{clan}** has been disbanded.",
+	"clan.left":                   "You left **{clan}**.",
+	"clan.kick_usage":             "Usage: `!cb clan kick @player`",
+	"clan.kicked":                 "<@{user}> was kicked from **{clan}**.",
+	"clan.promote_usage":          "Usage: `!cb clan promote @player <leader|officer|member>`",
+	"clan.promoted":               "<@{user}> is now a clan **{role}**.",
+	"clan.role.leader":            "leader",
+	"clan.role.officer":           "officer",
+	"clan.role.member":            "member",
+	"clan.bank_usage":             "Usage: `!cb clan {action} <coins>`",
+	"clan.deposited":              "🏦 You deposited {amount, plural, one {**#** coin} other {**#** coins}}. The clan bank now holds {balance, plural, one {**#** coin} other {**#** coins}}.",
+	"clan.withdrew":               "🏦 You withdrew {amount, plural, one {**#** coin} other {**#** coins}}. The clan bank now holds {balance, plural, one {**#** coin} other {**#** coins}}.",
+	"clan.unknown_command":        "Invalid clan command. Usage: `!cb clan [info|create|invite|join|leave|kick|promote|deposit|withdraw|ledger|perks|war]`",
+	"clan.not_found":              "{error}. Use `!cb clan create <name>` to found one.",
+	"clan.max_level":              "Max level",
+	"clan.progress":               "{xp}/{needed} XP to level {level}",
+	"clan.level":                  "Level",
+	"clan.bank":                   "Bank",
+	"clan.members":                "Members ({count}/{max})",
+	"clan.info_footer":            "Founded {date} | !cb clan perks to see unlocks",
+	"clan.ledger_empty":           "The clan bank has no transactions yet.",
+	"clan.war_spoils":             "War spoils",
+	"clan.ledger_line":            "`{date}` {who} {kind} **{amount}** (balance {balance})",
+	"clan.ledger_kind.deposit":    "deposit",
+	"clan.ledger_kind.withdrawal": "withdrawal",
+	"clan.ledger_kind.war_prize":  "war prize",
+	"clan.ledger_title":           "🏦 {clan} Bank Ledger",
+	"clan.ledger_footer":          "Current balance: {count, plural, one {# coin} other {# coins}}",
+	"clan.perk_line":              "{status} **Lv {level} — {name}**: {description}",
+	"clan.perks_title":            "Clan Perks",
+	"clan.perks_footer":           "Higher tiers replace lower ones. Clans level up from their members' battle XP and war victories.",
+
+	// Battle log
+	"battle.log.begins":           "Battle between {first} and {second} begins!",
+	"battle.log.no_action":        "No action selected",
+	"battle.log.defeated":         "{loser} has been defeated! {winner} wins the battle!",
+	"battle.log.forfeited":        "{loser} has forfeited the battle! {winner} wins!",
+	"battle.log.stunned":          "{name} is stunned and cannot move!",
+	"battle.log.attack_dodged":    "{name} attempts a physical attack, but {target} dodges!",
+	"battle.log.attack_missed":    "{name}'s attack misses!",
+	"battle.log.attack_crit":      "{name} lands a critical hit for {damage} damage!",
+	"battle.log.attack":           "{name} attacks {target} for {damage} damage!",
+	"battle.log.magic_no_mana":    "{name} doesn't have enough mana to cast a spell!",
+	"battle.log.magic_dodged":     "{name} casts a spell, but {target} manages to avoid it!",
+	"battle.log.magic_missed":     "{name}'s spell fizzles out!",
+	"battle.log.magic_crit":       "{name} casts a critical {element} spell for {damage} damage!",
+	"battle.log.magic":            "{name} casts a {element} spell on {target} for {damage} damage!",
+	"battle.log.super_effective":  "It's super effective!",
+	"battle.log.not_effective":    "It's not very effective...",
+	"battle.log.skill_no_mana":    "{name} tries to use {skill} but doesn't have enough mana!",
+	"battle.log.skill_heal_self":  "{name} uses **{skill}**, recovering {hp} HP!",
+	"battle.log.skill_heal":       "{name} uses **{skill}** on {target}, restoring {hp} HP!",
+	"battle.log.skill_dodged":     "{name} uses **{skill}**, but {target} dodges!",
+	"battle.log.skill_missed":     "{name}'s **{skill}** misses!",
+	"battle.log.skill":            "{name} uses **{skill}** on {target} for {damage} damage!",
+	"battle.log.immune":           "{name} is immune to {effect}!",
+	"battle.log.afflicted":        "{name} is now {effect}!",
+	"battle.log.defend":           "{name} takes a defensive stance, increasing defense!",
+	"battle.log.item":             "{name} uses a healing item, recovering {hp} HP!",
+	"battle.log.item_none":        "{name} reaches for a healing item, but has none left!",
+	"battle.log.escort_joins":     "{name} joins the fight alongside {ally}!",
+	"battle.log.falls":            "{name} falls, but the fight goes on!",
+	"battle.effect.burn":          "Burn",
+	"battle.effect.poison":        "Poison",
+	"battle.effect.stun":          "Stun",
+	"battle.effect.freeze":        "Freeze",
+	"battle.effect.defending":     "Defending",
+	"battle.effect.none":          "None",
+	"battle.status.round":         "⚔️ Round {round} ⚔️",
+	"battle.status.effects":       "Status:",
+	"battle.status.turn":          "Current turn: {name}",
+	"battle.status.recent":        "Recent actions:",
+	"battle.error.not_your_turn":  "it's not your turn",
+	"battle.error.invalid_action": "invalid action",
+	"battle.error.invalid_target": "invalid target",
+
+	// Battles
+	"battle.usage":                  "Invalid battle command. Usage: `!cb battle [start|npcs|attack|magic|defend|item]`",
+	"battle.unknown_command":        "Unknown battle command. Available commands: start, npcs, attack, magic, defend, item, status, forfeit",
+	"battle.unknown_npc":            "Unknown NPC \"{name}\". Use `!cb battle npcs` to see who you can fight.",
+	"battle.no_character":           "You need a character to battle! Use `!cb roll` to create one.",
+	"battle.already_in_battle":      "You are already in a battle! Finish or forfeit it first.",
+	"battle.target_no_character":    "That user doesn't have a character!",
+	"battle.target_in_battle":       "That player is already in a battle!",
+	"battle.pvp_no_characters":      "Error starting battle: One or both players don't have characters.",
+	"battle.not_in_battle":          "You're not in a battle! Use `!cb battle start` to begin one.",
+	"battle.not_your_turn":          "It's not your turn!",
+	"battle.turn_failed":            "Error processing turn: {error}",
+	"battle.forfeited":              "**{name}** has forfeited the battle!",
+	"battle.roster.title":           "👹 NPC Roster",
+	"battle.roster.description":     "Challenge one with `!cb battle start <name> [level|auto]`",
+	"battle.roster.details":         "{description}\nLevels {min}-{max} (default {default}) | {element} {race} | {ai} AI",
+	"battle.roster.skills":          "Skills: {skills}",
+	"battle.roster.immune":          "Immune: {effects}",
+	"battle.roster.escorts":         "Fights alongside: {names}",
+	"battle.challenge.title":        "⚔️ Battle Challenge!",
+	"battle.challenge.title_ranked": "🏅 Ranked Battle Challenge!",
+	"battle.challenge.title_war":    "⚔️ Clan War Challenge!",
+	"battle.challenge.description":  "<@{challenger}> has challenged <@{target}> to a battle!",
+	"battle.challenge.how":          "How to Accept",
+	"battle.challenge.how_value":    "Click the Accept button below to begin the battle.",
+	"battle.challenge.expires":      "Challenge expires in 60 seconds",
+	"battle.challenge.accept":       "Accept Challenge",
+	"battle.challenge.decline":      "Decline",
+	"battle.challenge.accepted":     "Battle challenge accepted! Starting battle...",
+	"battle.challenge.declined":     "<@{user}> declined the battle challenge.",
+	"battle.challenge.not_for_you":  "This challenge isn't for you!",
+	"battle.challenge.expired":      "Battle challenge expired.",
+	"battle.embed.title":            "⚔️ Battle ⚔️",
+	"battle.embed.description":      "Round {round}\n\nCurrent turn: **{name}**",
+	"battle.embed.participant":      "HP: {hp}/{maxHP} {hpBar}\nMP: {mp}/{maxMP} {mpBar}\nStatus: {status}",
+	"battle.embed.log":              "Battle Log",
+	"battle.embed.log_empty":        "The battle has just begun!",
+	"battle.embed.commands":         "Commands",
+	"battle.embed.commands_value":   "• `!cb battle attack` - Physical attack\n• `!cb battle magic` - Magical attack\n• `!cb battle defend` - Increase defense\n• `!cb battle item` - Use healing item\n• `!cb battle forfeit` - Give up",
+	"battle.embed.footer":           "Battle ID: {id}",
+	"battle.result.title":           "🏆 Battle Complete!",
+	"battle.result.title_lost":      "Battle Complete",
+	"battle.result.winner":          "**{name}** has won the battle!",
+	"battle.result.stats":           "Battle Statistics",
+	"battle.result.stats_value":     "Rounds: {rounds}\nRemaining HP: {hp}",
+	"battle.result.rewards":         "Rewards",
+	"battle.result.rewards_value":   "Experience: **+{xp}** XP (Total: {total})\nCurrency: **+{coins}** coins",
+	"battle.result.level_gap":       "📊 Level Gap",
+	"battle.result.loot":            "🎁 Loot",
+	"battle.result.ranked":          "🏅 Ranked",
+	"battle.level_up.title":         "🎉 Level Up!",
+	"battle.level_up.description":   "**{name}** has reached level **{level}**!",
+	"battle.level_up.growth":        "Character Growth",
+	"battle.level_up.growth_value":  "You earned new stat points! Check `!cb allocate` to spend them.",
+	"battle.loot.added":             "Added to inventory as `{key}`",
+	"battle.scaling.stronger":       "Defeated a stronger foe: **+{percent}%** XP",
+	"battle.scaling.farming":        "This foe is far below your level: rewards capped at **{percent}%** and no loot",
+	"battle.scaling.weaker":         "This foe is below your level: rewards reduced to **{percent}%**",
+	"battle.warning.farming":        "⚠️ This opponent is far below your level ({npcLevel} vs {level}). Rewards are capped at {percent}% and it won't drop loot. Try `auto` difficulty.",
+	"battle.warning.weaker":         "⚠️ This opponent is below your level ({npcLevel} vs {level}). Rewards are reduced to {percent}%.",
+	"battle.warning.stronger":       "⚠️ This opponent is {gap} levels above you. It will hit harder and your attacks will miss more often.",
+
+	// Dungeon
+	"dungeon.usage":                "Invalid dungeon command. Usage: `!cb dungeon [enter|descend|retreat|status]`",
+	"dungeon.no_character":         "You need a character to explore the dungeon! Use `!cb roll` to create one.",
+	"dungeon.no_run":               "{error}. Use `!cb dungeon enter` to start a run.",
+	"dungeon.finish_battle":        "Finish your current battle before moving on!",
+	"dungeon.character_gone":       "The character that started this run is gone. Use `!cb dungeon retreat` to leave.",
+	"dungeon.still_guarding":       "⚔️ **{enemy}** is still guarding floor {floor}!",
+	"dungeon.retreat_in_fight":     "You can't retreat in the middle of a fight!",
+	"dungeon.enter.title":          "🕯️ Entering the Dungeon",
+	"dungeon.enter.description":    "**{name}** steps into the darkness. HP, MP and status effects carry over between floors.",
+	"dungeon.room.combat":          "Combat",
+	"dungeon.room.boss":            "Boss",
+	"dungeon.rest.title":           "🏕️ Floor {floor} — Rest Room",
+	"dungeon.rest.description":     "A quiet campfire. You recover {percent}% of your HP and MP and shake off all status effects.",
+	"dungeon.treasure.title":       "💰 Floor {floor} — Treasure Room",
+	"dungeon.treasure.description": "You found a chest holding **{count, plural, one {# coin} other {# coins}}**!",
+	"dungeon.fight.title":          "⚔️ Floor {floor} — {room}",
+	"dungeon.fight.description":    "A level {level} **{enemy}** blocks the way!",
+	"dungeon.fight.boss":           "Defeat it to secure all the loot you're carrying.",
+	"dungeon.new_record":           "🏅 New deepest floor: **{floor}**!",
+	"dungeon.loot_kept":            "Loot Kept",
+	"dungeon.level_up":             "Reached level **{level}**!",
+	"dungeon.retreat.title":        "🏃 Retreated from the Dungeon",
+	"dungeon.retreat.description":  "**{name}** made it out from floor {floor}.",
+	"dungeon.retreat.loot":         "Coins: **+{coins}**\nExperience: **+{xp}** XP\n(Secured loot plus {percent}% of the rest)",
+	"dungeon.defeat.title":         "💀 Fallen in the Dungeon",
+	"dungeon.defeat.description":   "**{name}** was defeated by **{enemy}** on floor {floor}.",
+	"dungeon.defeat.loot":          "Coins: **+{coins}**\nExperience: **+{xp}** XP\n(Unsecured loot was lost)",
+	"dungeon.cleared.title":        "✅ Floor {floor} Cleared",
+	"dungeon.cleared.description":  "**{name}** defeated **{enemy}**! (+{coins} coins, +{xp} XP)",
+	"dungeon.cleared.secured":      "🔒 Your loot is now secured.",
+	"dungeon.status.title":         "🕯️ Dungeon — Floor {floor}",
+	"dungeon.status.fighting":      "Fighting a level {level} **{enemy}**.",
+	"dungeon.embed.floor":          "Floor",
+	"dungeon.embed.vitals":         "Vitals",
+	"dungeon.embed.vitals_value":   "HP {hp}/{maxHP}\nMP {mp}/{maxMP}\nStatus: {status}",
+	"dungeon.embed.loot":           "Loot",
+	"dungeon.embed.loot_value":     "Carrying: {coins} coins, {xp} XP\nSecured: {securedCoins} coins, {securedXP} XP",
+	"dungeon.embed.footer":         "Boss every {every} floors | !cb dungeon descend to go deeper | !cb dungeon retreat to leave",
+	"dungeon.embed.deepest":        "Deepest Floor",
+
+	// Clan wars
+	"war.usage":          "Invalid clan war command. Usage: `!cb clan war [declare|accept|fight|status]`",
+	"war.declare_usage":  "Usage: `!cb clan war declare <clan name>`",
+	"war.fight_usage":    "Usage: `!cb clan war fight @player`",
+	"war.declared":       "⚔️ **{attacker}** has declared war on **{defender}**! An officer of {defender} can answer with `!cb clan war accept` within {hours} hours.",
+	"war.started":        "The war is on! Challenge members of the other clan with `!cb clan war fight @player`. Every win scores a point for your clan over the next {hours} hours.",
+	"war.no_war":         "Your clan has no war in progress.",
+	"war.no_war_declare": "{error}. Use `!cb clan war declare <clan name>` to start one.",
+	"war.wrong_clan":     "That player isn't in the clan you're at war with.",
+	"war.score_failed":   "⚔️ The win couldn't be scored: {error}",
+	"war.scored":         "⚔️ <@{winner}> scores a point for **{clan}**! ({attacker} {attackerScore} - {defenderScore} {defender})",
+	"war.embed.title":    "⚔️ {attacker} vs {defender}",
+	"war.embed.score":    "Score",
+	"war.embed.pending":  "Waiting for {defender} to accept.",
+	"war.embed.ends_in":  "Ends in {duration}.",
+	"war.embed.draw":     "The war ended in a draw.",
+	"war.embed.winner":   "🏆 **{clan}** wins the war!",
+	"war.embed.prize":    "**{coins}** coins and **{xp}** clan XP go to the winner.",
+
+	// Matchmaking
+	"queue.usage":       "Invalid queue command. Usage: `!cb queue [ranked|casual|leave|status]`",
+	"queue.mode.ranked": "ranked",
+	"queue.mode.casual": "casual",
+	"queue.expired":     "<@{user}> no {mode} match was found in time, you've been removed from the queue.",
+	"queue.match_found": "⚔️ Match found! <@{first}> vs <@{second}> ({mode}) in <#{channel}>",
+	"queue.title":       "🔎 Searching for a Match",
+	"queue.joined":      "**{name}** joined the {mode} queue.",
+	"queue.level":       "Level",
+	"queue.rating":      "Rating",
+	"queue.footer":      "The search widens the longer you wait | Queue expires in {minutes} minutes | !cb queue leave to cancel",
+	"queue.not_queued":  "You are not in the queue.",
+	"queue.left":        "You left the matchmaking queue.",
+	"queue.no_entry":    "{error}. Use `!cb queue ranked` or `!cb queue casual` to join.",
+	"queue.status":      "You've been in the {mode} queue for {waited} (expires in {remaining}).",
+
+	// Tournaments
+	"tournament.usage":               "Invalid tournament command. Usage: `!cb tournament [create|join|start|ready|cancel|status]`",
+	"tournament.invalid_format":      "Invalid format. Usage: `!cb tournament create [single|double|swiss] [name]`",
+	"tournament.default_name":        "{name}'s Tournament",
+	"tournament.created.title":       "🏟️ Tournament Created",
+	"tournament.created.description": "**{name}** is open for signups!",
+	"tournament.created.footer":      "Use !cb tournament join to sign up | The organizer starts it with !cb tournament start or calls it off with !cb tournament cancel",
+	"tournament.format":              "Format",
+	"tournament.entry_fee":           "Entry Fee",
+	"tournament.entry_fee_value":     "{count, plural, one {# coin} other {# coins}} per player, paid into the prize pool",
+	"tournament.no_character":        "You need a character to compete! Use `!cb roll` to create one.",
+	"tournament.signups_closed":      "Signups for this tournament are closed.",
+	"tournament.already_joined":      "You're already signed up!",
+	"tournament.full":                "This tournament is full ({count} players).",
+	"tournament.joined":              "✅ **{name}** joined **{tournament}** ({count, plural, one {# player} other {# players}} signed up).",
+	"tournament.not_organizer":       "Only the organizer can start the tournament.",
+	"tournament.no_match":            "You don't have a match waiting in this round.",
+	"tournament.match_underway":      "Your match is already underway!",
+	"tournament.ready":               "✅ <@{user}> is ready for match #{match}. Waiting on <@{opponent}> ({remaining} left to check in).",
+	"tournament.player_busy":         "One of the players is still in another battle. Finish it and ready up again.",
+	"tournament.no_tournament":       "{error}. Use `!cb tournament create` to start one.",
+	"tournament.checkin_closed":      "⌛ Match #{match}: check-in closed, <@{winner}> advances by forfeit.",
+	"tournament.interrupted":         "⚠️ Match #{match} between <@{playerA}> and <@{playerB}> was interrupted. Use `!cb tournament ready` to replay it.",
+	"tournament.advances":            "🏟️ Match #{match}: <@{winner}> advances!",
+	"tournament.finish.title":        "🏆 {name} Complete!",
+	"tournament.finish.champion":     "<@{user}> is the champion!",
+	"tournament.finish.standings":    "Final Standings",
+	"tournament.finish.standing":     "{medal} **{name}** ({wins}-{losses}) — {prize} coins",
+	"tournament.finish.footer":       "{format} | {players} players | Prize pool: {pool} coins",
+	"tournament.round.match":         "Match #{match}: <@{playerA}> vs <@{playerB}>",
+	"tournament.round.ready":         "⚔️ **Round {round}** is ready! Use `!cb tournament ready` within {minutes} minutes or forfeit.",
+	"tournament.bracket.description": "{format} | {status} | {players} players",
+	"tournament.bracket.no_players":  "No players yet. Use `!cb tournament join`!",
+	"tournament.bracket.signups":     "Signups",
+	"tournament.bracket.round":       "Round {round}",
+	"tournament.bracket.standings":   "Standings",
+	"tournament.match.losers":        "[L]",
+	"tournament.match.final":         "[Final]",
+	"tournament.match.bye":           "bye",
+	"tournament.match.versus":        "{playerA} vs {playerB}",
+	"tournament.match.forfeit":       "(forfeit)",
+	"tournament.match.playing":       "⚔️ in progress",
+	"tournament.match.waiting":       "⏳ waiting",
+	"tournament.name.single":         "Single Elimination",
+	"tournament.name.double":         "Double Elimination",
+	"tournament.name.swiss":          "Swiss",
+	"tournament.name.signup":         "Signup",
+	"tournament.name.running":        "Running",
+	"tournament.name.finished":       "Finished",
+
+	// Boss phases
+	"battle.log.phase": "⚠️ {name} enters {phase}! {message}",
+
+	// Tournament entry fees
+	"tournament.fee_unaffordable":     "You need {count, plural, one {# coin} other {# coins}} to pay the entry fee.",
+	"tournament.cancel_not_organizer": "Only the organizer can cancel the tournament.",
+	"tournament.cancel_started":       "The tournament has already started and can't be cancelled.",
+	"tournament.cancelled":            "🚫 **{name}** was cancelled. {count, plural, one {# entry fee was} other {# entry fees were}} refunded.",
+
+	// Tournament states
+	"tournament.name.cancelled": "Cancelled",
+}
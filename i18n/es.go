@@ -0,0 +1,947 @@
+package i18n
+
+// spanish translates the English catalogue
+// Note: Keys under command. and data. translate commands and game data, their English lives with their definitions.
+var spanish = Catalogue{
+	// Usage errors
+	"usage.missing_command":    "falta el comando",
+	"usage.unknown_command":    "comando desconocido {command}",
+	"usage.choose_subcommand":  "elige uno de {choices}",
+	"usage.unknown_subcommand": "subcomando desconocido {word}, elige uno de {choices}",
+	"usage.missing_arg":        "falta {arg}",
+	"usage.unexpected":         "no se esperaba {word}",
+	"usage.not_integer":        "{arg} debe ser un número entero",
+	"usage.out_of_range":       "{arg} debe estar entre {min} y {max}",
+	"usage.not_user":           "{arg} debe ser una mención como @usuario",
+	"usage.not_choice":         "{arg} debe ser uno de {choices}",
+
+	// Dispatcher
+	"dispatch.unknown_command":          "Comando desconocido. Prueba `!cb help` para ver la lista de comandos.",
+	"dispatch.usage":                    "{reason}.\nUso: {usage}\nMira `!cb help {command}` para más detalles.",
+	"dispatch.banned":                   "Tienes prohibido jugar en este servidor.",
+	"dispatch.banned_dm":                "Tienes prohibido jugar en un servidor, así que tampoco puedes jugar por mensaje directo.",
+	"dispatch.guild_only":               "`!cb {command}` solo funciona en un canal de servidor.",
+	"dispatch.permission":               "Solo {who} pueden usar `!cb {command}`.",
+	"dispatch.permission.staff":         "los miembros con el permiso Gestionar servidor o un rol de administrador",
+	"dispatch.permission.manage_server": "los miembros con el permiso Gestionar servidor",
+	"dispatch.feature_off":              "La función {feature} está desactivada en este servidor.",
+	"dispatch.ranked_off":               "Las partidas clasificatorias están desactivadas en este servidor.",
+	"dispatch.roll_channels":            "Las tiradas solo están permitidas en {channels}.",
+	"dispatch.battle_channels":          "Los combates solo están permitidos en {channels}.",
+	"dispatch.slow_down":                "⏳ ¡Más despacio, <@{user}>! Vuelve a intentarlo en {seconds} s.",
+	"dispatch.guild_busy":               "⏳ Este servidor está enviando muchos comandos, vuelve a intentarlo en {seconds} s.",
+	"slash.failed":                      "No se pudo ejecutar: {error}",
+	"slash.used":                        "<@{user}> usó `{command}`",
+
+	// Language
+	"language.title":     "🌐 Idioma",
+	"language.current":   "Te respondo en **{language}**. Este servidor usa **{server}**.",
+	"language.available": "Idiomas disponibles",
+	"language.footer":    "!cb language <código> elige el tuyo, !cb language reset sigue al servidor",
+	"language.set":       "🌐 A partir de ahora te responderé en **{language}**.",
+	"language.reset":     "🌐 Ahora sigues el idioma del servidor.",
+	"language.unknown":   "No existe el idioma {language}. Elige entre:\n{languages}",
+	"language.failed":    "No se cambió el idioma: {error}",
+
+	// Help
+	"help.unknown":             "No existe el comando {command}. Prueba `!cb help` para ver la lista de comandos.",
+	"help.title":               "Ayuda de CrispyBot",
+	"help.description":         "Estos son los comandos que puedes usar. Todos funcionan también como comando de barra.\nUsa `!cb help <comando>` para ver sus argumentos.",
+	"help.footer":              "CrispyBot v1.0",
+	"help.footer_prefix":       "{footer} | Este servidor también usa {prefix} en lugar de !cb",
+	"help.command_line":        "`!cb {command}` {description}",
+	"help.category.general":    "General",
+	"help.category.characters": "Personajes",
+	"help.category.rolls":      "Tiradas",
+	"help.category.economy":    "Economía",
+	"help.category.battles":    "Combates",
+	"help.category.social":     "Social",
+	"help.category.server":     "Servidor",
+	"help.command_title":       "!cb {command}",
+	"help.aliases":             "{description}\nTambién: {aliases}",
+	"help.usage":               "Uso",
+	"help.choices":             "{description}: {choices}",
+	"help.optional":            "{description} (opcional)",
+	"help.only":                "🛡️ Solo {who}",
+	"help.guild_only":          "🏠 Solo en canales de servidor",
+	"help.cooldown":            "⏳ Espera de {cooldown}",
+
+	// Common
+	"common.coins": "{count, plural, one {# moneda} other {# monedas}}",
+	"common.error": "Error: {error}",
+
+	// Config
+	"config.usage_set":            "Uso: `!cb config set <ajuste> <valor>`, ajustes: {settings}",
+	"config.usage_reset":          "Uso: `!cb config reset <ajuste>`, ajustes: {settings}",
+	"config.usage_feature":        "Uso: `!cb config {action} <función>`, funciones: {features}",
+	"config.unknown":              "Comando de configuración desconocido. Disponibles: show, set, reset, enable, disable",
+	"config.failed":               "No se cambiaron los ajustes: {error}",
+	"config.updated":              "⚙️ ¡Ajustes actualizados!",
+	"config.prefix_value":         "{prefix} (y !cb)",
+	"config.title":                "⚙️ Ajustes del servidor",
+	"config.field.prefix":         "Prefijo (prefix)",
+	"config.field.daily":          "Recompensa diaria (daily)",
+	"config.field.shopsize":       "Tamaño de la tienda (shopsize)",
+	"config.field.rerolls":        "Rerolls completos (rerolls)",
+	"config.field.statrerolls":    "Rerolls de estadística (statrerolls)",
+	"config.field.language":       "Idioma (language)",
+	"config.field.rollchannels":   "Canales de tiradas (rollchannels)",
+	"config.field.battlechannels": "Canales de combate (battlechannels)",
+	"config.field.adminroles":     "Roles de administrador (adminroles)",
+	"config.field.features":       "Funciones",
+	"config.items":                "{count, plural, one {# objeto} other {# objetos}}",
+	"config.per_day":              "{count} al día",
+	"config.footer":               "Gestores del servidor: !cb config set|reset <ajuste> [valor] o !cb config enable|disable <función>",
+	"config.any_channel":          "cualquier canal",
+	"config.manage_server_only":   "Solo Gestionar servidor",
+
+	// Common
+	"common.item_mailed":     "📬 Tu inventario está lleno, así que se envió a tu buzón (`!cb mailbox`)",
+	"duration.hours_minutes": "{hours, plural, one {# hora} other {# horas}} y {minutes, plural, one {# minuto} other {# minutos}}",
+	"duration.minutes":       "{minutes, plural, one {# minuto} other {# minutos}}",
+	"item.no_stats":          "Sin bonificaciones de estadísticas",
+	"item.stat_modifier":     "• {value} a {stat}",
+
+	// Shop
+	"shop.error":             "Error al acceder a la tienda: {error}",
+	"shop.title":             "🛒 Tienda de objetos",
+	"shop.refresh":           "La tienda se renovará en {time}",
+	"shop.footer":            "Usa !cb buy [número] para comprar un objeto",
+	"shop.empty":             "No hay objetos disponibles",
+	"shop.empty_detail":      "La tienda está vacía. Vuelve cuando se renueve.",
+	"shop.item":              "{number}. {name} ({rarity}) - {price, plural, one {# moneda} other {# monedas}}",
+	"buy.usage":              "Indica el número del objeto que quieres comprar. Uso: `!cb buy [número|respec|slot]`",
+	"buy.failed":             "La compra falló: {error}",
+	"buy.respec":             "🔄 ¡Compraste una ficha de reasignación por **{price}** monedas! Ahora tienes **{count}**. Usa `!cb allocate reset` para recuperar tus puntos de estadística.",
+	"buy.invalid_number":     "Número de objeto no válido. Indica un número válido.",
+	"buy.title":              "Compra realizada",
+	"buy.bought":             "¡Compraste **{name}** por **{price}** monedas!",
+	"buy.details":            "Detalles del objeto",
+	"buy.details_value":      "**Rareza:** {rarity}\n{stats}",
+	"buy.footer":             "Escribe !cb wallet para ver tu saldo restante",
+	"wallet.title":           "💰 Tu cartera",
+	"wallet.balance":         "**Saldo:** {count, plural, one {# moneda} other {# monedas}}",
+	"wallet.inventory":       "Inventario",
+	"wallet.inventory_count": "Tienes {count, plural, one {# objeto} other {# objetos}} en tu inventario",
+	"wallet.footer":          "Usa !cb daily para ganar recompensas diarias",
+	"daily.failed":           "La recompensa diaria falló: {error}. La próxima estará disponible en {time}.",
+	"daily.streak":           "🔥 Racha de {count, plural, one {# día} other {# días}}",
+	"daily.streak_reset":     " (te saltaste un día, así que tu racha se reinició)",
+	"daily.title":            "Recompensa diaria",
+	"daily.received":         "¡Recibiste **{count}** monedas!",
+	"daily.streak_field":     "Racha",
+	"daily.balance":          "Nuevo saldo",
+	"daily.footer":           "Vuelve en {time} para más recompensas",
+	"daily.milestone_tokens": "• {count, plural, one {**#** ficha de reroll} other {**#** fichas de reroll}}",
+	"daily.milestone":        "🎉 ¡Hito de {day} días!",
+
+	// Equipment
+	"equip.usage":          "Indica qué objeto quieres equipar. Uso: `!cb equip [número de objeto]`",
+	"equip.not_found":      "No se encontró el objeto en tu inventario.",
+	"equip.failed":         "No se pudo equipar el objeto: {error}",
+	"equip.equipped_short": "¡Equipaste **{name}**!",
+	"equip.title":          "Objeto equipado",
+	"equip.equipped":       "¡Equipaste **{name}**!",
+	"equip.footer":         "Escribe !cb stats para ver las estadísticas actualizadas de tu personaje",
+	"unequip.nothing":      "No tienes ningún objeto equipado.",
+	"unequip.failed":       "No se pudo desequipar el objeto: {error}",
+	"unequip.title":        "Objeto desequipado",
+	"unequip.unequipped":   "Desequipaste **{name}**.",
+
+	// Inventory
+	"inventory.title":        "🎒 Tu inventario",
+	"inventory.description":  "Colección de objetos de {user}",
+	"inventory.footer":       "Usa !cb shop para encontrar más objetos",
+	"inventory.empty":        "Inventario vacío",
+	"inventory.empty_detail": "Aún no tienes objetos. ¡Visita la tienda con `!cb shop` para comprar alguno!",
+	"inventory.weapons":      "Armas ({count}/{max})",
+
+	// Mailbox
+	"mailbox.empty_message":  "Tu buzón está vacío.",
+	"mailbox.usage":          "Uso: `!cb mailbox claim <número|all>`",
+	"mailbox.claimed_all":    "📬 Reclamaste {count, plural, one {**#** objeto} other {**#** objetos}} de tu buzón.",
+	"mailbox.still_waiting":  "{count, plural, one {# objeto sigue} other {# objetos siguen}} esperando porque tu inventario está lleno.",
+	"mailbox.invalid_number": "Número de buzón no válido. Elige del 1 al {max}.",
+	"mailbox.claimed":        "📬 Reclamaste **{name}** ({rarity}) a tu inventario como `{key}`.",
+	"mailbox.title":          "📬 Tu buzón",
+	"mailbox.description":    "Objetos que llegaron mientras tu inventario estaba lleno (máximo {max} objetos). Los objetos sin reclamar caducan a los {days} días.",
+	"mailbox.footer":         "Usa !cb mailbox claim <número|all> para mover objetos a tu inventario",
+	"mailbox.empty":          "Buzón vacío",
+	"mailbox.empty_detail":   "No tienes nada esperando.",
+	"mailbox.more":           "Más objetos",
+	"mailbox.more_detail":    "...y {count} más. Reclama algunos para ver el resto.",
+	"mailbox.from":           "De: {source}\n{stats}",
+
+	// Common
+	"common.reroll_tokens": "{count, plural, one {# ficha de reroll} other {# fichas de reroll}}",
+
+	// Achievements and quests
+	"achievement.unlocked":    "🏆 Logro desbloqueado: {icon} {name}",
+	"achievement.reward":      "Recompensa",
+	"achievement.reward_line": "Recompensa: {reward}",
+	"achievement.unlocked_on": "{description}\nDesbloqueado el {date}",
+	"achievement.title":       "Logros de {user} ({unlocked}/{total})",
+	"quest.complete":          "📜 ¡Misión completada!",
+
+	// Achievement and quest data
+	"data.achievement.first_blood.name":           "Primera sangre",
+	"data.achievement.first_blood.description":    "Gana tu primer combate",
+	"data.achievement.centurion.name":             "Centurión",
+	"data.achievement.centurion.description":      "Gana 100 combates",
+	"data.achievement.elementalist.name":          "Elementalista",
+	"data.achievement.elementalist.description":   "Derrota a un rival de cada elemento",
+	"data.achievement.legendary_pull.name":        "Tirada legendaria",
+	"data.achievement.legendary_pull.description": "Consigue un personaje con una estadística, rasgo o característica legendaria",
+	"data.achievement.divine.name":                "Divino",
+	"data.achievement.divine.description":         "Ten un personaje de la raza Dios",
+	"data.achievement.bankrupt.name":              "Comprador compulsivo",
+	"data.achievement.bankrupt.description":       "Gasta tu última moneda en la tienda",
+	"data.achievement.seasoned.name":              "Curtido",
+	"data.achievement.seasoned.description":       "Alcanza el nivel 10",
+	"data.achievement.veteran.name":               "Veterano",
+	"data.achievement.veteran.description":        "Alcanza el nivel 25",
+	"data.achievement.deep_diver.name":            "Buceador de mazmorras",
+	"data.achievement.deep_diver.description":     "Supera el piso 10 de la mazmorra",
+	"data.achievement.dedicated.name":             "Constante",
+	"data.achievement.dedicated.description":      "Alcanza una racha diaria de 30 días",
+	"data.achievement.reborn.name":                "Renacido",
+	"data.achievement.reborn.description":         "Haz renacer a un personaje por primera vez",
+	"data.quest.daily_win_3":                      "Gana 3 combates",
+	"data.quest.daily_magic_3":                    "Gana 3 combates con magia",
+	"data.quest.daily_troll":                      "Derrota a un Trol",
+	"data.quest.daily_goblins":                    "Derrota a 2 Goblins",
+	"data.quest.daily_pvp":                        "Gana un combate contra otro jugador",
+	"data.quest.daily_buy":                        "Compra un objeto en la tienda",
+	"data.quest.daily_buy_epic":                   "Compra un objeto Épico",
+	"data.quest.daily_rerollstat":                 "Haz reroll de una estadística",
+	"data.quest.weekly_win_20":                    "Gana 20 combates",
+	"data.quest.weekly_magic_10":                  "Gana 10 combates con magia",
+	"data.quest.weekly_dungeon_5":                 "Supera el piso 5 de la mazmorra",
+	"data.quest.weekly_buy_3":                     "Compra 3 objetos en la tienda",
+	"data.quest.weekly_full_reroll":               "Haz reroll de tu personaje 3 veces",
+	"data.quest.weekly_daily_5":                   "Reclama tu recompensa diaria 5 veces",
+
+	// Common
+	"common.no_character": "¡Aún no tienes personaje! Usa `!cb roll` para crear uno.",
+
+	// Quests
+	"quests.title":  "Misiones de {user}",
+	"quests.daily":  "📅 Diarias (se reinician en {time})",
+	"quests.weekly": "🗓️ Semanales (se reinician en {time})",
+	"quests.footer": "Las misiones avanzan con combates, compras en la tienda y rerolls",
+	"quests.none":   "No tienes misiones asignadas.",
+
+	// Rank
+	"rank.no_character":  "¡Necesitas un personaje para jugar clasificatorias! Usa `!cb roll` para crear uno.",
+	"rank.unranked":      "Sin clasificar ({played}/{total} partidas de posicionamiento)",
+	"rank.season_rank":   "Puesto de temporada: n.º {position} de {total}",
+	"rank.title":         "🏅 Clasificación de {user}",
+	"rank.season_ends":   "La temporada {season} termina en {time}",
+	"rank.rank":          "Rango",
+	"rank.rating":        "Puntuación",
+	"rank.rating_value":  "{rating} (máximo {peak})",
+	"rank.record":        "Historial",
+	"rank.record_value":  "{wins}V - {losses}D",
+	"rank.season_reward": "Recompensa de temporada",
+	"rank.footer":        "Usa !cb battle start @usuario ranked para jugar una clasificatoria | La puntuación baja tras {days} días de inactividad",
+
+	// Roster
+	"roster.no_weapon":      "sin arma",
+	"roster.line":           "{marker} **{number}.** {race} {element} de nivel {level} · {weapon}",
+	"roster.title":          "Personajes de {user}",
+	"roster.footer":         "{used}/{slots} espacios usados | Usa !cb switch [número] para cambiar tu personaje activo",
+	"switch.usage":          "Indica a qué personaje quieres cambiar. Uso: `!cb switch [número]` (mira `!cb characters`)",
+	"switch.invalid_number": "Número de personaje no válido. Indica un número válido.",
+	"switch.in_battle":      "¡No puedes cambiar de personaje durante un combate!",
+	"switch.in_dungeon":     "¡No puedes cambiar de personaje en la mazmorra! Usa `!cb dungeon retreat` primero.",
+	"switch.queued":         "¡No puedes cambiar de personaje mientras estás en cola! Usa `!cb queue leave` primero.",
+	"switch.failed":         "No se pudo cambiar: {error}",
+	"switch.footer":         "{footer} | Ahora es tu personaje activo",
+	"buy.slot":              "🧑‍🤝‍🧑 ¡Compraste un espacio de personaje por **{price}** monedas! Ahora puedes tener **{count}** personajes. Usa `!cb roll` para ocuparlo.",
+
+	// Character
+	"character.equipped_weapon": "**Arma equipada:** {name}",
+	"character.no_weapon":       "Sin arma equipada",
+	"character.level":           "**Nivel {level}** ({xp} XP)",
+	"character.unspent":         " | {count, plural, one {**#** punto de estadística sin gastar} other {**#** puntos de estadística sin gastar}} (`!cb allocate`)",
+	"character.title":           "Personaje de {user}",
+	"character.summary":         "Raza: **{race}** | Elemento: **{element}** | Alineamiento: **{alignment}** | Altura: **{height}**",
+	"character.characteristics": "Características",
+	"character.stats":           "Estadísticas",
+	"character.traits":          "Rasgos",
+	"character.equipment":       "Equipo",
+	"character.id":              "ID del personaje: {id}",
+	"character.race":            "**Raza:** {name} ({rarity})",
+	"character.element":         "**Elemento:** {name} ({rarity})",
+	"character.alignment":       "**Alineamiento:** {name} ({rarity})",
+	"character.height":          "**Altura:** {name}",
+	"character.race_bonuses":    "**Bonificaciones/penalizaciones de raza:**",
+	"character.innate":          "**Rasgo innato:** {name} ({rarity})",
+	"character.bonuses":         "**Bonificaciones:**",
+	"character.weakness":        "**Debilidad:** {name}",
+	"character.penalties":       "**Penalizaciones:**",
+	"character.x_factor":        "**Factor X:** {name}",
+	"character.effects":         "**Efectos:**",
+	"character.no_traits":       "Sin rasgos especiales",
+	"stat.vitality":             "Vitalidad",
+	"stat.strength":             "Fuerza",
+	"stat.speed":                "Velocidad",
+	"stat.durability":           "Resistencia",
+	"stat.intelligence":         "Inteligencia",
+	"stat.mana":                 "Maná",
+	"stat.mastery":              "Maestría",
+
+	// Common
+	"common.cancel":          "Cancelar",
+	"common.confirm_expires": "Esta confirmación caduca en 60 segundos",
+
+	// Characters
+	"roll.slots_full":     "¡Tus **{count}** espacios de personaje están llenos! Usa `!cb characters` para verlos, `!cb delete` para liberar uno o `!cb buy slot` para comprar otro ({price} monedas).",
+	"roll.in_battle":      "¡No puedes crear un personaje nuevo durante un combate!",
+	"roll.failed":         "Error al generar el personaje: {error}",
+	"delete.title":        "⚠️ Borrar personaje",
+	"delete.confirm":      "¿Seguro que quieres borrar tu personaje activo? ¡No se puede deshacer!",
+	"delete.button":       "Sí, borrar personaje",
+	"delete.failed":       "No se pudo borrar el personaje: {error}",
+	"delete.deleted":      "Tu personaje se ha borrado. Usa `!cb characters` para ver el resto de tu plantilla o `!cb roll` para crear uno nuevo.",
+	"delete.canceled":     "Borrado del personaje cancelado.",
+	"rebirth.failed":      "El renacimiento falló: {error}",
+	"rebirth.title":       "✨ Renacimiento",
+	"rebirth.description": "Tu personaje volverá al nivel 1 y se generará de nuevo desde cero. Conservas tu inventario y tu cartera.",
+	"rebirth.perks":       "Ventajas de prestigio {level}",
+	"rebirth.perks_value": "• **+{stats}%** a todas las estadísticas\n• **+{rerolls}** rerolls completos diarios\n• **+{luck}** de suerte con el arma inicial\n• Insignia: {badge}",
+	"rebirth.button":      "Renacer",
+	"rebirth.canceled":    "Renacimiento cancelado.",
+	"rebirth.reborn":      "✨ ¡Has renacido! Ahora eres **{badge}**.",
+
+	// Rerolls
+	"reroll.lock_usage":          "{error}. Uso: `!cb reroll [--lock strength,race]`",
+	"reroll.lock_missing":        "indica qué bloquear después de --lock",
+	"reroll.lock_unknown_option": "opción desconocida {option}",
+	"reroll.lock_too_expensive":  "Bloquear {locks} cuesta **{cost}** monedas, pero solo tienes **{wallet}**.",
+	"reroll.failed":              "El reroll falló: {error}",
+	"reroll.footer":              "Rerolls completos restantes hoy: {count} | ¿Te arrepientes? !cb undo",
+	"reroll.footer_locks":        "Conservado {locks} por {cost} monedas",
+	"rerollstat.missing":         "Indica qué estadística quieres rerollear. Uso: `!cb rerollstat [vitality|strength|speed|durability|intelligence|mana|mastery]`",
+	"rerollstat.invalid":         "Estadística no válida. Las válidas son: vitality, strength, speed, durability, intelligence, mana, mastery",
+	"rerollstat.failed":          "El reroll de estadística falló: {error}",
+	"rerollstat.title":           "Resultado del reroll de estadística",
+	"rerollstat.description":     "¡Has rerolleado tu estadística de {stat}!",
+	"rerollstat.before":          "Antes",
+	"rerollstat.after":           "Después",
+	"rerollstat.footer":          "Rerolls de estadística restantes hoy: {count} | ¿Te arrepientes? !cb undo",
+	"rerolls.title":              "🎲 Estado de rerolls",
+	"rerolls.description":        "Tus rerolls diarios",
+	"rerolls.full":               "Rerolls de personaje completo",
+	"rerolls.stat":               "Rerolls de una estadística",
+	"rerolls.remaining":          "{left}/{total} restantes",
+	"rerolls.tokens":             "Fichas de reroll",
+	"rerolls.tokens_available":   "{count, plural, one {# disponible} other {# disponibles}}",
+	"rerolls.pity":               "Pity (tiradas sin un Épico)",
+	"rerolls.pity_boosted":       "(probabilidades aumentadas)",
+	"rerolls.refresh":            "Renovación",
+	"rerolls.refresh_in":         "Los rerolls se renuevan en {duration}",
+	"rerolls.footer":             "Usa !cb reroll para rerollear todo tu personaje o !cb rerollstat [stat] para una estadística concreta",
+
+	// History
+	"history.source.roll":       "🎲 Tirada",
+	"history.source.reroll":     "🔁 Reroll completo",
+	"history.source.rerollstat": "🎯 Reroll de estadística",
+	"history.source.rebirth":    "✨ Renacimiento",
+	"history.source.undo":       "↩️ Deshacer",
+	"history.title":             "📜 Historial de tiradas de {user}",
+	"history.footer":            "Página {page}/{pages} | {count, plural, one {# tirada} other {# tiradas}} | !cb undo restaura tu último reroll a cambio de una ficha de reroll",
+	"history.none":              "Aún no hay tiradas. Usa `!cb roll` para crear un personaje.",
+	"history.seed":              "Semilla {seed}",
+	"history.kept":              "🔒 Conservado {locks}",
+	"history.character":         "Nv {level} {element} {race} (rareza {rarity})",
+	"undo.in_battle":            "¡No puedes deshacer un reroll durante un combate!",
+	"undo.failed":               "No se pudo deshacer: {error}",
+	"undo.done":                 "↩️ ¡Reroll de <t:{time}:R> deshecho! Se gastó una ficha de reroll.",
+
+	// Leaderboard
+	"common.previous":             "◀ Anterior",
+	"common.next":                 "Siguiente ▶",
+	"leaderboard.unknown":         "Clasificación desconocida. Uso: `!cb leaderboard [{categories}] [global]`",
+	"leaderboard.scope.server":    "Este servidor",
+	"leaderboard.scope.global":    "Global",
+	"leaderboard.empty":           "Aún no hay nadie clasificado. ¡Sé el primero!",
+	"leaderboard.title":           "🏆 Clasificación de {category} ({scope})",
+	"leaderboard.category.level":  "nivel",
+	"leaderboard.category.wins":   "victorias",
+	"leaderboard.category.coins":  "monedas",
+	"leaderboard.category.rarity": "rareza",
+	"leaderboard.footer":          "Página {page}/{pages} | {count, plural, one {# jugador clasificado} other {# jugadores clasificados}}",
+	"leaderboard.unranked":        "Aún sin clasificar",
+	"leaderboard.your_rank":       "Tu posición",
+	"leaderboard.value.level":     "Nivel {count}",
+	"leaderboard.value.wins":      "{count, plural, one {# victoria} other {# victorias}}",
+	"leaderboard.value.rarity":    "{count, plural, one {# punto de rareza} other {# puntos de rareza}}",
+
+	// Allocation
+	"common.on":               "Activado",
+	"common.off":              "Desactivado",
+	"allocate.respec_failed":  "El reinicio de puntos falló: {error}",
+	"allocate.respec_done":    "🔄 ¡Puntos reiniciados! Se {count, plural, one {devolvió **#** punto} other {devolvieron **#** puntos}} de estadística. Gástalos con `!cb allocate <stat> <points>`.",
+	"allocate.invalid_stat":   "Estadística no válida. Las válidas son: vitality, strength, speed, durability, intelligence, mana, mastery",
+	"allocate.invalid_points": "Número de puntos no válido. Uso: `!cb allocate <stat> <points>`",
+	"allocate.failed":         "La asignación falló: {error}",
+	"allocate.added":          "⬆️ {points, plural, one {Añadido **#** punto} other {Añadidos **#** puntos}} a **{stat}** (ahora {total}). Te {count, plural, one {queda **#** punto} other {quedan **#** puntos}} sin gastar.",
+	"allocate.auto_usage":     "Uso: `!cb allocate auto <on|off>`",
+	"allocate.auto_off":       "El crecimiento automático está **desactivado**. Los nuevos puntos esperarán a `!cb allocate`.",
+	"allocate.auto_on":        "El crecimiento automático está **activado**. Los nuevos puntos seguirán la curva de crecimiento de tu raza.",
+	"allocate.auto_spent":     "Puntos sin gastar repartidos: {allocation}",
+	"allocate.title":          "⬆️ Puntos de estadística",
+	"allocate.description":    "**Nivel {level}** | **{unspent}** sin gastar de {earned} ganados ({perLevel} por nivel)\nCrecimiento automático: **{auto}** | Fichas de reinicio: **{tokens}**",
+	"allocate.allocated":      "Asignados",
+	"allocate.curve":          "Curva de crecimiento de {race}",
+	"allocate.footer":         "!cb allocate <stat> <points> | !cb allocate auto <on|off> | !cb allocate reset (usa una ficha de reinicio, {price} monedas con !cb buy respec)",
+
+	// Admin
+	"admin.usage":            "Uso: `!cb admin coins|rerolls @user <amount> [reason]`, `!cb admin item @user <rarity> [reason]`, `!cb admin resetchar|deletechar|endbattle|ban|unban @user [reason]`, `!cb admin refreshshop [reason]` o `!cb admin log [page]`",
+	"admin.shop_refreshed":   "🛒 La tienda se ha reabastecido.",
+	"admin.mention":          "Menciona al usuario para {action}. {usage}",
+	"admin.owner_only":       "Solo los dueños del bot pueden usar `{action}`, cambia jugadores y la tienda en todos los servidores.",
+	"admin.self_blocked":     "El staff no puede usar eso sobre sí mismo.",
+	"admin.not_member":       "<@{user}> no es miembro de este servidor.",
+	"admin.failed":           "La acción de administración falló: {error}",
+	"admin.amount_missing":   "indica una cantidad, negativa para restar",
+	"admin.amount_invalid":   "la cantidad debe ser un número entre -{limit} y {limit} distinto de 0",
+	"admin.coins":            "{amount} monedas para <@{user}>, su saldo ahora es **{balance}**.",
+	"admin.rerolls":          "{amount} fichas de reroll para <@{user}>, ahora tiene **{tokens}**.",
+	"admin.rarity_missing":   "indica una rareza: {rarities}",
+	"admin.rarity_invalid":   "{rarity} no es una rareza, elige entre {rarities}",
+	"admin.item":             "<@{user}> ha recibido **{item}** ({rarity}).",
+	"admin.item_mailed":      "Su inventario estaba lleno, así que fue a su buzón.",
+	"admin.in_battle":        "<@{user}> está en un combate, termínalo primero con `!cb admin endbattle`",
+	"admin.reset_character":  "El personaje activo de <@{user}> ha vuelto al nivel 1.",
+	"admin.delete_character": "El personaje activo de <@{user}> se ha borrado.",
+	"admin.battle_ended_by":  "{admin} ha terminado el combate.",
+	"admin.end_battle":       "El combate de <@{user}> terminó sin ganador.",
+	"admin.ban":              "<@{user}> tiene prohibido jugar en este servidor.",
+	"admin.unban":            "<@{user}> puede volver a jugar en este servidor.",
+	"admin.log.title":        "🛡️ Registro de administración",
+	"admin.log.footer":       "Página {page}/{pages} | {count, plural, one {# acción} other {# acciones}} | !cb admin log [page]",
+	"admin.log.none":         "No hay acciones de administración registradas.",
+	"admin.log.by":           "por <@{admin}>",
+	"admin.log.reason":       "Motivo: {reason}",
+
+	// Banners
+	"banner.unknown_command":   "Comando de banner desconocido. Disponibles: list, create, end, audit",
+	"banner.none":              "No hay banners activos ni programados. Las tiradas usan las probabilidades normales.",
+	"banner.scheduled":         "🕒 Empieza <t:{start}:R>, dura hasta <t:{end}:f>",
+	"banner.live":              "🔥 Activo ahora, termina <t:{end}:R>",
+	"banner.featured_races":    "Razas destacadas",
+	"banner.featured_innates":  "Rasgos innatos destacados",
+	"banner.featured_weapons":  "Armas destacadas",
+	"banner.odds":              "Probabilidades de rareza",
+	"banner.odds_value":        "Común {common} · Poco común {uncommon} · Raro {rare} · Épico {epic} · Legendario {legendary}",
+	"banner.create_usage":      "Uso: `!cb banner create <spec>`, por ejemplo {example}\nLas horas de inicio usan la zona horaria del reinicio diario.",
+	"banner.invalid":           "Banner no válido: {error}",
+	"banner.create_failed":     "No se pudo programar el banner: {error}",
+	"banner.created":           "🎏 ¡<@{user}> ha programado **{banner}**!",
+	"banner.end_usage":         "Uso: `!cb banner end <name>`",
+	"banner.ended":             "🏁 **{banner}** ha terminado. Las tiradas vuelven a las probabilidades normales.",
+	"banner.audit_usage":       "Uso: `!cb banner audit <name>`",
+	"banner.audit_title":       "📋 Auditoría de {banner}",
+	"banner.audit_description": "{rolls, plural, one {**#** tirada} other {**#** tiradas}} de {players, plural, one {**#** jugador} other {**#** jugadores}}\nProgramado por <@{user}>, de <t:{start}:f> a <t:{end}:f>",
+	"banner.audit_footer":      "Las semillas permiten repetir y comprobar cualquier tirada",
+
+	// Clans
+	"clan.create_usage":           "Uso: `!cb clan create <name>` (cuesta {price} monedas)",
+	"clan.created":                "🏰 ¡Se ha fundado **{clan}**! Invita a jugadores con `!cb clan invite @player`.",
+	"clan.invite_usage":           "Uso: `!cb clan invite @player`",
+	"clan.invited":                "✉️ ¡<@{user}> ha sido invitado a **{clan}**! Acepta con `!cb clan join {clan}`.",
+	"clan.join_usage":             "Uso: `!cb clan join <name>`",
+	"clan.joined":                 "🎉 ¡<@{user}> se ha unido a **{clan}**!",
+	"clan.disbanded":              "**{clan}** se ha disuelto.",
+	"clan.left":                   "Has salido de **{clan}**.",
+	"clan.kick_usage":             "Uso: `!cb clan kick @player`",
+	"clan.kicked":                 "<@{user}> ha sido expulsado de **{clan}**.",
+	"clan.promote_usage":          "Uso: `!cb clan promote @player <leader|officer|member>`",
+	"clan.promoted":               "<@{user}> ahora es **{role}** del clan.",
+	"clan.role.leader":            "líder",
+	"clan.role.officer":           "oficial",
+	"clan.role.member":            "miembro",
+	"clan.bank_usage":             "Uso: `!cb clan {action} <coins>`",
+	"clan.deposited":              "🏦 Has depositado {amount, plural, one {**#** moneda} other {**#** monedas}}. El banco del clan tiene ahora {balance, plural, one {**#** moneda} other {**#** monedas}}.",
+	"clan.withdrew":               "🏦 Has retirado {amount, plural, one {**#** moneda} other {**#** monedas}}. El banco del clan tiene ahora {balance, plural, one {**#** moneda} other {**#** monedas}}.",
+	"clan.unknown_command":        "Comando de clan no válido. Uso: `!cb clan [info|create|invite|join|leave|kick|promote|deposit|withdraw|ledger|perks|war]`",
+	"clan.not_found":              "{error}. Usa `!cb clan create <name>` para fundar uno.",
+	"clan.max_level":              "Nivel máximo",
+	"clan.progress":               "{xp}/{needed} XP para el nivel {level}",
+	"clan.level":                  "Nivel",
+	"clan.bank":                   "Banco",
+	"clan.members":                "Miembros ({count}/{max})",
+	"clan.info_footer":            "Fundado el {date} | !cb clan perks para ver las ventajas",
+	"clan.ledger_empty":           "El banco del clan aún no tiene movimientos.",
+	"clan.war_spoils":             "Botín de guerra",
+	"clan.ledger_line":            "`{date}` {who} {kind} **{amount}** (saldo {balance})",
+	"clan.ledger_kind.deposit":    "depósito",
+	"clan.ledger_kind.withdrawal": "retirada",
+	"clan.ledger_kind.war_prize":  "premio de guerra",
+	"clan.ledger_title":           "🏦 Movimientos del banco de {clan}",
+	"clan.ledger_footer":          "Saldo actual: {count, plural, one {# moneda} other {# monedas}}",
+	"clan.perk_line":              "{status} **Nv {level} — {name}**: {description}",
+	"clan.perks_title":            "Ventajas de clan",
+	"clan.perks_footer":           "Los niveles superiores sustituyen a los inferiores. Los clanes suben de nivel con la XP de combate de sus miembros y las victorias en guerra.",
+
+	// Clan perk data
+	"data.clan_perk.2.name":         "Cazagangas",
+	"data.clan_perk.2.description":  "5% de descuento en la tienda",
+	"data.clan_perk.3.name":         "Instrucción de combate",
+	"data.clan_perk.3.description":  "5% más de XP de combate",
+	"data.clan_perk.5.name":         "Red comercial",
+	"data.clan_perk.5.description":  "10% de descuento en la tienda",
+	"data.clan_perk.7.name":         "Academia de guerra",
+	"data.clan_perk.7.description":  "10% más de XP de combate",
+	"data.clan_perk.10.name":        "Gremio legendario",
+	"data.clan_perk.10.description": "15% de descuento en la tienda y 15% más de XP de combate",
+
+	// Battle log
+	"battle.log.begins":           "¡Comienza el combate entre {first} y {second}!",
+	"battle.log.no_action":        "Ninguna acción seleccionada",
+	"battle.log.defeated":         "¡{loser} ha sido derrotado! ¡{winner} gana el combate!",
+	"battle.log.forfeited":        "¡{loser} se ha rendido! ¡{winner} gana!",
+	"battle.log.stunned":          "¡{name} está aturdido y no puede moverse!",
+	"battle.log.attack_dodged":    "¡{name} intenta un ataque físico, pero {target} lo esquiva!",
+	"battle.log.attack_missed":    "¡El ataque de {name} falla!",
+	"battle.log.attack_crit":      "¡{name} asesta un golpe crítico de {damage} de daño!",
+	"battle.log.attack":           "¡{name} ataca a {target} y le hace {damage} de daño!",
+	"battle.log.magic_no_mana":    "¡{name} no tiene maná suficiente para lanzar un hechizo!",
+	"battle.log.magic_dodged":     "¡{name} lanza un hechizo, pero {target} consigue evitarlo!",
+	"battle.log.magic_missed":     "¡El hechizo de {name} se desvanece!",
+	"battle.log.magic_crit":       "¡{name} lanza un hechizo crítico de {element} que hace {damage} de daño!",
+	"battle.log.magic":            "¡{name} lanza un hechizo de {element} a {target} y le hace {damage} de daño!",
+	"battle.log.super_effective":  "¡Es muy eficaz!",
+	"battle.log.not_effective":    "No es muy eficaz...",
+	"battle.log.skill_no_mana":    "¡{name} intenta usar {skill} pero no tiene maná suficiente!",
+	"battle.log.skill_heal_self":  "¡{name} usa **{skill}** y recupera {hp} PV!",
+	"battle.log.skill_heal":       "¡{name} usa **{skill}** sobre {target} y le devuelve {hp} PV!",
+	"battle.log.skill_dodged":     "¡{name} usa **{skill}**, pero {target} lo esquiva!",
+	"battle.log.skill_missed":     "¡**{skill}** de {name} falla!",
+	"battle.log.skill":            "¡{name} usa **{skill}** sobre {target} y le hace {damage} de daño!",
+	"battle.log.immune":           "¡{name} es inmune a {effect}!",
+	"battle.log.afflicted":        "¡{name} ahora sufre {effect}!",
+	"battle.log.defend":           "¡{name} adopta una postura defensiva y aumenta su defensa!",
+	"battle.log.item":             "¡{name} usa un objeto curativo y recupera {hp} PV!",
+	"battle.log.item_none":        "¡{name} busca un objeto curativo, pero ya no le queda ninguno!",
+	"battle.log.escort_joins":     "¡{name} se une al combate junto a {ally}!",
+	"battle.log.falls":            "¡{name} cae, pero el combate sigue!",
+	"battle.effect.burn":          "Quemadura",
+	"battle.effect.poison":        "Veneno",
+	"battle.effect.stun":          "Aturdimiento",
+	"battle.effect.freeze":        "Congelación",
+	"battle.effect.defending":     "Defendiendo",
+	"battle.effect.none":          "Ninguno",
+	"battle.status.round":         "⚔️ Ronda {round} ⚔️",
+	"battle.status.effects":       "Estado:",
+	"battle.status.turn":          "Turno actual: {name}",
+	"battle.status.recent":        "Acciones recientes:",
+	"battle.error.not_your_turn":  "no es tu turno",
+	"battle.error.invalid_action": "acción no válida",
+	"battle.error.invalid_target": "objetivo no válido",
+
+	// Battles
+	"battle.usage":                  "Comando de combate no válido. Uso: `!cb battle [start|npcs|attack|magic|defend|item]`",
+	"battle.unknown_command":        "Comando de combate desconocido. Comandos disponibles: start, npcs, attack, magic, defend, item, status, forfeit",
+	"battle.unknown_npc":            "PNJ desconocido \"{name}\". Usa `!cb battle npcs` para ver contra quién puedes luchar.",
+	"battle.no_character":           "¡Necesitas un personaje para combatir! Usa `!cb roll` para crear uno.",
+	"battle.already_in_battle":      "¡Ya estás en un combate! Termínalo o ríndete primero.",
+	"battle.target_no_character":    "¡Ese usuario no tiene personaje!",
+	"battle.target_in_battle":       "¡Ese jugador ya está en un combate!",
+	"battle.pvp_no_characters":      "Error al iniciar el combate: uno o ambos jugadores no tienen personaje.",
+	"battle.not_in_battle":          "¡No estás en un combate! Usa `!cb battle start` para empezar uno.",
+	"battle.not_your_turn":          "¡No es tu turno!",
+	"battle.turn_failed":            "Error al procesar el turno: {error}",
+	"battle.forfeited":              "¡**{name}** se ha rendido!",
+	"battle.roster.title":           "👹 Lista de PNJ",
+	"battle.roster.description":     "Desafía a uno con `!cb battle start <name> [level|auto]`",
+	"battle.roster.details":         "{description}\nNiveles {min}-{max} (por defecto {default}) | {element} {race} | IA {ai}",
+	"battle.roster.skills":          "Habilidades: {skills}",
+	"battle.roster.immune":          "Inmune: {effects}",
+	"battle.roster.escorts":         "Lucha junto a: {names}",
+	"battle.challenge.title":        "⚔️ ¡Desafío de combate!",
+	"battle.challenge.title_ranked": "🏅 ¡Desafío de combate clasificatorio!",
+	"battle.challenge.title_war":    "⚔️ ¡Desafío de guerra de clanes!",
+	"battle.challenge.description":  "¡<@{challenger}> ha desafiado a <@{target}> a un combate!",
+	"battle.challenge.how":          "Cómo aceptar",
+	"battle.challenge.how_value":    "Pulsa el botón Aceptar de abajo para empezar el combate.",
+	"battle.challenge.expires":      "El desafío caduca en 60 segundos",
+	"battle.challenge.accept":       "Aceptar desafío",
+	"battle.challenge.decline":      "Rechazar",
+	"battle.challenge.accepted":     "¡Desafío aceptado! Empezando el combate...",
+	"battle.challenge.declined":     "<@{user}> ha rechazado el desafío.",
+	"battle.challenge.not_for_you":  "¡Este desafío no es para ti!",
+	"battle.challenge.expired":      "El desafío ha caducado.",
+	"battle.embed.title":            "⚔️ Combate ⚔️",
+	"battle.embed.description":      "Ronda {round}\n\nTurno actual: **{name}**",
+	"battle.embed.participant":      "PV: {hp}/{maxHP} {hpBar}\nPM: {mp}/{maxMP} {mpBar}\nEstado: {status}",
+	"battle.embed.log":              "Registro del combate",
+	"battle.embed.log_empty":        "¡El combate acaba de empezar!",
+	"battle.embed.commands":         "Comandos",
+	"battle.embed.commands_value":   "• `!cb battle attack` - Ataque físico\n• `!cb battle magic` - Ataque mágico\n• `!cb battle defend` - Aumentar la defensa\n• `!cb battle item` - Usar un objeto curativo\n• `!cb battle forfeit` - Rendirse",
+	"battle.embed.footer":           "ID del combate: {id}",
+	"battle.result.title":           "🏆 ¡Combate terminado!",
+	"battle.result.title_lost":      "Combate terminado",
+	"battle.result.winner":          "¡**{name}** ha ganado el combate!",
+	"battle.result.stats":           "Estadísticas del combate",
+	"battle.result.stats_value":     "Rondas: {rounds}\nPV restantes: {hp}",
+	"battle.result.rewards":         "Recompensas",
+	"battle.result.rewards_value":   "Experiencia: **+{xp}** XP (Total: {total})\nMonedas: **+{coins}**",
+	"battle.result.level_gap":       "📊 Diferencia de nivel",
+	"battle.result.loot":            "🎁 Botín",
+	"battle.result.ranked":          "🏅 Clasificatoria",
+	"battle.level_up.title":         "🎉 ¡Subida de nivel!",
+	"battle.level_up.description":   "¡**{name}** ha alcanzado el nivel **{level}**!",
+	"battle.level_up.growth":        "Crecimiento del personaje",
+	"battle.level_up.growth_value":  "¡Has ganado nuevos puntos de estadística! Mira `!cb allocate` para gastarlos.",
+	"battle.loot.added":             "Añadido al inventario como `{key}`",
+	"battle.scaling.stronger":       "Has derrotado a un rival más fuerte: **+{percent}%** de XP",
+	"battle.scaling.farming":        "Este rival está muy por debajo de tu nivel: recompensas limitadas al **{percent}%** y sin botín",
+	"battle.scaling.weaker":         "Este rival está por debajo de tu nivel: recompensas reducidas al **{percent}%**",
+	"battle.warning.farming":        "⚠️ Este rival está muy por debajo de tu nivel ({npcLevel} contra {level}). Las recompensas se limitan al {percent}% y no soltará botín. Prueba la dificultad `auto`.",
+	"battle.warning.weaker":         "⚠️ Este rival está por debajo de tu nivel ({npcLevel} contra {level}). Las recompensas se reducen al {percent}%.",
+	"battle.warning.stronger":       "⚠️ Este rival está {gap} niveles por encima de ti. Golpeará más fuerte y tus ataques fallarán más a menudo.",
+
+	// Dungeon
+	"dungeon.usage":                "Comando de mazmorra no válido. Uso: `!cb dungeon [enter|descend|retreat|status]`",
+	"dungeon.no_character":         "¡Necesitas un personaje para explorar la mazmorra! Usa `!cb roll` para crear uno.",
+	"dungeon.no_run":               "{error}. Usa `!cb dungeon enter` para empezar una incursión.",
+	"dungeon.finish_battle":        "¡Termina tu combate actual antes de seguir!",
+	"dungeon.character_gone":       "El personaje que empezó esta incursión ya no existe. Usa `!cb dungeon retreat` para salir.",
+	"dungeon.still_guarding":       "⚔️ ¡**{enemy}** sigue custodiando el piso {floor}!",
+	"dungeon.retreat_in_fight":     "¡No puedes retirarte en mitad de un combate!",
+	"dungeon.enter.title":          "🕯️ Entrando en la mazmorra",
+	"dungeon.enter.description":    "**{name}** se adentra en la oscuridad. Los PV, PM y estados se mantienen entre pisos.",
+	"dungeon.room.combat":          "Combate",
+	"dungeon.room.boss":            "Jefe",
+	"dungeon.rest.title":           "🏕️ Piso {floor} — Sala de descanso",
+	"dungeon.rest.description":     "Una hoguera tranquila. Recuperas el {percent}% de tus PV y PM y te libras de todos los estados.",
+	"dungeon.treasure.title":       "💰 Piso {floor} — Sala del tesoro",
+	"dungeon.treasure.description": "¡Has encontrado un cofre con **{count, plural, one {# moneda} other {# monedas}}**!",
+	"dungeon.fight.title":          "⚔️ Piso {floor} — {room}",
+	"dungeon.fight.description":    "¡Un **{enemy}** de nivel {level} bloquea el paso!",
+	"dungeon.fight.boss":           "Derrótalo para asegurar todo el botín que llevas.",
+	"dungeon.new_record":           "🏅 ¡Nuevo piso más profundo: **{floor}**!",
+	"dungeon.loot_kept":            "Botín conservado",
+	"dungeon.level_up":             "¡Has alcanzado el nivel **{level}**!",
+	"dungeon.retreat.title":        "🏃 Retirada de la mazmorra",
+	"dungeon.retreat.description":  "**{name}** ha salido desde el piso {floor}.",
+	"dungeon.retreat.loot":         "Monedas: **+{coins}**\nExperiencia: **+{xp}** XP\n(Botín asegurado más el {percent}% del resto)",
+	"dungeon.defeat.title":         "💀 Caído en la mazmorra",
+	"dungeon.defeat.description":   "**{name}** fue derrotado por **{enemy}** en el piso {floor}.",
+	"dungeon.defeat.loot":          "Monedas: **+{coins}**\nExperiencia: **+{xp}** XP\n(El botín sin asegurar se ha perdido)",
+	"dungeon.cleared.title":        "✅ Piso {floor} superado",
+	"dungeon.cleared.description":  "¡**{name}** ha derrotado a **{enemy}**! (+{coins} monedas, +{xp} XP)",
+	"dungeon.cleared.secured":      "🔒 Tu botín ya está asegurado.",
+	"dungeon.status.title":         "🕯️ Mazmorra — Piso {floor}",
+	"dungeon.status.fighting":      "Luchando contra un **{enemy}** de nivel {level}.",
+	"dungeon.embed.floor":          "Piso",
+	"dungeon.embed.vitals":         "Constantes",
+	"dungeon.embed.vitals_value":   "PV {hp}/{maxHP}\nPM {mp}/{maxMP}\nEstado: {status}",
+	"dungeon.embed.loot":           "Botín",
+	"dungeon.embed.loot_value":     "Llevas: {coins} monedas, {xp} XP\nAsegurado: {securedCoins} monedas, {securedXP} XP",
+	"dungeon.embed.footer":         "Jefe cada {every} pisos | !cb dungeon descend para bajar | !cb dungeon retreat para salir",
+	"dungeon.embed.deepest":        "Piso más profundo",
+
+	// Clan wars
+	"war.usage":          "Comando de guerra de clanes no válido. Uso: `!cb clan war [declare|accept|fight|status]`",
+	"war.declare_usage":  "Uso: `!cb clan war declare <clan name>`",
+	"war.fight_usage":    "Uso: `!cb clan war fight @player`",
+	"war.declared":       "⚔️ ¡**{attacker}** ha declarado la guerra a **{defender}**! Un oficial de {defender} puede responder con `!cb clan war accept` en {hours} horas.",
+	"war.started":        "¡Empieza la guerra! Desafía a miembros del otro clan con `!cb clan war fight @player`. Cada victoria suma un punto para tu clan durante las próximas {hours} horas.",
+	"war.no_war":         "Tu clan no tiene ninguna guerra en curso.",
+	"war.no_war_declare": "{error}. Usa `!cb clan war declare <clan name>` para empezar una.",
+	"war.wrong_clan":     "Ese jugador no está en el clan con el que estás en guerra.",
+	"war.score_failed":   "⚔️ No se pudo puntuar la victoria: {error}",
+	"war.scored":         "⚔️ ¡<@{winner}> suma un punto para **{clan}**! ({attacker} {attackerScore} - {defenderScore} {defender})",
+	"war.embed.title":    "⚔️ {attacker} contra {defender}",
+	"war.embed.score":    "Marcador",
+	"war.embed.pending":  "Esperando a que {defender} acepte.",
+	"war.embed.ends_in":  "Termina en {duration}.",
+	"war.embed.draw":     "La guerra terminó en empate.",
+	"war.embed.winner":   "🏆 ¡**{clan}** gana la guerra!",
+	"war.embed.prize":    "**{coins}** monedas y **{xp}** XP de clan para el ganador.",
+
+	// Matchmaking
+	"queue.usage":       "Comando de cola no válido. Uso: `!cb queue [ranked|casual|leave|status]`",
+	"queue.mode.ranked": "clasificatoria",
+	"queue.mode.casual": "casual",
+	"queue.expired":     "<@{user}> no se encontró una partida {mode} a tiempo, has salido de la cola.",
+	"queue.match_found": "⚔️ ¡Partida encontrada! <@{first}> contra <@{second}> ({mode}) en <#{channel}>",
+	"queue.title":       "🔎 Buscando partida",
+	"queue.joined":      "**{name}** se ha unido a la cola {mode}.",
+	"queue.level":       "Nivel",
+	"queue.rating":      "Puntuación",
+	"queue.footer":      "La búsqueda se amplía cuanto más esperas | La cola caduca en {minutes} minutos | !cb queue leave para cancelar",
+	"queue.not_queued":  "No estás en la cola.",
+	"queue.left":        "Has salido de la cola de emparejamiento.",
+	"queue.no_entry":    "{error}. Usa `!cb queue ranked` o `!cb queue casual` para unirte.",
+	"queue.status":      "Llevas {waited} en la cola {mode} (caduca en {remaining}).",
+
+	// Tournaments
+	"tournament.usage":               "Comando de torneo no válido. Uso: `!cb tournament [create|join|start|ready|cancel|status]`",
+	"tournament.invalid_format":      "Formato no válido. Uso: `!cb tournament create [single|double|swiss] [name]`",
+	"tournament.default_name":        "Torneo de {name}",
+	"tournament.created.title":       "🏟️ Torneo creado",
+	"tournament.created.description": "¡**{name}** tiene las inscripciones abiertas!",
+	"tournament.created.footer":      "Usa !cb tournament join para inscribirte | El organizador lo inicia con !cb tournament start o lo anula con !cb tournament cancel",
+	"tournament.format":              "Formato",
+	"tournament.entry_fee":           "Inscripción",
+	"tournament.entry_fee_value":     "{count, plural, one {# moneda} other {# monedas}} por jugador, que van a la bolsa de premios",
+	"tournament.no_character":        "¡Necesitas un personaje para competir! Usa `!cb roll` para crear uno.",
+	"tournament.signups_closed":      "Las inscripciones de este torneo están cerradas.",
+	"tournament.already_joined":      "¡Ya estás inscrito!",
+	"tournament.full":                "Este torneo está completo ({count} jugadores).",
+	"tournament.joined":              "✅ **{name}** se ha unido a **{tournament}** ({count, plural, one {# jugador inscrito} other {# jugadores inscritos}}).",
+	"tournament.not_organizer":       "Solo el organizador puede iniciar el torneo.",
+	"tournament.no_match":            "No tienes ninguna partida pendiente en esta ronda.",
+	"tournament.match_underway":      "¡Tu partida ya está en marcha!",
+	"tournament.ready":               "✅ <@{user}> está listo para la partida #{match}. Esperando a <@{opponent}> (quedan {remaining} para confirmar).",
+	"tournament.player_busy":         "Uno de los jugadores sigue en otro combate. Termínalo y vuelve a confirmar.",
+	"tournament.no_tournament":       "{error}. Usa `!cb tournament create` para empezar uno.",
+	"tournament.checkin_closed":      "⌛ Partida #{match}: confirmación cerrada, <@{winner}> avanza por incomparecencia.",
+	"tournament.interrupted":         "⚠️ La partida #{match} entre <@{playerA}> y <@{playerB}> se interrumpió. Usad `!cb tournament ready` para repetirla.",
+	"tournament.advances":            "🏟️ Partida #{match}: ¡<@{winner}> avanza!",
+	"tournament.finish.title":        "🏆 ¡{name} ha terminado!",
+	"tournament.finish.champion":     "¡<@{user}> es el campeón!",
+	"tournament.finish.standings":    "Clasificación final",
+	"tournament.finish.standing":     "{medal} **{name}** ({wins}-{losses}) — {prize} monedas",
+	"tournament.finish.footer":       "{format} | {players} jugadores | Bolsa: {pool} monedas",
+	"tournament.round.match":         "Partida #{match}: <@{playerA}> contra <@{playerB}>",
+	"tournament.round.ready":         "⚔️ ¡La **ronda {round}** está lista! Usa `!cb tournament ready` en {minutes} minutos o perderás por incomparecencia.",
+	"tournament.bracket.description": "{format} | {status} | {players} jugadores",
+	"tournament.bracket.no_players":  "Aún no hay jugadores. ¡Usa `!cb tournament join`!",
+	"tournament.bracket.signups":     "Inscripciones",
+	"tournament.bracket.round":       "Ronda {round}",
+	"tournament.bracket.standings":   "Clasificación",
+	"tournament.match.losers":        "[P]",
+	"tournament.match.final":         "[Final]",
+	"tournament.match.bye":           "pase directo",
+	"tournament.match.versus":        "{playerA} contra {playerB}",
+	"tournament.match.forfeit":       "(incomparecencia)",
+	"tournament.match.playing":       "⚔️ en curso",
+	"tournament.match.waiting":       "⏳ esperando",
+	"tournament.name.single":         "Eliminación simple",
+	"tournament.name.double":         "Doble eliminación",
+	"tournament.name.swiss":          "Suizo",
+	"tournament.name.signup":         "Inscripción",
+	"tournament.name.running":        "En curso",
+	"tournament.name.finished":       "Terminado",
+
+	// Boss phases
+	"battle.log.phase": "⚠️ ¡{name} entra en {phase}! {message}",
+
+	// NPC roster
+	"data.npc.Training Dummy":                                 "Un blanco de paja que apenas devuelve los golpes. Perfecto para practicar.",
+	"data.npc.Goblin":                                         "Un saqueador furtivo con un cuchillo envenenado.",
+	"data.npc.Cave Rat":                                       "Un roedor enfermo del tamaño de un perro.",
+	"data.npc.Slime":                                          "Una masa temblorosa que ignora los golpes.",
+	"data.npc.Bandit":                                         "Un salteador que ataca desde las sombras.",
+	"data.npc.Skeleton":                                       "Huesos traqueteantes animados por magia antigua.",
+	"data.npc.Wolf Pack":                                      "Lobos hambrientos que abruman a su presa.",
+	"data.npc.Dark Knight":                                    "Un paladín caído vestido de acero negro.",
+	"data.npc.Ghoul":                                          "Un cadáver voraz que propaga la podredumbre con cada arañazo.",
+	"data.npc.Troll":                                          "Un bruto enorme cuyas heridas se cierran tan rápido como se las haces.",
+	"data.npc.Dragon Whelp":                                   "Un dragón joven que ya escupe fuego.",
+	"data.npc.Wraith":                                         "Un espíritu vengativo que roba el calor del aire.",
+	"data.npc.Necromancer":                                    "Un mago oscuro que se cura con vida robada.",
+	"data.npc.Lich":                                           "Un hechicero inmortal envuelto en escarcha.",
+	"data.npc.Ancient Guardian":                               "Un centinela de cristal que lleva mil años montando guardia.",
+	"data.npc.Shadow Drake":                                   "Un dragón sin alas que caza en la oscuridad profunda.",
+	"data.npc.Dragon Lord":                                    "El antiguo soberano de los dragones.",
+	"data.npc.Goblin King":                                    "El tirano coronado de los túneles superiores.",
+	"data.npc.Bone Colossus":                                  "Un montón gigantesco de esqueletos fusionados.",
+	"data.npc.Troll Warlord":                                  "Un caudillo trol lleno de cicatrices que se niega a caer.",
+	"data.npc.Elder Necromancer":                              "Maestro de las criptas, que domina a la propia muerte.",
+	"data.npc_phase.default.Opening.name":                     "Apertura",
+	"data.npc_phase.default.Frenzy.name":                      "Frenesí",
+	"data.npc_phase.default.Frenzy.message":                   "¡Sus ataques se vuelven salvajes!",
+	"data.npc_phase.default.Last Stand.name":                  "Última resistencia",
+	"data.npc_phase.default.Last Stand.message":               "¡Lucha con todo lo que le queda!",
+	"data.npc_phase.Dragon Lord.Sovereign.name":               "Soberano",
+	"data.npc_phase.Dragon Lord.Takes Flight.name":            "Alza el vuelo",
+	"data.npc_phase.Dragon Lord.Takes Flight.message":         "¡El Señor de los Dragones se eleva en el aire!",
+	"data.npc_phase.Dragon Lord.Dragon's Fury.name":           "Furia del dragón",
+	"data.npc_phase.Dragon Lord.Dragon's Fury.message":        "¡Las escamas fundidas se abren de rabia!",
+	"data.npc_phase.Goblin King.Court.name":                   "Corte",
+	"data.npc_phase.Goblin King.Royal Rage.name":              "Ira real",
+	"data.npc_phase.Goblin King.Royal Rage.message":           "¡El Rey Goblin arroja su corona a un lado!",
+	"data.npc_phase.Bone Colossus.Bulwark.name":               "Baluarte",
+	"data.npc_phase.Bone Colossus.Collapse.name":              "Derrumbe",
+	"data.npc_phase.Bone Colossus.Collapse.message":           "¡Los huesos se desprenden mientras el Coloso arremete!",
+	"data.npc_phase.Troll Warlord.Warpath.name":               "Senda de guerra",
+	"data.npc_phase.Troll Warlord.Blood Frenzy.name":          "Frenesí de sangre",
+	"data.npc_phase.Troll Warlord.Blood Frenzy.message":       "¡Las heridas del Caudillo empiezan a cerrarse!",
+	"data.npc_phase.Troll Warlord.Last Stand.name":            "Última resistencia",
+	"data.npc_phase.Troll Warlord.Last Stand.message":         "¡El Caudillo ruge y carga!",
+	"data.npc_phase.Elder Necromancer.Incantation.name":       "Encantamiento",
+	"data.npc_phase.Elder Necromancer.Deathly Ritual.name":    "Ritual mortal",
+	"data.npc_phase.Elder Necromancer.Deathly Ritual.message": "¡La energía oscura regenera la carne del Nigromante!",
+	"data.npc_phase.Elder Necromancer.Lichdom.name":           "Transformación en liche",
+	"data.npc_phase.Elder Necromancer.Lichdom.message":        "¡El Nigromante Anciano abandona su envoltura mortal!",
+
+	// Commands
+	"command.help":                     "Lista los comandos o explica uno de ellos",
+	"command.help:command":             "Comando que explicar, como `clan war`",
+	"command.language":                 "Muestra o elige el idioma en el que te responde el bot",
+	"command.language:language":        "Nombre o código del idioma, o reset para seguir al servidor",
+	"command.roll":                     "Crea un personaje nuevo en un espacio libre y lo activa",
+	"command.stats":                    "Muestra las estadísticas de tu personaje activo",
+	"command.characters":               "Lista tus personajes (el prestigio y `buy slot` desbloquean más espacios)",
+	"command.switch":                   "Cambia tu personaje activo, usado en combates, equipo y XP",
+	"command.switch:number":            "Número en la lista del comando characters",
+	"command.allocate":                 "Gasta los puntos de estadística ganados al subir de nivel",
+	"command.allocate.spend":           "Gasta puntos en una estadística, o muestra tus puntos si no indicas ninguna",
+	"command.allocate.spend:stat":      "Estadística que subir",
+	"command.allocate.spend:points":    "Puntos que gastar, 1 si no se indica",
+	"command.allocate.auto":            "Gasta los puntos nuevos según la curva de crecimiento de tu raza",
+	"command.allocate.auto:state":      "Activa o desactiva el crecimiento automático",
+	"command.allocate.reset":           "Usa un token de reasignación para devolver todos los puntos asignados",
+	"command.rebirth":                  "Reinicia un personaje de nivel alto a cambio de ventajas de prestigio permanentes",
+	"command.delete":                   "Borra tu personaje activo (pide confirmación)",
+	"command.reroll":                   "Vuelve a tirar todo tu personaje usando una de tus tiradas completas diarias",
+	"command.reroll:lock":              "`--lock` y las estadísticas o rasgos que conservar, cada bloqueo cuesta más",
+	"command.rerollstat":               "Vuelve a tirar una estadística usando una de tus tiradas diarias",
+	"command.rerollstat:stat":          "Estadística que volver a tirar",
+	"command.rerolls":                  "Muestra tus tiradas restantes de hoy y tu pity hacia un Épico garantizado",
+	"command.history":                  "Recorre tus tiradas con la semilla de la que salió cada una",
+	"command.undo":                     "Gasta un token para restaurar tu personaje de antes de su última tirada",
+	"command.banner":                   "Muestra los banners temporales del servidor y sus probabilidades aumentadas",
+	"command.banner.list":              "Lista los banners activos y programados",
+	"command.banner.create":            "Programa un banner",
+	"command.banner.create:spec":       "Ajustes del banner como name=...; hours=...; race=...",
+	"command.banner.end":               "Termina un banner antes de tiempo",
+	"command.banner.end:name":          "Nombre del banner",
+	"command.banner.audit":             "Muestra quién tiró en un banner, con las semillas",
+	"command.banner.audit:name":        "Nombre del banner",
+	"command.shop":                     "Explora la tienda de objetos de hoy",
+	"command.buy":                      "Compra un objeto de la tienda, un token de reasignación o un espacio extra",
+	"command.buy:item":                 "Número del objeto, `respec` o `slot`",
+	"command.wallet":                   "Consulta tus monedas",
+	"command.daily":                    "Recoge tu recompensa diaria, los días seguidos forman una racha",
+	"command.inventory":                "Muestra los objetos que tienes",
+	"command.mailbox":                  "Guarda los objetos que llegaron con el inventario lleno",
+	"command.mailbox.list":             "Lista los objetos que te esperan",
+	"command.mailbox.claim":            "Mueve objetos a tu inventario",
+	"command.mailbox.claim:item":       "Número del objeto del buzón, o `all`",
+	"command.equip":                    "Equipa un objeto de tu inventario",
+	"command.equip:item":               "Número del objeto en el inventario",
+	"command.unequip":                  "Desequipa tu objeto equipado",
+	"command.battle":                   "Lucha contra PNJ u otros jugadores",
+	"command.battle.start":             "Empieza un combate",
+	"command.battle.start:opponent":    "Nombre del PNJ con nivel opcional o `auto`, o una mención a un jugador y `ranked`",
+	"command.battle.npcs":              "Lista los PNJ contra los que puedes luchar con sus niveles, habilidades e inmunidades",
+	"command.battle.attack":            "Ataca con tu arma",
+	"command.battle.magic":             "Lanza un hechizo",
+	"command.battle.defend":            "Se prepara para el siguiente golpe",
+	"command.battle.item":              "Usa tu objeto equipado",
+	"command.battle.status":            "Muestra el combate hasta ahora",
+	"command.battle.forfeit":           "Abandona el combate",
+	"command.queue":                    "Emparejamiento con jugadores de nivel y puntuación cercanos",
+	"command.queue.status":             "Muestra si estás en la cola",
+	"command.queue.ranked":             "Entra en la cola de partidas clasificatorias",
+	"command.queue.casual":             "Entra en la cola de partidas casuales",
+	"command.queue.leave":              "Sale de la cola",
+	"command.rank":                     "Muestra tu puntuación clasificatoria, tu liga y tu posición en la temporada",
+	"command.tournament":               "Organiza torneos de eliminación simple, doble eliminación o suizos",
+	"command.tournament.status":        "Muestra el cuadro y tu próxima partida",
+	"command.tournament.create":        "Abre las inscripciones de un torneo",
+	"command.tournament.create:format": "Formato del cuadro",
+	"command.tournament.create:name":   "Nombre del torneo",
+	"command.tournament.cancel":        "Anula un torneo que aún acepta inscripciones y devuelve las cuotas",
+	"command.tournament.join":          "Paga la inscripción y te apunta al torneo abierto",
+	"command.tournament.start":         "Cierra las inscripciones y sortea el cuadro",
+	"command.tournament.ready":         "Juega tu próxima partida",
+	"command.dungeon":                  "Baja por los pisos de la mazmorra con salas de descanso, tesoros y jefes",
+	"command.dungeon.status":           "Muestra tu incursión actual",
+	"command.dungeon.enter":            "Empieza una incursión nueva",
+	"command.dungeon.descend":          "Baja al siguiente piso",
+	"command.dungeon.retreat":          "Sale con el botín encontrado hasta ahora",
+	"command.leaderboard":              "Muestra los mejores jugadores de este servidor o de todos",
+	"command.leaderboard:category":     "Por qué clasificar",
+	"command.leaderboard:scope":        "Clasifica todos los servidores en lugar de este",
+	"command.achievements":             "Muestra tus logros y tu progreso hacia los bloqueados",
+	"command.quests":                   "Muestra tus misiones diarias y semanales (se renuevan con la tienda)",
+	"command.clan":                     "Los clanes suben de nivel juntos para obtener ventajas de tienda y XP",
+	"command.clan.info":                "Muestra tu clan, u otro por su nombre",
+	"command.clan.info:name":           "Nombre del clan",
+	"command.clan.create":              "Funda un clan",
+	"command.clan.create:name":         "Nombre del clan",
+	"command.clan.invite":              "Invita a un jugador (oficiales)",
+	"command.clan.invite:player":       "Jugador al que invitar",
+	"command.clan.join":                "Acepta la invitación de un clan",
+	"command.clan.join:name":           "Nombre del clan",
+	"command.clan.leave":               "Abandona tu clan",
+	"command.clan.kick":                "Expulsa a un miembro (oficiales)",
+	"command.clan.kick:member":         "Miembro que expulsar",
+	"command.clan.promote":             "Cambia el rango de un miembro (líder)",
+	"command.clan.promote:member":      "Miembro que ascender",
+	"command.clan.promote:role":        "Nuevo rango",
+	"command.clan.deposit":             "Ingresa monedas en el banco del clan",
+	"command.clan.deposit:amount":      "Monedas que ingresar",
+	"command.clan.withdraw":            "Retira monedas del banco del clan (oficiales)",
+	"command.clan.withdraw:amount":     "Monedas que retirar",
+	"command.clan.ledger":              "Muestra los últimos ingresos y retiradas del banco del clan",
+	"command.clan.perks":               "Muestra las ventajas de cada nivel de clan",
+	"command.clan.war":                 "Cada combate ganado contra miembros del otro clan suma un punto",
+	"command.clan.war.status":          "Muestra el marcador de la guerra de tu clan",
+	"command.clan.war.declare":         "Declara la guerra a otro clan (oficiales)",
+	"command.clan.war.declare:clan":    "Nombre del clan",
+	"command.clan.war.accept":          "Acepta una guerra declarada (oficiales)",
+	"command.clan.war.fight":           "Desafía a un miembro del clan enemigo",
+	"command.clan.war.fight:opponent":  "Miembro del clan enemigo",
+	"command.config":                   "Muestra los ajustes del servidor, los gestores del servidor pueden cambiarlos",
+	"command.config.show":              "Muestra todos los ajustes",
+	"command.config.set":               "Cambia un ajuste",
+	"command.config.set:setting":       "Ajuste que cambiar",
+	"command.config.set:value":         "Nuevo valor, `any` vacía una lista de canales o roles",
+	"command.config.reset":             "Devuelve un ajuste a su valor por defecto",
+	"command.config.reset:setting":     "Ajuste que restablecer",
+	"command.config.enable":            "Activa una función",
+	"command.config.enable:feature":    "Función que activar",
+	"command.config.disable":           "Desactiva una función",
+	"command.config.disable:feature":   "Función que desactivar",
+	"command.admin":                    "Herramientas del staff para arreglar jugadores, todo queda en el registro de auditoría",
+	"command.admin.coins":              "Da o quita monedas, solo dueños del bot",
+	"command.admin.coins:player":       "Jugador sobre el que actuar",
+	"command.admin.coins:amount":       "Cantidad que dar, negativa para quitar",
+	"command.admin.coins:reason":       "Motivo, queda en el registro de auditoría",
+	"command.admin.rerolls":            "Da o quita tokens de tirada, solo dueños del bot",
+	"command.admin.rerolls:player":     "Jugador sobre el que actuar",
+	"command.admin.rerolls:amount":     "Cantidad que dar, negativa para quitar",
+	"command.admin.rerolls:reason":     "Motivo, queda en el registro de auditoría",
+	"command.admin.item":               "Da un objeto de una rareza, solo dueños del bot",
+	"command.admin.item:player":        "Jugador sobre el que actuar",
+	"command.admin.item:rarity":        "Rareza del objeto",
+	"command.admin.item:reason":        "Motivo, queda en el registro de auditoría",
+	"command.admin.resetchar":          "Devuelve el personaje de un jugador al nivel 1, solo dueños del bot",
+	"command.admin.resetchar:player":   "Jugador sobre el que actuar",
+	"command.admin.resetchar:reason":   "Motivo, queda en el registro de auditoría",
+	"command.admin.deletechar":         "Borra el personaje activo de un jugador, solo dueños del bot",
+	"command.admin.deletechar:player":  "Jugador sobre el que actuar",
+	"command.admin.deletechar:reason":  "Motivo, queda en el registro de auditoría",
+	"command.admin.endbattle":          "Termina el combate atascado de un jugador",
+	"command.admin.endbattle:player":   "Jugador sobre el que actuar",
+	"command.admin.endbattle:reason":   "Motivo, queda en el registro de auditoría",
+	"command.admin.ban":                "Prohíbe jugar a un jugador en este servidor",
+	"command.admin.ban:player":         "Jugador sobre el que actuar",
+	"command.admin.ban:reason":         "Motivo, queda en el registro de auditoría",
+	"command.admin.unban":              "Levanta la prohibición de un jugador",
+	"command.admin.unban:player":       "Jugador sobre el que actuar",
+	"command.admin.unban:reason":       "Motivo, queda en el registro de auditoría",
+	"command.admin.refreshshop":        "Repone la tienda, solo dueños del bot",
+	"command.admin.refreshshop:reason": "Motivo, queda en el registro de auditoría",
+	"command.admin.log":                "Recorre el registro de auditoría",
+	"command.admin.log:page":           "Número de página",
+
+	// Tournament entry fees
+	"tournament.fee_unaffordable":     "Necesitas {count, plural, one {# moneda} other {# monedas}} para pagar la inscripción.",
+	"tournament.cancel_not_organizer": "Solo el organizador puede anular el torneo.",
+	"tournament.cancel_started":       "El torneo ya ha empezado y no se puede anular.",
+	"tournament.cancelled":            "🚫 **{name}** se ha anulado. Se {count, plural, one {ha devuelto # inscripción} other {han devuelto # inscripciones}}.",
+
+	// Tournament states
+	"tournament.name.cancelled": "Anulado",
+}
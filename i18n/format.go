@@ -0,0 +1,122 @@
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// format fills a message's {name} placeholders and picks its plural forms
+func (l Localizer) format(text string, values Args) string {
+	var out strings.Builder
+	for {
+		start := strings.IndexByte(text, '{')
+		if start < 0 {
+			out.WriteString(text)
+			return out.String()
+		}
+
+		end := closingBrace(text, start)
+		if end < 0 {
+			out.WriteString(text)
+			return out.String()
+		}
+
+		out.WriteString(text[:start])
+		out.WriteString(l.placeholder(text[start+1:end], values))
+		text = text[end+1:]
+	}
+}
+
+// placeholder expands the inside of one {...}
+// Note: A placeholder without a value is left as written.
+func (l Localizer) placeholder(inner string, values Args) string {
+	name, rest, isPlural := strings.Cut(inner, ",")
+	name = strings.TrimSpace(name)
+
+	value, ok := values[name]
+	if !ok {
+		return "{" + inner + "}"
+	}
+	if !isPlural {
+		return fmt.Sprint(value)
+	}
+
+	kind, forms, _ := strings.Cut(rest, ",")
+	count, isNumber := toInt(value)
+	if strings.TrimSpace(kind) != "plural" || !isNumber {
+		return fmt.Sprint(value)
+	}
+
+	form, ok := l.pluralForm(forms, count)
+	if !ok {
+		return fmt.Sprint(value)
+	}
+	return l.format(strings.ReplaceAll(form, "#", strconv.Itoa(count)), values)
+}
+
+// pluralForm picks the form for a count from `=0 {...} one {...} other {...}`
+// Note: An exact =N form wins over the locale's rule, other is the fallback.
+func (l Localizer) pluralForm(forms string, count int) (string, bool) {
+	rule := oneOther
+	if l.locale.Plural != nil {
+		rule = l.locale.Plural
+	}
+	category := rule(count)
+
+	choices := map[string]string{}
+	for {
+		start := strings.IndexByte(forms, '{')
+		if start < 0 {
+			break
+		}
+		end := closingBrace(forms, start)
+		if end < 0 {
+			break
+		}
+
+		choices[strings.TrimSpace(forms[:start])] = forms[start+1 : end]
+		forms = forms[end+1:]
+	}
+
+	if form, ok := choices["="+strconv.Itoa(count)]; ok {
+		return form, true
+	}
+	if form, ok := choices[category]; ok {
+		return form, true
+	}
+	form, ok := choices["other"]
+	return form, ok
+}
+
+// closingBrace finds the } matching the { at start, skipping nested pairs
+func closingBrace(text string, start int) int {
+	depth := 0
+	for i := start; i < len(text); i++ {
+		switch text[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// toInt reads a count from any whole number type
+func toInt(value any) (int, bool) {
+	switch number := value.(type) {
+	case int:
+		return number, true
+	case int32:
+		return int(number), true
+	case int64:
+		return int(number), true
+	case float64:
+		return int(number), true
+	}
+	return 0, false
+}
@@ -0,0 +1,130 @@
+package i18n
+
+import (
+	"slices"
+	"strings"
+)
+
+// Supported locales
+const (
+	English = "en"
+	Spanish = "es"
+)
+
+// Default is the locale used when neither the user nor the guild picked one
+const Default = English
+
+// Catalogue maps message keys to their text in one locale
+// Note: Text uses {name} placeholders and {count, plural, one {# coin} other {# coins}} plurals.
+type Catalogue map[string]string
+
+// Args are the named values filled into a message's placeholders
+type Args map[string]any
+
+// Locale
+/*
+	Code - Short code stored on users and guilds.
+	Name - Name of the language in that language.
+	Aliases - Other names accepted when picking it. Note: Matched without case.
+	Messages - The catalogue. Note: Missing keys fall back to English.
+	Plural - Picks the plural form for a count.
+*/
+type Locale struct {
+	Code     string
+	Name     string
+	Aliases  []string
+	Messages Catalogue
+	Plural   func(count int) string
+}
+
+// Locales lists every locale the bot ships, English first
+var Locales = []Locale{
+	{Code: English, Name: "English", Aliases: []string{"english", "en-us", "en-gb"}, Messages: english, Plural: oneOther},
+	{Code: Spanish, Name: "Español", Aliases: []string{"spanish", "espanol", "es-es", "es-419"}, Messages: spanish, Plural: oneOther},
+}
+
+// oneOther is the plural rule of languages where only 1 is singular
+func oneOther(count int) string {
+	if count == 1 {
+		return "one"
+	}
+	return "other"
+}
+
+// Find returns a locale by its code, name or an alias
+func Find(name string) (Locale, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, locale := range Locales {
+		if name == locale.Code || name == strings.ToLower(locale.Name) || slices.Contains(locale.Aliases, name) {
+			return locale, true
+		}
+	}
+	return Locale{}, false
+}
+
+// Codes lists the code of every locale
+func Codes() []string {
+	codes := []string{}
+	for _, locale := range Locales {
+		codes = append(codes, locale.Code)
+	}
+	return codes
+}
+
+// Localizer translates messages into one locale
+type Localizer struct {
+	locale Locale
+}
+
+// New returns a localizer for the first locale that is set and supported
+// Note: Pass the user's locale before the guild's, English is used when neither is.
+func New(codes ...string) Localizer {
+	for _, code := range codes {
+		if locale, ok := Find(code); ok && code != "" {
+			return Localizer{locale: locale}
+		}
+	}
+	return Localizer{locale: Locales[0]}
+}
+
+// Locale returns the code of the locale being translated into
+func (l Localizer) Locale() string {
+	if l.locale.Code == "" {
+		return Default
+	}
+	return l.locale.Code
+}
+
+// Lookup returns a message's raw text, falling back to English
+func (l Localizer) Lookup(key string) (string, bool) {
+	if text, ok := l.locale.Messages[key]; ok {
+		return text, true
+	}
+	text, ok := english[key]
+	return text, ok
+}
+
+// T translates a message and fills in its placeholders
+// Note: Unknown keys come back as the key itself, so a missing translation shows where it is.
+func (l Localizer) T(key string, args ...Args) string {
+	text, ok := l.Lookup(key)
+	if !ok {
+		return key
+	}
+
+	values := Args{}
+	for _, arg := range args {
+		for name, value := range arg {
+			values[name] = value
+		}
+	}
+	return l.format(text, values)
+}
+
+// Or translates a message that may not be in the catalogue, such as a command description
+func (l Localizer) Or(key string, fallback string, args ...Args) string {
+	if _, ok := l.Lookup(key); !ok {
+		return fallback
+	}
+	return l.T(key, args...)
+}
@@ -0,0 +1,153 @@
+package i18n
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFormat_Placeholders(t *testing.T) {
+	localizer := New(English)
+
+	got := localizer.format("{name} has {coins} coins", Args{"name": "Crispy", "coins": 250})
+	if got != "Crispy has 250 coins" {
+		t.Errorf("Expected the placeholders to be filled, got %q", got)
+	}
+
+	got = localizer.format("{name} meets {rival}", Args{"name": "Crispy"})
+	if got != "Crispy meets {rival}" {
+		t.Errorf("Expected a placeholder without a value to be left alone, got %q", got)
+	}
+}
+
+func TestFormat_Plurals(t *testing.T) {
+	localizer := New(English)
+	text := "{count, plural, =0 {no coins} one {# coin} other {# coins}} for {name}"
+
+	cases := map[int]string{
+		0: "no coins for Crispy",
+		1: "1 coin for Crispy",
+		7: "7 coins for Crispy",
+	}
+	for count, expected := range cases {
+		if got := localizer.format(text, Args{"count": count, "name": "Crispy"}); got != expected {
+			t.Errorf("Expected %q for %d, got %q", expected, count, got)
+		}
+	}
+
+	if got := localizer.format("{count, plural, other {# coins}}", Args{"count": int64(1)}); got != "1 coins" {
+		t.Errorf("Expected other to be the fallback form, got %q", got)
+	}
+	if got := localizer.format("{count, plural, one {# coin} other {# coins}}", Args{"count": "many"}); got != "many" {
+		t.Errorf("Expected a count that isn't a number to be printed as is, got %q", got)
+	}
+}
+
+func TestT_FallsBackToEnglish(t *testing.T) {
+	english["test.only.english"] = "Hello {name}"
+	defer delete(english, "test.only.english")
+
+	if got := New(Spanish).T("test.only.english", Args{"name": "Crispy"}); got != "Hello Crispy" {
+		t.Errorf("Expected a missing translation to use English, got %q", got)
+	}
+	if got := New(Spanish).T("test.missing"); got != "test.missing" {
+		t.Errorf("Expected an unknown key to come back as is, got %q", got)
+	}
+	if got := New(Spanish).Or("test.missing", "fallback"); got != "fallback" {
+		t.Errorf("Expected Or to use the fallback, got %q", got)
+	}
+}
+
+func TestNew_PicksFirstSupportedLocale(t *testing.T) {
+	cases := []struct {
+		codes    []string
+		expected string
+	}{
+		{[]string{"", "es"}, Spanish},
+		{[]string{"en", "es"}, English},
+		{[]string{"klingon", "Español"}, Spanish},
+		{[]string{"es-419"}, Spanish},
+		{nil, English},
+	}
+	for _, c := range cases {
+		if got := New(c.codes...).Locale(); got != c.expected {
+			t.Errorf("Expected %v to pick %s, got %s", c.codes, c.expected, got)
+		}
+	}
+
+	if got := (Localizer{}).Locale(); got != Default {
+		t.Errorf("Expected the zero localizer to be English, got %s", got)
+	}
+}
+
+// placeholders lists the placeholder names a message uses, including inside plural forms
+func placeholders(text string) []string {
+	names := []string{}
+	var walk func(text string)
+	walk = func(text string) {
+		for {
+			start := strings.IndexByte(text, '{')
+			if start < 0 {
+				return
+			}
+			end := closingBrace(text, start)
+			if end < 0 {
+				return
+			}
+
+			name, rest, isPlural := strings.Cut(text[start+1:end], ",")
+			if name = strings.TrimSpace(name); !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+			if isPlural {
+				_, forms, _ := strings.Cut(rest, ",")
+				for {
+					formStart := strings.IndexByte(forms, '{')
+					if formStart < 0 {
+						break
+					}
+					formEnd := closingBrace(forms, formStart)
+					if formEnd < 0 {
+						break
+					}
+					walk(forms[formStart+1 : formEnd])
+					forms = forms[formEnd+1:]
+				}
+			}
+			text = text[end+1:]
+		}
+	}
+
+	walk(text)
+	slices.Sort(names)
+	return names
+}
+
+func TestCatalogues_Complete(t *testing.T) {
+	for _, locale := range Locales[1:] {
+		for key, text := range locale.Messages {
+			if strings.HasPrefix(key, "command.") || strings.HasPrefix(key, "data.") {
+				continue
+			}
+
+			source, ok := english[key]
+			if !ok {
+				t.Errorf("%s has %s, which isn't in the English catalogue", locale.Code, key)
+				continue
+			}
+			if !reflect.DeepEqual(placeholders(source), placeholders(text)) {
+				t.Errorf("%s %s uses %v, English uses %v", locale.Code, key, placeholders(text), placeholders(source))
+			}
+			if strings.Count(text, "{") != strings.Count(text, "}") {
+				t.Errorf("%s %s has unbalanced braces", locale.Code, key)
+			}
+		}
+
+		for key := range english {
+			if _, ok := locale.Messages[key]; !ok {
+				t.Errorf("%s is missing %s", locale.Code, key)
+			}
+		}
+	}
+}
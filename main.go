@@ -16,9 +16,18 @@ func main() {
 
 	fmt.Println("Starting CrispyBot...")
 
+	// Make sure lookup and leaderboard indexes exist
+	database.EnsureIndexes(db)
+
+	// Score characters rolled before the rarity leaderboard
+	database.BackfillRarityScores(db)
+
 	// Start the shop refresh scheduler
 	database.StartShopRefreshScheduler(db)
 
+	// Start the ranked season scheduler
+	database.StartRankedScheduler(db)
+
 	// Start the Discord bot
 	go bugou.StartBot()
 
@@ -0,0 +1,249 @@
+package npc
+
+import (
+	"CrispyBot/roller"
+	"CrispyBot/variables"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// AI profiles
+const (
+	AIBalanced   = "balanced"
+	AIAggressive = "aggressive"
+	AIDefensive  = "defensive"
+	AICaster     = "caster"
+	AISupport    = "support"
+	AIBoss       = "boss"
+	AIDummy      = "dummy"
+)
+
+// Skill kinds
+const (
+	SkillPhysical = "physical"
+	SkillMagic    = "magic"
+	SkillHeal     = "heal"
+)
+
+// Stats are an NPC's base stats, using the same scale as character stats
+type Stats struct {
+	Vitality     int
+	Durability   int
+	Speed        int
+	Strength     int
+	Intelligence int
+	Mana         int
+	Mastery      int
+}
+
+// Skill is a signature move an NPC can use instead of a basic action
+type Skill struct {
+	Name         string
+	Kind         string  // physical, magic or heal
+	Power        float64 // Damage multiplier, or fraction of max HP healed
+	ManaCost     int
+	Effect       string // Status effect applied on hit, if any
+	EffectChance int    // Percent chance to apply the effect
+	EffectTurns  int
+}
+
+// Phase is one stage of a boss script, entered once HP falls to a threshold
+type Phase struct {
+	HPPercent   int // Phase starts at or below this percent of max HP
+	Name        string
+	Message     string  // Announced when the phase begins
+	DamageBoost float64 // Fraction added to damage when the phase begins
+	AIProfile   string  // Strategy used during the phase
+}
+
+// DefaultBossPhases is used by boss AI when a definition has no script
+var DefaultBossPhases = []Phase{
+	{HPPercent: 100, Name: "Opening", AIProfile: AIBalanced},
+	{HPPercent: 60, Name: "Frenzy", Message: "Its attacks grow wild!", DamageBoost: 0.15, AIProfile: AIAggressive},
+	{HPPercent: 25, Name: "Last Stand", Message: "It fights with everything it has left!", DamageBoost: 0.25, AIProfile: AIAggressive},
+}
+
+// LootEntry is a weighted outcome on an NPC's loot table
+type LootEntry struct {
+	Rarity string // Item rarity, or empty for no drop
+	Weight int
+}
+
+// Definition describes an NPC on the roster
+type Definition struct {
+	Name               string
+	Description        string
+	MinLevel           int
+	MaxLevel           int
+	DefaultLevel       int
+	BaseStats          Stats // Stats at level 1
+	GrowthStats        Stats // Stats gained per level after 1
+	Element            string
+	Race               string
+	Skills             []Skill
+	Immunities         []string
+	AIProfile          string
+	Phases             []Phase // Boss script, ordered from full HP down
+	Boss               bool
+	BaseExperience     int
+	ExperiencePerLevel int
+	BaseCoins          int
+	CoinsPerLevel      int
+	LootTable          []LootEntry
+	Escorts            []string // Roster NPCs that join its fights on its side
+}
+
+// Find looks up a roster NPC by name, ignoring case and spaces
+func Find(name string) (Definition, bool) {
+	key := normalizeName(name)
+	for _, definition := range Definitions {
+		if normalizeName(definition.Name) == key {
+			return definition, true
+		}
+	}
+	return Definition{}, false
+}
+
+// Roster returns the NPCs players can challenge directly, ordered by default level
+func Roster() []Definition {
+	roster := []Definition{}
+	for _, definition := range Definitions {
+		if !definition.Boss {
+			roster = append(roster, definition)
+		}
+	}
+
+	sort.SliceStable(roster, func(i, j int) bool {
+		return roster[i].DefaultLevel < roster[j].DefaultLevel
+	})
+
+	return roster
+}
+
+// Generic builds a definition for an NPC that isn't on the roster
+func Generic(name string) Definition {
+	return Definition{
+		Name:               name,
+		MinLevel:           1,
+		MaxLevel:           10,
+		DefaultLevel:       1,
+		BaseStats:          Stats{55, 55, 55, 55, 55, 55, 55},
+		GrowthStats:        Stats{5, 5, 5, 5, 5, 5, 5},
+		AIProfile:          AIBalanced,
+		BaseExperience:     variables.BaseExperienceGain,
+		ExperiencePerLevel: 10,
+		BaseCoins:          100,
+		CoinsPerLevel:      5,
+		LootTable:          lootWeak,
+	}
+}
+
+// ValidateLevel checks if the NPC can be challenged at a level
+func (d Definition) ValidateLevel(level int) error {
+	if level < d.MinLevel || level > d.MaxLevel {
+		return fmt.Errorf("%s can be fought at levels %d-%d", d.Name, d.MinLevel, d.MaxLevel)
+	}
+	return nil
+}
+
+// StatsAt returns the NPC's stats at a level, capped at the maximum stat value
+func (d Definition) StatsAt(level int) Stats {
+	if level < 1 {
+		level = 1
+	}
+	growth := level - 1
+
+	scale := func(base int, perLevel int) int {
+		value := base + perLevel*growth
+		if value > variables.MaxStatValue {
+			return variables.MaxStatValue
+		}
+		return value
+	}
+
+	return Stats{
+		Vitality:     scale(d.BaseStats.Vitality, d.GrowthStats.Vitality),
+		Durability:   scale(d.BaseStats.Durability, d.GrowthStats.Durability),
+		Speed:        scale(d.BaseStats.Speed, d.GrowthStats.Speed),
+		Strength:     scale(d.BaseStats.Strength, d.GrowthStats.Strength),
+		Intelligence: scale(d.BaseStats.Intelligence, d.GrowthStats.Intelligence),
+		Mana:         scale(d.BaseStats.Mana, d.GrowthStats.Mana),
+		Mastery:      scale(d.BaseStats.Mastery, d.GrowthStats.Mastery),
+	}
+}
+
+// ExperienceReward returns the XP for defeating the NPC at a level
+func (d Definition) ExperienceReward(level int) int {
+	return d.BaseExperience + d.ExperiencePerLevel*(level-1)
+}
+
+// CoinReward returns the coins for defeating the NPC at a level
+func (d Definition) CoinReward(level int) int {
+	return d.BaseCoins + d.CoinsPerLevel*(level-1)
+}
+
+// RollLoot rolls the loot table for the NPC at a level and returns the dropped rarity
+// Note: Returns "" for no drop. Higher levels, and levels above the NPC's default, may bump the rarity up.
+func (d Definition) RollLoot(level int, rng *rand.Rand) string {
+	total := 0
+	for _, entry := range d.LootTable {
+		total += entry.Weight
+	}
+	if total <= 0 {
+		return ""
+	}
+
+	rarity := ""
+	roll := rng.Intn(total)
+	for _, entry := range d.LootTable {
+		if roll < entry.Weight {
+			rarity = entry.Rarity
+			break
+		}
+		roll -= entry.Weight
+	}
+	if rarity == "" {
+		return ""
+	}
+
+	// Each bump halves the chance of the next one
+	bumpChance := level*variables.LootLevelBias + max(0, level-d.DefaultLevel)*variables.LootDifficultyBias
+	tiers := roller.TierNames()
+	tier := tierIndex(rarity)
+	for bumpChance > 0 && tier >= 0 && tier < len(tiers)-1 && rng.Intn(100) < bumpChance {
+		tier++
+		bumpChance /= 2
+	}
+	if tier < 0 {
+		return rarity
+	}
+
+	return tiers[tier]
+}
+
+// tierIndex returns the position of a rarity in the tier order, or -1 if unknown
+func tierIndex(rarity string) int {
+	for i, tier := range roller.TierNames() {
+		if tier == rarity {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsImmune checks if the NPC can't receive a status effect
+func (d Definition) IsImmune(effect string) bool {
+	for _, immunity := range d.Immunities {
+		if immunity == effect {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeName lowercases a name and strips spaces for lookups
+func normalizeName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, " ", ""))
+}
@@ -0,0 +1,98 @@
+package npc
+
+import (
+	"CrispyBot/variables"
+	"math/rand"
+	"testing"
+)
+
+func TestDefinitions_Valid(t *testing.T) {
+	seen := map[string]bool{}
+	for _, definition := range Definitions {
+		if seen[normalizeName(definition.Name)] {
+			t.Errorf("Duplicate NPC name %s", definition.Name)
+		}
+		seen[normalizeName(definition.Name)] = true
+
+		if err := definition.ValidateLevel(definition.DefaultLevel); err != nil {
+			t.Errorf("%s: default level out of range: %v", definition.Name, err)
+		}
+
+		total := 0
+		for _, entry := range definition.LootTable {
+			total += entry.Weight
+		}
+		if total <= 0 {
+			t.Errorf("%s: loot table has no weight", definition.Name)
+		}
+
+		for _, skill := range definition.Skills {
+			if skill.Kind != SkillPhysical && skill.Kind != SkillMagic && skill.Kind != SkillHeal {
+				t.Errorf("%s: skill %s has unknown kind %s", definition.Name, skill.Name, skill.Kind)
+			}
+		}
+
+		for _, name := range definition.Escorts {
+			escort, ok := Find(name)
+			if !ok || len(escort.Escorts) > 0 {
+				t.Errorf("%s: escort %s must be a roster NPC without escorts of its own", definition.Name, name)
+			}
+		}
+	}
+}
+
+func TestFind_IgnoresCaseAndSpaces(t *testing.T) {
+	for _, name := range []string{"Dark Knight", "dark knight", "DARKKNIGHT"} {
+		if definition, ok := Find(name); !ok || definition.Name != "Dark Knight" {
+			t.Errorf("Expected %q to find Dark Knight", name)
+		}
+	}
+	if _, ok := Find("Nobody"); ok {
+		t.Errorf("Expected unknown NPC to not be found")
+	}
+}
+
+func TestRoster_ExcludesBosses(t *testing.T) {
+	for _, definition := range Roster() {
+		if definition.Boss {
+			t.Errorf("Boss %s should not be on the challenge roster", definition.Name)
+		}
+	}
+}
+
+func TestStatsAt_CapsAtMax(t *testing.T) {
+	definition, _ := Find("Dragon Lord")
+	stats := definition.StatsAt(1000)
+	if stats.Vitality != variables.MaxStatValue || stats.Strength != variables.MaxStatValue {
+		t.Errorf("Expected stats capped at %d, got %+v", variables.MaxStatValue, stats)
+	}
+}
+
+func TestRollLoot_NoneTableNeverDrops(t *testing.T) {
+	definition, _ := Find("Training Dummy")
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		if rarity := definition.RollLoot(definition.MaxLevel, rng); rarity != "" {
+			t.Fatalf("Expected no drop, got %s", rarity)
+		}
+	}
+}
+
+func TestRollLoot_HigherLevelsDropRarer(t *testing.T) {
+	definition := Definition{DefaultLevel: 1, LootTable: []LootEntry{{Rarity: "Common", Weight: 1}}}
+	countBumped := func(level int) int {
+		rng := rand.New(rand.NewSource(42))
+		bumped := 0
+		for i := 0; i < 1000; i++ {
+			if definition.RollLoot(level, rng) != "Common" {
+				bumped++
+			}
+		}
+		return bumped
+	}
+
+	low, high := countBumped(1), countBumped(20)
+	if high <= low {
+		t.Errorf("Expected more rarity bumps at level 20 (%d) than level 1 (%d)", high, low)
+	}
+}
@@ -0,0 +1,401 @@
+package npc
+
+// Shared loot tables, from trash mobs to bosses
+var (
+	lootNone = []LootEntry{
+		{Rarity: "", Weight: 1},
+	}
+
+	lootWeak = []LootEntry{
+		{Rarity: "", Weight: 60},
+		{Rarity: "Common", Weight: 30},
+		{Rarity: "Uncommon", Weight: 9},
+		{Rarity: "Rare", Weight: 1},
+	}
+
+	lootMedium = []LootEntry{
+		{Rarity: "", Weight: 45},
+		{Rarity: "Common", Weight: 30},
+		{Rarity: "Uncommon", Weight: 17},
+		{Rarity: "Rare", Weight: 7},
+		{Rarity: "Epic", Weight: 1},
+	}
+
+	lootStrong = []LootEntry{
+		{Rarity: "", Weight: 35},
+		{Rarity: "Uncommon", Weight: 35},
+		{Rarity: "Rare", Weight: 20},
+		{Rarity: "Epic", Weight: 8},
+		{Rarity: "Legendary", Weight: 2},
+	}
+
+	lootBoss = []LootEntry{
+		{Rarity: "Rare", Weight: 55},
+		{Rarity: "Epic", Weight: 35},
+		{Rarity: "Legendary", Weight: 10},
+	}
+)
+
+// Definitions is the full NPC roster, including dungeon enemies and bosses
+var Definitions = []Definition{
+	{
+		Name:        "Training Dummy",
+		Description: "A straw target that never fights back hard. Perfect for practice.",
+		MinLevel:    1, MaxLevel: 10, DefaultLevel: 1,
+		BaseStats:   Stats{Vitality: 60, Durability: 20, Speed: 10, Strength: 10, Intelligence: 10, Mana: 10, Mastery: 10},
+		GrowthStats: Stats{Vitality: 8, Durability: 3},
+		Element:     "None", Race: "Construct",
+		Immunities:     []string{"Burn", "Poison", "Stun", "Freeze"},
+		AIProfile:      AIDummy,
+		BaseExperience: 40, ExperiencePerLevel: 5,
+		BaseCoins: 20, CoinsPerLevel: 2,
+		LootTable: lootNone,
+	},
+	{
+		Name:        "Goblin",
+		Description: "A sneaky raider with a poisoned knife.",
+		MinLevel:    1, MaxLevel: 5, DefaultLevel: 2,
+		BaseStats:   Stats{Vitality: 45, Durability: 35, Speed: 60, Strength: 55, Intelligence: 30, Mana: 30, Mastery: 45},
+		GrowthStats: Stats{Vitality: 5, Durability: 4, Speed: 6, Strength: 6, Intelligence: 2, Mana: 2, Mastery: 4},
+		Element:     "Earth", Race: "Goblin",
+		Skills: []Skill{
+			{Name: "Dirty Stab", Kind: SkillPhysical, Power: 1.3, Effect: "Poison", EffectChance: 25, EffectTurns: 3},
+		},
+		AIProfile:      AIAggressive,
+		BaseExperience: 80, ExperiencePerLevel: 10,
+		BaseCoins: 60, CoinsPerLevel: 8,
+		LootTable: lootWeak,
+	},
+	{
+		Name:        "Cave Rat",
+		Description: "A diseased rodent the size of a dog.",
+		MinLevel:    1, MaxLevel: 5, DefaultLevel: 1,
+		BaseStats:   Stats{Vitality: 35, Durability: 25, Speed: 70, Strength: 45, Intelligence: 10, Mana: 10, Mastery: 40},
+		GrowthStats: Stats{Vitality: 4, Durability: 3, Speed: 6, Strength: 5, Mastery: 4},
+		Element:     "Toxic", Race: "Beast",
+		Skills: []Skill{
+			{Name: "Plague Bite", Kind: SkillPhysical, Power: 1.1, Effect: "Poison", EffectChance: 40, EffectTurns: 3},
+		},
+		Immunities:     []string{"Poison"},
+		AIProfile:      AIAggressive,
+		BaseExperience: 60, ExperiencePerLevel: 8,
+		BaseCoins: 40, CoinsPerLevel: 5,
+		LootTable: lootWeak,
+	},
+	{
+		Name:        "Slime",
+		Description: "A wobbling mass that shrugs off blows.",
+		MinLevel:    1, MaxLevel: 5, DefaultLevel: 1,
+		BaseStats:   Stats{Vitality: 70, Durability: 50, Speed: 20, Strength: 35, Intelligence: 40, Mana: 40, Mastery: 30},
+		GrowthStats: Stats{Vitality: 7, Durability: 5, Speed: 2, Strength: 3, Intelligence: 4, Mana: 4, Mastery: 3},
+		Element:     "Water", Race: "Ooze",
+		Skills: []Skill{
+			{Name: "Engulf", Kind: SkillMagic, Power: 1.2, ManaCost: 10},
+		},
+		Immunities:     []string{"Poison", "Stun"},
+		AIProfile:      AIDefensive,
+		BaseExperience: 60, ExperiencePerLevel: 8,
+		BaseCoins: 40, CoinsPerLevel: 5,
+		LootTable: lootWeak,
+	},
+	{
+		Name:        "Bandit",
+		Description: "A highwayman who strikes from the shadows.",
+		MinLevel:    2, MaxLevel: 6, DefaultLevel: 3,
+		BaseStats:   Stats{Vitality: 55, Durability: 45, Speed: 60, Strength: 60, Intelligence: 35, Mana: 35, Mastery: 55},
+		GrowthStats: Stats{Vitality: 5, Durability: 5, Speed: 5, Strength: 6, Intelligence: 3, Mana: 3, Mastery: 5},
+		Element:     "Dark", Race: "Humans",
+		Skills: []Skill{
+			{Name: "Ambush", Kind: SkillPhysical, Power: 1.5, Effect: "Stun", EffectChance: 15, EffectTurns: 1},
+		},
+		AIProfile:      AIBalanced,
+		BaseExperience: 90, ExperiencePerLevel: 10,
+		BaseCoins: 80, CoinsPerLevel: 10,
+		LootTable: lootWeak,
+	},
+	{
+		Name:        "Skeleton",
+		Description: "Rattling bones animated by old magic.",
+		MinLevel:    2, MaxLevel: 7, DefaultLevel: 3,
+		BaseStats:   Stats{Vitality: 45, Durability: 55, Speed: 45, Strength: 60, Intelligence: 20, Mana: 20, Mastery: 50},
+		GrowthStats: Stats{Vitality: 4, Durability: 6, Speed: 4, Strength: 6, Intelligence: 2, Mana: 2, Mastery: 5},
+		Element:     "Dark", Race: "Skeleton",
+		Skills: []Skill{
+			{Name: "Bone Rattle", Kind: SkillPhysical, Power: 1.3, Effect: "Stun", EffectChance: 20, EffectTurns: 1},
+		},
+		Immunities:     []string{"Poison"},
+		AIProfile:      AIAggressive,
+		BaseExperience: 90, ExperiencePerLevel: 10,
+		BaseCoins: 70, CoinsPerLevel: 9,
+		LootTable: lootWeak,
+	},
+	{
+		Name:        "Wolf Pack",
+		Description: "Hungry wolves that overwhelm their prey.",
+		MinLevel:    3, MaxLevel: 7, DefaultLevel: 4,
+		BaseStats:   Stats{Vitality: 60, Durability: 40, Speed: 75, Strength: 65, Intelligence: 20, Mana: 20, Mastery: 55},
+		GrowthStats: Stats{Vitality: 6, Durability: 4, Speed: 7, Strength: 7, Intelligence: 1, Mana: 1, Mastery: 5},
+		Element:     "Wind", Race: "Beastfolk",
+		Skills: []Skill{
+			{Name: "Pack Frenzy", Kind: SkillPhysical, Power: 1.6},
+		},
+		AIProfile:      AIAggressive,
+		BaseExperience: 100, ExperiencePerLevel: 12,
+		BaseCoins: 90, CoinsPerLevel: 10,
+		LootTable: lootMedium,
+	},
+	{
+		Name:        "Dark Knight",
+		Description: "A fallen paladin clad in black steel.",
+		MinLevel:    4, MaxLevel: 9, DefaultLevel: 5,
+		BaseStats:   Stats{Vitality: 70, Durability: 75, Speed: 40, Strength: 70, Intelligence: 40, Mana: 40, Mastery: 60},
+		GrowthStats: Stats{Vitality: 7, Durability: 8, Speed: 3, Strength: 7, Intelligence: 3, Mana: 3, Mastery: 5},
+		Element:     "Dark", Race: "Undead",
+		Skills: []Skill{
+			{Name: "Shadow Cleave", Kind: SkillPhysical, Power: 1.5},
+		},
+		Immunities:     []string{"Poison"},
+		AIProfile:      AIDefensive,
+		BaseExperience: 120, ExperiencePerLevel: 14,
+		BaseCoins: 110, CoinsPerLevel: 12,
+		LootTable: lootMedium,
+	},
+	{
+		Name:        "Ghoul",
+		Description: "A ravenous corpse that spreads rot with every scratch.",
+		MinLevel:    4, MaxLevel: 9, DefaultLevel: 5,
+		BaseStats:   Stats{Vitality: 65, Durability: 50, Speed: 55, Strength: 70, Intelligence: 25, Mana: 25, Mastery: 50},
+		GrowthStats: Stats{Vitality: 7, Durability: 5, Speed: 5, Strength: 7, Intelligence: 2, Mana: 2, Mastery: 5},
+		Element:     "Toxic", Race: "Undead",
+		Skills: []Skill{
+			{Name: "Rotting Claw", Kind: SkillPhysical, Power: 1.3, Effect: "Poison", EffectChance: 35, EffectTurns: 3},
+		},
+		Immunities:     []string{"Poison"},
+		AIProfile:      AIAggressive,
+		BaseExperience: 115, ExperiencePerLevel: 13,
+		BaseCoins: 100, CoinsPerLevel: 11,
+		LootTable: lootMedium,
+	},
+	{
+		Name:        "Troll",
+		Description: "A hulking brute whose wounds close as fast as you make them.",
+		MinLevel:    5, MaxLevel: 10, DefaultLevel: 6,
+		BaseStats:   Stats{Vitality: 90, Durability: 65, Speed: 30, Strength: 80, Intelligence: 20, Mana: 40, Mastery: 45},
+		GrowthStats: Stats{Vitality: 9, Durability: 6, Speed: 2, Strength: 8, Intelligence: 1, Mana: 3, Mastery: 4},
+		Element:     "Earth", Race: "Giant",
+		Skills: []Skill{
+			{Name: "Club Smash", Kind: SkillPhysical, Power: 1.4, Effect: "Stun", EffectChance: 20, EffectTurns: 1},
+			{Name: "Regenerate", Kind: SkillHeal, Power: 0.2, ManaCost: 15},
+		},
+		Immunities:     []string{"Poison"},
+		AIProfile:      AIDefensive,
+		BaseExperience: 140, ExperiencePerLevel: 15,
+		BaseCoins: 130, CoinsPerLevel: 14,
+		LootTable: lootMedium,
+	},
+	{
+		Name:        "Dragon Whelp",
+		Description: "A young dragon, already breathing fire.",
+		MinLevel:    6, MaxLevel: 10, DefaultLevel: 7,
+		BaseStats:   Stats{Vitality: 75, Durability: 60, Speed: 60, Strength: 60, Intelligence: 80, Mana: 70, Mastery: 60},
+		GrowthStats: Stats{Vitality: 7, Durability: 6, Speed: 5, Strength: 5, Intelligence: 8, Mana: 7, Mastery: 5},
+		Element:     "Fire", Race: "Dragonborn",
+		Skills: []Skill{
+			{Name: "Flame Breath", Kind: SkillMagic, Power: 1.4, ManaCost: 20, Effect: "Burn", EffectChance: 35, EffectTurns: 3},
+		},
+		Immunities:     []string{"Burn"},
+		AIProfile:      AICaster,
+		BaseExperience: 160, ExperiencePerLevel: 16,
+		BaseCoins: 150, CoinsPerLevel: 15,
+		LootTable: lootStrong,
+	},
+	{
+		Name:        "Wraith",
+		Description: "A vengeful spirit that drains the warmth from the air.",
+		MinLevel:    6, MaxLevel: 10, DefaultLevel: 7,
+		BaseStats:   Stats{Vitality: 55, Durability: 40, Speed: 75, Strength: 30, Intelligence: 85, Mana: 80, Mastery: 65},
+		GrowthStats: Stats{Vitality: 5, Durability: 4, Speed: 7, Strength: 2, Intelligence: 8, Mana: 8, Mastery: 6},
+		Element:     "Dark", Race: "Ghost",
+		Skills: []Skill{
+			{Name: "Soul Chill", Kind: SkillMagic, Power: 1.3, ManaCost: 15, Effect: "Freeze", EffectChance: 25, EffectTurns: 2},
+		},
+		Immunities:     []string{"Poison", "Burn"},
+		AIProfile:      AICaster,
+		BaseExperience: 150, ExperiencePerLevel: 16,
+		BaseCoins: 140, CoinsPerLevel: 14,
+		LootTable: lootStrong,
+	},
+	{
+		Name:        "Necromancer",
+		Description: "A dark mage who patches itself up with stolen life.",
+		MinLevel:    7, MaxLevel: 10, DefaultLevel: 8,
+		BaseStats:   Stats{Vitality: 65, Durability: 45, Speed: 55, Strength: 35, Intelligence: 95, Mana: 90, Mastery: 70},
+		GrowthStats: Stats{Vitality: 6, Durability: 4, Speed: 5, Strength: 2, Intelligence: 9, Mana: 9, Mastery: 6},
+		Element:     "Dark", Race: "Undead",
+		Skills: []Skill{
+			{Name: "Plague Bolt", Kind: SkillMagic, Power: 1.3, ManaCost: 20, Effect: "Poison", EffectChance: 30, EffectTurns: 3},
+			{Name: "Dark Mend", Kind: SkillHeal, Power: 0.25, ManaCost: 25},
+		},
+		Immunities:     []string{"Poison"},
+		AIProfile:      AISupport,
+		BaseExperience: 180, ExperiencePerLevel: 18,
+		BaseCoins: 170, CoinsPerLevel: 17,
+		LootTable: lootStrong,
+	},
+	{
+		Name:        "Lich",
+		Description: "An undying sorcerer wreathed in frost.",
+		MinLevel:    8, MaxLevel: 10, DefaultLevel: 9,
+		BaseStats:   Stats{Vitality: 70, Durability: 55, Speed: 50, Strength: 30, Intelligence: 105, Mana: 100, Mastery: 80},
+		GrowthStats: Stats{Vitality: 6, Durability: 5, Speed: 4, Strength: 2, Intelligence: 10, Mana: 10, Mastery: 7},
+		Element:     "Frost", Race: "Undead",
+		Skills: []Skill{
+			{Name: "Glacial Spike", Kind: SkillMagic, Power: 1.5, ManaCost: 25, Effect: "Freeze", EffectChance: 30, EffectTurns: 2},
+		},
+		Immunities:     []string{"Poison", "Freeze"},
+		AIProfile:      AICaster,
+		BaseExperience: 200, ExperiencePerLevel: 20,
+		BaseCoins: 190, CoinsPerLevel: 19,
+		LootTable: lootStrong,
+		Escorts:   []string{"Necromancer"},
+	},
+	{
+		Name:        "Ancient Guardian",
+		Description: "A crystal sentinel that has stood watch for a thousand years.",
+		MinLevel:    8, MaxLevel: 10, DefaultLevel: 9,
+		BaseStats:   Stats{Vitality: 100, Durability: 100, Speed: 30, Strength: 75, Intelligence: 75, Mana: 70, Mastery: 70},
+		GrowthStats: Stats{Vitality: 9, Durability: 9, Speed: 2, Strength: 6, Intelligence: 6, Mana: 6, Mastery: 6},
+		Element:     "Crystal", Race: "Construct",
+		Skills: []Skill{
+			{Name: "Crystal Barrage", Kind: SkillMagic, Power: 1.5, ManaCost: 20},
+		},
+		Immunities:     []string{"Poison", "Stun"},
+		AIProfile:      AIDefensive,
+		BaseExperience: 210, ExperiencePerLevel: 20,
+		BaseCoins: 200, CoinsPerLevel: 20,
+		LootTable: lootStrong,
+	},
+	{
+		Name:        "Shadow Drake",
+		Description: "A wingless dragon that hunts in the deep dark.",
+		MinLevel:    8, MaxLevel: 10, DefaultLevel: 9,
+		BaseStats:   Stats{Vitality: 85, Durability: 70, Speed: 65, Strength: 90, Intelligence: 70, Mana: 60, Mastery: 70},
+		GrowthStats: Stats{Vitality: 8, Durability: 7, Speed: 5, Strength: 9, Intelligence: 6, Mana: 5, Mastery: 6},
+		Element:     "Dark", Race: "Dragonborn",
+		Skills: []Skill{
+			{Name: "Umbral Fang", Kind: SkillPhysical, Power: 1.6, Effect: "Poison", EffectChance: 20, EffectTurns: 3},
+		},
+		AIProfile:      AIAggressive,
+		BaseExperience: 220, ExperiencePerLevel: 21,
+		BaseCoins: 210, CoinsPerLevel: 21,
+		LootTable: lootStrong,
+	},
+	{
+		Name:        "Dragon Lord",
+		Description: "The ancient ruler of dragonkind.",
+		MinLevel:    9, MaxLevel: 10, DefaultLevel: 10,
+		BaseStats:   Stats{Vitality: 120, Durability: 90, Speed: 60, Strength: 100, Intelligence: 100, Mana: 90, Mastery: 85},
+		GrowthStats: Stats{Vitality: 10, Durability: 8, Speed: 5, Strength: 9, Intelligence: 9, Mana: 8, Mastery: 7},
+		Element:     "Fire", Race: "Dragonborn",
+		Skills: []Skill{
+			{Name: "Inferno", Kind: SkillMagic, Power: 1.8, ManaCost: 30, Effect: "Burn", EffectChance: 50, EffectTurns: 3},
+			{Name: "Tail Sweep", Kind: SkillPhysical, Power: 1.5, Effect: "Stun", EffectChance: 25, EffectTurns: 1},
+		},
+		Immunities: []string{"Burn", "Stun"},
+		Phases: []Phase{
+			{HPPercent: 100, Name: "Sovereign", AIProfile: AICaster},
+			{HPPercent: 60, Name: "Takes Flight", Message: "The Dragon Lord rises into the air!", DamageBoost: 0.15, AIProfile: AIAggressive},
+			{HPPercent: 25, Name: "Dragon's Fury", Message: "Molten scales crack open with rage!", DamageBoost: 0.3, AIProfile: AIAggressive},
+		},
+		AIProfile:      AIBoss,
+		BaseExperience: 300, ExperiencePerLevel: 25,
+		BaseCoins: 300, CoinsPerLevel: 25,
+		LootTable: lootBoss,
+	},
+
+	// Dungeon bosses can't be challenged directly
+	{
+		Name:        "Goblin King",
+		Description: "The crowned tyrant of the upper tunnels.",
+		MinLevel:    1, MaxLevel: 50, DefaultLevel: 8,
+		BaseStats:   Stats{Vitality: 80, Durability: 55, Speed: 60, Strength: 75, Intelligence: 40, Mana: 40, Mastery: 60},
+		GrowthStats: Stats{Vitality: 8, Durability: 5, Speed: 5, Strength: 7, Intelligence: 3, Mana: 3, Mastery: 5},
+		Element:     "Earth", Race: "Goblin",
+		Skills: []Skill{
+			{Name: "Royal Decree", Kind: SkillPhysical, Power: 1.6, Effect: "Stun", EffectChance: 20, EffectTurns: 1},
+			{Name: "Poisoned Crown", Kind: SkillPhysical, Power: 1.2, Effect: "Poison", EffectChance: 50, EffectTurns: 3},
+		},
+		Phases: []Phase{
+			{HPPercent: 100, Name: "Court", AIProfile: AIBalanced},
+			{HPPercent: 50, Name: "Royal Rage", Message: "The Goblin King hurls his crown aside!", DamageBoost: 0.2, AIProfile: AIAggressive},
+		},
+		AIProfile: AIBoss, Boss: true,
+		BaseExperience: 250, ExperiencePerLevel: 20,
+		BaseCoins: 250, CoinsPerLevel: 20,
+		LootTable: lootBoss,
+	},
+	{
+		Name:        "Bone Colossus",
+		Description: "A towering heap of fused skeletons.",
+		MinLevel:    1, MaxLevel: 50, DefaultLevel: 13,
+		BaseStats:   Stats{Vitality: 110, Durability: 85, Speed: 25, Strength: 85, Intelligence: 30, Mana: 30, Mastery: 55},
+		GrowthStats: Stats{Vitality: 10, Durability: 8, Speed: 2, Strength: 8, Intelligence: 2, Mana: 2, Mastery: 5},
+		Element:     "Dark", Race: "Skeleton",
+		Skills: []Skill{
+			{Name: "Ossuary Slam", Kind: SkillPhysical, Power: 1.7, Effect: "Stun", EffectChance: 30, EffectTurns: 1},
+		},
+		Immunities: []string{"Poison", "Burn"},
+		Phases: []Phase{
+			{HPPercent: 100, Name: "Bulwark", AIProfile: AIDefensive},
+			{HPPercent: 40, Name: "Collapse", Message: "Bones tumble loose as the Colossus lashes out!", DamageBoost: 0.3, AIProfile: AIAggressive},
+		},
+		AIProfile: AIBoss, Boss: true,
+		BaseExperience: 280, ExperiencePerLevel: 22,
+		BaseCoins: 280, CoinsPerLevel: 22,
+		LootTable: lootBoss,
+	},
+	{
+		Name:        "Troll Warlord",
+		Description: "A scarred troll chieftain that refuses to stay down.",
+		MinLevel:    1, MaxLevel: 50, DefaultLevel: 18,
+		BaseStats:   Stats{Vitality: 130, Durability: 80, Speed: 35, Strength: 100, Intelligence: 25, Mana: 60, Mastery: 60},
+		GrowthStats: Stats{Vitality: 11, Durability: 7, Speed: 3, Strength: 9, Intelligence: 1, Mana: 4, Mastery: 5},
+		Element:     "Earth", Race: "Giant",
+		Skills: []Skill{
+			{Name: "Warlord's Cleave", Kind: SkillPhysical, Power: 1.7},
+			{Name: "Troll Blood", Kind: SkillHeal, Power: 0.25, ManaCost: 20},
+		},
+		Immunities: []string{"Poison"},
+		Phases: []Phase{
+			{HPPercent: 100, Name: "Warpath", AIProfile: AIAggressive},
+			{HPPercent: 50, Name: "Blood Frenzy", Message: "The Warlord's wounds begin to knit!", DamageBoost: 0.1, AIProfile: AIDefensive},
+			{HPPercent: 20, Name: "Last Stand", Message: "The Warlord roars and charges!", DamageBoost: 0.3, AIProfile: AIAggressive},
+		},
+		AIProfile: AIBoss, Boss: true,
+		BaseExperience: 320, ExperiencePerLevel: 24,
+		BaseCoins: 320, CoinsPerLevel: 24,
+		LootTable: lootBoss,
+	},
+	{
+		Name:        "Elder Necromancer",
+		Description: "Master of the crypts, commanding death itself.",
+		MinLevel:    1, MaxLevel: 50, DefaultLevel: 23,
+		BaseStats:   Stats{Vitality: 100, Durability: 60, Speed: 60, Strength: 40, Intelligence: 120, Mana: 120, Mastery: 85},
+		GrowthStats: Stats{Vitality: 8, Durability: 5, Speed: 5, Strength: 2, Intelligence: 11, Mana: 11, Mastery: 7},
+		Element:     "Dark", Race: "Undead",
+		Skills: []Skill{
+			{Name: "Death Coil", Kind: SkillMagic, Power: 1.7, ManaCost: 30, Effect: "Poison", EffectChance: 40, EffectTurns: 3},
+			{Name: "Unholy Renewal", Kind: SkillHeal, Power: 0.3, ManaCost: 35},
+		},
+		Immunities: []string{"Poison", "Stun"},
+		Phases: []Phase{
+			{HPPercent: 100, Name: "Incantation", AIProfile: AICaster},
+			{HPPercent: 50, Name: "Deathly Ritual", Message: "Dark energy knits the Necromancer's flesh!", AIProfile: AISupport},
+			{HPPercent: 25, Name: "Lichdom", Message: "The Elder Necromancer sheds its mortal shell!", DamageBoost: 0.3, AIProfile: AICaster},
+		},
+		AIProfile: AIBoss, Boss: true,
+		BaseExperience: 360, ExperiencePerLevel: 26,
+		BaseCoins: 360, CoinsPerLevel: 26,
+		LootTable: lootBoss,
+	},
+}
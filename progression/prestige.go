@@ -0,0 +1,56 @@
+package progression
+
+import (
+	"CrispyBot/database/models"
+	"CrispyBot/variables"
+	"fmt"
+)
+
+// prestigeBadges are the badge titles unlocked at each prestige level, highest first
+var prestigeBadges = []struct {
+	Level int
+	Title string
+}{
+	{Level: 10, Title: "💎 Eternal"},
+	{Level: 5, Title: "🥇 Exalted"},
+	{Level: 3, Title: "🥈 Ascended"},
+	{Level: 1, Title: "🥉 Reborn"},
+}
+
+// CanRebirth checks that a character is high enough level to rebirth
+func CanRebirth(characterLevel int) error {
+	if characterLevel < variables.PrestigeMinLevel {
+		return fmt.Errorf("your character must reach level %d to rebirth (currently %d)", variables.PrestigeMinLevel, characterLevel)
+	}
+	return nil
+}
+
+// PerksFor returns the prestige perks granted at a prestige level
+func PerksFor(level int) models.Prestige {
+	return models.Prestige{
+		Level:            level,
+		StatBonusPercent: min(level*variables.PrestigeStatBonusPercent, variables.PrestigeMaxStatBonusPercent),
+		BonusRerolls:     min(level*variables.PrestigeRerollsPerLevel, variables.PrestigeMaxBonusRerolls),
+		WeaponLuck:       min(level*variables.PrestigeWeaponLuck, variables.PrestigeMaxWeaponLuck),
+	}
+}
+
+// PrestigeBadge returns the badge shown for a prestige level, or "" before the first rebirth
+func PrestigeBadge(level int) string {
+	for _, badge := range prestigeBadges {
+		if level >= badge.Level {
+			return fmt.Sprintf("%s (Prestige %d)", badge.Title, level)
+		}
+	}
+	return ""
+}
+
+// ApplyPrestigeBonus adds the prestige stat bonus on top of a sheet's other bonuses
+func ApplyPrestigeBonus(stats *models.StatsSheets, percent int) {
+	for _, name := range StatNames {
+		stat := StatByName(stats, name)
+		stat.TotalValue -= stat.PrestigeBonus
+		stat.PrestigeBonus = stat.TotalValue * percent / 100
+		stat.TotalValue += stat.PrestigeBonus
+	}
+}
@@ -0,0 +1,49 @@
+package progression
+
+import (
+	"CrispyBot/database/models"
+	"CrispyBot/variables"
+	"testing"
+)
+
+func TestCanRebirth(t *testing.T) {
+	if err := CanRebirth(variables.PrestigeMinLevel - 1); err == nil {
+		t.Errorf("Expected rebirth below level %d to fail", variables.PrestigeMinLevel)
+	}
+	if err := CanRebirth(variables.PrestigeMinLevel); err != nil {
+		t.Errorf("Expected rebirth at level %d to succeed, got %v", variables.PrestigeMinLevel, err)
+	}
+}
+
+func TestPerksFor_Capped(t *testing.T) {
+	perks := PerksFor(1000)
+	if perks.StatBonusPercent != variables.PrestigeMaxStatBonusPercent ||
+		perks.BonusRerolls != variables.PrestigeMaxBonusRerolls ||
+		perks.WeaponLuck != variables.PrestigeMaxWeaponLuck {
+		t.Errorf("Expected perks at their caps, got %+v", perks)
+	}
+	if perks := PerksFor(0); perks.StatBonusPercent != 0 || perks.BonusRerolls != 0 || perks.WeaponLuck != 0 {
+		t.Errorf("Expected no perks before prestige, got %+v", perks)
+	}
+}
+
+func TestPrestigeBadge(t *testing.T) {
+	if badge := PrestigeBadge(0); badge != "" {
+		t.Errorf("Expected no badge before prestige, got %q", badge)
+	}
+	if PrestigeBadge(1) == PrestigeBadge(10) {
+		t.Errorf("Expected higher prestige to show a different badge")
+	}
+}
+
+func TestApplyPrestigeBonus_Idempotent(t *testing.T) {
+	stats := models.StatsSheets{}
+	stats.Strength.TotalValue = 100
+
+	ApplyPrestigeBonus(&stats, 10)
+	ApplyPrestigeBonus(&stats, 10)
+
+	if stats.Strength.PrestigeBonus != 10 || stats.Strength.TotalValue != 110 {
+		t.Errorf("Expected +10 Strength once, got bonus %d total %d", stats.Strength.PrestigeBonus, stats.Strength.TotalValue)
+	}
+}
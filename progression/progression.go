@@ -0,0 +1,175 @@
+package progression
+
+import (
+	"CrispyBot/database/models"
+	"CrispyBot/variables"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// StatNames are the stats level points can be spent on, in display order
+var StatNames = []string{"Vitality", "Strength", "Speed", "Durability", "Intelligence", "Mana", "Mastery"}
+
+// PointsEarned returns the stat points a character has earned by reaching a level
+func PointsEarned(level int) int {
+	if level <= 1 {
+		return 0
+	}
+	return (level - 1) * variables.StatPointsPerLevel
+}
+
+// PointsSpent returns the stat points already allocated across a stat sheet
+func PointsSpent(stats models.StatsSheets) int {
+	spent := 0
+	for _, name := range StatNames {
+		spent += StatByName(&stats, name).LevelBonus
+	}
+	return spent
+}
+
+// UnspentPoints returns the stat points a character can still allocate
+func UnspentPoints(character models.Character) int {
+	unspent := PointsEarned(character.Level) - PointsSpent(character.Stats)
+	if unspent < 0 {
+		return 0
+	}
+	return unspent
+}
+
+// ParseStatName matches a stat name ignoring case and returns its canonical form
+func ParseStatName(name string) (string, bool) {
+	for _, statName := range StatNames {
+		if strings.EqualFold(statName, name) {
+			return statName, true
+		}
+	}
+	return "", false
+}
+
+// StatByName returns the stat on a sheet with the given canonical name, or nil if unknown
+func StatByName(stats *models.StatsSheets, name string) *models.Stat {
+	switch name {
+	case "Vitality":
+		return &stats.Vitality
+	case "Strength":
+		return &stats.Strength
+	case "Speed":
+		return &stats.Speed
+	case "Durability":
+		return &stats.Durability
+	case "Intelligence":
+		return &stats.Intelligence
+	case "Mana":
+		return &stats.Mana
+	case "Mastery":
+		return &stats.Mastery
+	}
+	return nil
+}
+
+// Room returns how many more level points a stat can take before hitting the stat cap
+func Room(stat models.Stat) int {
+	room := variables.MaxStatValue - stat.Value - stat.LevelBonus
+	if room < 0 {
+		return 0
+	}
+	return room
+}
+
+// ValidateAllocation checks that points can be spent on a stat
+func ValidateAllocation(character models.Character, statName string, points int) error {
+	if points <= 0 {
+		return fmt.Errorf("you must allocate at least 1 point")
+	}
+
+	stat := StatByName(&character.Stats, statName)
+	if stat == nil {
+		return fmt.Errorf("unknown stat %q", statName)
+	}
+
+	unspent := UnspentPoints(character)
+	if points > unspent {
+		return fmt.Errorf("you only have %d unspent stat points", unspent)
+	}
+	if points > Room(*stat) {
+		return fmt.Errorf("%s can only take %d more points before reaching the cap of %d", statName, Room(*stat), variables.MaxStatValue)
+	}
+
+	return nil
+}
+
+// GrowthCurve returns how strongly each stat grows for a race
+// Note: Every stat has a base weight, race buffs raise it and weaknesses lower it.
+func GrowthCurve(race models.Characteristic) map[string]int {
+	curve := make(map[string]int, len(StatNames))
+	for _, name := range StatNames {
+		weight := variables.GrowthBaseWeight + race.Stats_Value[name]/variables.GrowthWeightDivisor
+		if weight < 1 {
+			weight = 1
+		}
+		curve[name] = weight
+	}
+	return curve
+}
+
+// AutoAllocate spreads points along the race's growth curve, skipping stats at the cap
+// Note: Returns the points to add to each stat. Points that fit nowhere are left out.
+func AutoAllocate(character models.Character, points int) map[string]int {
+	allocation := make(map[string]int)
+	curve := GrowthCurve(character.Characteristics.Race)
+
+	room := make(map[string]int, len(StatNames))
+	for _, name := range StatNames {
+		room[name] = Room(*StatByName(&character.Stats, name))
+	}
+
+	for points > 0 {
+		// Split what's left by weight among stats that still have room
+		totalWeight := 0
+		for _, name := range StatNames {
+			if room[name] > 0 {
+				totalWeight += curve[name]
+			}
+		}
+		if totalWeight == 0 {
+			break
+		}
+
+		given := 0
+		for _, name := range StatNames {
+			if room[name] == 0 {
+				continue
+			}
+			share := min(points*curve[name]/totalWeight, room[name])
+			allocation[name] += share
+			room[name] -= share
+			given += share
+		}
+
+		// Hand leftovers one at a time to the heaviest stats
+		if given == 0 {
+			for _, name := range byWeight(curve) {
+				if room[name] > 0 {
+					allocation[name]++
+					room[name]--
+					given = 1
+					break
+				}
+			}
+		}
+
+		points -= given
+	}
+
+	return allocation
+}
+
+// byWeight returns stat names from the heaviest growth weight down, keeping display order on ties
+func byWeight(curve map[string]int) []string {
+	names := append([]string(nil), StatNames...)
+	sort.SliceStable(names, func(i, j int) bool {
+		return curve[names[i]] > curve[names[j]]
+	})
+	return names
+}
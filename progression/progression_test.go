@@ -0,0 +1,81 @@
+package progression
+
+import (
+	"CrispyBot/database/models"
+	"CrispyBot/variables"
+	"testing"
+)
+
+func newTestCharacter(level int) models.Character {
+	character := models.Character{Level: level}
+	for _, name := range StatNames {
+		StatByName(&character.Stats, name).Value = 100
+	}
+	return character
+}
+
+func TestUnspentPoints_SubtractsAllocated(t *testing.T) {
+	character := newTestCharacter(5)
+	character.Stats.Strength.LevelBonus = 2
+
+	expected := 4*variables.StatPointsPerLevel - 2
+	if unspent := UnspentPoints(character); unspent != expected {
+		t.Errorf("Expected %d unspent points, got %d", expected, unspent)
+	}
+	if unspent := UnspentPoints(newTestCharacter(1)); unspent != 0 {
+		t.Errorf("Expected no points at level 1, got %d", unspent)
+	}
+}
+
+func TestValidateAllocation(t *testing.T) {
+	character := newTestCharacter(3)
+	if err := ValidateAllocation(character, "Strength", UnspentPoints(character)); err != nil {
+		t.Errorf("Expected allocation to be valid, got %v", err)
+	}
+	if err := ValidateAllocation(character, "Strength", UnspentPoints(character)+1); err == nil {
+		t.Errorf("Expected overspending to fail")
+	}
+	if err := ValidateAllocation(character, "Height", 1); err == nil {
+		t.Errorf("Expected unknown stat to fail")
+	}
+
+	character.Stats.Speed.Value = variables.MaxStatValue
+	if err := ValidateAllocation(character, "Speed", 1); err == nil {
+		t.Errorf("Expected capped stat to fail")
+	}
+}
+
+func TestGrowthCurve_FollowsRace(t *testing.T) {
+	race := models.Characteristic{Stats_Value: map[string]int{"Durability": 75, "Speed": -75}}
+	curve := GrowthCurve(race)
+
+	if curve["Durability"] <= curve["Vitality"] {
+		t.Errorf("Expected buffed stat to grow faster, got %v", curve)
+	}
+	if curve["Speed"] < 1 || curve["Speed"] >= curve["Vitality"] {
+		t.Errorf("Expected weakened stat to grow slower but still grow, got %v", curve)
+	}
+}
+
+func TestAutoAllocate_SpendsAllPointsWithinCaps(t *testing.T) {
+	character := newTestCharacter(10)
+	character.Characteristics.Race = models.Characteristic{Stats_Value: map[string]int{"Strength": 75}}
+	character.Stats.Mana.Value = variables.MaxStatValue
+
+	points := UnspentPoints(character)
+	allocation := AutoAllocate(character, points)
+
+	total := 0
+	for _, given := range allocation {
+		total += given
+	}
+	if total != points {
+		t.Errorf("Expected %d points allocated, got %d (%v)", points, total, allocation)
+	}
+	if allocation["Mana"] != 0 {
+		t.Errorf("Expected capped stat to get nothing, got %d", allocation["Mana"])
+	}
+	if allocation["Strength"] <= allocation["Vitality"] {
+		t.Errorf("Expected Strength to get the most points, got %v", allocation)
+	}
+}
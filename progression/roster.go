@@ -0,0 +1,28 @@
+package progression
+
+import (
+	"CrispyBot/variables"
+	"fmt"
+)
+
+// CharacterSlots returns how many characters a user can hold at a prestige level with purchased slots
+func CharacterSlots(prestigeLevel int, purchased int) int {
+	prestigeSlots := min(prestigeLevel/variables.PrestigeLevelsPerSlot, variables.PrestigeMaxBonusSlots)
+	return variables.CharacterBaseSlots + prestigeSlots + min(purchased, variables.CharacterMaxPurchasedSlots)
+}
+
+// CanBuySlot checks that a user hasn't bought every purchasable slot yet
+func CanBuySlot(purchased int) error {
+	if purchased >= variables.CharacterMaxPurchasedSlots {
+		return fmt.Errorf("you already bought the maximum of %d extra character slots", variables.CharacterMaxPurchasedSlots)
+	}
+	return nil
+}
+
+// RosterIndex converts a 1-based roster position typed by a player into a slice index
+func RosterIndex(position int, rosterSize int) (int, error) {
+	if position < 1 || position > rosterSize {
+		return 0, fmt.Errorf("pick a character between 1 and %d", rosterSize)
+	}
+	return position - 1, nil
+}
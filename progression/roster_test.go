@@ -0,0 +1,40 @@
+package progression
+
+import (
+	"CrispyBot/variables"
+	"testing"
+)
+
+func TestCharacterSlots(t *testing.T) {
+	if slots := CharacterSlots(0, 0); slots != variables.CharacterBaseSlots {
+		t.Errorf("Expected %d base slots, got %d", variables.CharacterBaseSlots, slots)
+	}
+	if slots := CharacterSlots(variables.PrestigeLevelsPerSlot, 1); slots != variables.CharacterBaseSlots+2 {
+		t.Errorf("Expected one prestige slot and one bought slot, got %d", slots)
+	}
+
+	max := variables.CharacterBaseSlots + variables.PrestigeMaxBonusSlots + variables.CharacterMaxPurchasedSlots
+	if slots := CharacterSlots(1000, 1000); slots != max {
+		t.Errorf("Expected slots capped at %d, got %d", max, slots)
+	}
+}
+
+func TestCanBuySlot(t *testing.T) {
+	if err := CanBuySlot(0); err != nil {
+		t.Errorf("Expected the first slot to be purchasable, got %v", err)
+	}
+	if err := CanBuySlot(variables.CharacterMaxPurchasedSlots); err == nil {
+		t.Errorf("Expected buying past the cap to fail")
+	}
+}
+
+func TestRosterIndex(t *testing.T) {
+	if index, err := RosterIndex(2, 3); err != nil || index != 1 {
+		t.Errorf("Expected position 2 to be index 1, got %d (%v)", index, err)
+	}
+	for _, position := range []int{0, 4, -1} {
+		if _, err := RosterIndex(position, 3); err == nil {
+			t.Errorf("Expected position %d to be rejected", position)
+		}
+	}
+}
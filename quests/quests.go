@@ -0,0 +1,107 @@
+package quests
+
+import (
+	"CrispyBot/achievements"
+	"CrispyBot/database/models"
+	"CrispyBot/shop"
+	"hash/fnv"
+	"math/rand"
+	"time"
+)
+
+// Quest periods
+const (
+	PeriodDaily  = "daily"
+	PeriodWeekly = "weekly"
+)
+
+// Template describes a quest that can be assigned to players
+type Template struct {
+	ID          string
+	Description string
+	Period      string
+	Event       string            // Event type that advances the quest
+	Filter      map[string]string // Attributes an event must have to count
+	MinValue    int               // Lowest event value that counts, for floors and levels
+	Target      int
+	Reward      achievements.Reward
+}
+
+// Matches checks if an event counts toward the quest
+func (t Template) Matches(event achievements.Event) bool {
+	return event.Satisfies(t.Event, t.Filter) && event.Value >= t.MinValue
+}
+
+// Find looks up a quest template by ID
+func Find(id string) (Template, bool) {
+	for _, template := range Templates {
+		if template.ID == id {
+			return template, true
+		}
+	}
+	return Template{}, false
+}
+
+// PeriodStart returns the reset boundary the period is currently in
+// Note: Weeks start at the daily reset on Monday.
+func PeriodStart(period string, now time.Time) time.Time {
+	start := shop.CurrentPeriodStart(now)
+	if period == PeriodWeekly {
+		daysSinceMonday := (int(start.Weekday()) + 6) % 7
+		start = start.AddDate(0, 0, -daysSinceMonday)
+	}
+	return start
+}
+
+// NextReset returns when the period's quests are next replaced
+func NextReset(period string, now time.Time) time.Time {
+	if period == PeriodWeekly {
+		return PeriodStart(period, now).AddDate(0, 0, 7)
+	}
+	return shop.NextRefreshTime(now)
+}
+
+// Assign picks the player's quests for a period
+// Note: The pick is seeded by the player and period so it rotates every reset but is stable within one.
+func Assign(userID string, period string, periodStart time.Time, count int) models.QuestSet {
+	var pool []Template
+	for _, template := range Templates {
+		if template.Period == period {
+			pool = append(pool, template)
+		}
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(userID + period))
+	rng := rand.New(rand.NewSource(int64(hash.Sum64()) ^ periodStart.Unix()))
+	rng.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+
+	set := models.QuestSet{PeriodStart: periodStart}
+	for _, template := range pool[:min(count, len(pool))] {
+		set.Quests = append(set.Quests, models.Quest{TemplateID: template.ID})
+	}
+	return set
+}
+
+// Advance applies an event to the quests in a set and returns the templates it completed
+func Advance(set *models.QuestSet, event achievements.Event, now time.Time) []Template {
+	var completed []Template
+	for i := range set.Quests {
+		quest := &set.Quests[i]
+		if !quest.CompletedAt.IsZero() {
+			continue
+		}
+
+		template, ok := Find(quest.TemplateID)
+		if !ok || !template.Matches(event) {
+			continue
+		}
+
+		quest.Progress++
+		if quest.Progress >= template.Target {
+			quest.CompletedAt = now
+			completed = append(completed, template)
+		}
+	}
+	return completed
+}
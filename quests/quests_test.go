@@ -0,0 +1,97 @@
+package quests
+
+import (
+	"CrispyBot/achievements"
+	"CrispyBot/database/models"
+	"testing"
+	"time"
+)
+
+func TestAssign_StableWithinPeriod(t *testing.T) {
+	start := PeriodStart(PeriodDaily, time.Now())
+
+	first := Assign("user", PeriodDaily, start, 3)
+	second := Assign("user", PeriodDaily, start, 3)
+	if len(first.Quests) != 3 {
+		t.Fatalf("Expected 3 quests, got %d", len(first.Quests))
+	}
+	for i := range first.Quests {
+		if first.Quests[i].TemplateID != second.Quests[i].TemplateID {
+			t.Errorf("Assignment should be stable within a period")
+		}
+	}
+
+	// Every assigned quest must come from the period's pool without repeats
+	seen := make(map[string]bool)
+	for _, quest := range first.Quests {
+		template, ok := Find(quest.TemplateID)
+		if !ok || template.Period != PeriodDaily {
+			t.Errorf("Assigned %s is not a daily quest", quest.TemplateID)
+		}
+		if seen[quest.TemplateID] {
+			t.Errorf("Quest %s assigned twice", quest.TemplateID)
+		}
+		seen[quest.TemplateID] = true
+	}
+}
+
+func TestAssign_Rotates(t *testing.T) {
+	start := PeriodStart(PeriodDaily, time.Now())
+
+	// Over a couple of weeks the same player should see different sets
+	sets := make(map[string]bool)
+	for day := 0; day < 14; day++ {
+		set := Assign("user", PeriodDaily, start.AddDate(0, 0, day), 3)
+		key := ""
+		for _, quest := range set.Quests {
+			key += quest.TemplateID + ","
+		}
+		sets[key] = true
+	}
+	if len(sets) < 2 {
+		t.Errorf("Expected quests to rotate between days")
+	}
+}
+
+func TestAdvance(t *testing.T) {
+	set := models.QuestSet{Quests: []models.Quest{
+		{TemplateID: "daily_magic_3"},
+		{TemplateID: "daily_troll"},
+		{TemplateID: "daily_buy_epic"},
+	}}
+	now := time.Now()
+
+	Advance(&set, achievements.BattleWonEvent("Earth", "Troll", "attack"), now)
+	if set.Quests[0].Progress != 0 {
+		t.Errorf("A physical finish should not count toward magic wins")
+	}
+	if set.Quests[1].CompletedAt.IsZero() {
+		t.Errorf("Expected the Troll quest to complete")
+	}
+
+	for i := 0; i < 3; i++ {
+		Advance(&set, achievements.BattleWonEvent("Fire", "Goblin", "magic"), now)
+	}
+	if set.Quests[0].CompletedAt.IsZero() {
+		t.Errorf("Expected three magic wins to complete the quest")
+	}
+
+	completed := Advance(&set, achievements.BattleWonEvent("Earth", "Troll", "magic"), now)
+	if len(completed) != 0 {
+		t.Errorf("Completed quests should not complete again, got %v", completed)
+	}
+
+	if completed = Advance(&set, achievements.PurchaseEvent("Epic", 100), now); len(completed) != 1 {
+		t.Errorf("Expected buying an Epic item to complete its quest")
+	}
+}
+
+func TestPeriodStart_WeekStartsMonday(t *testing.T) {
+	start := PeriodStart(PeriodWeekly, time.Now())
+	if start.Weekday() != time.Monday {
+		t.Errorf("Expected the week to start on Monday, got %s", start.Weekday())
+	}
+	if !NextReset(PeriodWeekly, time.Now()).Equal(start.AddDate(0, 0, 7)) {
+		t.Errorf("Weekly reset should be a week after the start")
+	}
+}
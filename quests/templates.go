@@ -0,0 +1,131 @@
+package quests
+
+import "CrispyBot/achievements"
+
+// Templates lists every quest that can be assigned
+var Templates = []Template{
+	// Daily quests
+	{
+		ID:          "daily_win_3",
+		Description: "Win 3 battles",
+		Period:      PeriodDaily,
+		Event:       achievements.EventBattleWon,
+		Target:      3,
+		Reward:      achievements.Reward{Coins: 100},
+	},
+	{
+		ID:          "daily_magic_3",
+		Description: "Win 3 battles with magic",
+		Period:      PeriodDaily,
+		Event:       achievements.EventBattleWon,
+		Filter:      map[string]string{achievements.AttrFinisher: "magic"},
+		Target:      3,
+		Reward:      achievements.Reward{Coins: 150},
+	},
+	{
+		ID:          "daily_troll",
+		Description: "Defeat a Troll",
+		Period:      PeriodDaily,
+		Event:       achievements.EventBattleWon,
+		Filter:      map[string]string{achievements.AttrNPC: "Troll"},
+		Target:      1,
+		Reward:      achievements.Reward{Coins: 150},
+	},
+	{
+		ID:          "daily_goblins",
+		Description: "Defeat 2 Goblins",
+		Period:      PeriodDaily,
+		Event:       achievements.EventBattleWon,
+		Filter:      map[string]string{achievements.AttrNPC: "Goblin"},
+		Target:      2,
+		Reward:      achievements.Reward{Coins: 75},
+	},
+	{
+		ID:          "daily_pvp",
+		Description: "Win a battle against another player",
+		Period:      PeriodDaily,
+		Event:       achievements.EventBattleWon,
+		Filter:      map[string]string{achievements.AttrOpponent: "player"},
+		Target:      1,
+		Reward:      achievements.Reward{Coins: 150},
+	},
+	{
+		ID:          "daily_buy",
+		Description: "Buy an item from the shop",
+		Period:      PeriodDaily,
+		Event:       achievements.EventItemBought,
+		Target:      1,
+		Reward:      achievements.Reward{Coins: 50},
+	},
+	{
+		ID:          "daily_buy_epic",
+		Description: "Buy an Epic item",
+		Period:      PeriodDaily,
+		Event:       achievements.EventItemBought,
+		Filter:      map[string]string{achievements.AttrRarity: "Epic"},
+		Target:      1,
+		Reward:      achievements.Reward{RerollTokens: 1},
+	},
+	{
+		ID:          "daily_rerollstat",
+		Description: "Reroll a stat",
+		Period:      PeriodDaily,
+		Event:       achievements.EventCharacterRolled,
+		Filter:      map[string]string{achievements.AttrSource: achievements.SourceRerollStat},
+		Target:      1,
+		Reward:      achievements.Reward{Coins: 75},
+	},
+
+	// Weekly quests
+	{
+		ID:          "weekly_win_20",
+		Description: "Win 20 battles",
+		Period:      PeriodWeekly,
+		Event:       achievements.EventBattleWon,
+		Target:      20,
+		Reward:      achievements.Reward{Coins: 750, RerollTokens: 1},
+	},
+	{
+		ID:          "weekly_magic_10",
+		Description: "Win 10 battles with magic",
+		Period:      PeriodWeekly,
+		Event:       achievements.EventBattleWon,
+		Filter:      map[string]string{achievements.AttrFinisher: "magic"},
+		Target:      10,
+		Reward:      achievements.Reward{Coins: 600},
+	},
+	{
+		ID:          "weekly_dungeon_5",
+		Description: "Clear dungeon floor 5",
+		Period:      PeriodWeekly,
+		Event:       achievements.EventDungeonFloor,
+		MinValue:    5,
+		Target:      1,
+		Reward:      achievements.Reward{Coins: 500},
+	},
+	{
+		ID:          "weekly_buy_3",
+		Description: "Buy 3 items from the shop",
+		Period:      PeriodWeekly,
+		Event:       achievements.EventItemBought,
+		Target:      3,
+		Reward:      achievements.Reward{Coins: 300},
+	},
+	{
+		ID:          "weekly_full_reroll",
+		Description: "Reroll your character 3 times",
+		Period:      PeriodWeekly,
+		Event:       achievements.EventCharacterRolled,
+		Filter:      map[string]string{achievements.AttrSource: achievements.SourceReroll},
+		Target:      3,
+		Reward:      achievements.Reward{RerollTokens: 2},
+	},
+	{
+		ID:          "weekly_daily_5",
+		Description: "Claim your daily reward 5 times",
+		Period:      PeriodWeekly,
+		Event:       achievements.EventDailyClaimed,
+		Target:      5,
+		Reward:      achievements.Reward{Coins: 400},
+	},
+}
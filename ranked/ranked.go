@@ -0,0 +1,91 @@
+package ranked
+
+import (
+	"CrispyBot/variables"
+	"math"
+)
+
+// Rank Tier Model
+/*
+	Name - Display name of the tier.
+	MinRating - Lowest rating in the tier.
+	SeasonCoins - Coins paid at season end for finishing in the tier.
+	SeasonTokens - Reroll tokens paid at season end for finishing in the tier.
+*/
+type Tier struct {
+	Name         string
+	MinRating    int
+	SeasonCoins  int
+	SeasonTokens int
+}
+
+// Tiers are the rank tiers ordered from highest to lowest
+var Tiers = []Tier{
+	{Name: "Master", MinRating: 1800, SeasonCoins: 10000, SeasonTokens: 10},
+	{Name: "Diamond", MinRating: 1600, SeasonCoins: 6000, SeasonTokens: 6},
+	{Name: "Platinum", MinRating: 1400, SeasonCoins: 3500, SeasonTokens: 4},
+	{Name: "Gold", MinRating: 1250, SeasonCoins: 2000, SeasonTokens: 2},
+	{Name: "Silver", MinRating: 1100, SeasonCoins: 1000, SeasonTokens: 1},
+	{Name: "Bronze", MinRating: 0, SeasonCoins: 500, SeasonTokens: 0},
+}
+
+// GetTier returns the tier for a rating
+func GetTier(rating int) Tier {
+	for _, tier := range Tiers {
+		if rating >= tier.MinRating {
+			return tier
+		}
+	}
+	return Tiers[len(Tiers)-1]
+}
+
+// ExpectedScore returns the Elo probability that a player rated `rating` beats `opponent`
+func ExpectedScore(rating int, opponent int) float64 {
+	return 1.0 / (1.0 + math.Pow(10, float64(opponent-rating)/400.0))
+}
+
+// CalculateRatingChange returns the rating deltas for the winner and loser of a match
+func CalculateRatingChange(winnerRating int, loserRating int, winnerPlacement bool, loserPlacement bool) (int, int) {
+	expectedWin := ExpectedScore(winnerRating, loserRating)
+
+	winnerK := float64(variables.RankedKFactor)
+	if winnerPlacement {
+		winnerK = variables.RankedPlacementKFactor
+	}
+
+	loserK := float64(variables.RankedKFactor)
+	if loserPlacement {
+		loserK = variables.RankedPlacementKFactor
+	}
+
+	// Always move at least one point so every match counts
+	winnerDelta := int(math.Round(winnerK * (1.0 - expectedWin)))
+	if winnerDelta < 1 {
+		winnerDelta = 1
+	}
+
+	loserDelta := -int(math.Round(loserK * (1.0 - expectedWin)))
+	if loserDelta > -1 {
+		loserDelta = -1
+	}
+
+	return winnerDelta, loserDelta
+}
+
+// SoftReset moves a previous season's rating halfway back to the starting rating
+func SoftReset(rating int) int {
+	return (rating + variables.RankedStartingRating) / 2
+}
+
+// DecayRating applies one day of inactivity decay without dropping below the floor
+func DecayRating(rating int) int {
+	if rating <= variables.RankedDecayFloor {
+		return rating
+	}
+
+	decayed := rating - variables.RankedDecayAmount
+	if decayed < variables.RankedDecayFloor {
+		return variables.RankedDecayFloor
+	}
+	return decayed
+}
@@ -0,0 +1,84 @@
+package ranked
+
+import (
+	"CrispyBot/variables"
+	"testing"
+)
+
+func TestGetTier_Boundaries(t *testing.T) {
+	cases := map[int]string{
+		0:    "Bronze",
+		1099: "Bronze",
+		1100: "Silver",
+		1250: "Gold",
+		1400: "Platinum",
+		1600: "Diamond",
+		2500: "Master",
+	}
+	for rating, expected := range cases {
+		if tier := GetTier(rating); tier.Name != expected {
+			t.Errorf("Rating %d: expected %s, got %s", rating, expected, tier.Name)
+		}
+	}
+}
+
+func TestExpectedScore_Symmetric(t *testing.T) {
+	a := ExpectedScore(1200, 1000)
+	b := ExpectedScore(1000, 1200)
+	if a+b < 0.999 || a+b > 1.001 {
+		t.Errorf("Expected scores should sum to 1, got %f", a+b)
+	}
+	if a <= 0.5 {
+		t.Errorf("Higher rated player should be favored, got %f", a)
+	}
+}
+
+func TestCalculateRatingChange_EvenMatch(t *testing.T) {
+	winnerDelta, loserDelta := CalculateRatingChange(1000, 1000, false, false)
+	if winnerDelta != variables.RankedKFactor/2 {
+		t.Errorf("Expected winner +%d, got %+d", variables.RankedKFactor/2, winnerDelta)
+	}
+	if loserDelta != -variables.RankedKFactor/2 {
+		t.Errorf("Expected loser -%d, got %+d", variables.RankedKFactor/2, loserDelta)
+	}
+}
+
+func TestCalculateRatingChange_Placement(t *testing.T) {
+	placementDelta, _ := CalculateRatingChange(1000, 1000, true, false)
+	normalDelta, _ := CalculateRatingChange(1000, 1000, false, false)
+	if placementDelta <= normalDelta {
+		t.Errorf("Placement matches should move rating more (%d <= %d)", placementDelta, normalDelta)
+	}
+}
+
+func TestCalculateRatingChange_Upset(t *testing.T) {
+	upsetDelta, _ := CalculateRatingChange(1000, 1600, false, false)
+	expectedDelta, _ := CalculateRatingChange(1600, 1000, false, false)
+	if upsetDelta <= expectedDelta {
+		t.Errorf("Upsets should be worth more (%d <= %d)", upsetDelta, expectedDelta)
+	}
+	if expectedDelta < 1 {
+		t.Errorf("Winner should always gain at least 1, got %d", expectedDelta)
+	}
+}
+
+func TestDecayRating_Floor(t *testing.T) {
+	if r := DecayRating(1000); r != 1000 {
+		t.Errorf("Ratings below the floor should not decay, got %d", r)
+	}
+	if r := DecayRating(variables.RankedDecayFloor + 10); r != variables.RankedDecayFloor {
+		t.Errorf("Decay should stop at the floor, got %d", r)
+	}
+	if r := DecayRating(2000); r != 2000-variables.RankedDecayAmount {
+		t.Errorf("Expected %d, got %d", 2000-variables.RankedDecayAmount, r)
+	}
+}
+
+func TestSoftReset(t *testing.T) {
+	if r := SoftReset(variables.RankedStartingRating); r != variables.RankedStartingRating {
+		t.Errorf("Starting rating should be unchanged, got %d", r)
+	}
+	if r := SoftReset(2000); r >= 2000 || r <= variables.RankedStartingRating {
+		t.Errorf("Soft reset should move toward the start, got %d", r)
+	}
+}
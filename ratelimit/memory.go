@@ -0,0 +1,94 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Memory Window
+/*
+	Start - When the window began.
+	Count - Hits counted in it.
+	ExpiresAt - When the window can be forgotten.
+*/
+type memoryWindow struct {
+	Start     time.Time
+	Count     int
+	ExpiresAt time.Time
+}
+
+// Memory Cooldown
+/*
+	Uses - Allowed uses, newest first.
+	Refused - True once a use was refused since the last allowed one.
+	ExpiresAt - When the cooldown can be forgotten.
+*/
+type memoryCooldown struct {
+	Uses      []time.Time
+	Refused   bool
+	ExpiresAt time.Time
+}
+
+// MemoryStore counts hits in this process only
+// Note: Used when the database is unreachable and in tests, it isn't shared between instances.
+type MemoryStore struct {
+	mu        sync.Mutex
+	windows   map[string]memoryWindow
+	cooldowns map[string]memoryCooldown
+}
+
+// NewMemoryStore builds an empty in-process store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{windows: map[string]memoryWindow{}, cooldowns: map[string]memoryCooldown{}}
+}
+
+// Hit counts one use of a key in a window
+func (store *MemoryStore) Hit(key string, windowStart time.Time, window time.Duration) (int, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	// Forget finished windows so the map doesn't grow forever
+	for existingKey, existing := range store.windows {
+		if !existing.ExpiresAt.After(windowStart) {
+			delete(store.windows, existingKey)
+		}
+	}
+
+	current, ok := store.windows[key]
+	if !ok || !current.Start.Equal(windowStart) {
+		current = memoryWindow{Start: windowStart, ExpiresAt: windowStart.Add(window)}
+	}
+	current.Count++
+	store.windows[key] = current
+
+	return current.Count, nil
+}
+
+// Use records a use of a key unless limit uses already happened in the cooldown
+func (store *MemoryStore) Use(key string, now time.Time, cooldown time.Duration, limit int) (time.Duration, bool, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	// Forget finished cooldowns so the map doesn't grow forever
+	for existingKey, existing := range store.cooldowns {
+		if !existing.ExpiresAt.After(now) {
+			delete(store.cooldowns, existingKey)
+		}
+	}
+
+	current := store.cooldowns[key]
+	if len(current.Uses) >= limit {
+		oldest := current.Uses[limit-1]
+		if wait := oldest.Add(cooldown).Sub(now); wait > 0 {
+			first := !current.Refused
+			current.Refused = true
+			store.cooldowns[key] = current
+			return wait, first, nil
+		}
+	}
+
+	uses := append([]time.Time{now}, current.Uses...)
+	store.cooldowns[key] = memoryCooldown{Uses: uses[:min(len(uses), limit)], ExpiresAt: now.Add(cooldown)}
+
+	return 0, false, nil
+}
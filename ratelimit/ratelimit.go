@@ -0,0 +1,224 @@
+package ratelimit
+
+import (
+	"CrispyBot/variables"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Bucket scopes
+const (
+	ScopeUser    = "user"    // Every command a user sends
+	ScopeGuild   = "guild"   // Every command sent in a guild
+	ScopeCommand = "command" // One command sent by one user
+)
+
+// Bucket
+/*
+	Name - Label shown in logs. Note: For command buckets it's the command counted, either `roll` or `battle start`, and covers its subcommands.
+	Scope - Who shares the bucket: one user, one guild, or one user's uses of one command.
+	Limit - Commands allowed per window.
+	Window - Length of each window. Note: Command buckets measure it back from each use, so it's a cooldown rather than a fixed window.
+*/
+type Bucket struct {
+	Name   string
+	Scope  string
+	Limit  int
+	Window time.Duration
+}
+
+// Store counts hits in a fixed window and keeps recent uses for cooldowns
+// Note: Implementations shared between bot instances keep every instance on the same count.
+type Store interface {
+	// Hit counts one use of a key in the window starting at windowStart and returns the count so far
+	Hit(key string, windowStart time.Time, window time.Duration) (int, error)
+	// Use records a use of a key unless limit uses already happened in the cooldown before now
+	// Note: Returns the wait until the oldest of those uses ends, and true for the first refusal since the last allowed use.
+	Use(key string, now time.Time, cooldown time.Duration, limit int) (time.Duration, bool, error)
+}
+
+// Decision
+/*
+	Allowed - True if the command can run.
+	RetryAfter - Time left until the bucket that refused it opens again.
+	Bucket - The bucket that refused it. Note: Zero when allowed.
+	FirstRefusal - True the first time a window or cooldown refuses, so the user is told once instead of on every message.
+*/
+type Decision struct {
+	Allowed      bool
+	RetryAfter   time.Duration
+	Bucket       Bucket
+	FirstRefusal bool
+}
+
+// Limiter checks commands against a set of buckets
+type Limiter struct {
+	store   Store
+	buckets []Bucket
+}
+
+// NewLimiter builds a limiter over a store
+func NewLimiter(store Store, buckets []Bucket) *Limiter {
+	return &Limiter{store: store, buckets: buckets}
+}
+
+// Allow counts a command, named by its full path like `battle start`, against every bucket it falls in
+// Note: Command cooldowns are checked first so a refused command isn't counted in the user and guild buckets.
+// Store errors let the command through, a broken limiter shouldn't take the bot down.
+func (limiter *Limiter) Allow(userID string, guildID string, command string, now time.Time) Decision {
+	if decision := limiter.AllowCommand(userID, guildID, command, now); !decision.Allowed {
+		return decision
+	}
+	return limiter.AllowShared(userID, guildID, now)
+}
+
+// AllowCommand checks a command, named by its full path like `battle start`, against its cooldown buckets only
+func (limiter *Limiter) AllowCommand(userID string, guildID string, command string, now time.Time) Decision {
+	for _, bucket := range limiter.buckets {
+		if bucket.Scope != ScopeCommand {
+			continue
+		}
+		key, ok := bucket.key(userID, guildID, command)
+		if !ok {
+			continue
+		}
+
+		wait, first, err := limiter.store.Use(key, now, bucket.Window, bucket.Limit)
+		if err != nil {
+			fmt.Printf("Error checking cooldown %s: %v\n", bucket.Name, err)
+			continue
+		}
+
+		if wait > 0 {
+			return Decision{RetryAfter: wait, Bucket: bucket, FirstRefusal: first}
+		}
+	}
+
+	return Decision{Allowed: true}
+}
+
+// AllowShared counts a message against the user and guild buckets only
+// Note: It doesn't need a command, so messages can be counted before they're parsed.
+func (limiter *Limiter) AllowShared(userID string, guildID string, now time.Time) Decision {
+	for _, bucket := range limiter.buckets {
+		if bucket.Scope == ScopeCommand {
+			continue
+		}
+		key, ok := bucket.key(userID, guildID, "")
+		if !ok {
+			continue
+		}
+
+		windowStart := now.Truncate(bucket.Window)
+		count, err := limiter.store.Hit(key, windowStart, bucket.Window)
+		if err != nil {
+			fmt.Printf("Error checking rate limit %s: %v\n", bucket.Name, err)
+			continue
+		}
+
+		if count > bucket.Limit {
+			return Decision{
+				RetryAfter:   windowStart.Add(bucket.Window).Sub(now),
+				Bucket:       bucket,
+				FirstRefusal: count == bucket.Limit+1,
+			}
+		}
+	}
+
+	return Decision{Allowed: true}
+}
+
+// key names the counter a command uses in a bucket, or false if the bucket doesn't count it
+func (bucket Bucket) key(userID string, guildID string, command string) (string, bool) {
+	switch bucket.Scope {
+	case ScopeUser:
+		return fmt.Sprintf("user:%s", userID), true
+	case ScopeGuild:
+		if guildID == "" {
+			return "", false
+		}
+		return fmt.Sprintf("guild:%s", guildID), true
+	case ScopeCommand:
+		if command == bucket.Name || strings.HasPrefix(command, bucket.Name+" ") {
+			return fmt.Sprintf("command:%s:%s", bucket.Name, userID), true
+		}
+	}
+
+	return "", false
+}
+
+// ParseBuckets reads buckets from `user=8/10s; guild=60/10s; roll=1/5s; battle start=1/3s`
+// Note: `user` and `guild` are the shared buckets, every other name is a command.
+func ParseBuckets(spec string) ([]Bucket, error) {
+	buckets := []Bucket{}
+	for _, part := range strings.Split(spec, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, rate, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("%q should look like name=limit/window", part)
+		}
+		name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+
+		limitText, windowText, ok := strings.Cut(strings.TrimSpace(rate), "/")
+		if !ok {
+			return nil, fmt.Errorf("%q should look like name=limit/window", part)
+		}
+		limit, err := strconv.Atoi(strings.TrimSpace(limitText))
+		if err != nil || limit < 1 {
+			return nil, fmt.Errorf("the limit for %s must be at least 1", name)
+		}
+		window, err := time.ParseDuration(strings.TrimSpace(windowText))
+		if err != nil || window < time.Second {
+			return nil, fmt.Errorf("the window for %s must be a duration of at least 1s, like 10s", name)
+		}
+
+		bucket := Bucket{Name: name, Limit: limit, Window: window}
+		switch name {
+		case ScopeUser, ScopeGuild:
+			bucket.Scope = name
+		default:
+			bucket.Scope = ScopeCommand
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, nil
+}
+
+// Override replaces buckets with the same name and adds the rest
+func Override(buckets []Bucket, overrides []Bucket) []Bucket {
+	merged := append([]Bucket{}, buckets...)
+	for _, override := range overrides {
+		replaced := false
+		for i := range merged {
+			if merged[i].Name == override.Name {
+				merged[i] = override
+				replaced = true
+			}
+		}
+		if !replaced {
+			merged = append(merged, override)
+		}
+	}
+	return merged
+}
+
+// DefaultBuckets are the shared user and guild buckets
+// Note: Command cooldowns are declared with each command.
+func DefaultBuckets() []Bucket {
+	return []Bucket{
+		{Name: ScopeUser, Scope: ScopeUser, Limit: variables.RateLimitUserCommands, Window: variables.RateLimitUserSeconds * time.Second},
+		{Name: ScopeGuild, Scope: ScopeGuild, Limit: variables.RateLimitGuildCommands, Window: variables.RateLimitGuildSeconds * time.Second},
+	}
+}
+
+// RetrySeconds rounds a wait up to whole seconds for display
+func RetrySeconds(wait time.Duration) int {
+	return max(1, int((wait+time.Second-1)/time.Second))
+}
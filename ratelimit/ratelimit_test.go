@@ -0,0 +1,201 @@
+package ratelimit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestAllow_CommandBucket(t *testing.T) {
+	limiter := NewLimiter(NewMemoryStore(), []Bucket{
+		{Name: "roll", Scope: ScopeCommand, Limit: 1, Window: 5 * time.Second},
+	})
+	now := time.Date(2026, 1, 1, 12, 0, 1, 0, time.UTC)
+
+	if decision := limiter.Allow("1", "g", "roll", now); !decision.Allowed {
+		t.Fatalf("Expected the first roll to be allowed")
+	}
+
+	decision := limiter.Allow("1", "g", "roll", now.Add(time.Second))
+	if decision.Allowed || !decision.FirstRefusal || decision.RetryAfter != 4*time.Second {
+		t.Errorf("Expected a first refusal with 4s left, got %+v", decision)
+	}
+	if decision := limiter.Allow("1", "g", "roll", now.Add(2*time.Second)); decision.Allowed || decision.FirstRefusal {
+		t.Errorf("Expected a repeat refusal, got %+v", decision)
+	}
+
+	if decision := limiter.Allow("2", "g", "roll", now); !decision.Allowed {
+		t.Errorf("Expected another user's roll to be allowed")
+	}
+	if decision := limiter.Allow("1", "g", "stats", now); !decision.Allowed {
+		t.Errorf("Expected other commands to be allowed")
+	}
+	if decision := limiter.Allow("1", "g", "roll", now.Add(4*time.Second)); decision.Allowed {
+		t.Errorf("Expected the roll to stay refused until 5s after the last one")
+	}
+	if decision := limiter.Allow("1", "g", "roll", now.Add(5*time.Second)); !decision.Allowed {
+		t.Errorf("Expected the roll to be allowed once the cooldown ends")
+	}
+	if decision := limiter.Allow("1", "g", "roll", now.Add(6*time.Second)); decision.Allowed || !decision.FirstRefusal {
+		t.Errorf("Expected a new first refusal after an allowed roll, got %+v", decision)
+	}
+}
+
+func TestAllow_CooldownIgnoresWindowBoundaries(t *testing.T) {
+	limiter := NewLimiter(NewMemoryStore(), []Bucket{
+		{Name: "battle start", Scope: ScopeCommand, Limit: 1, Window: 3 * time.Second},
+	})
+	boundary := time.Date(2026, 1, 1, 12, 0, 3, 0, time.UTC)
+
+	limiter.Allow("1", "g", "battle start", boundary.Add(-time.Millisecond))
+	if decision := limiter.Allow("1", "g", "battle start", boundary); decision.Allowed {
+		t.Errorf("Expected a use a millisecond later to be refused across a window boundary")
+	}
+}
+
+func TestAllow_CooldownLimit(t *testing.T) {
+	limiter := NewLimiter(NewMemoryStore(), []Bucket{
+		{Name: "dungeon", Scope: ScopeCommand, Limit: 2, Window: 10 * time.Second},
+	})
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	limiter.Allow("1", "g", "dungeon", now)
+	if decision := limiter.Allow("1", "g", "dungeon", now.Add(2*time.Second)); !decision.Allowed {
+		t.Errorf("Expected a second use inside the cooldown to be allowed")
+	}
+	if decision := limiter.Allow("1", "g", "dungeon", now.Add(4*time.Second)); decision.Allowed || decision.RetryAfter != 6*time.Second {
+		t.Errorf("Expected a third use to wait for the oldest one, got %+v", decision)
+	}
+	if decision := limiter.Allow("1", "g", "dungeon", now.Add(10*time.Second)); !decision.Allowed {
+		t.Errorf("Expected a use once the oldest one ends to be allowed")
+	}
+}
+
+func TestAllow_CooldownRefusalsSkipSharedBuckets(t *testing.T) {
+	// Shared buckets come first, like DefaultBuckets followed by command cooldowns
+	limiter := NewLimiter(NewMemoryStore(), []Bucket{
+		{Name: ScopeUser, Scope: ScopeUser, Limit: 2, Window: time.Minute},
+		{Name: "roll", Scope: ScopeCommand, Limit: 1, Window: time.Minute},
+	})
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	limiter.Allow("1", "g", "roll", now)
+	for i := 0; i < 3; i++ {
+		limiter.Allow("1", "g", "roll", now)
+	}
+	if decision := limiter.Allow("1", "g", "stats", now); !decision.Allowed {
+		t.Errorf("Expected refused rolls not to use up the user bucket, got %+v", decision)
+	}
+}
+
+func TestAllowShared_SkipsCommandBuckets(t *testing.T) {
+	limiter := NewLimiter(NewMemoryStore(), []Bucket{
+		{Name: ScopeUser, Scope: ScopeUser, Limit: 2, Window: time.Minute},
+		{Name: "roll", Scope: ScopeCommand, Limit: 1, Window: time.Minute},
+	})
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	limiter.AllowCommand("1", "g", "roll", now)
+	if decision := limiter.AllowShared("1", "g", now); !decision.Allowed {
+		t.Errorf("Expected the shared buckets to ignore the roll cooldown, got %+v", decision)
+	}
+	limiter.AllowShared("1", "g", now)
+	if decision := limiter.AllowShared("1", "g", now); decision.Allowed || decision.Bucket.Scope != ScopeUser || !decision.FirstRefusal {
+		t.Errorf("Expected the third message to be refused by the user bucket, got %+v", decision)
+	}
+	if decision := limiter.AllowCommand("2", "g", "roll", now); !decision.Allowed {
+		t.Errorf("Expected the command check to ignore the shared buckets, got %+v", decision)
+	}
+}
+
+func TestAllow_SubCommandAndScopes(t *testing.T) {
+	limiter := NewLimiter(NewMemoryStore(), []Bucket{
+		{Name: "battle start", Scope: ScopeCommand, Limit: 1, Window: time.Minute},
+		{Name: ScopeGuild, Scope: ScopeGuild, Limit: 3, Window: time.Minute},
+	})
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	limiter.Allow("1", "g", "battle start", now)
+	if decision := limiter.Allow("1", "g", "battle start", now); decision.Allowed || decision.Bucket.Name != "battle start" {
+		t.Errorf("Expected the second battle start to be refused, got %+v", decision)
+	}
+	if decision := limiter.Allow("1", "g", "battle accept", now); !decision.Allowed {
+		t.Errorf("Expected other battle subcommands to be allowed")
+	}
+
+	// Refused commands aren't counted in the guild bucket, so this is the third guild hit
+	limiter.Allow("2", "g", "stats", now)
+	if decision := limiter.Allow("2", "g", "stats", now); decision.Allowed || decision.Bucket.Scope != ScopeGuild {
+		t.Errorf("Expected the guild bucket to refuse, got %+v", decision)
+	}
+	if decision := limiter.Allow("2", "", "stats", now); !decision.Allowed {
+		t.Errorf("Expected direct messages to skip the guild bucket")
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) Hit(string, time.Time, time.Duration) (int, error) {
+	return 0, fmt.Errorf("unreachable")
+}
+
+func (failingStore) Use(string, time.Time, time.Duration, int) (time.Duration, bool, error) {
+	return 0, false, fmt.Errorf("unreachable")
+}
+
+func TestAllow_FailsOpen(t *testing.T) {
+	limiter := NewLimiter(failingStore{}, []Bucket{
+		{Name: ScopeUser, Scope: ScopeUser, Limit: 1, Window: time.Second},
+		{Name: "roll", Scope: ScopeCommand, Limit: 1, Window: time.Second},
+	})
+	for i := 0; i < 3; i++ {
+		if !limiter.Allow("1", "g", "roll", time.Now()).Allowed {
+			t.Fatalf("Expected store errors to let commands through")
+		}
+	}
+}
+
+func TestParseBuckets(t *testing.T) {
+	buckets, err := ParseBuckets(" user=5/10s;  Battle   Start = 2/1m ; ")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(buckets) != 2 {
+		t.Fatalf("Expected 2 buckets, got %+v", buckets)
+	}
+	if buckets[0].Scope != ScopeUser || buckets[0].Limit != 5 || buckets[0].Window != 10*time.Second {
+		t.Errorf("Unexpected user bucket %+v", buckets[0])
+	}
+	if buckets[1].Scope != ScopeCommand || buckets[1].Name != "battle start" || buckets[1].Window != time.Minute {
+		t.Errorf("Unexpected command bucket %+v", buckets[1])
+	}
+
+	for _, spec := range []string{"roll", "roll=1", "roll=0/5s", "roll=1/soon", "roll=1/10ms"} {
+		if _, err := ParseBuckets(spec); err == nil {
+			t.Errorf("Expected %q to be rejected", spec)
+		}
+	}
+}
+
+func TestOverride(t *testing.T) {
+	overrides, _ := ParseBuckets("user=20/10s; dungeon=1/5s")
+	merged := Override(DefaultBuckets(), overrides)
+
+	if len(merged) != len(DefaultBuckets())+1 {
+		t.Fatalf("Expected one bucket to be added, got %d", len(merged))
+	}
+	for _, bucket := range merged {
+		if bucket.Name == ScopeUser && bucket.Limit != 20 {
+			t.Errorf("Expected the user bucket to be replaced, got %+v", bucket)
+		}
+	}
+}
+
+func TestRetrySeconds(t *testing.T) {
+	cases := map[time.Duration]int{0: 1, 300 * time.Millisecond: 1, time.Second: 1, 1500 * time.Millisecond: 2}
+	for wait, expected := range cases {
+		if got := RetrySeconds(wait); got != expected {
+			t.Errorf("RetrySeconds(%v) = %d, expected %d", wait, got, expected)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package roller
+
+import (
+	"CrispyBot/database/models"
+	"CrispyBot/variables"
+)
+
+// Banner Context
+/*
+	ID - ID of the banner the context was built from.
+	RaceBoosts - Extra weight percent for featured races within their tier.
+	InnateBoosts - Extra weight percent for featured innate traits within their tier.
+	WeaponBoosts - Extra weight percent for featured starting weapons.
+	Config - Odds used instead of the default ones. Note: Nil keeps the default odds.
+*/
+type Banner struct {
+	ID           string
+	RaceBoosts   map[string]int
+	InnateBoosts map[string]int
+	WeaponBoosts map[string]int
+	Config       *RarityConfig
+}
+
+// NewBanner builds the roll context for a scheduled banner
+func NewBanner(banner models.Banner) *Banner {
+	rollContext := &Banner{
+		ID:           banner.ID.Hex(),
+		RaceBoosts:   banner.RaceBoosts,
+		InnateBoosts: banner.InnateBoosts,
+		WeaponBoosts: banner.WeaponBoosts,
+	}
+
+	if banner.Odds != nil {
+		rollContext.Config = &RarityConfig{
+			Common:    banner.Odds.Common,
+			Uncommon:  banner.Odds.Uncommon,
+			Rare:      banner.Odds.Rare,
+			Epic:      banner.Odds.Epic,
+			Legendary: banner.Odds.Legendary,
+		}
+	}
+
+	return rollContext
+}
+
+// baseConfig returns the odds rolls start from before pity and luck
+// Note: A nil banner means no banner is running.
+func (banner *Banner) baseConfig() RarityConfig {
+	if banner == nil || banner.Config == nil {
+		return config
+	}
+	return *banner.Config
+}
+
+// boostsFor returns the banner's rate-ups for a pity category
+func (banner *Banner) boostsFor(category string) map[string]int {
+	if banner == nil {
+		return nil
+	}
+
+	switch category {
+	case PityRace:
+		return banner.RaceBoosts
+	case PityInnate:
+		return banner.InnateBoosts
+	default:
+		return nil
+	}
+}
+
+// weaponOptions returns the starting weapon weights with the banner's rate-ups applied
+func (banner *Banner) weaponOptions() []WeightedOption {
+	if banner == nil || len(banner.WeaponBoosts) == 0 {
+		return WeaponOptions
+	}
+
+	boosted := make([]WeightedOption, len(WeaponOptions))
+	for i, option := range WeaponOptions {
+		boosted[i] = WeightedOption{
+			Value:  option.Value,
+			Weight: option.Weight * (100 + banner.WeaponBoosts[option.Value]) / 100,
+		}
+	}
+	return boosted
+}
+
+// boostedOptions weights a tier's options by a banner's rate-ups
+func boostedOptions(options []string, boosts map[string]int) []WeightedOption {
+	weighted := make([]WeightedOption, len(options))
+	for i, option := range options {
+		weighted[i] = WeightedOption{Value: option, Weight: variables.BannerBaseWeight + boosts[option]}
+	}
+	return weighted
+}
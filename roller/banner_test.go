@@ -0,0 +1,37 @@
+package roller
+
+import (
+	"CrispyBot/database/models"
+	"math/rand"
+	"testing"
+)
+
+func TestRollBoostedTrait_FeaturedOptionRollsMore(t *testing.T) {
+	legendaryOnly := RarityConfig{Legendary: 1}
+	boosts := map[string]int{"Dragonborn": 900}
+
+	rng := rand.New(rand.NewSource(1))
+	featured := 0
+	for i := 0; i < 1000; i++ {
+		if RollBoostedTrait(RaceRarity, legendaryOnly, boosts, rng) == "Dragonborn" {
+			featured++
+		}
+	}
+
+	// Dragonborn weighs 1000 against God's 100, so it should land about 91% of the time
+	if featured < 850 || featured > 960 {
+		t.Errorf("Expected the featured race about 91%% of the time, got %d/1000", featured)
+	}
+}
+
+func TestNewBanner_CustomOdds(t *testing.T) {
+	banner := NewBanner(models.Banner{Odds: &models.RarityOdds{Epic: 1}})
+	if got := banner.baseConfig(); got != (RarityConfig{Epic: 1}) {
+		t.Errorf("Expected the banner's odds, got %+v", got)
+	}
+
+	var noBanner *Banner
+	if got := noBanner.baseConfig(); got != config {
+		t.Errorf("Expected the default odds without a banner, got %+v", got)
+	}
+}
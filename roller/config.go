@@ -25,3 +25,18 @@ func DefaultRarityConfig() RarityConfig {
 		Legendary: variables.Legendary_Chance,
 	}
 }
+
+// WithWeaponLuck moves chance from Common into Epic and Legendary
+// Note: Two thirds of the luck goes to Epic and the rest to Legendary. Common never drops below 1.
+func (config RarityConfig) WithWeaponLuck(luck int) RarityConfig {
+	luck = min(luck, config.Common-1)
+	if luck <= 0 {
+		return config
+	}
+
+	epicShare := luck * 2 / 3
+	config.Common -= luck
+	config.Epic += epicShare
+	config.Legendary += luck - epicShare
+	return config
+}
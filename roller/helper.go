@@ -15,13 +15,13 @@ func TierNames() []string {
 func SelectTier(config RarityConfig, rng *rand.Rand) string {
 	total := config.Common + config.Uncommon + config.Rare + config.Epic + config.Legendary
 	roll := rng.Intn(total)
-	if roll <= config.Common {
+	if roll < config.Common {
 		return "Common"
-	} else if roll <= config.Common+config.Uncommon {
+	} else if roll < config.Common+config.Uncommon {
 		return "Uncommon"
-	} else if roll <= config.Common+config.Uncommon+config.Rare {
+	} else if roll < config.Common+config.Uncommon+config.Rare {
 		return "Rare"
-	} else if roll <= config.Common+config.Uncommon+config.Rare+config.Epic {
+	} else if roll < config.Common+config.Uncommon+config.Rare+config.Epic {
 		return "Epic"
 	} else {
 		return "Legendary"
@@ -29,6 +29,12 @@ func SelectTier(config RarityConfig, rng *rand.Rand) string {
 }
 
 func RollRarityTrait(rarityMap map[string][]string, config RarityConfig, rng *rand.Rand) string {
+	return RollBoostedTrait(rarityMap, config, nil, rng)
+}
+
+// RollBoostedTrait rolls like RollRarityTrait, but featured options get extra weight within their tier
+// Note: Boosts are percentages on top of BannerBaseWeight. Without boosts every option in a tier is equally likely.
+func RollBoostedTrait(rarityMap map[string][]string, config RarityConfig, boosts map[string]int, rng *rand.Rand) string {
 	tier := SelectTier(config, rng)
 	options, ok := rarityMap[tier]
 	if !ok || len(options) == 0 {
@@ -39,6 +45,9 @@ func RollRarityTrait(rarityMap map[string][]string, config RarityConfig, rng *ra
 		}
 		return ""
 	}
+	if len(boosts) > 0 {
+		return RollWeightedOption(boostedOptions(options, boosts), rng)
+	}
 	return options[rng.Intn(len(options))]
 }
 
@@ -137,3 +137,18 @@ func TestRollEqualOption_Empty(t *testing.T) {
 		t.Errorf("Expected empty string for empty options, got '%s'", val)
 	}
 }
+
+func TestWithWeaponLuck_MovesCommonToHighTiers(t *testing.T) {
+	base := DefaultRarityConfig()
+	lucky := base.WithWeaponLuck(9)
+
+	if lucky.Common != base.Common-9 {
+		t.Errorf("Expected Common reduced by 9, got %d", lucky.Common)
+	}
+	if lucky.Epic+lucky.Legendary != base.Epic+base.Legendary+9 {
+		t.Errorf("Expected Epic and Legendary to gain 9 in total, got %+v", lucky)
+	}
+	if extreme := base.WithWeaponLuck(1000); extreme.Common < 1 {
+		t.Errorf("Expected Common to stay rollable, got %d", extreme.Common)
+	}
+}
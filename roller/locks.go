@@ -0,0 +1,121 @@
+package roller
+
+import (
+	"CrispyBot/database/models"
+	"CrispyBot/variables"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// LockableFields are the stats and characteristics a full reroll can keep, in display order
+var LockableFields = []string{
+	"vitality", "strength", "speed", "durability", "intelligence", "mana", "mastery",
+	"race", "alignment", "element", "height",
+}
+
+// ParseLocks turns a comma separated lock list into lockable field names in display order
+func ParseLocks(list string) ([]string, error) {
+	requested := map[string]bool{}
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !isLockable(name) {
+			return nil, fmt.Errorf("%s can't be locked, choose from: %s", name, strings.Join(LockableFields, ", "))
+		}
+		requested[name] = true
+	}
+
+	locks := []string{}
+	for _, name := range LockableFields {
+		if requested[name] {
+			locks = append(locks, name)
+		}
+	}
+
+	if len(locks) == len(LockableFields) {
+		return nil, fmt.Errorf("locking everything would leave nothing to reroll")
+	}
+
+	return locks, nil
+}
+
+// LockCost returns the coins charged for a reroll keeping a number of locks
+// Note: Each extra lock costs more than the one before it.
+func LockCost(count int) int {
+	return variables.RerollLockBaseCost * count * (count + 1) / 2
+}
+
+// GenerateLockedCharacter rolls a character from a seed but keeps the locked stats and characteristics of a base character
+// Note: Locked stats keep their rolled value only, the new character starts over at level 1.
+// Locked fields are still rolled underneath to follow the seed, but without pity, so a guarantee isn't spent on a result that's thrown away.
+func GenerateLockedCharacter(base models.Character, locks []string, seed int64, pity Pity, banner *Banner) models.Character {
+	character := generateCharacter(base.Owner, seed, pity, banner, locks)
+
+	for _, lock := range locks {
+		if stat, baseStat := lockedStat(&character.Stats, &base.Stats, lock); stat != nil {
+			*stat = models.Stat{
+				Rarity:    baseStat.Rarity,
+				Stat_Name: baseStat.Stat_Name,
+				Type:      baseStat.Type,
+				Value:     baseStat.Value,
+			}
+			continue
+		}
+
+		switch lock {
+		case "race":
+			character.Characteristics.Race = base.Characteristics.Race
+		case "alignment":
+			character.Characteristics.Alignment = base.Characteristics.Alignment
+		case "element":
+			character.Characteristics.Element = base.Characteristics.Element
+		case "height":
+			character.Characteristics.Height = base.Characteristics.Height
+		}
+	}
+
+	return character
+}
+
+// pityFor returns the pity a field rolls with, none when it's locked
+func pityFor(pity Pity, locks []string, field string) Pity {
+	if slices.Contains(locks, field) {
+		return nil
+	}
+	return pity
+}
+
+// lockedStat returns the matching stat of both sheets for a stat lock, or nil for a characteristic lock
+func lockedStat(stats *models.StatsSheets, base *models.StatsSheets, lock string) (*models.Stat, *models.Stat) {
+	switch lock {
+	case "vitality":
+		return &stats.Vitality, &base.Vitality
+	case "strength":
+		return &stats.Strength, &base.Strength
+	case "speed":
+		return &stats.Speed, &base.Speed
+	case "durability":
+		return &stats.Durability, &base.Durability
+	case "intelligence":
+		return &stats.Intelligence, &base.Intelligence
+	case "mana":
+		return &stats.Mana, &base.Mana
+	case "mastery":
+		return &stats.Mastery, &base.Mastery
+	default:
+		return nil, nil
+	}
+}
+
+// isLockable checks if a field name can be locked
+func isLockable(name string) bool {
+	for _, field := range LockableFields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
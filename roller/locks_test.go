@@ -0,0 +1,79 @@
+package roller
+
+import (
+	"CrispyBot/variables"
+	"reflect"
+	"testing"
+)
+
+func TestParseLocks(t *testing.T) {
+	locks, err := ParseLocks(" Race,strength, ,race")
+	if err != nil {
+		t.Fatalf("Expected valid locks, got %v", err)
+	}
+	if !reflect.DeepEqual(locks, []string{"strength", "race"}) {
+		t.Errorf("Expected deduplicated locks in display order, got %v", locks)
+	}
+
+	if _, err := ParseLocks("strength,luck"); err == nil {
+		t.Errorf("Expected an unknown lock to be rejected")
+	}
+
+	all := ""
+	for _, field := range LockableFields {
+		all += field + ","
+	}
+	if _, err := ParseLocks(all); err == nil {
+		t.Errorf("Expected locking every field to be rejected")
+	}
+}
+
+func TestLockCost_Escalates(t *testing.T) {
+	if cost := LockCost(0); cost != 0 {
+		t.Errorf("Expected no cost without locks, got %d", cost)
+	}
+	if cost := LockCost(1); cost != variables.RerollLockBaseCost {
+		t.Errorf("Expected the first lock to cost %d, got %d", variables.RerollLockBaseCost, cost)
+	}
+	if LockCost(3)-LockCost(2) <= LockCost(2)-LockCost(1) {
+		t.Errorf("Expected each extra lock to cost more than the last")
+	}
+}
+
+func TestGenerateLockedCharacter_LockedFieldsSkipPity(t *testing.T) {
+	base := GenerateCharacter("owner", 1, nil, nil)
+	raceMisses := PityRules[PityRace].HardLimit - 1
+	pity := Pity{PityRace: raceMisses, PityStat: PityRules[PityStat].HardLimit - 1}
+
+	locked := GenerateLockedCharacter(base, []string{"vitality", "race"}, 2, pity, nil)
+	if pity[PityRace] != raceMisses {
+		t.Errorf("Expected the locked race to leave its pity at %d, got %d", raceMisses, pity[PityRace])
+	}
+
+	// Vitality rolls first, so the guarantee has to land on durability
+	if tier := locked.Stats.Durability.Rarity; tier != "Epic" && tier != "Legendary" {
+		t.Errorf("Expected the stat guarantee on the first unlocked stat, got %s", tier)
+	}
+}
+
+func TestGenerateLockedCharacter(t *testing.T) {
+	base := GenerateCharacter("owner", 1, nil, nil)
+	base.Stats.Strength.LevelBonus = 12
+	base.Level = 15
+
+	locked := GenerateLockedCharacter(base, []string{"strength", "race"}, 2, nil, nil)
+	if !reflect.DeepEqual(locked.Characteristics.Race, base.Characteristics.Race) {
+		t.Errorf("Expected the race to be kept")
+	}
+	if locked.Stats.Strength.Stat_Name != base.Stats.Strength.Stat_Name || locked.Stats.Strength.Value != base.Stats.Strength.Value {
+		t.Errorf("Expected strength to be kept, got %+v", locked.Stats.Strength)
+	}
+	if locked.Stats.Strength.LevelBonus != 0 || locked.Level != 1 {
+		t.Errorf("Expected the locked character to start over at level 1 without allocated points")
+	}
+
+	fresh := GenerateCharacter("owner", 2, nil, nil)
+	if locked.Stats.Vitality != fresh.Stats.Vitality || !reflect.DeepEqual(locked.Characteristics.Element, fresh.Characteristics.Element) {
+		t.Errorf("Expected unlocked fields to come from the seed")
+	}
+}
@@ -0,0 +1,84 @@
+package roller
+
+import (
+	"CrispyBot/variables"
+	"math/rand"
+)
+
+// Pity categories
+const (
+	PityRace   = "race"
+	PityStat   = "stat"
+	PityInnate = "innate"
+)
+
+// PityCategories lists the pity categories in display order
+var PityCategories = []string{PityRace, PityStat, PityInnate}
+
+// Pity Rule
+/*
+	SoftStart - Rolls without an Epic before the odds start ramping up.
+	HardLimit - Roll number that is guaranteed to be at least Epic.
+*/
+type PityRule struct {
+	SoftStart int
+	HardLimit int
+}
+
+// PityRules are the thresholds for each pity category
+var PityRules = map[string]PityRule{
+	PityRace:   {SoftStart: variables.PityRaceSoftStart, HardLimit: variables.PityRaceHardLimit},
+	PityStat:   {SoftStart: variables.PityStatSoftStart, HardLimit: variables.PityStatHardLimit},
+	PityInnate: {SoftStart: variables.PityInnateSoftStart, HardLimit: variables.PityInnateHardLimit},
+}
+
+// Pity counts each category's rolls since the last Epic or better
+// Note: A nil Pity rolls with the base odds and records nothing.
+type Pity map[string]int
+
+// ConfigFor returns the odds for the next roll in a category
+func (pity Pity) ConfigFor(category string, base RarityConfig) RarityConfig {
+	rule, ok := PityRules[category]
+	if pity == nil || !ok {
+		return base
+	}
+
+	// Odds without Epic or Legendary have nothing to guarantee, and removing the rest would leave no tier at all
+	if base.Epic+base.Legendary == 0 {
+		return base
+	}
+
+	// The hard limit removes every tier below Epic
+	misses := pity[category]
+	if misses+1 >= rule.HardLimit {
+		return RarityConfig{Epic: base.Epic, Legendary: base.Legendary}
+	}
+
+	// Past the soft start, every roll moves more weight toward Epic and Legendary
+	if misses >= rule.SoftStart {
+		return base.WithWeaponLuck((misses - rule.SoftStart + 1) * variables.PitySoftStep)
+	}
+
+	return base
+}
+
+// Record updates a category's counter after a roll of the given tier
+func (pity Pity) Record(category string, tier string) {
+	if pity == nil {
+		return
+	}
+
+	if tier == "Epic" || tier == "Legendary" {
+		pity[category] = 0
+		return
+	}
+	pity[category]++
+}
+
+// rollWithPity rolls a trait from a rarity map using the running banner and the category's pity, and records the result
+func rollWithPity(rarityMap map[string][]string, category string, pity Pity, banner *Banner, rng *rand.Rand) (string, string) {
+	name := RollBoostedTrait(rarityMap, pity.ConfigFor(category, banner.baseConfig()), banner.boostsFor(category), rng)
+	tier := getTierForTrait(name, rarityMap)
+	pity.Record(category, tier)
+	return name, tier
+}
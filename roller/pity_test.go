@@ -0,0 +1,95 @@
+package roller
+
+import (
+	"CrispyBot/variables"
+	"math/rand"
+	"testing"
+)
+
+func TestPity_HardLimitGuaranteesEpic(t *testing.T) {
+	rule := PityRules[PityRace]
+	pity := Pity{PityRace: rule.HardLimit - 1}
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		tier := SelectTier(pity.ConfigFor(PityRace, DefaultRarityConfig()), rng)
+		if tier != "Epic" && tier != "Legendary" {
+			t.Fatalf("Expected the hard limit to guarantee Epic or better, got %s", tier)
+		}
+	}
+}
+
+func TestPity_NoEpicOddsKeepBase(t *testing.T) {
+	base := RarityConfig{Common: 50, Uncommon: 50}
+	pity := Pity{PityRace: PityRules[PityRace].HardLimit}
+
+	if config := pity.ConfigFor(PityRace, base); config != base {
+		t.Errorf("Expected odds without Epic or Legendary to stay as they are, got %+v", config)
+	}
+
+	// Used to panic in rng.Intn(0)
+	GenerateCharacter("1", 1, pity, &Banner{Config: &base})
+}
+
+func TestPity_SoftRampRaisesOdds(t *testing.T) {
+	base := DefaultRarityConfig()
+	rule := PityRules[PityStat]
+
+	if config := (Pity{PityStat: rule.SoftStart - 1}).ConfigFor(PityStat, base); config != base {
+		t.Errorf("Expected base odds before the soft start, got %+v", config)
+	}
+
+	first := (Pity{PityStat: rule.SoftStart}).ConfigFor(PityStat, base)
+	later := (Pity{PityStat: rule.SoftStart + 3}).ConfigFor(PityStat, base)
+	if first.Epic+first.Legendary != base.Epic+base.Legendary+variables.PitySoftStep {
+		t.Errorf("Expected the first soft pity roll to gain %d, got %+v", variables.PitySoftStep, first)
+	}
+	if later.Epic+later.Legendary <= first.Epic+first.Legendary {
+		t.Errorf("Expected the odds to keep rising, got %+v then %+v", first, later)
+	}
+}
+
+func TestPity_Record(t *testing.T) {
+	pity := Pity{}
+	pity.Record(PityInnate, "Rare")
+	pity.Record(PityInnate, "Common")
+	if pity[PityInnate] != 2 {
+		t.Errorf("Expected 2 misses, got %d", pity[PityInnate])
+	}
+	pity.Record(PityInnate, "Legendary")
+	if pity[PityInnate] != 0 {
+		t.Errorf("Expected an Epic or better to reset the counter, got %d", pity[PityInnate])
+	}
+
+	var none Pity
+	none.Record(PityInnate, "Common")
+	if config := none.ConfigFor(PityInnate, DefaultRarityConfig()); config != DefaultRarityConfig() {
+		t.Errorf("Expected nil pity to use the base odds")
+	}
+}
+
+func TestGenerateCharacter_CountsStatPity(t *testing.T) {
+	pity := Pity{}
+	character := GenerateCharacter("owner", 7, pity, nil)
+
+	misses := 0
+	for _, stat := range []string{character.Stats.Vitality.Rarity, character.Stats.Durability.Rarity, character.Stats.Speed.Rarity,
+		character.Stats.Strength.Rarity, character.Stats.Intelligence.Rarity, character.Stats.Mana.Rarity, character.Stats.Mastery.Rarity} {
+		if stat == "Epic" || stat == "Legendary" {
+			misses = 0
+		} else {
+			misses++
+		}
+	}
+	if pity[PityStat] != misses {
+		t.Errorf("Expected %d stat misses, got %d", misses, pity[PityStat])
+	}
+}
+
+func TestRollRarityTrait_LegendaryReachable(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	only := RarityConfig{Legendary: 1}
+	if tier := getTierForTrait(RollRarityTrait(RaceRarity, only, rng), RaceRarity); tier != "Legendary" {
+		t.Errorf("Expected a Legendary race, got %s", tier)
+	}
+}
@@ -8,17 +8,29 @@ import (
 	"time"
 )
 
-func GenerateCharacter(ownerID string) models.Character {
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+// NewSeed returns a fresh RNG seed for a roll
+func NewSeed() int64 {
+	return time.Now().UnixNano()
+}
+
+// GenerateCharacter rolls a character from a seed under the running banner, updating the pity counters as it goes
+// Note: The same seed, pity and banner always roll the same character, so snapshots can record how a roll came about.
+func GenerateCharacter(ownerID string, seed int64, pity Pity, banner *Banner) models.Character {
+	return generateCharacter(ownerID, seed, pity, banner, nil)
+}
+
+// generateCharacter rolls a character from a seed, rolling locked fields without pity
+func generateCharacter(ownerID string, seed int64, pity Pity, banner *Banner, locks []string) models.Character {
+	rng := rand.New(rand.NewSource(seed))
 
 	// Generate stats
-	stats := generateStats(rng)
+	stats := generateStats(rng, pity, banner, locks)
 
 	// Generate traits (innate, inadequacy, x-factor)
-	traits := generateTraits(rng)
+	traits := generateTraits(rng, pity, banner)
 
 	// Generate characteristics (race, alignment, element, height)
-	characteristics := generateCharacteristics(rng)
+	characteristics := generateCharacteristics(rng, pity, banner, locks)
 
 	// Create the character
 	character := models.Character{
@@ -34,9 +46,10 @@ func GenerateCharacter(ownerID string) models.Character {
 }
 
 // Create a new function to generate an Item for the initial weapon
-func GenerateInitialWeaponItem(alignment string, rng *rand.Rand) models.Item {
+// Note: Weapon luck from prestige shifts the odds toward Epic and Legendary. A running banner can feature weapons and replace the default odds.
+func GenerateInitialWeaponItem(alignment string, weaponLuck int, banner *Banner, rng *rand.Rand) models.Item {
 	// Generate weapon name using existing weighted options
-	weaponName := RollWeightedOption(WeaponOptions, rng)
+	weaponName := RollWeightedOption(banner.weaponOptions(), rng)
 
 	// Determine rarity based on alignment
 	var rarity string
@@ -52,9 +65,9 @@ func GenerateInitialWeaponItem(alignment string, rng *rand.Rand) models.Item {
 			Legendary: variables.Legendary_Chance + variables.HeroAlignmentLegendaryBoost,
 		}
 
-		rarity = SelectTier(rarityConfig, rng)
+		rarity = SelectTier(rarityConfig.WithWeaponLuck(weaponLuck), rng)
 	} else {
-		rarity = SelectTier(DefaultRarityConfig(), rng)
+		rarity = SelectTier(banner.baseConfig().WithWeaponLuck(weaponLuck), rng)
 	}
 
 	// Generate stats based on rarity
@@ -72,15 +85,15 @@ func GenerateInitialWeaponItem(alignment string, rng *rand.Rand) models.Item {
 }
 
 // Generate random stats based on rarity
-func generateStats(rng *rand.Rand) models.StatsSheets {
+func generateStats(rng *rand.Rand, pity Pity, banner *Banner, locks []string) models.StatsSheets {
 	// Generate each stat
-	vitality := GenerateStat(variables.Vitality, VitalityRarity, rng)
-	durability := GenerateStat(variables.Durability, DurabilityRarity, rng)
-	speed := GenerateStat(variables.Speed, SpeedRarity, rng)
-	strength := GenerateStat(variables.Strength, StrengthRarity, rng)
-	intelligence := GenerateStat(variables.Intelligence, IntelligenceRarity, rng)
-	mana := GenerateStat(variables.Mana, ManaRarity, rng)
-	mastery := GenerateStat(variables.Mastery, MasteryRarity, rng)
+	vitality := GenerateStat(variables.Vitality, VitalityRarity, rng, pityFor(pity, locks, "vitality"), banner)
+	durability := GenerateStat(variables.Durability, DurabilityRarity, rng, pityFor(pity, locks, "durability"), banner)
+	speed := GenerateStat(variables.Speed, SpeedRarity, rng, pityFor(pity, locks, "speed"), banner)
+	strength := GenerateStat(variables.Strength, StrengthRarity, rng, pityFor(pity, locks, "strength"), banner)
+	intelligence := GenerateStat(variables.Intelligence, IntelligenceRarity, rng, pityFor(pity, locks, "intelligence"), banner)
+	mana := GenerateStat(variables.Mana, ManaRarity, rng, pityFor(pity, locks, "mana"), banner)
+	mastery := GenerateStat(variables.Mastery, MasteryRarity, rng, pityFor(pity, locks, "mastery"), banner)
 
 	return models.StatsSheets{
 		Vitality:     vitality,
@@ -93,13 +106,10 @@ func generateStats(rng *rand.Rand) models.StatsSheets {
 	}
 }
 
-// Generate a single stat with random rarity
-func GenerateStat(statType variables.StatType, rarityMap map[string][]string, rng *rand.Rand) models.Stat {
-	// First, select a trait name using RollRarityTrait
-	statName := RollRarityTrait(rarityMap, config, rng)
-
-	// Then determine the correct rarity based on the selected trait name
-	rarity := getTierForTrait(statName, rarityMap)
+// Generate a single stat with random rarity, with the odds shifted by the stat pity
+func GenerateStat(statType variables.StatType, rarityMap map[string][]string, rng *rand.Rand, pity Pity, banner *Banner) models.Stat {
+	// Select a trait name and the rarity it belongs to
+	statName, rarity := rollWithPity(rarityMap, PityStat, pity, banner, rng)
 
 	// Get base value for the stat
 	baseValue := getStatBaseValue(statType, statName)
@@ -156,9 +166,9 @@ func getStatBaseValue(statType variables.StatType, statName string) int {
 }
 
 // Generate character traits (innate, inadequacy, x-factor)
-func generateTraits(rng *rand.Rand) models.TraitsSheets {
+func generateTraits(rng *rand.Rand, pity Pity, banner *Banner) models.TraitsSheets {
 	// Generate innate trait (buff)
-	innateTrait := generateInnateTrait(rng)
+	innateTrait := generateInnateTrait(rng, pity, banner)
 
 	// Generate inadequacy trait (weakness)
 	inadequacyTrait := generateInadequacyTrait(rng)
@@ -174,9 +184,9 @@ func generateTraits(rng *rand.Rand) models.TraitsSheets {
 }
 
 // Updated generateCharacteristics to include height
-func generateCharacteristics(rng *rand.Rand) models.Characteristics {
+func generateCharacteristics(rng *rand.Rand, pity Pity, banner *Banner, locks []string) models.Characteristics {
 	// Generate race characteristic
-	race := generateRaceCharacteristic(rng)
+	race := generateRaceCharacteristic(rng, pityFor(pity, locks, "race"), banner)
 
 	// Generate alignment characteristic
 	alignment := generateAlignmentCharacteristic(rng)
@@ -196,9 +206,8 @@ func generateCharacteristics(rng *rand.Rand) models.Characteristics {
 }
 
 // Generate an innate trait
-func generateInnateTrait(rng *rand.Rand) models.Trait {
-	traitName := RollRarityTrait(InnateRarity, config, rng)
-	rarity := getTierForTrait(traitName, InnateRarity)
+func generateInnateTrait(rng *rand.Rand, pity Pity, banner *Banner) models.Trait {
+	traitName, rarity := rollWithPity(InnateRarity, PityInnate, pity, banner, rng)
 
 	// Get trait stat values
 	statsValues := make(map[string]int)
@@ -261,9 +270,8 @@ func generateXFactorTrait(rng *rand.Rand) models.Trait {
 }
 
 // Generate a race characteristic
-func generateRaceCharacteristic(rng *rand.Rand) models.Characteristic {
-	raceName := RollRarityTrait(RaceRarity, config, rng)
-	rarity := getTierForTrait(raceName, RaceRarity)
+func generateRaceCharacteristic(rng *rand.Rand, pity Pity, banner *Banner) models.Characteristic {
+	raceName, rarity := rollWithPity(RaceRarity, PityRace, pity, banner, rng)
 
 	// Get race stat values
 	statsValues := make(map[string]int)
@@ -489,3 +497,29 @@ func calculatePrice(rarity string, stats map[string]int) int {
 
 	return int(float64(basePrice) * priceModifier)
 }
+
+// rarityPoints is how much each tier adds to a character's rarity score
+var rarityPoints = map[string]int{
+	"Common":    0,
+	"Uncommon":  1,
+	"Rare":      2,
+	"Epic":      4,
+	"Legendary": 8,
+}
+
+// CalculateRarityScore sums the rarity of a character's stats, innate trait and race
+func CalculateRarityScore(character models.Character) int {
+	stats := character.Stats
+	score := rarityPoints[stats.Vitality.Rarity] +
+		rarityPoints[stats.Durability.Rarity] +
+		rarityPoints[stats.Speed.Rarity] +
+		rarityPoints[stats.Strength.Rarity] +
+		rarityPoints[stats.Intelligence.Rarity] +
+		rarityPoints[stats.Mana.Rarity] +
+		rarityPoints[stats.Mastery.Rarity]
+
+	score += rarityPoints[character.Traits.Innate.Rarity]
+	score += rarityPoints[character.Characteristics.Race.Rarity]
+
+	return score
+}
@@ -0,0 +1,22 @@
+package roller
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGenerateCharacter_SameSeedSameCharacter(t *testing.T) {
+	first := GenerateCharacter("owner", 42, nil, nil)
+	second := GenerateCharacter("owner", 42, nil, nil)
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("Expected the same seed to roll the same character")
+	}
+
+	differs := false
+	for seed := int64(43); seed < 53 && !differs; seed++ {
+		differs = !reflect.DeepEqual(first, GenerateCharacter("owner", seed, nil, nil))
+	}
+	if !differs {
+		t.Errorf("Expected different seeds to roll different characters")
+	}
+}
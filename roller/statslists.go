@@ -11,7 +11,7 @@ var (
 		"Uncommon":  {"Drawf", "Elf", "Centaur", "Minotaur", "Cyclops", "Mushfolk", "Beastfolk", "Lamia", "Undead", "Harpy"},
 		"Rare":      {"Dullahan", "Merfolk", "Fairy", "Druid", "Vampire", "Werewolf", "Ghost"},
 		"Epic":      {"Demon", "Angel", "Djinn", "Wizard/Witch"},
-		"Legandary": {"God", "Dragonborn"},
+		"Legendary": {"God", "Dragonborn"},
 	}
 
 	VitalityRarity = map[string][]string{
@@ -19,7 +19,7 @@ var (
 		"Uncommon":  {"Weak", "Heathly"},
 		"Rare":      {"Frail", "Robust"},
 		"Epic":      {"Helpless", "Vigorous"},
-		"Legandary": {"Helpless-", "Vigorous+"},
+		"Legendary": {"Helpless-", "Vigorous+"},
 	}
 
 	SpeedRarity = map[string][]string{
@@ -27,7 +27,7 @@ var (
 		"Uncommon":  {"Slow", "Fast"},
 		"Rare":      {"Sluggish", "Accelerated"},
 		"Epic":      {"Crippled", "Supersonic"},
-		"Legandary": {"Torid", "Hypersonic"},
+		"Legendary": {"Torid", "Hypersonic"},
 	}
 
 	StrengthRarity = map[string][]string{
@@ -35,7 +35,7 @@ var (
 		"Uncommon":  {"Weak", "Strong"},
 		"Rare":      {"Scrwny", "Formidable"},
 		"Epic":      {"Forceless", "Overpowering"},
-		"Legandary": {"Forceless-", "Overpowering+"},
+		"Legendary": {"Forceless-", "Overpowering+"},
 	}
 
 	DurabilityRarity = map[string][]string{
@@ -43,7 +43,7 @@ var (
 		"Uncommon":  {"Vincible", "Reinforced"},
 		"Rare":      {"Vulnerable", "Armored"},
 		"Epic":      {"Defenseless", "Fortified"},
-		"Legandary": {"Defenseless-", "Fortified+"},
+		"Legendary": {"Defenseless-", "Fortified+"},
 	}
 
 	IntelligenceRarity = map[string][]string{
@@ -51,7 +51,7 @@ var (
 		"Uncommon":  {"Dumb", "Smart"},
 		"Rare":      {"Lobotomized", "Genius"},
 		"Epic":      {"Mindless", "Prodigious"},
-		"Legandary": {"Mindless-", "Prodigious+"},
+		"Legendary": {"Mindless-", "Prodigious+"},
 	}
 
 	ManaRarity = map[string][]string{
@@ -59,7 +59,7 @@ var (
 		"Uncommon":  {"Hexed", "Enchanted"},
 		"Rare":      {"Lowly", "Conjuring"},
 		"Epic":      {"Mana-Less", "Overflowing"},
-		"Legandary": {"No-Mana", "Overflowing+"},
+		"Legendary": {"No-Mana", "Overflowing+"},
 	}
 
 	MasteryRarity = map[string][]string{
@@ -67,7 +67,7 @@ var (
 		"Uncommon":  {"Amateur", "Skilled"},
 		"Rare":      {"Novice", "Expert"},
 		"Epic":      {"Skill-less", "Mastered"},
-		"Legandary": {"Skill-less-", "Mastered+"},
+		"Legendary": {"Skill-less-", "Mastered+"},
 	}
 
 	InnateRarity = map[string][]string{
@@ -75,7 +75,7 @@ var (
 		"Uncommon":  {"Fast Learner", "Abounding Flow", "Big Boned"},
 		"Rare":      {"Druid's Blessing", "Naturally Skilled"},
 		"Epic":      {"Call of Hercules", "Speed Force"},
-		"Legandary": {"Blessed", "Isekai Protag"},
+		"Legendary": {"Blessed", "Isekai Protag"},
 	}
 
 	InadequacyOptions = []WeightedOption{
@@ -0,0 +1,322 @@
+package settings
+
+import (
+	"CrispyBot/database/models"
+	"CrispyBot/i18n"
+	"CrispyBot/shop"
+	"CrispyBot/variables"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DefaultPrefix is the command prefix every guild accepts
+const DefaultPrefix = "!cb"
+
+// Features that can be switched off per guild
+const (
+	FeatureBattles      = "battles"
+	FeatureRanked       = "ranked"
+	FeatureTournaments  = "tournaments"
+	FeatureDungeons     = "dungeons"
+	FeatureShop         = "shop"
+	FeatureDaily        = "daily"
+	FeatureRerolls      = "rerolls"
+	FeatureClans        = "clans"
+	FeatureBanners      = "banners"
+	FeatureQuests       = "quests"
+	FeatureAchievements = "achievements"
+)
+
+// Features lists every feature that can be switched off
+var Features = []string{
+	FeatureBattles,
+	FeatureRanked,
+	FeatureTournaments,
+	FeatureDungeons,
+	FeatureShop,
+	FeatureDaily,
+	FeatureRerolls,
+	FeatureClans,
+	FeatureBanners,
+	FeatureQuests,
+	FeatureAchievements,
+}
+
+// Setting keys admins can change
+const (
+	KeyPrefix         = "prefix"
+	KeyRollChannels   = "rollchannels"
+	KeyBattleChannels = "battlechannels"
+	KeyDailyReward    = "daily"
+	KeyFullRerolls    = "rerolls"
+	KeyStatRerolls    = "statrerolls"
+	KeyShopSize       = "shopsize"
+	KeyAdminRoles     = "adminroles"
+	KeyLanguage       = "language"
+)
+
+// Keys lists every setting that can be changed
+var Keys = []string{KeyPrefix, KeyRollChannels, KeyBattleChannels, KeyDailyReward, KeyFullRerolls, KeyStatRerolls, KeyShopSize, KeyAdminRoles, KeyLanguage}
+
+// Mentions matched with a bare ID as the fallback
+var (
+	channelMention = regexp.MustCompile(`^<#(\d+)>$|^(\d+)$`)
+	roleMention    = regexp.MustCompile(`^<@&(\d+)>$|^(\d+)$`)
+)
+
+// Guild Settings
+/*
+	Prefix - Custom command prefix. Note: Empty when the guild only uses the default.
+	RollChannels - Channels where rolls are allowed. Note: Empty allows every channel.
+	BattleChannels - Channels where battles are allowed. Note: Empty allows every channel.
+	DailyReward - Base coins for a daily claim.
+	FullRerolls - Full rerolls granted every reset.
+	StatRerolls - Stat rerolls granted every reset.
+	ShopSize - Shop items shown.
+	Disabled - Features switched off.
+	AdminRoles - Roles allowed to use admin commands.
+	Banned - Discord IDs banned from playing.
+	Locale - Language the bot answers in. Note: Empty keeps English.
+*/
+type Settings struct {
+	Prefix         string
+	RollChannels   []string
+	BattleChannels []string
+	DailyReward    int
+	FullRerolls    int
+	StatRerolls    int
+	ShopSize       int
+	Disabled       []string
+	AdminRoles     []string
+	Banned         []string
+	Locale         string
+}
+
+// Defaults returns the settings of a guild that never changed anything
+func Defaults() Settings {
+	return Settings{
+		DailyReward: variables.DailyBaseReward,
+		FullRerolls: variables.DailyFullRerolls,
+		StatRerolls: variables.DailyStatRerolls,
+		ShopSize:    shop.ShopInventorySize,
+	}
+}
+
+// Resolve fills in the defaults for everything a guild hasn't set
+func Resolve(config models.GuildConfig) Settings {
+	resolved := Defaults()
+	resolved.Prefix = config.Prefix
+	resolved.RollChannels = config.RollChannels
+	resolved.BattleChannels = config.BattleChannels
+	resolved.Disabled = config.DisabledFeatures
+	resolved.AdminRoles = config.AdminRoles
+	resolved.Banned = config.BannedUsers
+	resolved.Locale = config.Locale
+
+	if config.DailyReward != nil {
+		resolved.DailyReward = *config.DailyReward
+	}
+	if config.FullRerolls != nil {
+		resolved.FullRerolls = *config.FullRerolls
+	}
+	if config.StatRerolls != nil {
+		resolved.StatRerolls = *config.StatRerolls
+	}
+	if config.ShopSize != nil {
+		resolved.ShopSize = *config.ShopSize
+	}
+
+	return resolved
+}
+
+// Enabled reports whether a feature is switched on
+func (s Settings) Enabled(feature string) bool {
+	return !slices.Contains(s.Disabled, feature)
+}
+
+// IsBanned reports whether a user is banned from playing
+func (s Settings) IsBanned(userID string) bool {
+	return slices.Contains(s.Banned, userID)
+}
+
+// IsAdminRole reports whether any of a member's roles may use admin commands
+func (s Settings) IsAdminRole(roles []string) bool {
+	return slices.ContainsFunc(roles, func(role string) bool { return slices.Contains(s.AdminRoles, role) })
+}
+
+// AllowsChannel reports whether a channel is in an allow list
+// Note: An empty list allows every channel.
+func AllowsChannel(channels []string, channelID string) bool {
+	return len(channels) == 0 || slices.Contains(channels, channelID)
+}
+
+// FullRerollsLeft converts a stored full reroll count into what's left under the guild's allowance
+// Note: Users are granted the default allowance at every reset, so a guild's allowance shifts it.
+func (s Settings) FullRerollsLeft(stored int) int {
+	return max(0, stored+s.FullRerolls-variables.DailyFullRerolls)
+}
+
+// StatRerollsLeft converts a stored stat reroll count into what's left under the guild's allowance
+func (s Settings) StatRerollsLeft(stored int) int {
+	return max(0, stored+s.StatRerolls-variables.DailyStatRerolls)
+}
+
+// Set validates a new value for a setting and stores it on the config
+func Set(config *models.GuildConfig, key string, value string) error {
+	value = strings.TrimSpace(value)
+
+	switch strings.ToLower(key) {
+	case KeyPrefix:
+		if value == "" || strings.ContainsAny(value, " \t\n") || len(value) > variables.GuildPrefixMaxLength {
+			return fmt.Errorf("the prefix must be 1 to %d characters without spaces", variables.GuildPrefixMaxLength)
+		}
+		if value == DefaultPrefix {
+			config.Prefix = ""
+			return nil
+		}
+		config.Prefix = value
+	case KeyRollChannels:
+		channels, err := parseMentions(value, channelMention, "channel")
+		if err != nil {
+			return err
+		}
+		config.RollChannels = channels
+	case KeyBattleChannels:
+		channels, err := parseMentions(value, channelMention, "channel")
+		if err != nil {
+			return err
+		}
+		config.BattleChannels = channels
+	case KeyAdminRoles:
+		roles, err := parseMentions(value, roleMention, "role")
+		if err != nil {
+			return err
+		}
+		config.AdminRoles = roles
+	case KeyLanguage:
+		locale, ok := i18n.Find(value)
+		if !ok {
+			return fmt.Errorf("unknown language %s, choose from %s", value, strings.Join(i18n.Codes(), ", "))
+		}
+		config.Locale = locale.Code
+		if locale.Code == i18n.Default {
+			config.Locale = ""
+		}
+	case KeyDailyReward:
+		return setNumber(&config.DailyReward, value, 0, variables.GuildMaxDailyReward, "the daily reward")
+	case KeyFullRerolls:
+		return setNumber(&config.FullRerolls, value, 0, variables.GuildMaxDailyRerolls, "full rerolls")
+	case KeyStatRerolls:
+		return setNumber(&config.StatRerolls, value, 0, variables.GuildMaxDailyRerolls, "stat rerolls")
+	case KeyShopSize:
+		return setNumber(&config.ShopSize, value, 1, shop.ShopMaxInventorySize, "the shop size")
+	default:
+		return fmt.Errorf("unknown setting %s, choose from %s", key, strings.Join(Keys, ", "))
+	}
+
+	return nil
+}
+
+// Reset puts a setting back to its default
+func Reset(config *models.GuildConfig, key string) error {
+	switch strings.ToLower(key) {
+	case KeyPrefix:
+		config.Prefix = ""
+	case KeyRollChannels:
+		config.RollChannels = nil
+	case KeyBattleChannels:
+		config.BattleChannels = nil
+	case KeyDailyReward:
+		config.DailyReward = nil
+	case KeyFullRerolls:
+		config.FullRerolls = nil
+	case KeyStatRerolls:
+		config.StatRerolls = nil
+	case KeyShopSize:
+		config.ShopSize = nil
+	case KeyAdminRoles:
+		config.AdminRoles = nil
+	case KeyLanguage:
+		config.Locale = ""
+	default:
+		return fmt.Errorf("unknown setting %s, choose from %s", key, strings.Join(Keys, ", "))
+	}
+
+	return nil
+}
+
+// SetFeature switches a feature on or off
+func SetFeature(config *models.GuildConfig, feature string, enabled bool) error {
+	feature = strings.ToLower(feature)
+	if !slices.Contains(Features, feature) {
+		return fmt.Errorf("unknown feature %s, choose from %s", feature, strings.Join(Features, ", "))
+	}
+
+	config.DisabledFeatures = slices.DeleteFunc(config.DisabledFeatures, func(disabled string) bool {
+		return disabled == feature
+	})
+	if !enabled {
+		config.DisabledFeatures = append(config.DisabledFeatures, feature)
+	}
+
+	return nil
+}
+
+// SetBanned bans or unbans a user
+func SetBanned(config *models.GuildConfig, userID string, banned bool) error {
+	if slices.Contains(config.BannedUsers, userID) == banned {
+		if banned {
+			return fmt.Errorf("<@%s> is already banned", userID)
+		}
+		return fmt.Errorf("<@%s> isn't banned", userID)
+	}
+
+	if banned {
+		config.BannedUsers = append(config.BannedUsers, userID)
+		return nil
+	}
+	config.BannedUsers = slices.DeleteFunc(config.BannedUsers, func(bannedID string) bool {
+		return bannedID == userID
+	})
+	return nil
+}
+
+// setNumber stores a whole number setting within bounds
+func setNumber(target **int, value string, minimum int, maximum int, name string) error {
+	number, err := strconv.Atoi(value)
+	if err != nil || number < minimum || number > maximum {
+		return fmt.Errorf("%s must be a number from %d to %d", name, minimum, maximum)
+	}
+
+	*target = &number
+	return nil
+}
+
+// parseMentions reads channel or role mentions, or their IDs, separated by spaces or commas
+// Note: `any` clears the list.
+func parseMentions(value string, mention *regexp.Regexp, kind string) ([]string, error) {
+	if strings.EqualFold(value, "any") {
+		return nil, nil
+	}
+
+	ids := []string{}
+	for _, field := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' }) {
+		match := mention.FindStringSubmatch(field)
+		if match == nil {
+			return nil, fmt.Errorf("%s is not a %s, mention it or write `any`", field, kind)
+		}
+
+		id := match[1] + match[2]
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("mention at least one %s, or write `any`", kind)
+	}
+	return ids, nil
+}
@@ -0,0 +1,126 @@
+package settings
+
+import (
+	"CrispyBot/database/models"
+	"CrispyBot/variables"
+	"reflect"
+	"testing"
+)
+
+func TestResolve_DefaultsAndOverrides(t *testing.T) {
+	if got := Resolve(models.GuildConfig{}); !reflect.DeepEqual(got, Defaults()) {
+		t.Errorf("Expected an empty config to resolve to the defaults, got %+v", got)
+	}
+
+	zero := 0
+	resolved := Resolve(models.GuildConfig{Prefix: "?", FullRerolls: &zero})
+	if resolved.Prefix != "?" || resolved.FullRerolls != 0 {
+		t.Errorf("Expected the overrides to apply, got %+v", resolved)
+	}
+	if resolved.StatRerolls != variables.DailyStatRerolls {
+		t.Errorf("Expected unset values to keep the default, got %d", resolved.StatRerolls)
+	}
+}
+
+func TestSet(t *testing.T) {
+	config := models.GuildConfig{}
+
+	if err := Set(&config, "rerolls", "5"); err != nil || config.FullRerolls == nil || *config.FullRerolls != 5 {
+		t.Errorf("Expected rerolls to be set to 5, got %v (%v)", config.FullRerolls, err)
+	}
+	if err := Set(&config, "rollchannels", "<#111>, 222 <#111>"); err != nil || !reflect.DeepEqual(config.RollChannels, []string{"111", "222"}) {
+		t.Errorf("Expected two roll channels, got %v (%v)", config.RollChannels, err)
+	}
+	if err := Set(&config, "rollchannels", "any"); err != nil || config.RollChannels != nil {
+		t.Errorf("Expected `any` to clear the roll channels, got %v (%v)", config.RollChannels, err)
+	}
+	if err := Set(&config, "adminroles", "<@&333>"); err != nil || !Resolve(config).IsAdminRole([]string{"1", "333"}) {
+		t.Errorf("Expected the mentioned role to be an admin role, got %v (%v)", config.AdminRoles, err)
+	}
+	if err := Set(&config, "prefix", DefaultPrefix); err != nil || config.Prefix != "" {
+		t.Errorf("Expected the default prefix to clear the custom one, got %q (%v)", config.Prefix, err)
+	}
+	if err := Set(&config, "language", "Español"); err != nil || Resolve(config).Locale != "es" {
+		t.Errorf("Expected the language to be stored as its code, got %q (%v)", config.Locale, err)
+	}
+	if err := Set(&config, "language", "en"); err != nil || config.Locale != "" {
+		t.Errorf("Expected English to clear the language, got %q (%v)", config.Locale, err)
+	}
+
+	invalid := map[string]string{
+		"prefix":         "way too long",
+		"daily":          "-5",
+		"statrerolls":    "lots",
+		"shopsize":       "0",
+		"battlechannels": "#general",
+		"luck":           "7",
+		"language":       "klingon",
+	}
+	for key, value := range invalid {
+		if err := Set(&config, key, value); err == nil {
+			t.Errorf("Expected %s=%s to be rejected", key, value)
+		}
+	}
+}
+
+func TestSetFeature(t *testing.T) {
+	config := models.GuildConfig{}
+
+	SetFeature(&config, "Dungeons", false)
+	SetFeature(&config, "dungeons", false)
+	if !reflect.DeepEqual(config.DisabledFeatures, []string{FeatureDungeons}) {
+		t.Errorf("Expected dungeons to be disabled once, got %v", config.DisabledFeatures)
+	}
+	if Resolve(config).Enabled(FeatureDungeons) {
+		t.Errorf("Expected dungeons to be off")
+	}
+
+	SetFeature(&config, "dungeons", true)
+	if !Resolve(config).Enabled(FeatureDungeons) {
+		t.Errorf("Expected dungeons to be back on")
+	}
+
+	if err := SetFeature(&config, "gambling", false); err == nil {
+		t.Errorf("Expected an unknown feature to be rejected")
+	}
+}
+
+func TestSetBanned(t *testing.T) {
+	config := models.GuildConfig{}
+
+	if err := SetBanned(&config, "42", true); err != nil || !Resolve(config).IsBanned("42") {
+		t.Errorf("Expected the user to be banned (%v)", err)
+	}
+	if err := SetBanned(&config, "42", true); err == nil {
+		t.Errorf("Expected a second ban to be rejected")
+	}
+	if err := SetBanned(&config, "42", false); err != nil || Resolve(config).IsBanned("42") {
+		t.Errorf("Expected the user to be unbanned (%v)", err)
+	}
+	if err := SetBanned(&config, "42", false); err == nil {
+		t.Errorf("Expected unbanning someone who isn't banned to be rejected")
+	}
+}
+
+func TestRerollsLeft(t *testing.T) {
+	generous := Defaults()
+	generous.FullRerolls = variables.DailyFullRerolls + 3
+	if got := generous.FullRerollsLeft(0); got != 3 {
+		t.Errorf("Expected a generous guild to leave 3 rerolls after the default ones, got %d", got)
+	}
+
+	strict := Defaults()
+	strict.StatRerolls = 0
+	if got := strict.StatRerollsLeft(variables.DailyStatRerolls); got != 0 {
+		t.Errorf("Expected no stat rerolls in a guild that allows none, got %d", got)
+	}
+}
+
+func TestAllowsChannel(t *testing.T) {
+	if !AllowsChannel(nil, "123") {
+		t.Errorf("Expected an empty list to allow every channel")
+	}
+	if AllowsChannel([]string{"1"}, "2") {
+		t.Errorf("Expected a channel outside the list to be refused")
+	}
+}
@@ -3,14 +3,18 @@ package shop
 import (
 	"CrispyBot/database/models"
 	"CrispyBot/roller"
+	"CrispyBot/variables"
 	"math/rand"
 	"time"
 )
 
 const (
-	// Number of items to generate for the shop
+	// Number of items shown in the shop unless a guild changes it
 	ShopInventorySize = 6
 
+	// Number of items to generate for the shop, the most a guild can show
+	ShopMaxInventorySize = 12
+
 	// Rarity chances - similar to character generation
 	CommonChance    = 50
 	UncommonChance  = 25
@@ -44,11 +48,35 @@ var statTypes = []string{
 	"Mastery",
 }
 
+// ResetLocation returns the timezone used for the daily reset schedule
+func ResetLocation() *time.Location {
+	if variables.Reset_timezone == "" {
+		return time.Local
+	}
+
+	location, err := time.LoadLocation(variables.Reset_timezone)
+	if err != nil {
+		return time.Local
+	}
+
+	return location
+}
+
+// CurrentPeriodStart returns the most recent reset boundary (midnight in the reset timezone)
+func CurrentPeriodStart(now time.Time) time.Time {
+	local := now.In(ResetLocation())
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+}
+
+// NextRefreshTime returns the next reset boundary after now
+func NextRefreshTime(now time.Time) time.Time {
+	return CurrentPeriodStart(now).AddDate(0, 0, 1)
+}
+
 // CreateShop generates a new shop with a random inventory
 func CreateShop() models.Shop {
 	// Set shop refresh timer to next midnight
-	now := time.Now()
-	nextMidnight := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	nextMidnight := NextRefreshTime(time.Now())
 	// Create random inventory
 	inventory := GenerateInventory()
 
@@ -66,8 +94,7 @@ func IsShopExpired(shop models.Shop) bool {
 // RefreshShop creates a new inventory and updates the timer
 func RefreshShop(shop *models.Shop) {
 	// Set new timer to next midnight
-	now := time.Now()
-	nextMidnight := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	nextMidnight := NextRefreshTime(time.Now())
 
 	// Generate new inventory
 	shop.Timer = nextMidnight
@@ -79,33 +106,30 @@ func GenerateInventory() models.Inventory {
 	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
 	items := make(map[int]models.Item)
 
-	// Use the existing weapon options from the roller package
-	weaponOptions := roller.WeaponOptions
-
 	// Generate a random set of items
-	for i := 0; i < ShopInventorySize; i++ {
-		// Select a random weapon
-		weaponName := roller.RollWeightedOption(weaponOptions, rng)
+	for i := 0; i < ShopMaxInventorySize; i++ {
+		// Generate random rarity for the item and roll a weapon of that rarity
+		items[i] = GenerateItem(GenerateItemRarity(rng), rng)
+	}
 
-		// Generate random rarity for the item
-		rarity := GenerateItemRarity(rng)
+	return models.Inventory{Items: items}
+}
 
-		// Generate random stats for the item based on rarity
-		stats := GenerateItemStats(rarity, rng)
+// GenerateItem creates a random weapon of the given rarity
+func GenerateItem(rarity string, rng *rand.Rand) models.Item {
+	// Select a random weapon
+	weaponName := roller.RollWeightedOption(roller.WeaponOptions, rng)
 
-		// Calculate price based on rarity and stats
-		price := CalculatePrice(rarity, stats)
+	// Generate random stats for the item based on rarity
+	stats := GenerateItemStats(rarity, rng)
 
-		// Create and add the item to the inventory
-		items[i] = models.Item{
-			Name:   weaponName,
-			Rarity: rarity,
-			Stats:  stats,
-			Price:  price,
-		}
+	// Calculate price based on rarity and stats
+	return models.Item{
+		Name:   weaponName,
+		Rarity: rarity,
+		Stats:  stats,
+		Price:  CalculatePrice(rarity, stats),
 	}
-
-	return models.Inventory{Items: items}
 }
 
 // generateItemRarity determines the rarity of an item
@@ -0,0 +1,363 @@
+package tournament
+
+import (
+	"CrispyBot/database/models"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Bracket formats
+const (
+	FormatSingle = "single"
+	FormatDouble = "double"
+	FormatSwiss  = "swiss"
+)
+
+// Tournament states
+const (
+	StatusSignup    = "signup"
+	StatusRunning   = "running"
+	StatusFinished  = "finished"
+	StatusCancelled = "cancelled"
+)
+
+// Match states
+const (
+	MatchWaiting = "waiting"
+	MatchPlaying = "playing"
+	MatchDone    = "done"
+)
+
+// Bracket labels
+const (
+	BracketWinners    = "W"
+	BracketLosers     = "L"
+	BracketGrandFinal = "GF"
+	BracketSwiss      = "S"
+)
+
+// swissBye marks a Swiss bye in a player's opponent list
+const swissBye = "bye"
+
+// IsValidFormat checks if a bracket format is supported
+func IsValidFormat(format string) bool {
+	return format == FormatSingle || format == FormatDouble || format == FormatSwiss
+}
+
+// Start seeds the players and generates the first round
+func Start(t *models.Tournament) ([]*models.TournamentMatch, error) {
+	if t.Status != StatusSignup {
+		return nil, fmt.Errorf("tournament has already started")
+	}
+
+	if len(t.Players) < 2 {
+		return nil, fmt.Errorf("at least 2 players are needed to start")
+	}
+
+	// Seed in signup order
+	for i := range t.Players {
+		t.Players[i].Seed = i + 1
+	}
+
+	if t.Format == FormatSwiss {
+		t.SwissRounds = int(math.Ceil(math.Log2(float64(len(t.Players)))))
+	}
+
+	t.Status = StatusRunning
+	return NextRound(t), nil
+}
+
+// NextRound pairs the remaining players for the next round and returns the matches still to be played
+func NextRound(t *models.Tournament) []*models.TournamentMatch {
+	t.Round++
+
+	switch t.Format {
+	case FormatDouble:
+		winners := activePlayers(t, 0, 0)
+		losers := activePlayers(t, 1, 1)
+
+		// The last two players standing meet in the grand final
+		if len(winners)+len(losers) == 2 {
+			finalists := append(winners, losers...)
+			addMatch(t, BracketGrandFinal, finalists[0], finalists[1])
+		} else {
+			pairElimination(t, BracketWinners, winners)
+			pairElimination(t, BracketLosers, losers)
+		}
+	case FormatSwiss:
+		pairSwiss(t)
+	default:
+		pairElimination(t, BracketWinners, activePlayers(t, 0, 0))
+	}
+
+	return OpenMatches(t)
+}
+
+// RecordResult marks a match as won and updates both players' records
+func RecordResult(t *models.Tournament, matchID int, winnerID string, forfeit bool) error {
+	match := FindMatch(t, matchID)
+	if match == nil {
+		return fmt.Errorf("match %d not found", matchID)
+	}
+
+	if match.Status == MatchDone {
+		return fmt.Errorf("match %d is already decided", matchID)
+	}
+
+	var loserID string
+	switch winnerID {
+	case match.PlayerA:
+		loserID = match.PlayerB
+	case match.PlayerB:
+		loserID = match.PlayerA
+	default:
+		return fmt.Errorf("player is not in match %d", matchID)
+	}
+
+	match.Status = MatchDone
+	match.Winner = winnerID
+	match.Forfeit = forfeit
+
+	winner := FindPlayer(t, winnerID)
+	loser := FindPlayer(t, loserID)
+	winner.Wins++
+	winner.Opponents = append(winner.Opponents, loserID)
+	loser.Losses++
+	loser.Opponents = append(loser.Opponents, winnerID)
+
+	return nil
+}
+
+// RoundComplete checks if every match in the current round is decided
+func RoundComplete(t *models.Tournament) bool {
+	for _, match := range t.Matches {
+		if match.Round == t.Round && match.Status != MatchDone {
+			return false
+		}
+	}
+	return true
+}
+
+// IsFinished checks if the tournament has a champion
+func IsFinished(t *models.Tournament) bool {
+	if t.Status == StatusFinished {
+		return true
+	}
+
+	if !RoundComplete(t) {
+		return false
+	}
+
+	switch t.Format {
+	case FormatSwiss:
+		return t.Round >= t.SwissRounds
+	case FormatDouble:
+		return len(activePlayers(t, 0, 1)) <= 1
+	default:
+		return len(activePlayers(t, 0, 0)) <= 1
+	}
+}
+
+// Standings returns the players ordered from first place down
+func Standings(t *models.Tournament) []models.TournamentPlayer {
+	standings := make([]models.TournamentPlayer, len(t.Players))
+	copy(standings, t.Players)
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if t.Format == FormatSwiss {
+			if a.Wins != b.Wins {
+				return a.Wins > b.Wins
+			}
+			if a.Losses != b.Losses {
+				return a.Losses < b.Losses
+			}
+			return a.Seed < b.Seed
+		}
+
+		// Elimination formats rank survivors first, then players who went further
+		if a.Losses != b.Losses {
+			return a.Losses < b.Losses
+		}
+		if a.Wins != b.Wins {
+			return a.Wins > b.Wins
+		}
+		return a.Seed < b.Seed
+	})
+
+	return standings
+}
+
+// OpenMatches returns the current round's matches that still need to be played
+func OpenMatches(t *models.Tournament) []*models.TournamentMatch {
+	open := []*models.TournamentMatch{}
+	for i := range t.Matches {
+		if t.Matches[i].Round == t.Round && t.Matches[i].Status != MatchDone {
+			open = append(open, &t.Matches[i])
+		}
+	}
+	return open
+}
+
+// FindMatch returns a match by its number
+func FindMatch(t *models.Tournament, matchID int) *models.TournamentMatch {
+	for i := range t.Matches {
+		if t.Matches[i].ID == matchID {
+			return &t.Matches[i]
+		}
+	}
+	return nil
+}
+
+// FindPlayer returns a signed-up player by Discord ID
+func FindPlayer(t *models.Tournament, discordID string) *models.TournamentPlayer {
+	for i := range t.Players {
+		if t.Players[i].DiscordID == discordID {
+			return &t.Players[i]
+		}
+	}
+	return nil
+}
+
+// PlayerMatch returns the player's undecided match in the current round
+func PlayerMatch(t *models.Tournament, discordID string) *models.TournamentMatch {
+	for _, match := range OpenMatches(t) {
+		if match.PlayerA == discordID || match.PlayerB == discordID {
+			return match
+		}
+	}
+	return nil
+}
+
+// activePlayers returns players with a loss count in [minLosses, maxLosses], in seed order
+func activePlayers(t *models.Tournament, minLosses int, maxLosses int) []string {
+	players := []models.TournamentPlayer{}
+	for _, player := range t.Players {
+		if player.Losses >= minLosses && player.Losses <= maxLosses {
+			players = append(players, player)
+		}
+	}
+
+	sort.SliceStable(players, func(i, j int) bool {
+		return players[i].Seed < players[j].Seed
+	})
+
+	ids := make([]string, len(players))
+	for i, player := range players {
+		ids[i] = player.DiscordID
+	}
+	return ids
+}
+
+// pairElimination pairs highest seed against lowest, giving the top seed a bye on odd counts
+func pairElimination(t *models.Tournament, bracket string, players []string) {
+	if len(players) == 0 {
+		return
+	}
+
+	if len(players)%2 == 1 {
+		addBye(t, bracket, players[0], false)
+		players = players[1:]
+	}
+
+	for i := 0; i < len(players)/2; i++ {
+		addMatch(t, bracket, players[i], players[len(players)-1-i])
+	}
+}
+
+// pairSwiss pairs players with similar records who haven't met yet
+func pairSwiss(t *models.Tournament) {
+	players := make([]models.TournamentPlayer, len(t.Players))
+	copy(players, t.Players)
+
+	sort.SliceStable(players, func(i, j int) bool {
+		if players[i].Wins != players[j].Wins {
+			return players[i].Wins > players[j].Wins
+		}
+		return players[i].Seed < players[j].Seed
+	})
+
+	// The lowest-ranked player without a bye sits out on odd counts
+	if len(players)%2 == 1 {
+		byeIdx := len(players) - 1
+		for i := len(players) - 1; i >= 0; i-- {
+			if !contains(players[i].Opponents, swissBye) {
+				byeIdx = i
+				break
+			}
+		}
+		addBye(t, BracketSwiss, players[byeIdx].DiscordID, true)
+		players = append(players[:byeIdx], players[byeIdx+1:]...)
+	}
+
+	paired := make(map[string]bool)
+	for i, player := range players {
+		if paired[player.DiscordID] {
+			continue
+		}
+
+		// Prefer the closest-ranked opponent not played yet, fall back to the closest
+		opponentIdx := -1
+		for j := i + 1; j < len(players); j++ {
+			if paired[players[j].DiscordID] {
+				continue
+			}
+			if opponentIdx == -1 {
+				opponentIdx = j
+			}
+			if !contains(player.Opponents, players[j].DiscordID) {
+				opponentIdx = j
+				break
+			}
+		}
+
+		if opponentIdx == -1 {
+			continue
+		}
+
+		paired[player.DiscordID] = true
+		paired[players[opponentIdx].DiscordID] = true
+		addMatch(t, BracketSwiss, player.DiscordID, players[opponentIdx].DiscordID)
+	}
+}
+
+// addMatch schedules a match for the current round
+func addMatch(t *models.Tournament, bracket string, playerA string, playerB string) {
+	t.Matches = append(t.Matches, models.TournamentMatch{
+		ID:      len(t.Matches) + 1,
+		Round:   t.Round,
+		Bracket: bracket,
+		PlayerA: playerA,
+		PlayerB: playerB,
+		Status:  MatchWaiting,
+	})
+}
+
+// addBye records a bye, which counts as a win only in Swiss
+func addBye(t *models.Tournament, bracket string, playerID string, countsAsWin bool) {
+	t.Matches = append(t.Matches, models.TournamentMatch{
+		ID:      len(t.Matches) + 1,
+		Round:   t.Round,
+		Bracket: bracket,
+		PlayerA: playerID,
+		Status:  MatchDone,
+		Winner:  playerID,
+	})
+
+	if countsAsWin {
+		player := FindPlayer(t, playerID)
+		player.Wins++
+		player.Opponents = append(player.Opponents, swissBye)
+	}
+}
+
+// contains checks if a slice holds a value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
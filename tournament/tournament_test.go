@@ -0,0 +1,94 @@
+package tournament
+
+import (
+	"CrispyBot/database/models"
+	"fmt"
+	"testing"
+)
+
+// Helper to create a tournament with n signed-up players
+func newTestTournament(format string, n int) *models.Tournament {
+	t := &models.Tournament{Format: format, Status: StatusSignup}
+	for i := 1; i <= n; i++ {
+		t.Players = append(t.Players, models.TournamentPlayer{DiscordID: fmt.Sprintf("p%d", i)})
+	}
+	return t
+}
+
+// Helper that plays every round out, letting the higher seed win
+func playOut(t *testing.T, tour *models.Tournament) {
+	matches, err := Start(tour)
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	for rounds := 0; rounds < 50; rounds++ {
+		for _, match := range matches {
+			winner := match.PlayerA
+			if FindPlayer(tour, match.PlayerB).Seed < FindPlayer(tour, match.PlayerA).Seed {
+				winner = match.PlayerB
+			}
+			if err := RecordResult(tour, match.ID, winner, false); err != nil {
+				t.Fatalf("RecordResult failed: %v", err)
+			}
+		}
+		if IsFinished(tour) {
+			return
+		}
+		matches = NextRound(tour)
+	}
+	t.Fatalf("Tournament never finished")
+}
+
+func TestStart_RequiresTwoPlayers(t *testing.T) {
+	if _, err := Start(newTestTournament(FormatSingle, 1)); err == nil {
+		t.Errorf("Expected error when starting with one player")
+	}
+}
+
+func TestSingleElimination_OddPlayers(t *testing.T) {
+	tour := newTestTournament(FormatSingle, 5)
+	playOut(t, tour)
+
+	standings := Standings(tour)
+	if standings[0].DiscordID != "p1" {
+		t.Errorf("Expected top seed to win, got %s", standings[0].DiscordID)
+	}
+	for _, player := range standings[1:] {
+		if player.Losses != 1 {
+			t.Errorf("Player %s should have exactly 1 loss, got %d", player.DiscordID, player.Losses)
+		}
+	}
+}
+
+func TestDoubleElimination_EveryoneElseHasTwoLosses(t *testing.T) {
+	tour := newTestTournament(FormatDouble, 6)
+	playOut(t, tour)
+
+	standings := Standings(tour)
+	if standings[0].Losses > 1 {
+		t.Errorf("Champion should have at most 1 loss, got %d", standings[0].Losses)
+	}
+	for _, player := range standings[1:] {
+		if player.Losses != 2 {
+			t.Errorf("Player %s should have 2 losses, got %d", player.DiscordID, player.Losses)
+		}
+	}
+}
+
+func TestSwiss_NoRematches(t *testing.T) {
+	tour := newTestTournament(FormatSwiss, 8)
+	playOut(t, tour)
+
+	if tour.Round != 3 {
+		t.Errorf("Expected 3 Swiss rounds for 8 players, got %d", tour.Round)
+	}
+	for _, player := range tour.Players {
+		seen := map[string]bool{}
+		for _, opponent := range player.Opponents {
+			if seen[opponent] {
+				t.Errorf("Player %s faced %s twice", player.DiscordID, opponent)
+			}
+			seen[opponent] = true
+		}
+	}
+}
@@ -34,6 +34,7 @@ const (
 	// Action costs
 	PhysicalAttackManaCost  = 0   // Mana cost for physical attacks
 	MagicAttackBaseManaCost = 15  // Base mana cost for magic attacks
+	BattleItemUses          = 3   // Healing items each fighter can use per battle
 	BaseExperienceGain      = 100 // Base XP earned per battle
 	ExperienceModifier      = 1.0 // Global modifier for XP gains (can be adjusted)
 
@@ -41,6 +42,150 @@ const (
 	LevelUpBaseXP     = 100 // Base XP needed for level 2
 	LevelUpMultiplier = 1.5 // Each level requires 1.5x more XP than the previous
 
+	// Stat growth values
+	StatPointsPerLevel  = 3   // Allocatable stat points earned per level
+	GrowthBaseWeight    = 2   // Growth curve weight of a stat with no race modifier
+	GrowthWeightDivisor = 25  // Race stat modifier per extra point of growth weight
+	RespecTokenPrice    = 500 // Coins for a respec token that refunds all allocated points
+
+	// Level gap values
+	LevelGapDamagePercent     = 3  // Percent more damage dealt per level above the target
+	LevelGapAccuracyPerLevel  = 2  // Accuracy points gained per level above the target
+	LevelGapMaxLevels         = 10 // Level difference beyond which combat scaling stops growing
+	LevelGapXPBonusPercent    = 10 // Extra XP percent per level an NPC is above the player
+	LevelGapMaxXPBonusPercent = 50 // Cap on the extra XP percent for fighting stronger NPCs
+	FarmingGraceLevels        = 2  // Levels below the player an NPC can be before rewards drop
+	FarmingPenaltyPercent     = 20 // Reward percent lost per level beyond the grace
+	FarmingMinRewardPercent   = 10 // Rewards never drop below this percent. Note: No loot drops at this floor.
+
+	// Reroll allowance values
+	DailyFullRerolls = 2 // Full rerolls granted every reset. Note: Guild configs can change the allowance.
+	DailyStatRerolls = 1 // Stat rerolls granted every reset. Note: Guild configs can change the allowance.
+
+	// Pity values
+	PityRaceSoftStart   = 15 // Race rolls without an Epic before the soft pity ramp starts
+	PityRaceHardLimit   = 25 // Race roll that is guaranteed to be at least Epic
+	PityStatSoftStart   = 25 // Stat rolls without an Epic before the soft pity ramp starts. Note: A character rolls 7 stats.
+	PityStatHardLimit   = 40 // Stat roll that is guaranteed to be at least Epic
+	PityInnateSoftStart = 15 // Innate trait rolls without an Epic before the soft pity ramp starts
+	PityInnateHardLimit = 25 // Innate trait roll that is guaranteed to be at least Epic
+	PitySoftStep        = 3  // Chance moved from Common to Epic and Legendary per roll into the soft pity ramp
+
+	// Banner values
+	BannerBaseWeight = 100     // Weight of an option without a rate-up. Note: Rate-ups are percents on top of this.
+	BannerMaxBoost   = 1000    // Largest rate-up percent a banner can give one option
+	BannerMaxHours   = 24 * 14 // Longest a banner can run
+	BannerAuditSize  = 10      // Rolls listed in a banner's audit
+
+	// Reroll lock values
+	RerollLockBaseCost = 200 // Coins for the first lock on a full reroll. Note: The nth lock costs n times this.
+
+	// Roll history values
+	SnapshotPageSize = 5  // Snapshots shown per history page
+	UndoWindowHours  = 24 // Hours after a reroll during which it can still be undone
+
+	// Prestige values
+	PrestigeMinLevel            = 20 // Character level required to rebirth
+	PrestigeStatBonusPercent    = 2  // Percent added to every stat per prestige level
+	PrestigeMaxStatBonusPercent = 20 // Cap on the prestige stat bonus
+	PrestigeRerollsPerLevel     = 1  // Extra daily full rerolls per prestige level
+	PrestigeMaxBonusRerolls     = 3  // Cap on the extra daily full rerolls
+	PrestigeWeaponLuck          = 3  // Starting weapon chance moved from Common to Epic and Legendary per prestige level
+	PrestigeMaxWeaponLuck       = 30 // Cap on the starting weapon luck
+
+	// Character roster values
+	CharacterBaseSlots         = 2    // Character slots every user starts with
+	PrestigeLevelsPerSlot      = 2    // Prestige levels needed for each extra character slot
+	PrestigeMaxBonusSlots      = 2    // Cap on the character slots earned through prestige
+	CharacterSlotPrice         = 2500 // Coins for one extra character slot
+	CharacterMaxPurchasedSlots = 3    // Cap on the character slots that can be bought
+
+	// Daily reward values
+	DailyBaseReward     = 100 // Coins paid for every daily claim. Note: Guild configs can change it.
+	DailyStreakBonus    = 10  // Extra coins per consecutive day
+	DailyMaxStreakBonus = 200 // Cap on the streak coin bonus
+
+	// Guild config values
+	GuildPrefixMaxLength      = 5    // Longest custom command prefix
+	GuildMaxDailyReward       = 1000 // Cap on a guild's base daily reward
+	GuildMaxDailyRerolls      = 10   // Cap on a guild's daily full or stat reroll allowance
+	GuildSettingsCacheMinutes = 5    // Minutes guild settings are cached before being reloaded
+
+	// Locale values
+	UserLocaleCacheMinutes = 5 // Minutes a user's language is cached before being reloaded
+
+	// Rate limit values
+	RateLimitUserCommands  = 8  // Commands one user can send per user window
+	RateLimitUserSeconds   = 10 // Length of the per-user window
+	RateLimitGuildCommands = 60 // Commands one guild can send per guild window
+	RateLimitGuildSeconds  = 10 // Length of the per-guild window
+	RateLimitRollSeconds   = 3  // Seconds between rolls
+	RateLimitRerollSeconds = 2  // Seconds between rerolls or undos
+	RateLimitBattleSeconds = 5  // Seconds between battle or queue commands
+
+	// Admin values
+	AdminMaxCoinAdjust   = 100000 // Most coins one admin action can grant or deduct
+	AdminMaxRerollAdjust = 50     // Most reroll tokens one admin action can grant or deduct
+	AdminLogPageSize     = 10     // Audit log entries shown per page
+
+	// Ranked PvP values
+	RankedStartingRating   = 1000 // Rating for a character's first ranked match
+	RankedKFactor          = 32   // Elo K-factor after placements
+	RankedPlacementKFactor = 64   // Elo K-factor during placement matches
+	RankedPlacementMatches = 5    // Matches before a rank tier is shown
+	RankedDecayAfterDays   = 7    // Days without a match before rating decays
+	RankedDecayAmount      = 25   // Rating lost per day of inactivity
+	RankedDecayFloor       = 1400 // Rating decay never goes below this
+	RankedSeasonLengthDays = 56   // Length of a ranked season
+
+	// Matchmaking values
+	QueueTimeoutMinutes     = 10  // Minutes before a queue entry expires
+	QueueRatingWindowBase   = 100 // Starting rating difference allowed in ranked
+	QueueRatingWindowGrowth = 50  // Extra rating difference allowed per widen interval
+	QueueLevelWindowBase    = 3   // Starting level difference allowed
+	QueueLevelWindowGrowth  = 1   // Extra level difference allowed per widen interval
+	QueueWidenSeconds       = 30  // Seconds between search window increases
+
+	// Tournament values
+	TournamentEntryFee       = 100 // Coins each player pays to sign up, all of which go into the prize pool
+	TournamentMaxPlayers     = 32  // Signup cap for a single tournament
+	TournamentCheckInMinutes = 15  // Minutes players have to ready up before a no-show forfeit
+	TournamentFirstPrizePct  = 60  // Share of the prize pool paid to first place
+	TournamentSecondPrizePct = 30  // Share of the prize pool paid to second place
+	TournamentThirdPrizePct  = 10  // Share of the prize pool paid to third place
+
+	// Dungeon values
+	DungeonBossEvery             = 5  // A boss guards every Nth floor
+	DungeonBossLevelBonus        = 3  // Extra levels a boss has over the floor's enemies
+	DungeonRestChance            = 15 // Percent chance a non-boss floor is a rest room
+	DungeonTreasureChance        = 15 // Percent chance a non-boss floor is a treasure room
+	DungeonRestHealPercent       = 30 // Percent of max HP and MP restored in a rest room
+	DungeonCoinsPerFloor         = 15 // Coins per floor depth for winning a fight
+	DungeonXPPerFloor            = 10 // Experience per floor depth for winning a fight
+	DungeonTreasureCoinsPerFloor = 25 // Coins per floor depth found in a treasure room
+	DungeonRetreatLootPercent    = 50 // Percent of unsecured loot kept when retreating
+
+	// Loot values
+	MaxInventorySize   = 30 // Items a user can hold before new drops go to the mailbox
+	LootLevelBias      = 1  // Percent chance per NPC level to bump a drop's rarity
+	LootDifficultyBias = 3  // Extra bump chance per level fought above the NPC's default
+	MailboxExpiryDays  = 30 // Days an unclaimed mailbox item is kept
+
+	// Quest values
+	DailyQuestCount  = 3 // Quests assigned each day
+	WeeklyQuestCount = 2 // Quests assigned each week
+
+	// Clan values
+	ClanCreationCost  = 1000 // Coins to found a clan
+	ClanMaxMembers    = 20   // Member cap for a single clan
+	ClanNameMinLength = 3
+	ClanNameMaxLength = 24
+	ClanXPPerLevel    = 1000 // Clan XP scaling, level N needs ClanXPPerLevel * N(N-1)/2 in total
+	ClanMaxLevel      = 10
+	ClanWarHours      = 24   // How long an accepted war lasts
+	ClanWarPrize      = 1000 // Coins paid into the winning clan's bank
+	ClanWarXP         = 500  // Clan XP awarded to the winning clan
+
 	// Random starting weapon chances
 	HeroAlignmentEpicBoost      = 10 // Percentage points to add to Epic chance for Heroes
 	HeroAlignmentLegendaryBoost = 10
@@ -10,4 +10,13 @@ var (
 	Bottoken    string = os.Getenv("BOTTOKEN")
 	Mongodb_uri string = os.Getenv("MONGODB_URI")
 	Db_name     string = os.Getenv("DB_NAME")
+
+	// Reset_timezone is the IANA zone used for the daily shop/reward reset (defaults to server local time)
+	Reset_timezone string = os.Getenv("RESET_TIMEZONE")
+
+	// Rate_limits overrides command cooldown buckets, like "user=8/10s; roll=1/5s; battle start=1/3s"
+	Rate_limits string = os.Getenv("RATE_LIMITS")
+
+	// Bot_owners lists the Discord IDs, comma separated, allowed to use admin commands that change state shared by every server
+	Bot_owners string = os.Getenv("BOT_OWNERS")
 )